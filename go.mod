@@ -14,7 +14,7 @@ require (
 	github.com/google/go-cmp v0.3.1 // indirect
 	github.com/integrii/flaggy v1.4.0
 	github.com/jesseduffield/gocui v0.3.1-0.20200513110002-8cde0b9be542
-	github.com/jesseduffield/termbox-go v0.0.0-20200405031649-4dc645f7e8ba // indirect
+	github.com/jesseduffield/termbox-go v0.0.0-20200405031649-4dc645f7e8ba
 	github.com/kardianos/osext v0.0.0-20190222173326-2bc1f35cddc0
 	github.com/konsorten/go-windows-terminal-sequences v1.0.2 // indirect
 	github.com/mattn/go-colorable v0.1.4 // indirect
@@ -34,6 +34,13 @@ require (
 	github.com/spkg/bom v0.0.0-20160624110644-59b7046e48ad
 	github.com/stretchr/testify v1.4.0
 	github.com/tcnksm/go-gitconfig v0.1.2
+	golang.org/x/sys v0.0.0-20200302150141-5c8b2ff67527
 	golang.org/x/text v0.3.2
 	gopkg.in/yaml.v2 v2.2.7
 )
+
+// these forks add OSC 8 hyperlinks, which the upstream versions don't have
+replace (
+	github.com/jesseduffield/gocui => ./third_party/gocui
+	github.com/jesseduffield/termbox-go => ./third_party/termbox-go
+)
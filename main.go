@@ -41,6 +41,12 @@ func main() {
 	configFlag := false
 	flaggy.Bool(&configFlag, "c", "config", "Print the current default config")
 
+	profile := ""
+	flaggy.String(&profile, "", "profile", "Name of a config profile in the profiles dir next to your config file, to layer over your config")
+
+	configDir := ""
+	flaggy.String(&configDir, "", "config-dir", "Directory to keep lazygit's config, state and caches in, in place of the standard ones")
+
 	flaggy.Parse()
 
 	if versionFlag {
@@ -59,7 +65,7 @@ func main() {
 		}
 	}
 
-	appConfig, err := config.NewAppConfig("lazygit", version, commit, date, buildSource, debuggingFlag)
+	appConfig, err := config.NewAppConfig("lazygit", version, commit, date, buildSource, debuggingFlag, configDir, profile)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
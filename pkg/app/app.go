@@ -14,7 +14,6 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/gui"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/updates"
-	"github.com/shibukawa/configdir"
 	"github.com/sirupsen/logrus"
 )
 
@@ -44,12 +43,6 @@ func newProductionLogger(config config.AppConfigurer) *logrus.Logger {
 	return log
 }
 
-func globalConfigDir() string {
-	configDirs := configdir.New("jesseduffield", "lazygit")
-	configDir := configDirs.QueryFolders(configdir.Global)[0]
-	return configDir.Path
-}
-
 func getLogLevel() logrus.Level {
 	strLevel := os.Getenv("LOG_LEVEL")
 	level, err := logrus.ParseLevel(strLevel)
@@ -62,7 +55,10 @@ func getLogLevel() logrus.Level {
 func newDevelopmentLogger(config config.AppConfigurer) *logrus.Logger {
 	log := logrus.New()
 	log.SetLevel(getLogLevel())
-	file, err := os.OpenFile(filepath.Join(globalConfigDir(), "development.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err := os.MkdirAll(config.GetStateDir(), 0755); err != nil {
+		panic(err)
+	}
+	file, err := os.OpenFile(filepath.Join(config.GetStateDir(), "development.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
 	if err != nil {
 		panic("unable to log to file") // TODO: don't panic (also, remove this call to the `panic` function)
 	}
@@ -98,6 +94,9 @@ func NewApp(config config.AppConfigurer, filterPath string) (*App, error) {
 	}
 	var err error
 	app.Log = newLogger(config)
+	for _, warning := range config.GetStartupWarnings() {
+		app.Log.Warn(warning)
+	}
 	app.Tr = i18n.NewLocalizer(app.Log)
 
 	// if we are being called in 'demon' mode, we can just return here
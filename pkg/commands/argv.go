@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExecutableFromArgs is ExecutableFromString for a command that's already
+// split into its arguments. They reach the command exactly as they are, so
+// file names and messages with quotes, backslashes, '$'s, backticks or
+// newlines in them need no quoting
+func (c *OSCommand) ExecutableFromArgs(args ...string) *exec.Cmd {
+	cmd := c.command(args[0], args[1:]...)
+	cmd.Env = append(c.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	return cmd
+}
+
+// RunCommandArgsWithOutput is RunCommandWithOutput for a command given as its
+// arguments, which is how commands taking file names, branch names or
+// messages from the user should be run
+func (c *OSCommand) RunCommandArgsWithOutput(args ...string) (string, error) {
+	command := ArgsString(args)
+	c.Log.WithField("command", command).Info("RunCommand")
+	var output string
+	err := c.queued(context.Background(), args, func() error {
+		start := time.Now()
+		cmd := c.ExecutableFromArgs(args...)
+		var err error
+		output, err = sanitisedCommandOutput(cmd.CombinedOutput())
+		c.recordCommand(command, cmd, start, output, err)
+		return err
+	})
+	c.recordFailure(command, err)
+	return output, err
+}
+
+// RunCommandArgs is RunCommandArgsWithOutput for when we only care whether the
+// command worked
+func (c *OSCommand) RunCommandArgs(args ...string) error {
+	_, err := c.RunCommandArgsWithOutput(args...)
+	return err
+}
+
+// ArgsString is the command line for a command given as its arguments, for the
+// logs and the command history. Arguments are only quoted if they need to be,
+// and ExecutableFromString splits the result back into the same arguments
+func ArgsString(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\") {
+			quoted[i] = arg
+		} else {
+			quoted[i] = quoteArg(arg)
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// quoteArg quotes an argument for a command string which we split into
+// arguments ourselves, with str.ToArgv, so that it comes back out as it went
+// in. That's not a shell, so nothing but quotes and backslashes is special.
+// Outside of Windows a backslash escapes whatever comes after it, quotes
+// included. On Windows it only disappears before a double quote, and can't
+// escape one, so there we quote ' and " with each other
+func quoteArg(arg string) string {
+	if runtime.GOOS != "windows" {
+		escaper := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+		return "'" + escaper.Replace(arg) + "'"
+	}
+
+	var builder strings.Builder
+	inQuote := false
+	for _, r := range arg {
+		if r == '\'' || r == '"' {
+			if inQuote {
+				builder.WriteByte('\'')
+				inQuote = false
+			}
+			if r == '\'' {
+				builder.WriteString(`"'"`)
+			} else {
+				builder.WriteString(`'"'`)
+			}
+			continue
+		}
+		if !inQuote {
+			builder.WriteByte('\'')
+			inQuote = true
+		}
+		builder.WriteRune(r)
+	}
+	if inQuote {
+		builder.WriteByte('\'')
+	}
+	if arg == "" {
+		return "''"
+	}
+	return builder.String()
+}
+
+// ShellQuote quotes an argument for a command run in the user's shell, like an
+// os.copyToClipboardCommand, where unlike with Quote '$'s and backticks would
+// otherwise be expanded
+func (c *OSCommand) ShellQuote(arg string) string {
+	if c.Platform.os == "windows" {
+		// cmd.exe has no way of escaping a double quote inside double
+		// quotes other than doubling it, and nothing's expanded in them but
+		// %VARIABLES%, which can't be escaped at all
+		return `"` + strings.Replace(arg, `"`, `""`, -1) + `"`
+	}
+	return "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+}
+
+// unquotePath undoes the quoting git does to paths with unusual characters in
+// them, like "dir/caf\303\251 \"menu\".txt", leaving other paths as they are.
+// A renamed file's 'old -> new' can have either path quoted
+func unquotePath(path string) string {
+	if strings.HasPrefix(path, `"`) {
+		if end := quotedPathEnd(path); end != -1 && strings.HasPrefix(path[end:], " -> ") {
+			return unquotePath(path[:end]) + " -> " + unquotePath(path[end+len(" -> "):])
+		}
+	} else if i := strings.Index(path, " -> "); i != -1 {
+		return path[:i] + " -> " + unquotePath(path[i+len(" -> "):])
+	}
+
+	if len(path) < 2 || !strings.HasPrefix(path, `"`) || !strings.HasSuffix(path, `"`) {
+		return path
+	}
+	unquoted, err := strconv.Unquote(path)
+	if err != nil {
+		return path
+	}
+	return unquoted
+}
+
+// quotedPathEnd returns where the quoted path at the start of s ends, just
+// after its closing quote, or -1 if it doesn't end
+func quotedPathEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
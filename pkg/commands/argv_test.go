@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/mgutz/str"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandRunCommandArgs is a function.
+func TestOSCommandRunCommandArgs(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"stash", "save", `it's "$HOME" \ ` + "`whoami`\nsecond line"}, args)
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, osCommand.RunCommandArgs("git", "stash", "save", `it's "$HOME" \ `+"`whoami`\nsecond line"))
+}
+
+// TestArgsString is a function.
+func TestArgsString(t *testing.T) {
+	scenarios := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"git", "add", "file.txt"}, "git add file.txt"},
+		{[]string{"git", "add", "my file.txt"}, "git add 'my file.txt'"},
+		{[]string{"git", "commit", "-m", "it's done"}, `git commit -m 'it\'s done'`},
+		{[]string{"git", "commit", "-m", ""}, "git commit -m ''"},
+	}
+
+	for _, s := range scenarios {
+		actual := ArgsString(s.args)
+		assert.EqualValues(t, s.expected, actual)
+		assert.EqualValues(t, s.args, str.ToArgv(actual))
+	}
+}
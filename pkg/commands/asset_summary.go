@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	// registering the formats we can read dimensions from
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// AssetVersion : what we can tell about one side of a change to a binary file,
+// given that git can only tell us that the two sides differ
+type AssetVersion struct {
+	Exists bool
+	Size   int64
+	Format string // only set for images we know how to decode
+	Width  int
+	Height int
+}
+
+// maxImageHeaderSize is how much of a file we read to find an image's
+// dimensions, which is plenty even for a JPEG with a large EXIF block in front
+const maxImageHeaderSize = 64 << 10
+
+// DescribeAsset describes content of the given size from its first bytes,
+// reading its dimensions if it's an image in a format we support
+func DescribeAsset(size int64, header []byte) AssetVersion {
+	version := AssetVersion{Exists: true, Size: size}
+	if config, format, err := image.DecodeConfig(bytes.NewReader(header)); err == nil {
+		version.Format = format
+		version.Width = config.Width
+		version.Height = config.Height
+	}
+	return version
+}
+
+// describeBlob describes the object at a rev:path spec, e.g. 'HEAD:foo.png' or
+// ':foo.png' for the index, without reading more of it than we need to
+func (c *GitCommand) describeBlob(spec string) AssetVersion {
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "cat-file", "-s", spec)
+	if err != nil {
+		return AssetVersion{}
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return AssetVersion{}
+	}
+
+	cmd := c.OSCommand.ExecutableFromArgs("git", "cat-file", "blob", spec)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil || cmd.Start() != nil {
+		return DescribeAsset(size, nil)
+	}
+	header, _ := readHeader(stdout)
+	// we've got what we came for, so there's no need to wait for the rest
+	_ = cmd.Process.Kill()
+	_ = cmd.Wait()
+	return DescribeAsset(size, header)
+}
+
+// describeWorkingTreeFile describes a file in the working tree
+func describeWorkingTreeFile(fileName string) AssetVersion {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return AssetVersion{}
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return AssetVersion{}
+	}
+	header, _ := readHeader(f)
+	return DescribeAsset(info.Size(), header)
+}
+
+func readHeader(r io.Reader) ([]byte, error) {
+	return ioutil.ReadAll(io.LimitReader(r, maxImageHeaderSize))
+}
+
+// FileAssetVersions describes both sides of a binary file's staged or unstaged change
+func (c *GitCommand) FileAssetVersions(file *File, cached bool) (AssetVersion, AssetVersion) {
+	split := strings.Split(file.Name, " -> ")
+	oldName, newName := split[0], file.NewName()
+
+	if cached {
+		return c.describeBlob("HEAD:" + oldName), c.describeBlob(":" + newName)
+	}
+
+	newVersion := describeWorkingTreeFile(newName)
+	if !file.Tracked && !file.HasStagedChanges {
+		return AssetVersion{}, newVersion
+	}
+	return c.describeBlob(":" + newName), newVersion
+}
+
+// CommitFileAssetVersions describes both sides of a commit's change to a binary file
+func (c *GitCommand) CommitFileAssetVersions(sha string, fileName string) (AssetVersion, AssetVersion) {
+	return c.describeBlob(sha + "^:" + fileName), c.describeBlob(sha + ":" + fileName)
+}
+
+// AssetSummary spells out how a binary file changed, in place of git's
+// 'Binary files differ'
+func AssetSummary(fileName string, oldVersion AssetVersion, newVersion AssetVersion) string {
+	lines := []string{fmt.Sprintf("Binary file: %s", fileName), ""}
+	lines = append(lines, "old: "+describeAssetVersion(oldVersion))
+	lines = append(lines, "new: "+describeAssetVersion(newVersion))
+	if oldVersion.Exists && newVersion.Exists {
+		lines = append(lines, "", "size change: "+formatSizeDelta(newVersion.Size-oldVersion.Size))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func describeAssetVersion(version AssetVersion) string {
+	if !version.Exists {
+		return "(none)"
+	}
+	description := formatSize(version.Size)
+	if version.Format != "" {
+		description += fmt.Sprintf(", %dx%d %s", version.Width, version.Height, version.Format)
+	}
+	return description
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size)
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		value /= unit
+		if value < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+	}
+	return ""
+}
+
+func formatSizeDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + formatSize(-delta)
+	}
+	return "+" + formatSize(delta)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDescribeAsset is a function.
+func TestDescribeAsset(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 3, 2))))
+	content := buf.Bytes()
+
+	assert.EqualValues(t, AssetVersion{Exists: true, Size: 1000, Format: "png", Width: 3, Height: 2}, DescribeAsset(1000, content))
+	// an image's dimensions come at the start, so its header is all we need
+	assert.EqualValues(t, AssetVersion{Exists: true, Size: 1000, Format: "png", Width: 3, Height: 2}, DescribeAsset(1000, content[:33]))
+	assert.EqualValues(t, AssetVersion{Exists: true, Size: 4}, DescribeAsset(4, []byte{0, 1, 2, 3}))
+}
+
+// TestAssetSummary is a function.
+func TestAssetSummary(t *testing.T) {
+	type scenario struct {
+		testName   string
+		oldVersion AssetVersion
+		newVersion AssetVersion
+		expected   string
+	}
+
+	scenarios := []scenario{
+		{
+			"resized image",
+			AssetVersion{Exists: true, Size: 2048, Format: "png", Width: 640, Height: 480},
+			AssetVersion{Exists: true, Size: 1536, Format: "png", Width: 320, Height: 240},
+			"Binary file: a.png\n\nold: 2.0 KB, 640x480 png\nnew: 1.5 KB, 320x240 png\n\nsize change: -512 B",
+		},
+		{
+			"new file",
+			AssetVersion{},
+			AssetVersion{Exists: true, Size: 3 << 20},
+			"Binary file: a.png\n\nold: (none)\nnew: 3.0 MB",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, AssetSummary("a.png", s.oldVersion, s.newVersion))
+		})
+	}
+}
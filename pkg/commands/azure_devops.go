@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// AzureDevOpsRepoInformation is RepoInformation for a repo on Azure DevOps,
+// whose owner is the organization and project. Its remote urls come in a few
+// shapes:
+//
+//	https://dev.azure.com/org/project/_git/repo
+//	https://org.visualstudio.com/DefaultCollection/project/_git/repo
+//	git@ssh.dev.azure.com:v3/org/project/repo
+//	org@vs-ssh.visualstudio.com:v3/org/project/repo
+//
+// and for Azure DevOps Server, https://server/tfs/collection/project/_git/repo.
+// Project names can have spaces, so the parts are left escaped, ready to go
+// into links and API urls
+func (r *RemoteURL) AzureDevOpsRepoInformation() *RepoInformation {
+	splits := strings.Split(strings.TrimSuffix(strings.Trim(r.Path, "/"), ".git"), "/")
+	for i, split := range splits {
+		splits[i] = url.PathEscape(split)
+	}
+
+	if r.Scheme == "ssh" && len(splits) > 1 && splits[0] == "v3" {
+		return &RepoInformation{
+			Owner:      strings.Join(splits[1:len(splits)-1], "/"),
+			Repository: splits[len(splits)-1],
+		}
+	}
+
+	owner := splits[:len(splits)-1]
+	repo := splits[len(splits)-1]
+	for i, split := range splits {
+		if split == "_git" && i+1 < len(splits) {
+			owner = splits[:i]
+			repo = splits[i+1]
+			break
+		}
+	}
+	if r.IsOnDomain("visualstudio.com") {
+		// the old domains have the organization in the host
+		if len(owner) > 0 && owner[0] == "DefaultCollection" {
+			owner = owner[1:]
+		}
+		owner = append([]string{strings.TrimSuffix(strings.ToLower(r.Host), ".visualstudio.com")}, owner...)
+	}
+
+	return &RepoInformation{
+		Owner:      strings.Join(owner, "/"),
+		Repository: repo,
+	}
+}
+
+// azureDevOpsAPI talks to the Azure DevOps API about a single repo
+type azureDevOpsAPI struct {
+	// repoURL is the API's URL for the repo, e.g.
+	// https://dev.azure.com/org/project/_apis/git/repositories/repo
+	repoURL string
+	token   string
+}
+
+// azureDevOpsAPI returns a client for the service's API, with the personal
+// access token from git.azureDevOps.tokenSecret
+func (pr *PullRequest) azureDevOpsAPI(service *Service, repoInfo *RepoInformation) (*azureDevOpsAPI, error) {
+	token, err := pr.apiToken("git.azureDevOps.tokenSecret")
+	if err != nil {
+		return nil, err
+	}
+
+	return &azureDevOpsAPI{
+		// the owner and repo come escaped
+		repoURL: fmt.Sprintf("%s/%s/_apis/git/repositories/%s", service.APIURL, repoInfo.Owner, repoInfo.Repository),
+		token:   token,
+	}, nil
+}
+
+// workItemRegexp matches work item mentions like '#123' or 'AB#123'
+var workItemRegexp = regexp.MustCompile(`(?:^|[\s(\[])(?:AB)?#(\d+)\b`)
+
+// branchWorkItemRegexp matches the work item id branches made for one start
+// with, like '123-fix-login' or 'feature/123-fix-login'
+var branchWorkItemRegexp = regexp.MustCompile(`(?:^|/)(\d+)-`)
+
+// azureDevOpsWorkItems returns the ids of the work items the branch is named
+// after or the description mentions, for the pull request to be linked to
+func azureDevOpsWorkItems(branch string, description *PullRequestDescription) []string {
+	ids := []string{}
+	seen := map[string]bool{}
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if match := branchWorkItemRegexp.FindStringSubmatch(branch); match != nil {
+		add(match[1])
+	}
+	for _, match := range workItemRegexp.FindAllStringSubmatch(description.Title+"\n"+description.Body, -1) {
+		add(match[1])
+	}
+	return ids
+}
+
+// createPullRequest creates a pull request from the branch into base, linked
+// to the work items it mentions, returning the link to it
+func (api *azureDevOpsAPI) createPullRequest(branch string, base string, description *PullRequestDescription) (string, error) {
+	workItemRefs := []map[string]string{}
+	for _, id := range azureDevOpsWorkItems(branch, description) {
+		workItemRefs = append(workItemRefs, map[string]string{"id": id})
+	}
+	body := map[string]interface{}{
+		"sourceRefName": "refs/heads/" + branch,
+		"targetRefName": "refs/heads/" + base,
+		"title":         description.Title,
+		"description":   description.Body,
+		"workItemRefs":  workItemRefs,
+	}
+	// personal access tokens go in as the password, with no username
+	authorization := "Basic " + base64.StdEncoding.EncodeToString([]byte(":"+api.token))
+	created := struct {
+		PullRequestID int `json:"pullRequestId"`
+		Repository    struct {
+			WebURL string `json:"webUrl"`
+		} `json:"repository"`
+	}{}
+	if _, err := doJSONRequest("POST", api.repoURL+"/pullrequests?api-version=7.0", authorization, body, &created); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/pullrequest/%d", created.Repository.WebURL, created.PullRequestID), nil
+}
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRemoteURLAzureDevOpsRepoInformation is a function.
+func TestRemoteURLAzureDevOpsRepoInformation(t *testing.T) {
+	type scenario struct {
+		testName      string
+		repoURL       string
+		expectedOwner string
+		expectedRepo  string
+	}
+
+	scenarios := []scenario{
+		{"https", "https://contoso@dev.azure.com/contoso/web/_git/storefront", "contoso/web", "storefront"},
+		{"ssh", "git@ssh.dev.azure.com:v3/contoso/web/storefront", "contoso/web", "storefront"},
+		{"project with a space", "https://dev.azure.com/contoso/My%20Project/_git/storefront", "contoso/My%20Project", "storefront"},
+		{"visualstudio.com", "https://contoso.visualstudio.com/DefaultCollection/web/_git/storefront", "contoso/web", "storefront"},
+		{"visualstudio.com ssh", "contoso@vs-ssh.visualstudio.com:v3/contoso/web/storefront", "contoso/web", "storefront"},
+		{"Azure DevOps Server", "https://tfs.work.com/tfs/Main/web/_git/storefront.git", "tfs/Main/web", "storefront"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			remoteURL, err := ParseRemoteURL(s.repoURL)
+			assert.NoError(t, err)
+			repoInfo := remoteURL.AzureDevOpsRepoInformation()
+			assert.EqualValues(t, s.expectedOwner, repoInfo.Owner)
+			assert.EqualValues(t, s.expectedRepo, repoInfo.Repository)
+		})
+	}
+}
+
+// TestCreateAzureDevOpsPullRequestLink is a function.
+func TestCreateAzureDevOpsPullRequestLink(t *testing.T) {
+	gitCommand := NewDummyGitCommand()
+	gitCommand.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		if strings.HasPrefix(cmd, "git") {
+			return exec.Command("echo", "contoso@vs-ssh.visualstudio.com:v3/contoso/web/storefront")
+		}
+
+		assert.Equal(t, "open", cmd)
+		assert.Equal(t, []string{"https://dev.azure.com/contoso/web/_git/storefront/pullrequestcreate?sourceRef=feature/cart&targetRef=develop"}, args)
+		return exec.Command("echo")
+	}
+	gitCommand.OSCommand.Config.GetUserConfig().Set("os.openLinkCommand", "open {{link}}")
+	gitCommand.OSCommand.getenv = func(string) string { return "" }
+
+	description := &PullRequestDescription{Title: "Add a cart", Body: "- Add a cart"}
+	prefilled, err := NewPullRequest(gitCommand).CreateWithDescription(&Branch{Name: "feature/cart"}, "develop", description)
+	assert.NoError(t, err)
+	assert.False(t, prefilled)
+}
+
+// TestCreateAzureDevOpsPullRequestWithAPI is a function.
+func TestCreateAzureDevOpsPullRequestWithAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/contoso/web/_apis/git/repositories/storefront/pullrequests", r.URL.Path)
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "", username)
+		assert.Equal(t, "pat", password)
+
+		body := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.EqualValues(t, "refs/heads/feature/1234-cart", body["sourceRefName"])
+		assert.EqualValues(t, "refs/heads/develop", body["targetRefName"])
+		assert.EqualValues(t, []interface{}{
+			map[string]interface{}{"id": "1234"},
+			map[string]interface{}{"id": "99"},
+		}, body["workItemRefs"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"pullRequestId": 7, "repository": {"webUrl": "https://dev.azure.com/contoso/web/_git/storefront"}}`))
+	}))
+	defer server.Close()
+
+	gitCommand := NewDummyGitCommand()
+	gitCommand.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		if strings.HasPrefix(cmd, "git") {
+			return exec.Command("echo", "git@ssh.dev.azure.com:v3/contoso/web/storefront")
+		}
+
+		assert.Equal(t, "open", cmd)
+		assert.Equal(t, []string{"https://dev.azure.com/contoso/web/_git/storefront/pullrequest/7"}, args)
+		return exec.Command("echo")
+	}
+	gitCommand.OSCommand.Config.GetUserConfig().Set("os.openLinkCommand", "open {{link}}")
+	gitCommand.OSCommand.getenv = func(name string) string {
+		if name == "AZURE_DEVOPS_TOKEN" {
+			return "pat"
+		}
+		return ""
+	}
+
+	service := NewService("azuredevops", "dev.azure.com", "dev.azure.com")
+	service.APIURL = server.URL
+	pr := &PullRequest{GitServices: []*Service{service}, GitCommand: gitCommand}
+
+	description := &PullRequestDescription{Title: "Add a cart", Body: "- Add a cart (AB#99)\n- Fix #1234"}
+	prefilled, err := pr.CreateWithDescription(&Branch{Name: "feature/1234-cart"}, "develop", description)
+	assert.NoError(t, err)
+	assert.True(t, prefilled)
+}
@@ -11,4 +11,5 @@ type Branch struct {
 	Pullables    string
 	UpstreamName string
 	Head         bool
+	WorktreePath string // set when the branch is checked out in another worktree
 }
@@ -133,6 +133,7 @@ outer:
 		}
 		branches = append([]*Branch{{Name: currentBranchName, DisplayName: currentBranchDisplayName, Head: true, Recency: "  *"}}, branches...)
 	}
+	b.GitCommand.setWorktreePaths(branches)
 	return branches
 }
 
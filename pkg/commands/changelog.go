@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ChangelogCommit is a commit to be listed in a changelog
+type ChangelogCommit struct {
+	Sha     string
+	Message string
+}
+
+// the sections of a changelog section, in the order they're shown. Commits
+// that don't follow the conventional commit format, or have a type not listed
+// here (e.g. chore or ci), go under 'Other changes'
+var changelogGroups = []struct {
+	commitType string
+	heading    string
+}{
+	{"feat", "Features"},
+	{"fix", "Bug fixes"},
+	{"perf", "Performance improvements"},
+	{"revert", "Reverts"},
+	{"refactor", "Refactoring"},
+	{"docs", "Documentation"},
+	{"", "Other changes"},
+}
+
+// matches e.g. 'feat(parser)!: support arrays', capturing the type, scope,
+// breaking marker and description
+var conventionalCommitRegexp = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+
+// GetChangelogCommits returns the non-merge commits reachable from to but not
+// from, newest first. An empty from means every commit up to to
+func (c *GitCommand) GetChangelogCommits(from string, to string) ([]ChangelogCommit, error) {
+	revisionRange := to
+	if from != "" {
+		revisionRange = fmt.Sprintf("%s..%s", from, to)
+	}
+	output, err := c.OSCommand.RunCommandWithOutput("git log --no-merges --no-color --format=%%H%%x00%%B%%x00 %s --", revisionRange)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []ChangelogCommit{}
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		commits = append(commits, ChangelogCommit{
+			Sha:     strings.TrimSpace(fields[i]),
+			Message: strings.TrimSpace(fields[i+1]),
+		})
+	}
+	return commits, nil
+}
+
+// BuildChangelog renders a markdown changelog section with the given title,
+// grouping the commits by their conventional commit type. Breaking changes,
+// marked with a '!' after the type or a 'BREAKING CHANGE:' footer, are also
+// called out in a section of their own at the top
+func BuildChangelog(title string, commits []ChangelogCommit) string {
+	grouped := map[string][]string{}
+	breaking := []string{}
+
+	for _, commit := range commits {
+		subject := strings.SplitN(commit.Message, "\n", 2)[0]
+		shortSha := commit.Sha
+		if len(shortSha) > 7 {
+			shortSha = shortSha[:7]
+		}
+
+		commitType := ""
+		description := subject
+		isBreaking := strings.Contains(commit.Message, "\nBREAKING CHANGE:") || strings.Contains(commit.Message, "\nBREAKING-CHANGE:")
+		if match := conventionalCommitRegexp.FindStringSubmatch(subject); match != nil {
+			commitType = strings.ToLower(match[1])
+			description = match[4]
+			if match[2] != "" {
+				description = fmt.Sprintf("**%s:** %s", match[2], description)
+			}
+			isBreaking = isBreaking || match[3] == "!"
+		}
+
+		entry := fmt.Sprintf("- %s (%s)", description, shortSha)
+		if isBreaking {
+			breaking = append(breaking, entry)
+		}
+
+		group := ""
+		for _, g := range changelogGroups {
+			if g.commitType == commitType {
+				group = commitType
+				break
+			}
+		}
+		grouped[group] = append(grouped[group], entry)
+	}
+
+	sections := []string{"## " + title}
+	if len(breaking) > 0 {
+		sections = append(sections, "### Breaking changes\n\n"+strings.Join(breaking, "\n"))
+	}
+	for _, g := range changelogGroups {
+		if entries := grouped[g.commitType]; len(entries) > 0 {
+			sections = append(sections, fmt.Sprintf("### %s\n\n%s", g.heading, strings.Join(entries, "\n")))
+		}
+	}
+	return strings.Join(sections, "\n\n") + "\n"
+}
+
+// InsertChangelogSection puts a new section into the content of a changelog
+// file, above the most recent existing section and below any introduction
+func InsertChangelogSection(content string, section string) string {
+	if strings.TrimSpace(content) == "" {
+		return "# Changelog\n\n" + section
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			return strings.Join(lines[:i], "\n") + "\n" + section + "\n" + strings.Join(lines[i:], "\n")
+		}
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + section
+}
+
+// WriteChangelogSection adds a section to the changelog file at the given path,
+// creating the file if need be, and stages it
+func (c *GitCommand) WriteChangelogSection(path string, section string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return WrapError(err)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(InsertChangelogSection(string(content), section)), 0644); err != nil {
+		return WrapError(err)
+	}
+	return c.StageFile(path)
+}
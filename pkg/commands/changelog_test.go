@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetChangelogCommits is a function.
+func TestGitCommandGetChangelogCommits(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, "v1.0.0..v1.1.0", args[len(args)-2])
+		return exec.Command("printf", "abc\\x00feat: arrays\n\nmore detail\n\\x00\ndef\\x00fix: crash\n\\x00\n")
+	})
+
+	commits, err := gitCmd.GetChangelogCommits("v1.0.0", "v1.1.0")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []ChangelogCommit{
+		{Sha: "abc", Message: "feat: arrays\n\nmore detail"},
+		{Sha: "def", Message: "fix: crash"},
+	}, commits)
+}
+
+// TestBuildChangelog is a function.
+func TestBuildChangelog(t *testing.T) {
+	commits := []ChangelogCommit{
+		{Sha: "1111111111", Message: "feat(parser)!: support arrays"},
+		{Sha: "2222222222", Message: "fix: don't crash on empty input"},
+		{Sha: "3333333333", Message: "chore: bump deps"},
+		{Sha: "4444444444", Message: "feat: add a flag\n\nBREAKING CHANGE: the old flag is gone"},
+		{Sha: "5555555555", Message: "Update README"},
+	}
+
+	expected := `## v1.1.0
+
+### Breaking changes
+
+- **parser:** support arrays (1111111)
+- add a flag (4444444)
+
+### Features
+
+- **parser:** support arrays (1111111)
+- add a flag (4444444)
+
+### Bug fixes
+
+- don't crash on empty input (2222222)
+
+### Other changes
+
+- bump deps (3333333)
+- Update README (5555555)
+`
+
+	assert.EqualValues(t, expected, BuildChangelog("v1.1.0", commits))
+}
+
+// TestInsertChangelogSection is a function.
+func TestInsertChangelogSection(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  string
+		expected string
+	}
+
+	section := "## v2\n\n- new\n"
+
+	scenarios := []scenario{
+		{
+			"new file",
+			"",
+			"# Changelog\n\n## v2\n\n- new\n",
+		},
+		{
+			"above the latest section",
+			"# Changelog\n\nAll notable changes.\n\n## v1\n\n- old\n",
+			"# Changelog\n\nAll notable changes.\n\n## v2\n\n- new\n\n## v1\n\n- old\n",
+		},
+		{
+			"no sections yet",
+			"# Changelog\n",
+			"# Changelog\n\n## v2\n\n- new\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, InsertChangelogSection(s.content, section))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// CopyToClipboard copies the string with os.copyToClipboardCommand if the user
+// has set one, or else with whichever clipboard tool we can find. Failing
+// that, e.g. over ssh, we ask the terminal to do it with an OSC 52 escape
+// sequence, which most terminals and tmux support
+func (c *OSCommand) CopyToClipboard(str string) error {
+	if commandTemplate := c.Config.GetUserConfig().GetString("os.copyToClipboardCommand"); commandTemplate != "" {
+		// the command gets the string on its stdin. A {{str}} in it is
+		// quoted for us splitting it up, not for a shell, so it's only safe
+		// in a command which isn't run through one
+		command := utils.ResolvePlaceholderString(commandTemplate, map[string]string{"str": c.Quote(str)})
+		return WrapError(c.copyWith(c.ExecutableFromString(command), str))
+	}
+
+	if args := c.clipboardCommand(); args != nil {
+		if err := c.copyWith(c.command(args[0], args[1:]...), str); err == nil {
+			return nil
+		}
+		c.Log.Warnf("%s failed, copying with OSC 52 instead", args[0])
+	}
+
+	_, err := io.WriteString(c.terminal, osc52Sequence(str, c.getenv("TMUX") != ""))
+	return err
+}
+
+// copyWith runs the clipboard command with the string on its stdin. We leave
+// stdout alone, because xclip stays around in the background to hand the text
+// out, holding on to any pipe we'd give it
+func (c *OSCommand) copyWith(cmd *exec.Cmd, str string) error {
+	cmd.Stdin = strings.NewReader(str)
+	return cmd.Run()
+}
+
+// clipboardCommand returns the first clipboard tool which is available and
+// can reach the user's clipboard, or nil if there isn't one
+func (c *OSCommand) clipboardCommand() []string {
+	candidates := [][]string{}
+	switch c.Platform.os {
+	case "darwin":
+		candidates = append(candidates, []string{"pbcopy"})
+	case "windows":
+		candidates = append(candidates, []string{"clip.exe"})
+	default:
+		// WSLg sets DISPLAY too, but the Windows clipboard is the one to use
+		if c.isWSL() {
+			candidates = append(candidates, []string{"clip.exe"})
+		}
+		if c.getenv("WAYLAND_DISPLAY") != "" {
+			candidates = append(candidates, []string{"wl-copy"})
+		}
+		if c.getenv("DISPLAY") != "" {
+			candidates = append(candidates,
+				[]string{"xclip", "-selection", "clipboard"},
+				[]string{"xsel", "--clipboard", "--input"},
+			)
+		}
+		// older versions of WSL don't set the variables isWSL looks for
+		candidates = append(candidates, []string{"clip.exe"})
+	}
+
+	for _, candidate := range candidates {
+		if _, err := c.lookPath(candidate[0]); err == nil {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// osc52Sequence returns the escape sequence which asks the terminal to put the
+// string on the clipboard. Inside tmux it has to be wrapped to be passed on to
+// the terminal tmux is running in
+func osc52Sequence(str string, inTmux bool) string {
+	sequence := fmt.Sprintf("\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(str)))
+	if inTmux {
+		return "\x1bPtmux;" + strings.Replace(sequence, "\x1b", "\x1b\x1b", -1) + "\x1b\\"
+	}
+	return sequence
+}
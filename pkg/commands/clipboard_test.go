@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandClipboardCommand is a function.
+func TestOSCommandClipboardCommand(t *testing.T) {
+	type scenario struct {
+		testName  string
+		os        string
+		env       map[string]string
+		available []string
+		expected  []string
+	}
+
+	scenarios := []scenario{
+		{"macOS", "darwin", nil, []string{"pbcopy"}, []string{"pbcopy"}},
+		{"Wayland", "linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, []string{"wl-copy"}},
+		{"X11 with xsel only", "linux", map[string]string{"DISPLAY": ":0"}, []string{"xsel"}, []string{"xsel", "--clipboard", "--input"}},
+		{"WSL", "linux", nil, []string{"xclip", "clip.exe"}, []string{"clip.exe"}},
+		{"WSLg", "linux", map[string]string{"WSL_DISTRO_NAME": "Ubuntu", "DISPLAY": ":0"}, []string{"xclip", "clip.exe"}, []string{"clip.exe"}},
+		{"ssh session", "linux", nil, []string{"xclip"}, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			osCommand := NewDummyOSCommand()
+			osCommand.Platform.os = s.os
+			osCommand.getenv = func(name string) string { return s.env[name] }
+			osCommand.lookPath = func(name string) (string, error) {
+				for _, available := range s.available {
+					if name == available {
+						return "/usr/bin/" + name, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+
+			assert.EqualValues(t, s.expected, osCommand.clipboardCommand())
+		})
+	}
+}
+
+// TestOSCommandCopyToClipboardWithOSC52 is a function.
+func TestOSCommandCopyToClipboardWithOSC52(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("os.copyToClipboardCommand", "")
+	osCommand.Platform.os = "linux"
+	osCommand.getenv = func(string) string { return "" }
+	osCommand.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		t.Fatal("no command should be run")
+		return nil
+	})
+	var terminal bytes.Buffer
+	osCommand.terminal = &terminal
+
+	assert.NoError(t, osCommand.CopyToClipboard("abc123"))
+	assert.EqualValues(t, "\x1b]52;c;YWJjMTIz\a", terminal.String())
+}
+
+// TestOSCommandCopyToClipboardWithCustomCommand is a function.
+func TestOSCommandCopyToClipboardWithCustomCommand(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("os.copyToClipboardCommand", "my-clipboard-tool --copy")
+	var copied bytes.Buffer
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "my-clipboard-tool", cmd)
+		assert.EqualValues(t, []string{"--copy"}, args)
+		command := exec.Command("cat")
+		command.Stdout = &copied
+		return command
+	})
+
+	assert.NoError(t, osCommand.CopyToClipboard("it's $(id)"))
+	assert.EqualValues(t, "it's $(id)", copied.String())
+}
+
+// TestOSC52Sequence is a function.
+func TestOSC52Sequence(t *testing.T) {
+	assert.EqualValues(t, "\x1b]52;c;YWJjMTIz\a", osc52Sequence("abc123", false))
+	assert.EqualValues(t, "\x1bPtmux;\x1b\x1b]52;c;YWJjMTIz\a\x1b\\", osc52Sequence("abc123", true))
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// CommandRecord is a command we ran, kept in the command history so that the
+// user can see what lazygit has been doing to their repo
+type CommandRecord struct {
+	Command  string
+	Start    time.Time
+	Duration time.Duration
+	// ExitCode is the command's exit status, or -1 if it didn't exit by
+	// itself, e.g. because it was killed or couldn't be started, or if we
+	// don't know it
+	ExitCode int
+	// Output is what the command wrote, cut down to its last
+	// commandHistory.maxOutputLength bytes
+	Output string
+}
+
+// Failed tells us whether the command failed
+func (r CommandRecord) Failed() bool {
+	return r.ExitCode != 0
+}
+
+// commandHistory holds the most recent commands we ran, in a ring buffer of
+// commandHistory.size records, which is made the first time it's needed
+type commandHistory struct {
+	mutex   sync.Mutex
+	records []CommandRecord
+	// next is where the next record goes, and full tells us whether records
+	// has wrapped around, in which case that's also the oldest one
+	next int
+	full bool
+}
+
+// recordCommand adds a command to the command history, once it's done. cmd,
+// if we have it, tells us the exit status, and otherwise we go by err
+func (c *OSCommand) recordCommand(command string, cmd *exec.Cmd, start time.Time, output string, err error) {
+	userConfig := c.Config.GetUserConfig()
+
+	exitCode := 0
+	if cmd != nil && cmd.ProcessState != nil {
+		exitCode = cmd.ProcessState.ExitCode()
+	} else if err != nil {
+		exitCode = -1
+	}
+	if output == "" && err != nil {
+		output = err.Error()
+	}
+
+	record := CommandRecord{
+		Command:  command,
+		Start:    start,
+		Duration: time.Since(start),
+		ExitCode: exitCode,
+		Output:   truncateOutput(output, userConfig.GetInt("commandHistory.maxOutputLength")),
+	}
+
+	history := &c.history
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+	if history.records == nil {
+		size := userConfig.GetInt("commandHistory.size")
+		if size <= 0 {
+			return
+		}
+		history.records = make([]CommandRecord, size)
+	}
+	history.records[history.next] = record
+	history.next = (history.next + 1) % len(history.records)
+	history.full = history.full || history.next == 0
+}
+
+// CommandHistory returns the commands we've run, oldest first
+func (c *OSCommand) CommandHistory() []CommandRecord {
+	history := &c.history
+	history.mutex.Lock()
+	defer history.mutex.Unlock()
+
+	if !history.full {
+		return append([]CommandRecord{}, history.records[:history.next]...)
+	}
+	records := make([]CommandRecord, 0, len(history.records))
+	records = append(records, history.records[history.next:]...)
+	return append(records, history.records[:history.next]...)
+}
+
+// ExportCommandHistory writes the command history to a file, oldest first,
+// with each command's output indented below it
+func (c *OSCommand) ExportCommandHistory(path string) error {
+	var builder strings.Builder
+	for _, record := range c.CommandHistory() {
+		fmt.Fprintf(&builder, "%s (%s, exit status %d) %s\n", record.Start.Format("2006-01-02 15:04:05.000"), record.Duration.Round(time.Millisecond), record.ExitCode, record.Command)
+		for _, line := range strings.Split(strings.TrimRight(record.Output, "\n"), "\n") {
+			if line != "" {
+				builder.WriteString("    " + line + "\n")
+			}
+		}
+	}
+	return ioutil.WriteFile(path, []byte(builder.String()), 0644)
+}
+
+// truncateOutput keeps the last maxLength bytes of a command's output, which
+// is where any error will be
+func truncateOutput(output string, maxLength int) string {
+	if maxLength <= 0 || len(output) <= maxLength {
+		return output
+	}
+	start := len(output) - maxLength
+	// so as not to start halfway through a character
+	for start < len(output) && !utf8.RuneStart(output[start]) {
+		start++
+	}
+	return "..." + output[start:]
+}
+
+// outputTail keeps the end of a command's output as it's written, for the
+// command history, without holding on to all of a long one
+type outputTail struct {
+	maxLength int
+	text      []byte
+}
+
+func (t *outputTail) add(text string) {
+	t.text = append(t.text, text...)
+	if t.maxLength > 0 && len(t.text) > 2*t.maxLength {
+		t.text = append([]byte(nil), t.text[len(t.text)-t.maxLength:]...)
+	}
+}
+
+func (t *outputTail) String() string {
+	return string(t.text)
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandCommandHistory is a function.
+func TestOSCommandCommandHistory(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("commandHistory.size", 3)
+	osCommand.Config.GetUserConfig().Set("commandHistory.maxOutputLength", 5)
+
+	assert.Len(t, osCommand.CommandHistory(), 0)
+
+	start := time.Now()
+	osCommand.recordCommand("git status", nil, start, "clean", nil)
+	osCommand.recordCommand("git fetch", nil, start, "", errors.New("could not resolve host"))
+	records := osCommand.CommandHistory()
+	assert.Len(t, records, 2)
+	assert.Equal(t, "git status", records[0].Command)
+	assert.False(t, records[0].Failed())
+	assert.Equal(t, "git fetch", records[1].Command)
+	assert.Equal(t, -1, records[1].ExitCode)
+	assert.Equal(t, "... host", records[1].Output)
+
+	osCommand.recordCommand("git log", nil, start, "", nil)
+	osCommand.recordCommand("git diff", nil, start, "", nil)
+	commands := []string{}
+	for _, record := range osCommand.CommandHistory() {
+		commands = append(commands, record.Command)
+	}
+	assert.EqualValues(t, []string{"git fetch", "git log", "git diff"}, commands)
+}
+
+// TestOSCommandCommandHistoryRecordsExitCodes is a function.
+func TestOSCommandCommandHistoryRecordsExitCodes(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+
+	_, err := osCommand.RunCommandWithOutput("sh -c 'echo oops; exit 3'")
+	assert.Error(t, err)
+
+	records := osCommand.CommandHistory()
+	assert.Len(t, records, 1)
+	assert.Equal(t, "sh -c 'echo oops; exit 3'", records[0].Command)
+	assert.Equal(t, 3, records[0].ExitCode)
+	assert.Equal(t, "oops\n", records[0].Output)
+}
+
+// TestOSCommandExportCommandHistory is a function.
+func TestOSCommandExportCommandHistory(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.recordCommand("git push", nil, time.Now(), "To origin\n   abc..def  master -> master\n", nil)
+
+	dir, err := ioutil.TempDir("", "lazygit-command-history")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "history.log")
+
+	assert.NoError(t, osCommand.ExportCommandHistory(path))
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^\d{4}-\d\d-\d\d \d\d:\d\d:\d\d\.\d{3} \([^,]+, exit status 0\) git push\n    To origin\n       abc..def  master -> master\n$`, string(content))
+}
+
+// TestTruncateOutput is a function.
+func TestTruncateOutput(t *testing.T) {
+	assert.Equal(t, "short", truncateOutput("short", 10))
+	assert.Equal(t, "anything", truncateOutput("anything", 0))
+	assert.Equal(t, "...✓ok", truncateOutput("fine ✓ok", 5))
+}
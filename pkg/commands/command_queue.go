@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// how many times, and how far apart, a git command which found the index
+// locked is retried. Whatever holds the lock is usually done within a second
+const (
+	indexLockRetries           = 10
+	defaultIndexLockRetryDelay = 200 * time.Millisecond
+)
+
+// readOnlyGitCommands are the git commands which only read from the repo. They
+// can run alongside each other, unlike the rest, which wait for their turn
+var readOnlyGitCommands = map[string]bool{
+	"status": true, "log": true, "diff": true, "show": true, "rev-parse": true,
+	"rev-list": true, "ls-files": true, "ls-tree": true,
+	"cat-file": true, "for-each-ref": true, "merge-base": true, "describe": true,
+	"blame": true, "shortlog": true, "grep": true, "diff-tree": true,
+	"diff-index": true, "diff-files": true, "check-ignore": true,
+	"check-attr": true, "name-rev": true, "show-ref": true, "var": true,
+	"version": true, "count-objects": true, "verify-commit": true,
+	"verify-tag": true, "credential": true,
+}
+
+// unqueuedGitCommands don't wait for their turn at all. They talk to a remote,
+// which can take as long as the network likes, and only write refs, which git
+// locks one at a time, so there's no need for them to hold up anything else or
+// to be held up themselves
+var unqueuedGitCommands = map[string]bool{
+	"fetch": true, "push": true, "ls-remote": true,
+}
+
+// readOnlyGitActions are the read-only forms of git commands which can also
+// write, by the first argument after the command
+var readOnlyGitActions = map[string][]string{
+	"stash":    {"list", "show"},
+	"config":   {"--get", "--get-all", "--get-regexp", "--list", "-l"},
+	"remote":   {"get-url", "show", "-v"},
+	"worktree": {"list"},
+	"reflog":   {"show"},
+	"notes":    {"list", "show"},
+}
+
+// isReadOnlyGitCommand tells us whether the command, given as its arguments,
+// is a git command which doesn't write to the repo. Commands that aren't git
+// commands are neither, and aren't queued at all
+func isReadOnlyGitCommand(args []string) (isGit bool, readOnly bool) {
+	isGit, command, rest := gitSubcommand(args)
+	if !isGit {
+		return false, false
+	}
+	if command == "" {
+		return true, true
+	}
+
+	if readOnlyGitCommands[command] {
+		return true, true
+	}
+	if actions, ok := readOnlyGitActions[command]; ok {
+		if len(rest) == 0 {
+			// without an action these list things, except for stash, which
+			// stashes, and config and worktree, which need one
+			return true, command == "remote" || command == "reflog" || command == "notes"
+		}
+		for _, action := range actions {
+			if rest[0] == action {
+				return true, true
+			}
+		}
+		return true, false
+	}
+
+	if command == "branch" || command == "tag" {
+		// these list, unless given a name to create, delete or move, or told
+		// to change the current branch
+		for _, arg := range rest {
+			if !strings.HasPrefix(arg, "-") || arg == "--unset-upstream" || arg == "--edit-description" || strings.HasPrefix(arg, "--set-upstream-to") {
+				return true, false
+			}
+		}
+		return true, true
+	}
+
+	return true, false
+}
+
+// gitSubcommand splits a git command, given as its arguments, into the
+// subcommand, e.g. 'log', and the arguments after it, skipping any options for
+// git itself like -c key=value
+func gitSubcommand(args []string) (isGit bool, command string, rest []string) {
+	if len(args) == 0 || args[0] != "git" {
+		return false, "", nil
+	}
+
+	i := 1
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		if args[i] == "-c" || args[i] == "-C" {
+			i++
+		}
+		i++
+	}
+	if i >= len(args) {
+		return true, "", nil
+	}
+	return true, args[i], args[i+1:]
+}
+
+// queued runs a command, given as its arguments, once it may run: alongside
+// other read-only git commands, or on its own if it writes to the repo. This
+// keeps our background refreshes from taking the index lock out from under
+// the user's own actions. run is tried again for a while if git finds the
+// index locked anyway, e.g. by a git process outside lazygit, so it has to
+// start the command afresh each time. If ctx is cancelled while the command
+// is waiting for its turn, it isn't run at all
+func (c *OSCommand) queued(ctx context.Context, args []string, run func() error) error {
+	done, err := c.waitForTurn(ctx, args)
+	if err != nil {
+		return err
+	}
+	defer done()
+	return c.retryWhileIndexLocked(run)
+}
+
+// waitForTurn waits until the command may run, returning the function to call
+// once it's done, to let the next one go, or ctx's error if it's cancelled
+// first
+func (c *OSCommand) waitForTurn(ctx context.Context, args []string) (func(), error) {
+	isGit, readOnly := isReadOnlyGitCommand(args)
+	if _, command, _ := gitSubcommand(args); !isGit || unqueuedGitCommands[command] {
+		return func() {}, nil
+	}
+	lock, unlock := c.queue.Lock, c.queue.Unlock
+	if readOnly {
+		lock, unlock = c.queue.RLock, c.queue.RUnlock
+	}
+	if err := lockWithContext(ctx, lock, unlock); err != nil {
+		return nil, err
+	}
+	return unlock, nil
+}
+
+// lockWithContext takes a lock, unless ctx is cancelled before we get it
+func lockWithContext(ctx context.Context, lock func(), unlock func()) error {
+	if ctx.Done() == nil {
+		lock()
+		return nil
+	}
+
+	locked := make(chan struct{})
+	go func() {
+		lock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+		return nil
+	case <-ctx.Done():
+		// there's no giving up on a mutex, so we let the lock go as soon as
+		// we get it
+		go func() {
+			<-locked
+			unlock()
+		}()
+		return ctx.Err()
+	}
+}
+
+// retryWhileIndexLocked runs run until it doesn't fail on git finding the
+// index locked, or until we've tried enough times
+func (c *OSCommand) retryWhileIndexLocked(run func() error) error {
+	err := run()
+	for attempt := 0; attempt < indexLockRetries && isIndexLockedError(err); attempt++ {
+		c.Log.Warn("the index is locked, retrying")
+		time.Sleep(c.indexLockRetryDelay)
+		err = run()
+	}
+	return err
+}
+
+// isIndexLockedError tells us whether git failed because it found the index
+// locked, as in "fatal: Unable to create '.../.git/index.lock': File exists."
+func isIndexLockedError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "index.lock': File exists")
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/mgutz/str"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsReadOnlyGitCommand is a function.
+func TestIsReadOnlyGitCommand(t *testing.T) {
+	type scenario struct {
+		command          string
+		expectedIsGit    bool
+		expectedReadOnly bool
+	}
+
+	scenarios := []scenario{
+		{"git status --untracked-files=all --porcelain -z", true, true},
+		{"git -c color.ui=false log --oneline", true, true},
+		{"git --no-pager diff -- file.txt", true, true},
+		{"git stash list", true, true},
+		{"git stash", true, false},
+		{"git stash pop stash@{0}", true, false},
+		{"git config --get remote.origin.url", true, true},
+		{"git config user.name Peter", true, false},
+		{"git remote", true, true},
+		{"git remote add upstream git@github.com:a/b.git", true, false},
+		{"git branch -r", true, true},
+		{"git branch --sort=-committerdate --format=%(refname:short)", true, true},
+		{"git branch feature", true, false},
+		{"git branch -D feature", true, false},
+		{"git branch --unset-upstream", true, false},
+		{"git tag --list", true, true},
+		{"git tag v1.0.0", true, false},
+		{"git commit -m 'message'", true, false},
+		{"git add -- file.txt", true, false},
+		{"git checkout master", true, false},
+		{"echo hello", false, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.command, func(t *testing.T) {
+			isGit, readOnly := isReadOnlyGitCommand(str.ToArgv(s.command))
+			assert.EqualValues(t, s.expectedIsGit, isGit)
+			assert.EqualValues(t, s.expectedReadOnly, readOnly)
+		})
+	}
+}
+
+// TestOSCommandRetriesWhileIndexLocked is a function.
+func TestOSCommandRetriesWhileIndexLocked(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.indexLockRetryDelay = time.Millisecond
+	attempts := 0
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		attempts++
+		if attempts < 3 {
+			return exec.Command("sh", "-c", "echo \"fatal: Unable to create '/repo/.git/index.lock': File exists.\" >&2; exit 128")
+		}
+		return exec.Command("echo", "done")
+	})
+
+	output, err := osCommand.RunCommandWithOutput("git add -- file.txt")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "done\n", output)
+	assert.EqualValues(t, 3, attempts)
+}
+
+// TestOSCommandWritesWaitForReads is a function.
+func TestOSCommandWritesWaitForReads(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		return exec.Command("echo")
+	})
+
+	// a read-only command is in progress
+	osCommand.queue.RLock()
+
+	// other reads go ahead
+	assert.NoError(t, osCommand.RunCommand("git status"))
+
+	// but writes wait for it to finish
+	done := make(chan error)
+	go func() {
+		done <- osCommand.RunCommand("git commit -m message")
+	}()
+	select {
+	case <-done:
+		t.Fatal("the commit ran while the read-only command was running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	osCommand.queue.RUnlock()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the commit never ran")
+	}
+}
+
+// TestOSCommandNetworkCommandsDontWait is a function.
+func TestOSCommandNetworkCommandsDontWait(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		return exec.Command("echo")
+	})
+
+	// a command which writes to the repo is in progress
+	osCommand.queue.Lock()
+	defer osCommand.queue.Unlock()
+
+	done := make(chan error)
+	go func() {
+		done <- osCommand.RunCommand("git fetch origin")
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the fetch waited for the write")
+	}
+}
+
+// TestOSCommandStopsWaitingWhenCancelled is a function.
+func TestOSCommandStopsWaitingWhenCancelled(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	ran := false
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		ran = true
+		return exec.Command("echo")
+	})
+
+	// a command which writes to the repo is in progress
+	osCommand.queue.Lock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := osCommand.RunCommandWithOutputContext(ctx, "git log")
+	assert.EqualValues(t, context.DeadlineExceeded, err)
+	assert.False(t, ran, "the command shouldn't run once it's been given up on")
+
+	// the lock it was waiting for isn't left taken once it's free
+	osCommand.queue.Unlock()
+	assert.NoError(t, osCommand.RunCommand("git commit -m message"))
+}
@@ -4,12 +4,24 @@ package commands
 type Commit struct {
 	Sha           string
 	Name          string
-	Status        string // one of "unpushed", "pushed", "merged", "rebasing" or "selected"
+	Status        string // one of "unpushed", "pushed", "merged", "equivalent", "rebasing" or "selected"
 	Action        string // one of "", "pick", "edit", "squash", "reword", "drop", "fixup"
 	Tags          []string
-	ExtraInfo     string // something like 'HEAD -> master, tag: v0.15.2'
+	Refs          []*CommitRef
+	Parents       []string // the shas of the commit's parents, first parent first
 	Author        string
 	UnixTimestamp int64
+	Annotated     bool // whether we have a local annotation for this commit
+	// MergeExpansion is only set in first-parent mode: one of "", "collapsed" or
+	// "expanded" for mainline merges, or "merged-in" for the commits listed
+	// beneath an expanded merge
+	MergeExpansion string
+}
+
+// CommitRef : A branch, remote branch or tag pointing at a commit
+type CommitRef struct {
+	Name string
+	Kind string // one of "head" (the checked out branch, or HEAD when detached), "branch", "remote", "tag" or "other"
 }
 
 func (c *Commit) ShortSha() string {
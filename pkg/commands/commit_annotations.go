@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// annotations are free-form review notes attached to commits. Unlike git notes
+// they live in our own state dir, keyed by sha, so they never end up in the
+// repo or get pushed anywhere
+func (c *GitCommand) annotationsDir() string {
+	return filepath.Join(c.Config.GetStateDir(), "annotations")
+}
+
+// GetCommitAnnotation returns the annotation attached to a commit, or an empty
+// string if there isn't one
+func (c *GitCommand) GetCommitAnnotation(sha string) string {
+	content, err := ioutil.ReadFile(filepath.Join(c.annotationsDir(), sha))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// SetCommitAnnotation attaches an annotation to a commit, replacing any
+// existing one. An empty annotation removes it
+func (c *GitCommand) SetCommitAnnotation(sha string, annotation string) error {
+	path := filepath.Join(c.annotationsDir(), sha)
+	annotation = strings.TrimSpace(annotation)
+	if annotation == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return WrapError(err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(c.annotationsDir(), 0755); err != nil {
+		return WrapError(err)
+	}
+	return WrapError(ioutil.WriteFile(path, []byte(annotation+"\n"), 0644))
+}
+
+// GetAnnotatedShas returns the shas of every commit with an annotation
+func (c *GitCommand) GetAnnotatedShas() map[string]bool {
+	annotated := map[string]bool{}
+	entries, err := ioutil.ReadDir(c.annotationsDir())
+	if err != nil {
+		return annotated
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			annotated[entry.Name()] = true
+		}
+	}
+	return annotated
+}
+
+// SetAnnotatedStatuses flags each of the given commits that has an annotation
+func SetAnnotatedStatuses(commits []*Commit, annotatedShas map[string]bool) {
+	for _, commit := range commits {
+		commit.Annotated = annotatedShas[commit.Sha]
+	}
+}
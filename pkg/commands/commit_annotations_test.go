@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandSetCommitAnnotation is a function.
+func TestGitCommandSetCommitAnnotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-annotations")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	appConfig := NewDummyAppConfig()
+	appConfig.StateDir = dir
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config = appConfig
+
+	assert.Equal(t, "", gitCmd.GetCommitAnnotation("abc"))
+	assert.EqualValues(t, map[string]bool{}, gitCmd.GetAnnotatedShas())
+
+	assert.NoError(t, gitCmd.SetCommitAnnotation("abc", "  needs a second look\n"))
+	assert.NoError(t, gitCmd.SetCommitAnnotation("def", "fine"))
+	assert.Equal(t, "needs a second look", gitCmd.GetCommitAnnotation("abc"))
+	assert.EqualValues(t, map[string]bool{"abc": true, "def": true}, gitCmd.GetAnnotatedShas())
+
+	// clearing an annotation removes it altogether
+	assert.NoError(t, gitCmd.SetCommitAnnotation("abc", " "))
+	assert.Equal(t, "", gitCmd.GetCommitAnnotation("abc"))
+	assert.EqualValues(t, map[string]bool{"def": true}, gitCmd.GetAnnotatedShas())
+}
+
+// TestSetAnnotatedStatuses is a function.
+func TestSetAnnotatedStatuses(t *testing.T) {
+	commits := []*Commit{{Sha: "abc"}, {Sha: "def", Annotated: true}, {Sha: "ghi"}}
+
+	SetAnnotatedStatuses(commits, map[string]bool{"abc": true})
+
+	assert.True(t, commits[0].Annotated)
+	assert.False(t, commits[1].Annotated)
+	assert.False(t, commits[2].Annotated)
+}
@@ -1,11 +1,19 @@
 package commands
 
+import (
+	"sort"
+	"strings"
+)
+
 // CommitFile : A git commit file
 type CommitFile struct {
 	Sha           string
 	Name          string
 	DisplayString string
 	Status        int // one of 'WHOLE' 'PART' 'NONE'
+	IsDirectory   bool
+	Viewed        bool // whether it's been marked as viewed while reviewing the commit
+	IsBinary      bool // whether git considers the commit's change to it binary
 }
 
 const (
@@ -17,3 +25,76 @@ const (
 	// PART is for when you're only talking about specific lines that have been modified
 	PART
 )
+
+// BuildCommitFileTree returns the given files along with an entry for each
+// directory they're in, ordered so that each directory comes before its
+// contents, and with display strings indented to show the nesting
+func BuildCommitFileTree(files []*CommitFile) []*CommitFile {
+	sorted := make([]*CommitFile, len(files))
+	copy(sorted, files)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	nodes := []*CommitFile{}
+	openDirs := []string{}
+	for _, file := range sorted {
+		parts := strings.Split(file.Name, "/")
+		parentParts := parts[:len(parts)-1]
+
+		common := 0
+		for common < len(openDirs) && common < len(parentParts) && openDirs[common] == strings.Join(parentParts[:common+1], "/") {
+			common++
+		}
+		openDirs = openDirs[:common]
+
+		for i := common; i < len(parentParts); i++ {
+			dirName := strings.Join(parentParts[:i+1], "/")
+			openDirs = append(openDirs, dirName)
+			nodes = append(nodes, &CommitFile{
+				Sha:           file.Sha,
+				Name:          dirName,
+				DisplayString: strings.Repeat("  ", i) + parentParts[i] + "/",
+				Status:        directoryStatus(files, dirName),
+				IsDirectory:   true,
+			})
+		}
+
+		node := *file
+		node.DisplayString = strings.Repeat("  ", len(parentParts)) + parts[len(parts)-1]
+		nodes = append(nodes, &node)
+	}
+
+	return nodes
+}
+
+// FilesInDirectory returns the files (not directories) within the given
+// directory, at any depth
+func FilesInDirectory(files []*CommitFile, dirName string) []*CommitFile {
+	result := []*CommitFile{}
+	for _, file := range files {
+		if !file.IsDirectory && strings.HasPrefix(file.Name, dirName+"/") {
+			result = append(result, file)
+		}
+	}
+	return result
+}
+
+// directoryStatus is WHOLE if every file in the directory is wholly in the
+// patch, PART if only some of it is, and UNSELECTED otherwise
+func directoryStatus(files []*CommitFile, dirName string) int {
+	status := UNSELECTED
+	allWhole := true
+	for _, file := range FilesInDirectory(files, dirName) {
+		if file.Status != WHOLE {
+			allWhole = false
+		}
+		if file.Status != UNSELECTED {
+			status = PART
+		}
+	}
+	if allWhole && status == PART {
+		return WHOLE
+	}
+	return status
+}
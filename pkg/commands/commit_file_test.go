@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildCommitFileTree is a function.
+func TestBuildCommitFileTree(t *testing.T) {
+	type scenario struct {
+		testName string
+		files    []*CommitFile
+		expected []*CommitFile
+	}
+
+	scenarios := []scenario{
+		{
+			"no files",
+			[]*CommitFile{},
+			[]*CommitFile{},
+		},
+		{
+			"files at the root",
+			[]*CommitFile{
+				{Sha: "123", Name: "b.go", DisplayString: "b.go"},
+				{Sha: "123", Name: "a.go", DisplayString: "a.go"},
+			},
+			[]*CommitFile{
+				{Sha: "123", Name: "a.go", DisplayString: "a.go"},
+				{Sha: "123", Name: "b.go", DisplayString: "b.go"},
+			},
+		},
+		{
+			"nested directories",
+			[]*CommitFile{
+				{Sha: "123", Name: "README.md", DisplayString: "README.md"},
+				{Sha: "123", Name: "pkg/gui/gui.go", DisplayString: "pkg/gui/gui.go", Status: WHOLE},
+				{Sha: "123", Name: "pkg/commands/git.go", DisplayString: "pkg/commands/git.go", Status: WHOLE},
+				{Sha: "123", Name: "pkg/commands/os.go", DisplayString: "pkg/commands/os.go"},
+			},
+			[]*CommitFile{
+				{Sha: "123", Name: "README.md", DisplayString: "README.md"},
+				{Sha: "123", Name: "pkg", DisplayString: "pkg/", Status: PART, IsDirectory: true},
+				{Sha: "123", Name: "pkg/commands", DisplayString: "  commands/", Status: PART, IsDirectory: true},
+				{Sha: "123", Name: "pkg/commands/git.go", DisplayString: "    git.go", Status: WHOLE},
+				{Sha: "123", Name: "pkg/commands/os.go", DisplayString: "    os.go"},
+				{Sha: "123", Name: "pkg/gui", DisplayString: "  gui/", Status: WHOLE, IsDirectory: true},
+				{Sha: "123", Name: "pkg/gui/gui.go", DisplayString: "    gui.go", Status: WHOLE},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, BuildCommitFileTree(s.files))
+		})
+	}
+}
+
+// TestFilesInDirectory is a function.
+func TestFilesInDirectory(t *testing.T) {
+	files := BuildCommitFileTree([]*CommitFile{
+		{Name: "pkg/gui/gui.go"},
+		{Name: "pkg/commands/git.go"},
+		{Name: "pkgs.go"},
+	})
+
+	names := []string{}
+	for _, file := range FilesInDirectory(files, "pkg") {
+		names = append(names, file.Name)
+	}
+	assert.EqualValues(t, []string{"pkg/commands/git.go", "pkg/gui/gui.go"}, names)
+}
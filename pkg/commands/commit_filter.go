@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommitFilter narrows the commits panel down by date and author. Since and
+// Until take anything git's date parser does (e.g. '2020-06-01' or '2 weeks
+// ago') and Author is matched against both the author name and email
+type CommitFilter struct {
+	Since  string
+	Until  string
+	Author string
+}
+
+// IsEmpty tells us whether the filter lets every commit through
+func (f CommitFilter) IsEmpty() bool {
+	return f.Since == "" && f.Until == "" && f.Author == ""
+}
+
+// Args returns the git log flags for the filter
+func (f CommitFilter) Args(quote func(string) string) string {
+	args := []string{}
+	if f.Since != "" {
+		args = append(args, "--since="+quote(f.Since))
+	}
+	if f.Until != "" {
+		args = append(args, "--until="+quote(f.Until))
+	}
+	if f.Author != "" {
+		args = append(args, "--author="+quote(f.Author))
+	}
+	return strings.Join(args, " ")
+}
+
+// String describes the filter for the commits panel's title
+func (f CommitFilter) String() string {
+	parts := []string{}
+	if f.Since != "" {
+		parts = append(parts, fmt.Sprintf("since %s", f.Since))
+	}
+	if f.Until != "" {
+		parts = append(parts, fmt.Sprintf("until %s", f.Until))
+	}
+	if f.Author != "" {
+		parts = append(parts, fmt.Sprintf("by %s", f.Author))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CurrentUserEmail returns the email that new commits will be authored with
+func (c *GitCommand) CurrentUserEmail() string {
+	return c.getConfigValue("user.email")
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCommitFilter is a function.
+func TestCommitFilter(t *testing.T) {
+	type scenario struct {
+		testName       string
+		filter         CommitFilter
+		expectedEmpty  bool
+		expectedArgs   string
+		expectedString string
+	}
+
+	quote := func(s string) string { return "'" + s + "'" }
+
+	scenarios := []scenario{
+		{
+			"no filter",
+			CommitFilter{},
+			true,
+			"",
+			"",
+		},
+		{
+			"since only",
+			CommitFilter{Since: "2 weeks ago"},
+			false,
+			"--since='2 weeks ago'",
+			"since 2 weeks ago",
+		},
+		{
+			"everything",
+			CommitFilter{Since: "2020-06-01", Until: "2020-06-30", Author: "jesse@example.com"},
+			false,
+			"--since='2020-06-01' --until='2020-06-30' --author='jesse@example.com'",
+			"since 2020-06-01, until 2020-06-30, by jesse@example.com",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expectedEmpty, s.filter.IsEmpty())
+			assert.EqualValues(t, s.expectedArgs, s.filter.Args(quote))
+			assert.EqualValues(t, s.expectedString, s.filter.String())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -58,14 +59,13 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *Commit {
 	unixTimestamp := split[1]
 	author := split[2]
 	extraInfo := strings.TrimSpace(split[3])
-	message := strings.Join(split[4:], SEPARATION_CHAR)
+	parents := strings.Fields(split[4])
+	message := strings.Join(split[5:], SEPARATION_CHAR)
+	refs := parseCommitRefs(extraInfo)
 	tags := []string{}
-
-	if extraInfo != "" {
-		re := regexp.MustCompile(`tag: ([^,\)]+)`)
-		tagMatch := re.FindStringSubmatch(extraInfo)
-		if len(tagMatch) > 1 {
-			tags = append(tags, tagMatch[1])
+	for _, ref := range refs {
+		if ref.Kind == "tag" {
+			tags = append(tags, ref.Name)
 		}
 	}
 
@@ -75,26 +75,74 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *Commit {
 		Sha:           sha,
 		Name:          message,
 		Tags:          tags,
-		ExtraInfo:     extraInfo,
+		Refs:          refs,
+		Parents:       parents,
 		UnixTimestamp: int64(unitTimestampInt),
 		Author:        author,
 	}
 }
 
+// parseCommitRefs parses the decoration git log gives us with --decorate=full
+// example input:
+// (HEAD -> refs/heads/master, refs/remotes/origin/master, tag: refs/tags/v0.15.2)
+func parseCommitRefs(decoration string) []*CommitRef {
+	decoration = strings.TrimSuffix(strings.TrimPrefix(decoration, "("), ")")
+	if decoration == "" {
+		return nil
+	}
+
+	refs := []*CommitRef{}
+	for _, item := range strings.Split(decoration, ", ") {
+		if strings.HasPrefix(item, "HEAD -> ") {
+			refs = append(refs, &CommitRef{Name: strings.TrimPrefix(item, "HEAD -> refs/heads/"), Kind: "head"})
+			continue
+		}
+
+		item = strings.TrimPrefix(item, "tag: ")
+		switch {
+		case item == "HEAD":
+			refs = append(refs, &CommitRef{Name: item, Kind: "head"})
+		case strings.HasPrefix(item, "refs/heads/"):
+			refs = append(refs, &CommitRef{Name: strings.TrimPrefix(item, "refs/heads/"), Kind: "branch"})
+		case strings.HasPrefix(item, "refs/remotes/"):
+			refs = append(refs, &CommitRef{Name: strings.TrimPrefix(item, "refs/remotes/"), Kind: "remote"})
+		case strings.HasPrefix(item, "refs/tags/"):
+			refs = append(refs, &CommitRef{Name: strings.TrimPrefix(item, "refs/tags/"), Kind: "tag"})
+		default:
+			refs = append(refs, &CommitRef{Name: strings.TrimPrefix(item, "refs/"), Kind: "other"})
+		}
+	}
+	return refs
+}
+
 type GetCommitsOptions struct {
 	Limit      bool
 	FilterPath string
+	// BaseBranch is the ref that commits are checked against to see if they've
+	// been merged. If empty we fall back to master (or develop for feature branches)
+	BaseBranch string
+	Filter     CommitFilter
+	// FirstParent only follows the first parent of merge commits, giving us
+	// the branch's mainline
+	FirstParent bool
 }
 
 // GetCommits obtains the commits of the current branch
 func (c *CommitListBuilder) GetCommits(options GetCommitsOptions) ([]*Commit, error) {
+	return c.GetCommitsWithContext(context.Background(), options)
+}
+
+// GetCommitsWithContext is GetCommits for when loading the log may take long
+// enough that the user wants to give up on it, as with the full log of a huge
+// repo
+func (c *CommitListBuilder) GetCommitsWithContext(ctx context.Context, options GetCommitsOptions) ([]*Commit, error) {
 	commits := []*Commit{}
 	var rebasingCommits []*Commit
 	rebaseMode, err := c.GitCommand.RebaseMode()
 	if err != nil {
 		return nil, err
 	}
-	if rebaseMode != "" && options.FilterPath == "" {
+	if rebaseMode != "" && options.FilterPath == "" && options.Filter.IsEmpty() {
 		// here we want to also prepend the commits that we're in the process of rebasing
 		rebasingCommits, err = c.getRebasingCommits(rebaseMode)
 		if err != nil {
@@ -108,7 +156,7 @@ func (c *CommitListBuilder) GetCommits(options GetCommitsOptions) ([]*Commit, er
 	unpushedCommits := c.getUnpushedCommits()
 	cmd := c.getLogCmd(options)
 
-	err = RunLineOutputCmd(cmd, func(line string) (bool, error) {
+	err = RunLineOutputCmdWithContext(ctx, cmd, func(line string) (bool, error) {
 		if strings.Split(line, " ")[0] != "gpg:" {
 			commit := c.extractCommitFromLine(line)
 			_, unpushed := unpushedCommits[commit.ShortSha()]
@@ -121,14 +169,14 @@ func (c *CommitListBuilder) GetCommits(options GetCommitsOptions) ([]*Commit, er
 		return nil, err
 	}
 
-	if rebaseMode != "" {
+	if rebaseMode != "" && options.Filter.IsEmpty() {
 		currentCommit := commits[len(rebasingCommits)]
 		blue := color.New(color.FgYellow)
 		youAreHere := blue.Sprintf("<-- %s ---", c.Tr.SLocalize("YouAreHere"))
 		currentCommit.Name = fmt.Sprintf("%s %s", youAreHere, currentCommit.Name)
 	}
 
-	commits, err = c.setCommitMergedStatuses(commits)
+	commits, err = c.setCommitMergedStatuses(commits, options.BaseBranch)
 	if err != nil {
 		return nil, err
 	}
@@ -246,19 +294,28 @@ func (c *CommitListBuilder) commitFromPatch(content string) (*Commit, error) {
 	}, nil
 }
 
-func (c *CommitListBuilder) setCommitMergedStatuses(commits []*Commit) ([]*Commit, error) {
-	ancestor, err := c.getMergeBase()
+func (c *CommitListBuilder) setCommitMergedStatuses(commits []*Commit, baseBranch string) ([]*Commit, error) {
+	baseBranch, err := c.getBaseBranch(baseBranch)
+	if err != nil {
+		return nil, err
+	}
+	ancestor, err := c.getMergeBase(baseBranch)
 	if err != nil {
 		return nil, err
 	}
 	if ancestor == "" {
 		return commits, nil
 	}
+	equivalents := c.getCommitsWithEquivalentIn(baseBranch)
 	passedAncestor := false
 	for i, commit := range commits {
 		if strings.HasPrefix(ancestor, commit.Sha) {
 			passedAncestor = true
 		}
+		if equivalents[commit.Sha] && (commit.Status == "pushed" || commit.Status == "unpushed") {
+			commits[i].Status = "equivalent"
+			continue
+		}
 		if commit.Status != "pushed" {
 			continue
 		}
@@ -269,22 +326,55 @@ func (c *CommitListBuilder) setCommitMergedStatuses(commits []*Commit) ([]*Commi
 	return commits, nil
 }
 
-func (c *CommitListBuilder) getMergeBase() (string, error) {
+// getBaseBranch returns the branch we check commits against to see if they've
+// been merged, defaulting to master (or develop if we're on a feature branch)
+func (c *CommitListBuilder) getBaseBranch(baseBranch string) (string, error) {
+	if baseBranch != "" {
+		return baseBranch, nil
+	}
+
 	currentBranch, _, err := c.GitCommand.CurrentBranchName()
 	if err != nil {
 		return "", err
 	}
 
-	baseBranch := "master"
 	if strings.HasPrefix(currentBranch, "feature/") {
-		baseBranch = "develop"
+		return "develop", nil
+	}
+	return "master", nil
+}
+
+func (c *CommitListBuilder) getMergeBase(baseBranch string) (string, error) {
+	baseBranch, err := c.getBaseBranch(baseBranch)
+	if err != nil {
+		return "", err
 	}
 
 	// swallowing error because it's not a big deal; probably because there are no commits yet
-	output, _ := c.OSCommand.RunCommandWithOutput("git merge-base HEAD %s", baseBranch)
+	output, _ := c.OSCommand.RunCommandArgsWithOutput("git", "merge-base", "HEAD", baseBranch)
 	return output, nil
 }
 
+// getCommitsWithEquivalentIn returns the shas of the commits on HEAD (since the
+// merge base) whose changes are already in baseBranch according to git cherry.
+// Because git cherry compares patch ids, this picks up commits that were
+// cherry-picked or rebased onto the base branch, meaning they're safe to drop
+func (c *CommitListBuilder) getCommitsWithEquivalentIn(baseBranch string) map[string]bool {
+	equivalents := map[string]bool{}
+
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "cherry", baseBranch, "HEAD")
+	if err != nil {
+		return equivalents
+	}
+	for _, line := range utils.SplitLines(output) {
+		if strings.HasPrefix(line, "- ") {
+			equivalents[strings.TrimPrefix(line, "- ")] = true
+		}
+	}
+
+	return equivalents
+}
+
 // getUnpushedCommits Returns the sha's of the commits that have not yet been pushed
 // to the remote branch of the current branch, a map is returned to ease look up
 func (c *CommitListBuilder) getUnpushedCommits() map[string]bool {
@@ -308,9 +398,22 @@ func (c *CommitListBuilder) getLogCmd(options GetCommitsOptions) *exec.Cmd {
 	}
 
 	filterFlag := ""
+	if !options.Filter.IsEmpty() {
+		filterFlag = " " + options.Filter.Args(c.OSCommand.Quote)
+	}
 	if options.FilterPath != "" {
-		filterFlag = fmt.Sprintf(" --follow -- %s", c.OSCommand.Quote(options.FilterPath))
+		filterFlag += fmt.Sprintf(" --follow -- %s", c.OSCommand.Quote(options.FilterPath))
 	}
 
-	return c.OSCommand.ExecutableFromString(fmt.Sprintf("git log --oneline --pretty=format:\"%%H%s%%at%s%%aN%s%%d%s%%s\" %s --abbrev=%d --date=unix %s", SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, limitFlag, 20, filterFlag))
+	firstParentFlag := ""
+	if options.FirstParent {
+		firstParentFlag = " --first-parent"
+	}
+
+	return c.OSCommand.ExecutableFromString(fmt.Sprintf("git log %s %s%s --abbrev=%d --date=unix --decorate=full %s", c.prettyFormatArg(), limitFlag, firstParentFlag, 20, filterFlag))
+}
+
+// prettyFormatArg is the git log format that extractCommitFromLine parses
+func (c *CommitListBuilder) prettyFormatArg() string {
+	return fmt.Sprintf("--oneline --pretty=format:\"%%H%s%%at%s%%aN%s%%d%s%%P%s%%s\"", SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR, SEPARATION_CHAR)
 }
@@ -63,14 +63,16 @@ func TestCommitListBuilderGetUnpushedCommits(t *testing.T) {
 // TestCommitListBuilderGetMergeBase is a function.
 func TestCommitListBuilderGetMergeBase(t *testing.T) {
 	type scenario struct {
-		testName string
-		command  func(string, ...string) *exec.Cmd
-		test     func(string, error)
+		testName   string
+		baseBranch string
+		command    func(string, ...string) *exec.Cmd
+		test       func(string, error)
 	}
 
 	scenarios := []scenario{
 		{
 			"swallows an error if the call to merge-base returns an error",
+			"",
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
 
@@ -91,6 +93,7 @@ func TestCommitListBuilderGetMergeBase(t *testing.T) {
 		},
 		{
 			"returns the commit when master",
+			"",
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
 
@@ -111,6 +114,7 @@ func TestCommitListBuilderGetMergeBase(t *testing.T) {
 		},
 		{
 			"checks against develop when a feature branch",
+			"",
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
 
@@ -129,8 +133,30 @@ func TestCommitListBuilderGetMergeBase(t *testing.T) {
 				assert.Equal(t, "blah\n", output)
 			},
 		},
+		{
+			"checks against the given base branch",
+			"origin/develop",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+
+				switch args[0] {
+				case "symbolic-ref":
+					assert.EqualValues(t, []string{"symbolic-ref", "--short", "HEAD"}, args)
+					return exec.Command("echo", "feature/test")
+				case "merge-base":
+					assert.EqualValues(t, []string{"merge-base", "HEAD", "origin/develop"}, args)
+					return exec.Command("echo", "blah")
+				}
+				return nil
+			},
+			func(output string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "blah\n", output)
+			},
+		},
 		{
 			"bubbles up error if there is one",
+			"",
 			func(cmd string, args ...string) *exec.Cmd {
 				return exec.Command("test")
 			},
@@ -145,7 +171,125 @@ func TestCommitListBuilderGetMergeBase(t *testing.T) {
 		t.Run(s.testName, func(t *testing.T) {
 			c := NewDummyCommitListBuilder()
 			c.OSCommand.SetCommand(s.command)
-			s.test(c.getMergeBase())
+			s.test(c.getMergeBase(s.baseBranch))
+		})
+	}
+}
+
+// TestCommitListBuilderGetCommitsWithEquivalentIn is a function.
+func TestCommitListBuilderGetCommitsWithEquivalentIn(t *testing.T) {
+	type scenario struct {
+		testName   string
+		baseBranch string
+		command    func(string, ...string) *exec.Cmd
+		test       func(map[string]bool)
+	}
+
+	scenarios := []scenario{
+		{
+			"git cherry fails",
+			"origin/develop",
+			func(string, ...string) *exec.Cmd {
+				return exec.Command("test")
+			},
+			func(equivalents map[string]bool) {
+				assert.EqualValues(t, map[string]bool{}, equivalents)
+			},
+		},
+		{
+			"Only commits with an equivalent in the base branch are returned",
+			"origin/develop",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"cherry", "origin/develop", "HEAD"}, args)
+				return exec.Command("echo", "- 8a2bb0e1\n+ 78976bc2\n- 1f2e3d4c")
+			},
+			func(equivalents map[string]bool) {
+				assert.EqualValues(t, map[string]bool{"8a2bb0e1": true, "1f2e3d4c": true}, equivalents)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			c := NewDummyCommitListBuilder()
+			c.OSCommand.SetCommand(s.command)
+			s.test(c.getCommitsWithEquivalentIn(s.baseBranch))
+		})
+	}
+}
+
+// TestCommitListBuilderSetCommitMergedStatuses is a function.
+func TestCommitListBuilderSetCommitMergedStatuses(t *testing.T) {
+	c := NewDummyCommitListBuilder()
+	c.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+
+		switch args[0] {
+		case "symbolic-ref":
+			return exec.Command("echo", "feature/test")
+		case "merge-base":
+			assert.EqualValues(t, []string{"merge-base", "HEAD", "develop"}, args)
+			return exec.Command("echo", "ccc")
+		case "cherry":
+			assert.EqualValues(t, []string{"cherry", "develop", "HEAD"}, args)
+			return exec.Command("echo", "+ aaa\n- bbb")
+		}
+		return nil
+	})
+
+	commits, err := c.setCommitMergedStatuses([]*Commit{
+		{Sha: "aaa", Status: "unpushed"},
+		{Sha: "bbb", Status: "unpushed"},
+		{Sha: "ccc", Status: "pushed"},
+		{Sha: "ddd", Status: "pushed"},
+	}, "")
+
+	assert.NoError(t, err)
+	statuses := []string{}
+	for _, commit := range commits {
+		statuses = append(statuses, commit.Status)
+	}
+	assert.EqualValues(t, []string{"unpushed", "equivalent", "merged", "merged"}, statuses)
+}
+
+// TestParseCommitRefs is a function.
+func TestParseCommitRefs(t *testing.T) {
+	type scenario struct {
+		testName   string
+		decoration string
+		expected   []*CommitRef
+	}
+
+	scenarios := []scenario{
+		{
+			"No decoration",
+			"",
+			nil,
+		},
+		{
+			"Checked out branch with tags and a remote branch",
+			"(HEAD -> refs/heads/master, tag: refs/tags/v2, refs/remotes/origin/master)",
+			[]*CommitRef{
+				{Name: "master", Kind: "head"},
+				{Name: "v2", Kind: "tag"},
+				{Name: "origin/master", Kind: "remote"},
+			},
+		},
+		{
+			"Detached head and other refs",
+			"(HEAD, refs/heads/feature/test, refs/stash)",
+			[]*CommitRef{
+				{Name: "HEAD", Kind: "head"},
+				{Name: "feature/test", Kind: "branch"},
+				{Name: "stash", Kind: "other"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parseCommitRefs(s.decoration))
 		})
 	}
 }
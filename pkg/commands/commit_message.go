@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CommitMessage is a commit message split into its parts
+type CommitMessage struct {
+	Subject  string
+	Body     string
+	Trailers []*Trailer
+}
+
+// Trailer is a 'Key: value' line at the end of a commit message, like
+// 'Signed-off-by: Jesse Duffield <jessedduffield@gmail.com>'
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+var trailerRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s+(.+)$`)
+
+// ParseCommitMessage splits a raw commit message into its subject, body and
+// trailers. As with git interpret-trailers, the trailers are the final
+// paragraph, and only if every line of it looks like a trailer
+func ParseCommitMessage(message string) *CommitMessage {
+	message = strings.TrimSpace(strings.Replace(message, "\r\n", "\n", -1))
+	parts := strings.SplitN(message, "\n", 2)
+
+	commitMessage := &CommitMessage{Subject: parts[0]}
+	if len(parts) == 1 {
+		return commitMessage
+	}
+
+	paragraphs := strings.Split(strings.TrimSpace(parts[1]), "\n\n")
+	lastParagraph := paragraphs[len(paragraphs)-1]
+	trailers := []*Trailer{}
+	for _, line := range strings.Split(lastParagraph, "\n") {
+		match := trailerRegex.FindStringSubmatch(line)
+		if match == nil {
+			trailers = nil
+			break
+		}
+		trailers = append(trailers, &Trailer{Key: match[1], Value: match[2]})
+	}
+
+	if len(trailers) > 0 {
+		commitMessage.Trailers = trailers
+		paragraphs = paragraphs[:len(paragraphs)-1]
+	}
+	commitMessage.Body = strings.Join(paragraphs, "\n\n")
+
+	return commitMessage
+}
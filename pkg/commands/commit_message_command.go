@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// GenerateCommitMessage runs the user's commitMessageCommand in their shell
+// with the staged diff on its stdin, and returns what it prints as a suggested
+// commit message
+func (c *GitCommand) GenerateCommitMessage(command string) (string, error) {
+	diff, err := c.OSCommand.RunCommandWithOutput("git diff --cached --no-color --no-ext-diff")
+	if err != nil {
+		return "", err
+	}
+
+	cmd := c.OSCommand.ShellExecutable(command)
+	if err := c.OSCommand.WithSecrets(cmd, command); err != nil {
+		return "", err
+	}
+	cmd.Stdin = strings.NewReader(diff)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New(message)
+		}
+		return "", WrapError(err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
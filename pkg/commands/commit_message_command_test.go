@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGenerateCommitMessage is a function.
+func TestGitCommandGenerateCommitMessage(t *testing.T) {
+	type scenario struct {
+		testName        string
+		command         string
+		expectedMessage string
+		expectedError   string
+	}
+
+	scenarios := []scenario{
+		{
+			"the staged diff is passed on stdin",
+			"head -n 1 | tr a-z A-Z",
+			"DIFF --GIT A/MAIN.GO B/MAIN.GO",
+			"",
+		},
+		{
+			"failures are reported with what the command printed to stderr",
+			"echo 'no api key' >&2; exit 1",
+			"",
+			"no api key",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				if cmd == "git" {
+					assert.EqualValues(t, []string{"diff", "--cached", "--no-color", "--no-ext-diff"}, args)
+					return exec.Command("printf", "diff --git a/main.go b/main.go\n+package main\n")
+				}
+				return exec.Command("sh", "-c", args[len(args)-1])
+			}
+
+			message, err := gitCmd.GenerateCommitMessage(s.command)
+			assert.EqualValues(t, s.expectedMessage, message)
+			if s.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, s.expectedError)
+			}
+		})
+	}
+}
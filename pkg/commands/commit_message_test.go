@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseCommitMessage is a function.
+func TestParseCommitMessage(t *testing.T) {
+	type scenario struct {
+		testName string
+		message  string
+		expected *CommitMessage
+	}
+
+	scenarios := []scenario{
+		{
+			"subject only",
+			"fix the thing\n",
+			&CommitMessage{Subject: "fix the thing"},
+		},
+		{
+			"subject and body",
+			"fix the thing\n\nit was broken\n\n- because of this\n- and that\n",
+			&CommitMessage{
+				Subject: "fix the thing",
+				Body:    "it was broken\n\n- because of this\n- and that",
+			},
+		},
+		{
+			"body and trailers",
+			"fix the thing\n\nit was broken\n\nSigned-off-by: Jesse Duffield <jessedduffield@gmail.com>\nCloses: #123\n",
+			&CommitMessage{
+				Subject: "fix the thing",
+				Body:    "it was broken",
+				Trailers: []*Trailer{
+					{Key: "Signed-off-by", Value: "Jesse Duffield <jessedduffield@gmail.com>"},
+					{Key: "Closes", Value: "#123"},
+				},
+			},
+		},
+		{
+			"last paragraph is not all trailers",
+			"fix the thing\n\nNote: this is a sentence\nthat continues here",
+			&CommitMessage{
+				Subject: "fix the thing",
+				Body:    "Note: this is a sentence\nthat continues here",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, ParseCommitMessage(s.message))
+		})
+	}
+}
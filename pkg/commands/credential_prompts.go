@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// CredentialPrompt is an entry of git.credentialPrompts in the user's config:
+// a regexp for a prompt that a remote or credential helper shows, along with
+// what it asks for: username, password, passphrase, otp or hostKey
+type CredentialPrompt struct {
+	Pattern string `mapstructure:"pattern"`
+	AskFor  string `mapstructure:"askFor"`
+}
+
+type credentialPrompt struct {
+	pattern *regexp.Regexp
+	askFor  string
+}
+
+// defaultCredentialPrompts maps the prompts git, ssh and gpg show on the
+// terminal to what they're asking for. They're matched against the end of
+// what the command has written since the last prompt, so that a prompt which
+// follows a few lines of explanation, like ssh's for an unknown host key, is
+// still recognised
+var defaultCredentialPrompts = []credentialPrompt{
+	{regexp.MustCompile(`Username\s+for\s+'.+':\s*$`), "username"},
+	// one-time codes some hosts and credential helpers ask for after the
+	// password, which wording varies the most, hence git.credentialPrompts.
+	// The words mustn't be in quotes, to leave out e.g. urls with otp in them
+	{regexp.MustCompile(`(?i)(^|\n)[^'\n]*\b(verification\s+code|authentication\s+code|one-time\s+(pass)?code|one-time\s+password|two-factor|2fa|otp|totp)\b[^\n]*:\s*$`), "otp"},
+	{regexp.MustCompile(`Password\s+for\s+'.+':\s*$`), "password"},
+	{regexp.MustCompile(`\S+'s\s+password:\s*$`), "password"},
+	// ssh keys, and gpg when signing e.g. a push certificate via a loopback/tty pinentry
+	{regexp.MustCompile(`Enter\s+passphrase(\s+for\s+key\s+'.+')?:\s*$`), "passphrase"},
+	// e.g. for a hardware security key
+	{regexp.MustCompile(`\bPIN\b[^\n]*:\s*$`), "password"},
+	{regexp.MustCompile(`continue\s+connecting\s+\(yes/no[^)]*\)\?\s*$`), "hostKey"},
+	// what ssh asks when the answer to the above was neither
+	{regexp.MustCompile(`Please\s+type\s+'yes',\s+'no'[^\n]*:\s*$`), "hostKey"},
+}
+
+// getCredentialPrompts returns the prompts from git.credentialPrompts ahead of
+// the ones we know of, so that they can match prompts the defaults would get
+// wrong
+func (c *OSCommand) getCredentialPrompts() ([]credentialPrompt, error) {
+	configured := []CredentialPrompt{}
+	if err := c.Config.GetUserConfig().UnmarshalKey("git.credentialPrompts", &configured); err != nil {
+		return nil, err
+	}
+
+	prompts := []credentialPrompt{}
+	for _, prompt := range configured {
+		pattern, err := regexp.Compile(prompt.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pattern in git.credentialPrompts: %v", err)
+		}
+		switch prompt.AskFor {
+		case "username", "password", "passphrase", "otp", "hostKey":
+		default:
+			return nil, fmt.Errorf("Unknown askFor '%s' in git.credentialPrompts. Use one of: username, password, passphrase, otp, hostKey", prompt.AskFor)
+		}
+		prompts = append(prompts, credentialPrompt{pattern: pattern, askFor: prompt.AskFor})
+	}
+	return append(prompts, defaultCredentialPrompts...), nil
+}
+
+// detectCredentialPrompt tells us what the command is asking for, if the text
+// it has written ends with one of the prompts
+func detectCredentialPrompt(prompts []credentialPrompt, text string) (string, bool) {
+	for _, prompt := range prompts {
+		if prompt.pattern.MatchString(text) {
+			return prompt.askFor, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDetectCredentialPrompt is a function.
+func TestDetectCredentialPrompt(t *testing.T) {
+	type scenario struct {
+		text   string
+		askFor string
+		ok     bool
+	}
+
+	scenarios := []scenario{
+		{"Username for 'https://github.com': ", "username", true},
+		{"me\nPassword for 'https://me@github.com': ", "password", true},
+		{"Password for 'https://me@git.example.com/otp-tools.git': ", "password", true},
+		{"git@example.com's password: ", "password", true},
+		{"Enter passphrase for key '/home/me/.ssh/id_ed25519': ", "passphrase", true},
+		{"Enter passphrase: ", "passphrase", true},
+		{"Enter PIN for 'ECDSA-SK key': ", "password", true},
+		{"secret\nVerification code: ", "otp", true},
+		{"Enter your one-time password: ", "otp", true},
+		{"Two-factor authentication code for me: ", "otp", true},
+		{"OTP: ", "otp", true},
+		{"The authenticity of host 'example.com (1.2.3.4)' can't be established.\nED25519 key fingerprint is SHA256:abc.\nAre you sure you want to continue connecting (yes/no/[fingerprint])? ", "hostKey", true},
+		{"Please type 'yes', 'no' or the fingerprint: ", "hostKey", true},
+		{"Username for 'https://github.com': me\nremote: Mapping objects: 3", "", false},
+		{"Password for 'https://me@github.com': \nFetching origin", "", false},
+	}
+
+	for _, s := range scenarios {
+		askFor, ok := detectCredentialPrompt(defaultCredentialPrompts, s.text)
+		assert.EqualValues(t, s.askFor, askFor, s.text)
+		assert.EqualValues(t, s.ok, ok, s.text)
+	}
+}
+
+// TestOSCommandGetCredentialPrompts is a function.
+func TestOSCommandGetCredentialPrompts(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("git.credentialPrompts", []map[string]interface{}{
+		{"pattern": `Token from your authenticator app: $`, "askFor": "otp"},
+	})
+
+	prompts, err := osCommand.getCredentialPrompts()
+	assert.NoError(t, err)
+	askFor, ok := detectCredentialPrompt(prompts, "Token from your authenticator app: ")
+	assert.True(t, ok)
+	assert.EqualValues(t, "otp", askFor)
+
+	osCommand.Config.GetUserConfig().Set("git.credentialPrompts", []map[string]interface{}{
+		{"pattern": `Code: $`, "askFor": "code"},
+	})
+	_, err = osCommand.getCredentialPrompts()
+	assert.EqualError(t, err, "Unknown askFor 'code' in git.credentialPrompts. Use one of: username, password, passphrase, otp, hostKey")
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+)
+
+// DiffAlgorithms are the values we cycle through for git's --diff-algorithm
+// flag. The empty string leaves it up to git (i.e. diff.algorithm or myers)
+var DiffAlgorithms = []string{"", "histogram", "patience", "minimal"}
+
+// DiffOptions : flags applied to every diff we show the user. They are never
+// applied to plain diffs because those get parsed and re-applied as patches,
+// and a patch that ignores whitespace won't apply cleanly
+type DiffOptions struct {
+	IgnoreWhitespace  bool
+	IgnoreBlankLines  bool
+	IgnoreLineEndings bool
+	Algorithm         string
+}
+
+// NewDiffOptions reads the user's default diff options from their config
+func NewDiffOptions(config config.AppConfigurer) DiffOptions {
+	userConfig := config.GetUserConfig()
+	return DiffOptions{
+		IgnoreWhitespace:  userConfig.GetBool("git.diff.ignoreWhitespace"),
+		IgnoreBlankLines:  userConfig.GetBool("git.diff.ignoreBlankLines"),
+		IgnoreLineEndings: userConfig.GetBool("git.diff.ignoreLineEndings"),
+		Algorithm:         userConfig.GetString("git.diff.algorithm"),
+	}
+}
+
+// Flags returns the git flags for these options
+func (o DiffOptions) Flags() []string {
+	flags := []string{}
+	if o.IgnoreWhitespace {
+		flags = append(flags, "-w")
+	}
+	if o.IgnoreBlankLines {
+		flags = append(flags, "--ignore-blank-lines")
+	}
+	if o.IgnoreLineEndings {
+		flags = append(flags, "--ignore-cr-at-eol")
+	}
+	if o.Algorithm != "" {
+		flags = append(flags, "--diff-algorithm="+o.Algorithm)
+	}
+	return flags
+}
+
+// NextAlgorithm returns the algorithm that comes after the current one in DiffAlgorithms
+func (o DiffOptions) NextAlgorithm() string {
+	for i, algorithm := range DiffAlgorithms {
+		if algorithm == o.Algorithm {
+			return DiffAlgorithms[(i+1)%len(DiffAlgorithms)]
+		}
+	}
+	return DiffAlgorithms[0]
+}
+
+// DiffFlags is the string to splice into a diff command we're showing the
+// user, with a leading space so it can go straight after the previous argument
+func (c *GitCommand) DiffFlags() string {
+	flags := c.DiffOptions.Flags()
+	if len(flags) == 0 {
+		return ""
+	}
+	return " " + strings.Join(flags, " ")
+}
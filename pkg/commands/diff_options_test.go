@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiffOptionsFlags is a function.
+func TestDiffOptionsFlags(t *testing.T) {
+	type scenario struct {
+		testName string
+		options  DiffOptions
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"defaults",
+			DiffOptions{},
+			[]string{},
+		},
+		{
+			"everything",
+			DiffOptions{IgnoreWhitespace: true, IgnoreBlankLines: true, IgnoreLineEndings: true, Algorithm: "histogram"},
+			[]string{"-w", "--ignore-blank-lines", "--ignore-cr-at-eol", "--diff-algorithm=histogram"},
+		},
+		{
+			"just the algorithm",
+			DiffOptions{Algorithm: "patience"},
+			[]string{"--diff-algorithm=patience"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, s.options.Flags())
+		})
+	}
+}
+
+// TestDiffOptionsNextAlgorithm is a function.
+func TestDiffOptionsNextAlgorithm(t *testing.T) {
+	assert.EqualValues(t, "histogram", DiffOptions{}.NextAlgorithm())
+	assert.EqualValues(t, "patience", DiffOptions{Algorithm: "histogram"}.NextAlgorithm())
+	assert.EqualValues(t, "", DiffOptions{Algorithm: "minimal"}.NextAlgorithm())
+	assert.EqualValues(t, "", DiffOptions{Algorithm: "myers"}.NextAlgorithm())
+}
+
+// TestGitCommandDiffCmdStrWithDiffOptions is a function.
+func TestGitCommandDiffCmdStrWithDiffOptions(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DiffOptions = DiffOptions{IgnoreWhitespace: true, Algorithm: "histogram"}
+	file := &File{Name: "test.txt", HasUnstagedChanges: true, Tracked: true}
+
+	assert.EqualValues(t, "git diff --color=always -w --diff-algorithm=histogram  -- 'test.txt'", gitCmd.DiffCmdStr(file, false, false))
+	// plain diffs get turned into patches so they must not ignore anything
+	assert.EqualValues(t, "git diff --color=never  -- 'test.txt'", gitCmd.DiffCmdStr(file, true, false))
+}
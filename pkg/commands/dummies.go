@@ -2,6 +2,9 @@ package commands
 
 import (
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
 
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
@@ -32,11 +35,32 @@ func NewDummyAppConfig() *config.AppConfig {
 		Debug:       false,
 		BuildSource: "",
 		UserConfig:  userConfig,
+		StateDir:    filepath.Join(dummyDir(), "state"),
+		CacheDir:    filepath.Join(dummyDir(), "cache"),
 	}
 	_ = yaml.Unmarshal([]byte{}, appConfig.AppState)
 	return appConfig
 }
 
+var (
+	dummyDirOnce sync.Once
+	dummyDirPath string
+)
+
+// dummyDir is a temp dir for the state and cache dirs of dummy configs, so that
+// what tests write there, like the patches ApplyPatch saves, stays out of the
+// source tree
+func dummyDir() string {
+	dummyDirOnce.Do(func() {
+		dir, err := ioutil.TempDir("", "lazygit-test")
+		if err != nil {
+			dir = filepath.Join(os.TempDir(), "lazygit-test")
+		}
+		dummyDirPath = dir
+	})
+	return dummyDirPath
+}
+
 // NewDummyLog creates a new dummy Log for testing
 func NewDummyLog() *logrus.Entry {
 	log := logrus.New()
@@ -3,93 +3,86 @@
 package commands
 
 import (
-	"bufio"
 	"bytes"
-	"strings"
-	"unicode/utf8"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/go-errors/errors"
 
 	"github.com/creack/pty"
 )
 
-// RunCommandWithOutputLiveWrapper runs a command and return every word that gets written in stdout
-// Output is a function that executes by every word that gets read by bufio
-// As return of output you need to give a string that will be written to stdin
-// NOTE: If the return data is empty it won't written anything to stdin
-func RunCommandWithOutputLiveWrapper(c *OSCommand, command string, output func(string) string) error {
+// RunCommandWithOutputLiveWrapper runs a command in a pseudo terminal, which is
+// where git, ssh and gpg write their prompts and read the answers from. The
+// prompts are passed to output, and its answers written back, as described at
+// answerPrompts. The command is killed if ctx is cancelled before it finishes. What it wrote
+// to stderr, where git's progress goes, is returned. If onChunk is given, it's
+// passed what the command writes to stdout and stderr as it's written, with
+// stdout going there rather than to the pty. Prompts are written to the
+// terminal itself, so output still sees them
+func RunCommandWithOutputLiveWrapper(ctx context.Context, c *OSCommand, command string, onChunk func(OutputChunk), output func(string) string) (string, error) {
+	start := time.Now()
 	cmd := c.ExecutableFromString(command)
-	cmd.Env = append(cmd.Env, "LANG=en_US.UTF-8", "LC_ALL=en_US.UTF-8")
+	// git's messages are translated into the user's language, so we ask for
+	// English ones to be able to recognise its prompts. LANGUAGE would win
+	// over the rest otherwise
+	cmd.Env = append(cmd.Env, "LANG=en_US.UTF-8", "LC_ALL=en_US.UTF-8", "LANGUAGE=en_US:en")
 
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
+	if onChunk != nil {
+		var mutex sync.Mutex
+		cmd.Stdout = &chunkWriter{stream: Stdout, mutex: &mutex, onChunk: onChunk}
+		cmd.Stderr = io.MultiWriter(&stderr, &chunkWriter{stream: Stderr, mutex: &mutex, onChunk: onChunk})
+	}
 
-	ptmx, err := pty.Start(cmd)
-
+	ptmx, err := startInPty(cmd)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	go func() {
-		scanner := bufio.NewScanner(ptmx)
-		scanner.Split(scanWordsWithNewLines)
-		for scanner.Scan() {
-			toOutput := strings.Trim(scanner.Text(), " ")
-			_, _ = ptmx.WriteString(output(toOutput))
-		}
-	}()
+	go answerPrompts(ptmx, output)
 
-	err = cmd.Wait()
+	err = waitWithContext(ctx, cmd)
 	ptmx.Close()
+	// what the command wrote to the pty went to output, and may have had
+	// the user's answers to prompts in it, so we only keep stderr
+	c.recordCommand(command, cmd, start, collapseProgress(stderr.String()), err)
 	if err != nil {
-		return errors.New(stderr.String())
+		return "", errors.New(withoutProgress(stderr.String()))
 	}
 
-	return nil
+	return stderr.String(), nil
 }
 
-// scanWordsWithNewLines is a copy of bufio.ScanWords but this also captures new lines
-// For specific comments about this function take a look at: bufio.ScanWords
-func scanWordsWithNewLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	start := 0
-	for width := 0; start < len(data); start += width {
-		var r rune
-		r, width = utf8.DecodeRune(data[start:])
-		if !isSpace(r) {
-			break
-		}
-	}
-	for width, i := 0, start; i < len(data); i += width {
-		var r rune
-		r, width = utf8.DecodeRune(data[i:])
-		if isSpace(r) {
-			return i + width, data[start:i], nil
-		}
-	}
-	if atEOF && len(data) > start {
-		return len(data), data[start:], nil
+// startInPty starts the command with a new pty as its terminal, returning the
+// pty's master end. We don't use pty.Start, which gives Ctty as the parent's
+// fd of the terminal, when Go wants the child's since 1.15
+func startInPty(cmd *exec.Cmd) (*os.File, error) {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return nil, err
 	}
-	return start, nil, nil
-}
+	defer tty.Close()
 
-// isSpace is also copied from the bufio package and has been modified to also captures new lines
-// For specific comments about this function take a look at: bufio.isSpace
-func isSpace(r rune) bool {
-	if r <= '\u00FF' {
-		switch r {
-		case ' ', '\t', '\v', '\f':
-			return true
-		case '\u0085', '\u00A0':
-			return true
-		}
-		return false
+	cmd.Stdin = tty
+	if cmd.Stdout == nil {
+		cmd.Stdout = tty
 	}
-	if '\u2000' <= r && r <= '\u200a' {
-		return true
+	if cmd.Stderr == nil {
+		cmd.Stderr = tty
 	}
-	switch r {
-	case '\u1680', '\u2028', '\u2029', '\u202f', '\u205f', '\u3000':
-		return true
+	// the command leads a new session with the pty (its stdin, fd 0) as
+	// controlling terminal, which is where ssh and gpg write their prompts
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+	if err := cmd.Start(); err != nil {
+		ptmx.Close()
+		return nil, err
 	}
-	return false
+	return ptmx, nil
 }
@@ -0,0 +1,54 @@
+// +build !windows
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandDetectUnamePass is a function.
+func TestOSCommandDetectUnamePass(t *testing.T) {
+	// the password prompt is split over two lines and two writes
+	script, err := ioutil.TempFile("", "prompts*.sh")
+	assert.NoError(t, err)
+	defer os.Remove(script.Name())
+	_, err = script.WriteString(`printf "Username for 'https://example.com': "; read u; printf "Password for\n"; sleep 0.1; printf "'https://$u@example.com': "; read p; test "$u:$p" = "me:secret"`)
+	assert.NoError(t, err)
+	script.Close()
+
+	asked := []string{}
+	err = NewDummyOSCommand().DetectUnamePass("sh "+script.Name(), func(askFor string) string {
+		asked = append(asked, askFor)
+		if askFor == "username" {
+			return "me\n"
+		}
+		return "secret\n"
+	})
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"username", "password"}, asked)
+}
+
+// TestOSCommandDetectUnamePassSSHPassphrase is a function.
+func TestOSCommandDetectUnamePassSSHPassphrase(t *testing.T) {
+	// ssh writes its prompt straight to the terminal, not to stdout
+	script, err := ioutil.TempFile("", "prompts*.sh")
+	assert.NoError(t, err)
+	defer os.Remove(script.Name())
+	_, err = script.WriteString(`printf "Enter passphrase for key '/home/me/.ssh/id_ed25519': " > /dev/tty; read p < /dev/tty; test "$p" = "hunter2"`)
+	assert.NoError(t, err)
+	script.Close()
+
+	asked := []string{}
+	err = NewDummyOSCommand().DetectUnamePass("sh "+script.Name(), func(askFor string) string {
+		asked = append(asked, askFor)
+		return "hunter2\n"
+	})
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"passphrase"}, asked)
+}
@@ -2,8 +2,410 @@
 
 package commands
 
-// RunCommandWithOutputLiveWrapper runs a command live but because of windows compatibility this command can't be ran there
-// TODO: Remove this hack and replace it with a proper way to run commands live on windows
-func RunCommandWithOutputLiveWrapper(c *OSCommand, command string, output func(string) string) error {
-	return c.RunCommand(command)
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/go-errors/errors"
+	"golang.org/x/sys/windows"
+)
+
+// the vendored golang.org/x/sys predates pseudo consoles, so we call them
+// ourselves
+var (
+	kernel32                              = windows.NewLazySystemDLL("kernel32.dll")
+	procCreatePseudoConsole               = kernel32.NewProc("CreatePseudoConsole")
+	procClosePseudoConsole                = kernel32.NewProc("ClosePseudoConsole")
+	procInitializeProcThreadAttributeList = kernel32.NewProc("InitializeProcThreadAttributeList")
+	procUpdateProcThreadAttribute         = kernel32.NewProc("UpdateProcThreadAttribute")
+	procDeleteProcThreadAttributeList     = kernel32.NewProc("DeleteProcThreadAttributeList")
+)
+
+const (
+	procThreadAttributeHandleList    = 0x00020002
+	procThreadAttributePseudoConsole = 0x00020016
+)
+
+// startupInfoEx is STARTUPINFOEXW, which lets us hand CreateProcess the
+// pseudo console to attach the command to
+type startupInfoEx struct {
+	windows.StartupInfo
+	attributeList *byte
+}
+
+// RunCommandWithOutputLiveWrapper runs a command attached to a pseudo console,
+// which is where git, ssh and gpg write their prompts and read the answers
+// from. The prompts are passed to output, and its answers written back, as
+// described at answerPrompts. The command is killed if ctx is cancelled before
+// it finishes. What it wrote to stderr, where git's progress goes, is returned.
+// If onChunk is given, it's passed what the command writes to stdout and
+// stderr as it's written. Pseudo consoles arrived in Windows 10 1809; before
+// that, the command is run without one and can't be answered
+func RunCommandWithOutputLiveWrapper(ctx context.Context, c *OSCommand, command string, onChunk func(OutputChunk), output func(string) string) (string, error) {
+	if procCreatePseudoConsole.Find() != nil {
+		stdout, err := c.RunCommandWithOutputContext(ctx, command)
+		if onChunk != nil {
+			onChunk(OutputChunk{Stream: Stdout, Text: stdout})
+		}
+		return stdout, err
+	}
+
+	start := time.Now()
+	cmd := c.ExecutableFromString(command)
+	// git's messages are translated into the user's language, so we ask for
+	// English ones to be able to recognise its prompts
+	cmd.Env = append(cmd.Env, "LANG=en_US.UTF-8", "LC_ALL=en_US.UTF-8", "LANGUAGE=en_US:en")
+
+	var stderr bytes.Buffer
+	var stdoutWriter, stderrWriter io.Writer = ioutil.Discard, &stderr
+	if onChunk != nil {
+		var mutex sync.Mutex
+		stdoutWriter = &chunkWriter{stream: Stdout, mutex: &mutex, onChunk: onChunk}
+		stderrWriter = io.MultiWriter(&stderr, &chunkWriter{stream: Stderr, mutex: &mutex, onChunk: onChunk})
+	}
+
+	process, err := startInPseudoConsole(cmd, stdoutWriter, stderrWriter)
+	if err != nil {
+		return "", WrapError(err)
+	}
+	go answerPrompts(process.terminal, output)
+
+	err = process.wait(ctx)
+	process.close()
+	// what the command wrote to the console went to output, and may have had
+	// the user's answers to prompts in it, so we only keep stderr
+	c.recordCommand(command, cmd, start, collapseProgress(stderr.String()), err)
+	if err != nil {
+		return "", errors.New(withoutProgress(stderr.String()))
+	}
+
+	return stderr.String(), nil
+}
+
+// consoleProcess is a command attached to a pseudo console of its own
+type consoleProcess struct {
+	process windows.Handle
+	// job is the job object the command and anything it starts are in, so
+	// that we can kill them all, or 0 if we couldn't make one
+	job     windows.Handle
+	console windows.Handle
+	// terminal reads what the command writes to its console, and writes
+	// what it reads from there
+	terminal *consoleTerminal
+	// copying is done once the command's stdout and stderr are closed
+	copying sync.WaitGroup
+}
+
+type consoleTerminal struct {
+	input  *os.File
+	output *os.File
+}
+
+func (t *consoleTerminal) Read(p []byte) (int, error) {
+	return t.output.Read(p)
+}
+
+func (t *consoleTerminal) Write(p []byte) (int, error) {
+	return t.input.Write(p)
+}
+
+// startInPseudoConsole starts the command with a new pseudo console, which it
+// writes its prompts to and reads the answers from. Its stdout and stderr go
+// through pipes rather than the console, to stdout and stderr
+func startInPseudoConsole(cmd *exec.Cmd, stdout io.Writer, stderr io.Writer) (*consoleProcess, error) {
+	console, terminal, err := newPseudoConsole()
+	if err != nil {
+		return nil, err
+	}
+	p := &consoleProcess{console: console, terminal: terminal}
+
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		p.close()
+		return nil, err
+	}
+	stderrReader, stderrWriter, err := os.Pipe()
+	if err != nil {
+		stdoutReader.Close()
+		stdoutWriter.Close()
+		p.close()
+		return nil, err
+	}
+	// the command gets its input from the console, not stdin
+	stdin, err := os.Open(os.DevNull)
+	if err != nil {
+		stdoutReader.Close()
+		stdoutWriter.Close()
+		stderrReader.Close()
+		stderrWriter.Close()
+		p.close()
+		return nil, err
+	}
+
+	processInfo, err := createConsoleProcess(cmd, console, []windows.Handle{
+		windows.Handle(stdin.Fd()),
+		windows.Handle(stdoutWriter.Fd()),
+		windows.Handle(stderrWriter.Fd()),
+	})
+	// the command has its own copies of these now
+	stdin.Close()
+	stdoutWriter.Close()
+	stderrWriter.Close()
+	if err != nil {
+		stdoutReader.Close()
+		stderrReader.Close()
+		p.close()
+		return nil, err
+	}
+	p.process = processInfo.Process
+
+	// like startInProcessGroup, we only let the command go once it's in its
+	// job, so that nothing it starts can slip out
+	if job, err := newJobObject(); err == nil {
+		if err := windows.AssignProcessToJobObject(job, p.process); err == nil {
+			p.job = job
+		} else {
+			windows.CloseHandle(job)
+		}
+	}
+	_, err = windows.ResumeThread(processInfo.Thread)
+	windows.CloseHandle(processInfo.Thread)
+	if err != nil {
+		_ = windows.TerminateProcess(p.process, 1)
+		stdoutReader.Close()
+		stderrReader.Close()
+		p.close()
+		return nil, err
+	}
+
+	p.copying.Add(2)
+	for _, pipe := range []struct {
+		reader *os.File
+		writer io.Writer
+	}{{stdoutReader, stdout}, {stderrReader, stderr}} {
+		go func(reader *os.File, writer io.Writer) {
+			defer p.copying.Done()
+			_, _ = io.Copy(writer, reader)
+			reader.Close()
+		}(pipe.reader, pipe.writer)
+	}
+	return p, nil
+}
+
+// newPseudoConsole makes a pseudo console, returning it along with our end of
+// it
+func newPseudoConsole() (windows.Handle, *consoleTerminal, error) {
+	var inputRead, inputWrite, outputRead, outputWrite windows.Handle
+	if err := windows.CreatePipe(&inputRead, &inputWrite, nil, 0); err != nil {
+		return 0, nil, err
+	}
+	if err := windows.CreatePipe(&outputRead, &outputWrite, nil, 0); err != nil {
+		windows.CloseHandle(inputRead)
+		windows.CloseHandle(inputWrite)
+		return 0, nil, err
+	}
+
+	var console windows.Handle
+	size := windows.Coord{X: 80, Y: 25}
+	result, _, _ := procCreatePseudoConsole.Call(
+		uintptr(*(*uint32)(unsafe.Pointer(&size))),
+		uintptr(inputRead),
+		uintptr(outputWrite),
+		0,
+		uintptr(unsafe.Pointer(&console)),
+	)
+	// the console has its own copies of its ends of the pipes
+	windows.CloseHandle(inputRead)
+	windows.CloseHandle(outputWrite)
+	if result != 0 {
+		windows.CloseHandle(inputWrite)
+		windows.CloseHandle(outputRead)
+		return 0, nil, fmt.Errorf("CreatePseudoConsole failed: 0x%x", result)
+	}
+
+	return console, &consoleTerminal{
+		input:  os.NewFile(uintptr(inputWrite), "console input"),
+		output: os.NewFile(uintptr(outputRead), "console output"),
+	}, nil
+}
+
+// createConsoleProcess starts the command, suspended, attached to the console
+// and with the given stdin, stdout and stderr, which are the only handles it
+// inherits
+func createConsoleProcess(cmd *exec.Cmd, console windows.Handle, stdHandles []windows.Handle) (*windows.ProcessInformation, error) {
+	attributeList, err := newProcThreadAttributeList(2)
+	if err != nil {
+		return nil, err
+	}
+	defer procDeleteProcThreadAttributeList.Call(uintptr(unsafe.Pointer(&attributeList[0])))
+
+	if err := updateProcThreadAttribute(attributeList, procThreadAttributePseudoConsole, uintptr(console), unsafe.Sizeof(console)); err != nil {
+		return nil, err
+	}
+	if err := updateProcThreadAttribute(attributeList, procThreadAttributeHandleList, uintptr(unsafe.Pointer(&stdHandles[0])), uintptr(len(stdHandles))*unsafe.Sizeof(stdHandles[0])); err != nil {
+		return nil, err
+	}
+
+	startupInfo := &startupInfoEx{attributeList: &attributeList[0]}
+	startupInfo.Cb = uint32(unsafe.Sizeof(*startupInfo))
+	startupInfo.Flags = windows.STARTF_USESTDHANDLES
+	startupInfo.StdInput, startupInfo.StdOutput, startupInfo.StdErr = stdHandles[0], stdHandles[1], stdHandles[2]
+
+	args := make([]string, len(cmd.Args))
+	for i, arg := range cmd.Args {
+		args[i] = windows.EscapeArg(arg)
+	}
+	commandLine, err := windows.UTF16PtrFromString(strings.Join(args, " "))
+	if err != nil {
+		return nil, err
+	}
+	path, err := windows.UTF16PtrFromString(cmd.Path)
+	if err != nil {
+		return nil, err
+	}
+	var dir *uint16
+	if cmd.Dir != "" {
+		if dir, err = windows.UTF16PtrFromString(cmd.Dir); err != nil {
+			return nil, err
+		}
+	}
+
+	// the handles have to be inheritable to be handed down, which we make
+	// them just for as long as it takes
+	syscall.ForkLock.Lock()
+	defer syscall.ForkLock.Unlock()
+	for _, handle := range stdHandles {
+		if err := windows.SetHandleInformation(handle, windows.HANDLE_FLAG_INHERIT, windows.HANDLE_FLAG_INHERIT); err != nil {
+			return nil, err
+		}
+	}
+
+	processInfo := &windows.ProcessInformation{}
+	flags := uint32(windows.EXTENDED_STARTUPINFO_PRESENT | windows.CREATE_UNICODE_ENVIRONMENT | windows.CREATE_SUSPENDED)
+	err = windows.CreateProcess(path, commandLine, nil, nil, true, flags, environmentBlock(cmd.Env), dir, &startupInfo.StartupInfo, processInfo)
+	runtime.KeepAlive(stdHandles)
+	runtime.KeepAlive(startupInfo)
+	if err != nil {
+		return nil, err
+	}
+	return processInfo, nil
+}
+
+// newProcThreadAttributeList makes room for the given number of attributes
+func newProcThreadAttributeList(count int) ([]byte, error) {
+	var size uintptr
+	// the first call just tells us how much room is needed
+	_, _, _ = procInitializeProcThreadAttributeList.Call(0, uintptr(count), 0, uintptr(unsafe.Pointer(&size)))
+	if size == 0 {
+		return nil, errors.New("InitializeProcThreadAttributeList gave no size")
+	}
+	attributeList := make([]byte, size)
+	if ok, _, err := procInitializeProcThreadAttributeList.Call(uintptr(unsafe.Pointer(&attributeList[0])), uintptr(count), 0, uintptr(unsafe.Pointer(&size))); ok == 0 {
+		return nil, err
+	}
+	return attributeList, nil
+}
+
+func updateProcThreadAttribute(attributeList []byte, attribute uintptr, value uintptr, size uintptr) error {
+	if ok, _, err := procUpdateProcThreadAttribute.Call(uintptr(unsafe.Pointer(&attributeList[0])), 0, attribute, value, size, 0, 0); ok == 0 {
+		return err
+	}
+	return nil
+}
+
+// environmentBlock turns an environment into the block of null terminated
+// 'key=value' strings CreateProcess wants. Like exec, we let a later entry win
+// over an earlier one for the same variable, which Windows doesn't care about
+// the case of
+func environmentBlock(env []string) *uint16 {
+	seen := map[string]bool{}
+	deduped := []string{}
+	for i := len(env) - 1; i >= 0; i-- {
+		if env[i] == "" || strings.ContainsRune(env[i], 0) {
+			continue
+		}
+		// variables like '=C:' start with an '=' of their own
+		key := strings.ToUpper(env[i][:1] + strings.SplitN(env[i][1:], "=", 2)[0])
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append([]string{env[i]}, deduped...)
+	}
+
+	block := []uint16{}
+	for _, entry := range deduped {
+		block = append(block, utf16.Encode([]rune(entry))...)
+		block = append(block, 0)
+	}
+	// the block ends with an empty string
+	block = append(block, 0)
+	if len(deduped) == 0 {
+		block = append(block, 0)
+	}
+	return &block[0]
+}
+
+// wait waits for the command to finish, killing it if ctx is cancelled first
+func (p *consoleProcess) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.kill()
+		case <-done:
+		}
+	}()
+
+	if _, err := windows.WaitForSingleObject(p.process, windows.INFINITE); err != nil {
+		return err
+	}
+	p.copying.Wait()
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var exitCode uint32
+	if err := windows.GetExitCodeProcess(p.process, &exitCode); err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("exit status %d", exitCode)
+	}
+	return nil
+}
+
+// kill kills the command along with anything it started
+func (p *consoleProcess) kill() {
+	if p.job != 0 {
+		_ = windows.TerminateJobObject(p.job, 1)
+		return
+	}
+	_ = windows.TerminateProcess(p.process, 1)
+}
+
+// close closes the console, which lets answerPrompts finish reading it, and
+// everything else we have open
+func (p *consoleProcess) close() {
+	_, _, _ = procClosePseudoConsole.Call(uintptr(p.console))
+	p.terminal.input.Close()
+	p.terminal.output.Close()
+	if p.process != 0 {
+		windows.CloseHandle(p.process)
+	}
+	if p.job != 0 {
+		windows.CloseHandle(p.job)
+	}
 }
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// fetchHeadBranchRegexp matches a line of FETCH_HEAD for a branch, e.g.
+// "<sha>\tnot-for-merge\tbranch 'master' of github.com:user/repo"
+var fetchHeadBranchRegexp = regexp.MustCompile(`^[0-9a-f]+\t[^\t]*\tbranch '(.+)' of (.+)$`)
+
+// setFetchTimes sets when each of the remotes' branches was last fetched. git
+// appends to a remote-tracking branch's reflog whenever a fetch moves it, so
+// that file was last written when the branch last changed. A fetch which found
+// nothing new leaves the reflog alone but still names the branch in
+// FETCH_HEAD, so we go by that too when it's for the same remote
+func (c *GitCommand) setFetchTimes(remotes []*Remote) {
+	commonDir := c.commonGitDir()
+	fetchedBranches, fetchHeadTime := readFetchHead(filepath.Join(c.DotGitDir, "FETCH_HEAD"))
+
+	for _, remote := range remotes {
+		urls := map[string]bool{}
+		for _, url := range remote.Urls {
+			urls[normaliseRemoteUrl(url)] = true
+		}
+
+		for _, branch := range remote.Branches {
+			reflogPath := filepath.Join(commonDir, "logs", "refs", "remotes", remote.Name, filepath.FromSlash(branch.Name))
+			if info, err := os.Stat(reflogPath); err == nil {
+				branch.FetchedAt = info.ModTime().Unix()
+			}
+			if fetchHeadTime > branch.FetchedAt && urls[fetchedBranches[branch.Name]] {
+				branch.FetchedAt = fetchHeadTime
+			}
+		}
+	}
+}
+
+// commonGitDir is the git directory shared by all of the repo's worktrees,
+// which is where the refs and their reflogs are kept
+func (c *GitCommand) commonGitDir() string {
+	content, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "commondir"))
+	if err != nil {
+		return c.DotGitDir
+	}
+	commonDir := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(c.DotGitDir, commonDir)
+	}
+	return commonDir
+}
+
+// readFetchHead returns the branches named in the FETCH_HEAD file at path,
+// mapped to the normalised url they were fetched from, and when it was written
+func readFetchHead(path string) (map[string]string, int64) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, 0
+	}
+
+	branches := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		match := fetchHeadBranchRegexp.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match != nil {
+			branches[match[1]] = normaliseRemoteUrl(match[2])
+		}
+	}
+	return branches, info.ModTime().Unix()
+}
+
+// normaliseRemoteUrl cuts a remote's url down to what FETCH_HEAD says it was
+// fetched from, which leaves out the user and any trailing '.git' or '/', e.g.
+// 'git@github.com:user/repo.git' becomes 'github.com:user/repo'
+func normaliseRemoteUrl(url string) string {
+	url = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSpace(url), "/"), ".git")
+	if i := strings.Index(url, "://"); i != -1 {
+		url = url[i+len("://"):]
+	}
+	if at := strings.Index(url, "@"); at != -1 && !strings.Contains(url[:at], "/") {
+		url = url[at+1:]
+	}
+	return url
+}
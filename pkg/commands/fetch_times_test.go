@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormaliseRemoteUrl is a function.
+func TestNormaliseRemoteUrl(t *testing.T) {
+	for url, expected := range map[string]string{
+		"git@github.com:user/repo.git":        "github.com:user/repo",
+		"https://github.com/user/repo.git":    "github.com/user/repo",
+		"https://token@github.com/user/repo/": "github.com/user/repo",
+		"ssh://git@example.com:2222/srv/repo": "example.com:2222/srv/repo",
+		"/srv/git/repo.git":                   "/srv/git/repo",
+		"/srv/git/user@host/repo":             "/srv/git/user@host/repo",
+	} {
+		assert.EqualValues(t, expected, normaliseRemoteUrl(url), url)
+	}
+}
+
+// TestGitCommandSetFetchTimes is a function.
+func TestGitCommandSetFetchTimes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-fetch-times")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	reflogTime := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	fetchHeadTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	for _, name := range []string{"master", "feature/login", "develop"} {
+		path := filepath.Join(dir, "logs", "refs", "remotes", "origin", filepath.FromSlash(name))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte("0000 1111 A U Thor <a@u.thor> 0 +0000\tfetch: storing head\n"), 0644))
+		assert.NoError(t, os.Chtimes(path, reflogTime, reflogTime))
+	}
+
+	fetchHead := "1111\t\tbranch 'master' of github.com:user/repo\n" +
+		"2222\tnot-for-merge\tbranch 'feature/login' of github.com:user/repo\n" +
+		"3333\tnot-for-merge\tbranch 'develop' of github.com:someone-else/repo\n"
+	fetchHeadPath := filepath.Join(dir, "FETCH_HEAD")
+	assert.NoError(t, ioutil.WriteFile(fetchHeadPath, []byte(fetchHead), 0644))
+	assert.NoError(t, os.Chtimes(fetchHeadPath, fetchHeadTime, fetchHeadTime))
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+	remotes := []*Remote{
+		{
+			Name: "origin",
+			Urls: []string{"git@github.com:user/repo.git"},
+			Branches: []*RemoteBranch{
+				{Name: "master", RemoteName: "origin"},
+				{Name: "feature/login", RemoteName: "origin"},
+				{Name: "develop", RemoteName: "origin"},
+				{Name: "never-fetched", RemoteName: "origin"},
+			},
+		},
+	}
+	gitCmd.setFetchTimes(remotes)
+
+	branches := remotes[0].Branches
+	assert.EqualValues(t, fetchHeadTime.Unix(), branches[0].FetchedAt)
+	assert.EqualValues(t, fetchHeadTime.Unix(), branches[1].FetchedAt)
+	// FETCH_HEAD's develop came from another remote
+	assert.EqualValues(t, reflogTime.Unix(), branches[2].FetchedAt)
+	assert.EqualValues(t, 0, branches[3].FetchedAt)
+}
@@ -1,5 +1,7 @@
 package commands
 
+import "strings"
+
 // File : A file from git status
 // duplicating this for now
 type File struct {
@@ -13,4 +15,19 @@ type File struct {
 	DisplayString           string
 	Type                    string // one of 'file', 'directory', and 'other'
 	ShortStatus             string // e.g. 'AD', ' A', 'M ', '??'
+
+	IsSubmodule                  bool
+	SubmoduleHasNewCommits       bool // the submodule's HEAD differs from the commit recorded in the superproject
+	SubmoduleHasModifiedContent  bool // tracked files inside the submodule have changed
+	SubmoduleHasUntrackedContent bool
+
+	StagedHunks int // only set for files with both staged and unstaged changes
+	TotalHunks  int
+}
+
+// NewName returns the file's name, or for a rename, which git shows as
+// 'old -> new', the name it's been renamed to
+func (f *File) NewName() string {
+	split := strings.Split(f.Name, " -> ")
+	return split[len(split)-1]
 }
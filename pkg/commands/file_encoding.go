@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// the encodings we know how to transcode a diff from. Anything else (including
+// binary content) is shown exactly as git gives it to us
+const (
+	EncodingUTF8    = "utf-8"
+	EncodingUTF16LE = "utf-16le"
+	EncodingUTF16BE = "utf-16be"
+	EncodingLatin1  = "latin-1"
+)
+
+// how much of a file we look at before guessing its encoding
+const encodingSampleSize = 8000
+
+// DetectEncoding guesses the encoding of some file content from its byte order
+// mark, or failing that from where its null bytes fall
+func DetectEncoding(content []byte) string {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xef, 0xbb, 0xbf}):
+		return EncodingUTF8
+	case bytes.HasPrefix(content, []byte{0xff, 0xfe}):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(content, []byte{0xfe, 0xff}):
+		return EncodingUTF16BE
+	}
+
+	evenNulls, oddNulls := 0, 0
+	for i, b := range content {
+		if b != 0 {
+			continue
+		}
+		if i%2 == 0 {
+			evenNulls++
+		} else {
+			oddNulls++
+		}
+	}
+
+	// ascii-heavy UTF-16 text has a null byte in every other position
+	pairs := len(content) / 2
+	if pairs > 0 {
+		if oddNulls > pairs/2 && evenNulls == 0 {
+			return EncodingUTF16LE
+		}
+		if evenNulls > pairs/2 && oddNulls == 0 {
+			return EncodingUTF16BE
+		}
+	}
+
+	if evenNulls+oddNulls > 0 {
+		// binary: nothing to transcode
+		return EncodingUTF8
+	}
+
+	if validUTF8Prefix(content) {
+		return EncodingUTF8
+	}
+	return EncodingLatin1
+}
+
+// validUTF8Prefix is utf8.Valid but tolerates a rune cut in half at the end of
+// our sample
+func validUTF8Prefix(content []byte) bool {
+	for i := 0; i < utf8.UTFMax && i <= len(content); i++ {
+		if utf8.Valid(content[:len(content)-i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// FileEncoding guesses the encoding of a file in the working tree
+func (c *GitCommand) FileEncoding(file *File) string {
+	split := strings.Split(file.Name, " -> ")
+	f, err := os.Open(split[len(split)-1])
+	if err != nil {
+		return EncodingUTF8
+	}
+	defer f.Close()
+
+	sample := make([]byte, encodingSampleSize)
+	n, _ := f.Read(sample)
+	return DetectEncoding(sample[:n])
+}
+
+// DecodeToUTF8 converts content in the given encoding to a UTF-8 string
+func DecodeToUTF8(content []byte, encoding string) string {
+	switch encoding {
+	case EncodingLatin1:
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	case EncodingUTF16LE, EncodingUTF16BE:
+		units := make([]uint16, 0, len(content)/2)
+		for i := 0; i+1 < len(content); i += 2 {
+			if encoding == EncodingUTF16LE {
+				units = append(units, uint16(content[i])|uint16(content[i+1])<<8)
+			} else {
+				units = append(units, uint16(content[i])<<8|uint16(content[i+1]))
+			}
+		}
+		return strings.TrimPrefix(string(utf16.Decode(units)), "\ufeff")
+	default:
+		return string(content)
+	}
+}
+
+// TranscodeDiff rewrites the content lines of a diff of a file in the given
+// encoding as UTF-8. The diff headers are always ascii so we leave them alone.
+// Git splits UTF-16 content on the first byte of each newline, so each line
+// picks up a stray null byte from its neighbour which we drop before decoding.
+// Colours are reapplied from scratch because git may colour a line in pieces
+func TranscodeDiff(diff string, encoding string) string {
+	if encoding == EncodingUTF8 {
+		return diff
+	}
+
+	lines := strings.Split(diff, "\n")
+	inHunk := false
+	for i, line := range lines {
+		plain := utils.Decolorise(line)
+		switch {
+		case strings.HasPrefix(plain, "diff "):
+			inHunk = false
+			continue
+		case strings.HasPrefix(plain, "@@"):
+			inHunk = true
+			continue
+		case !inHunk || plain == "" || strings.HasPrefix(plain, "\\"):
+			continue
+		}
+
+		content := []byte(plain[1:])
+		if len(content)%2 == 1 {
+			if encoding == EncodingUTF16LE && content[0] == 0 {
+				content = content[1:]
+			} else if encoding == EncodingUTF16BE && content[len(content)-1] == 0 {
+				content = content[:len(content)-1]
+			}
+		}
+
+		decoded := plain[:1] + strings.TrimSuffix(DecodeToUTF8(content, encoding), "\r")
+		switch plain[0] {
+		case '+':
+			decoded = utils.ColoredString(decoded, color.FgGreen)
+		case '-':
+			decoded = utils.ColoredString(decoded, color.FgRed)
+		}
+		lines[i] = decoded
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDetectEncoding is a function.
+func TestDetectEncoding(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  []byte
+		expected string
+	}
+
+	scenarios := []scenario{
+		{"empty", []byte{}, EncodingUTF8},
+		{"ascii", []byte("hello\n"), EncodingUTF8},
+		{"utf-8", []byte("héllo\n"), EncodingUTF8},
+		{"utf-8 cut mid rune", []byte("héllo")[:2], EncodingUTF8},
+		{"latin-1", []byte{'h', 0xe9, 'l', 'l', 'o', '\n'}, EncodingLatin1},
+		{"utf-16le with bom", []byte{0xff, 0xfe, 'h', 0, 'i', 0}, EncodingUTF16LE},
+		{"utf-16be with bom", []byte{0xfe, 0xff, 0, 'h', 0, 'i'}, EncodingUTF16BE},
+		{"utf-16le without bom", []byte{'h', 0, 'i', 0, '\n', 0}, EncodingUTF16LE},
+		{"utf-16be without bom", []byte{0, 'h', 0, 'i', 0, '\n'}, EncodingUTF16BE},
+		{"binary", []byte{0, 0, 1, 2, 0, 3, 0xff, 0}, EncodingUTF8},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, DetectEncoding(s.content))
+		})
+	}
+}
+
+// TestTranscodeDiff is a function.
+func TestTranscodeDiff(t *testing.T) {
+	type scenario struct {
+		testName string
+		diff     string
+		encoding string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"utf-8 is left alone",
+			"@@ -1 +1 @@\n-a\n+b\n",
+			EncodingUTF8,
+			"@@ -1 +1 @@\n-a\n+b\n",
+		},
+		{
+			"latin-1",
+			"diff --git a/f b/f\n--- a/f\n+++ b/f\n@@ -1 +1 @@\n \xe9\n",
+			EncodingLatin1,
+			"diff --git a/f b/f\n--- a/f\n+++ b/f\n@@ -1 +1 @@\n é\n",
+		},
+		{
+			"utf-16le",
+			"@@ -1,2 +1,2 @@\n \xff\xfea\x00\n+\x00b\x00\r\x00\n \x00\n\\ No newline at end of file\n",
+			EncodingUTF16LE,
+			"@@ -1,2 +1,2 @@\n a\n+b\n \n\\ No newline at end of file\n",
+		},
+		{
+			"utf-16be",
+			"@@ -1,2 +1,2 @@\n \x00a\x00\n \x00b\x00\n",
+			EncodingUTF16BE,
+			"@@ -1,2 +1,2 @@\n a\n b\n",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, TranscodeDiff(s.diff, s.encoding))
+		})
+	}
+}
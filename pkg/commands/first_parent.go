@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"fmt"
+)
+
+// in first-parent mode the commits panel only shows the current branch's
+// mainline, with each merge commit standing in for the commits it brought in.
+// A merge can be expanded to list those commits beneath it
+
+// getMergedInCommits returns the commits a merge brought in from its second
+// parent, i.e. the ones that weren't already on the mainline
+func (c *CommitListBuilder) getMergedInCommits(merge *Commit) ([]*Commit, error) {
+	commits := []*Commit{}
+	cmd := c.OSCommand.ExecutableFromString(fmt.Sprintf("git log %s --abbrev=%d --date=unix --decorate=full %s^1..%s^2", c.prettyFormatArg(), 20, merge.Sha, merge.Sha))
+	err := RunLineOutputCmd(cmd, func(line string) (bool, error) {
+		commit := c.extractCommitFromLine(line)
+		commit.Status = merge.Status
+		commit.MergeExpansion = "merged-in"
+		commits = append(commits, commit)
+		return false, nil
+	})
+	return commits, err
+}
+
+// ExpandMerges marks which of the given mainline commits are merges and lists
+// the merged-in commits of the expanded ones beneath them
+func (c *CommitListBuilder) ExpandMerges(commits []*Commit, expandedMerges map[string]bool) ([]*Commit, error) {
+	result := make([]*Commit, 0, len(commits))
+	for _, commit := range commits {
+		result = append(result, commit)
+		if len(commit.Parents) < 2 {
+			continue
+		}
+		if !expandedMerges[commit.Sha] {
+			commit.MergeExpansion = "collapsed"
+			continue
+		}
+
+		commit.MergeExpansion = "expanded"
+		mergedIn, err := c.getMergedInCommits(commit)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, mergedIn...)
+	}
+	return result, nil
+}
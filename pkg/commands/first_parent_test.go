@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCommitListBuilderExpandMerges is a function.
+func TestCommitListBuilderExpandMerges(t *testing.T) {
+	c := NewDummyCommitListBuilder()
+	c.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, "log", args[0])
+		assert.EqualValues(t, "merge2^1..merge2^2", args[len(args)-1])
+		return exec.Command("echo", "side2|1590000002|Jesse| |side1|second side commit\nside1|1590000001|Jesse| |base|first side commit")
+	})
+
+	commits := []*Commit{
+		{Sha: "top", Status: "unpushed", Parents: []string{"merge1"}},
+		{Sha: "merge1", Status: "pushed", Parents: []string{"merge2", "other"}},
+		{Sha: "merge2", Status: "pushed", Parents: []string{"bottom", "side2"}},
+		{Sha: "bottom", Status: "pushed", Parents: []string{"base"}},
+	}
+
+	result, err := c.ExpandMerges(commits, map[string]bool{"merge2": true})
+	assert.NoError(t, err)
+
+	summary := []string{}
+	for _, commit := range result {
+		summary = append(summary, commit.Sha+" "+commit.MergeExpansion+" "+commit.Status)
+	}
+	assert.EqualValues(t, []string{
+		"top  unpushed",
+		"merge1 collapsed pushed",
+		"merge2 expanded pushed",
+		"side2 merged-in pushed",
+		"side1 merged-in pushed",
+		"bottom  pushed",
+	}, summary)
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/go-errors/errors"
+)
+
+// forgeAPIClient is what we talk to the APIs of Gitea, Azure DevOps and the
+// like with. We wait on it with a spinner up, so a server that never answers
+// mustn't leave us waiting forever
+var forgeAPIClient = &http.Client{Timeout: 30 * time.Second}
+
+// linkNextRegexp matches the link to the next page in a Link header, e.g.
+// '<https://codeberg.org/api/v1/repos/owner/repo/pulls?page=2>; rel="next"'
+var linkNextRegexp = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// doJSONRequest sends a request with a JSON body, if there is one, to a
+// forge's API, decoding the JSON response into result. authorization is the
+// Authorization header, if any. It returns the response's headers, which is
+// where the API says whether there's another page. Errors carry the API's own
+// message when it gives one
+func doJSONRequest(method string, url string, authorization string, body interface{}, result interface{}) (http.Header, error) {
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := forgeAPIClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiError := struct {
+			Message string `json:"message"`
+		}{}
+		if json.NewDecoder(resp.Body).Decode(&apiError) == nil && apiError.Message != "" {
+			return nil, errors.New(apiError.Message)
+		}
+		return nil, fmt.Errorf("%s %s: %s", method, url, resp.Status)
+	}
+
+	return resp.Header, json.NewDecoder(resp.Body).Decode(result)
+}
+
+// nextPageURL returns the link to the next page of results from the
+// response's Link header, or an empty string if this was the last page
+func nextPageURL(header http.Header) string {
+	for _, link := range header["Link"] {
+		if match := linkNextRegexp.FindStringSubmatch(link); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
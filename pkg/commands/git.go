@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -18,6 +19,7 @@ import (
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/jesseduffield/lazygit/pkg/config"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/sirupsen/logrus"
 	gitconfig "github.com/tcnksm/go-gitconfig"
@@ -85,9 +87,23 @@ type GitCommand struct {
 	DotGitDir            string
 	onSuccessfulContinue func() error
 	PatchManager         *PatchManager
+	DiffOptions          DiffOptions
 
 	// Push to current determines whether the user has configured to push to the remote branch of the same name as the current or not
 	PushToCurrent bool
+
+	// PartialCloneFilter is the filter the repo was cloned with if it's a
+	// partial clone, e.g. 'blob:none'. Empty for full clones
+	PartialCloneFilter string
+
+	// UsingFsmonitor tells us whether git has a filesystem monitor telling it
+	// which files have changed, so that status doesn't have to check them all
+	UsingFsmonitor bool
+
+	// Prefix is the directory lazygit was started in, relative to the repo's
+	// root, e.g. 'pkg/gui'. It's empty if that was the root. We work from the
+	// root, but can show paths relative to here
+	Prefix string
 }
 
 // NewGitCommand it runs git commands
@@ -95,6 +111,10 @@ func NewGitCommand(log *logrus.Entry, osCommand *OSCommand, tr *i18n.Localizer,
 	var worktree *gogit.Worktree
 	var repo *gogit.Repository
 
+	if _, err := getGitConfigOverrides(config); err != nil {
+		return nil, err
+	}
+
 	// see what our default push behaviour is
 	output, err := osCommand.RunCommandWithOutput("git config --get push.default")
 	pushToCurrent := false
@@ -104,10 +124,20 @@ func NewGitCommand(log *logrus.Entry, osCommand *OSCommand, tr *i18n.Localizer,
 		pushToCurrent = strings.TrimSpace(output) == "current"
 	}
 
+	fsmonitorOutput, _ := osCommand.RunCommandWithOutput("git config --get core.fsmonitor")
+	usingFsmonitor := isFsmonitorValue(strings.TrimSpace(fsmonitorOutput))
+
+	prefix := ""
 	fs := []func() error{
 		func() error {
 			return verifyInGitRepo(osCommand.RunCommand)
 		},
+		func() error {
+			// we have to ask before we move to the repo's root
+			output, err := osCommand.RunCommandWithOutput("git rev-parse --show-prefix")
+			prefix = strings.TrimSuffix(strings.TrimSpace(output), "/")
+			return err
+		},
 		func() error {
 			return navigateToRepoRootDirectory(os.Stat, os.Chdir)
 		},
@@ -129,6 +159,8 @@ func NewGitCommand(log *logrus.Entry, osCommand *OSCommand, tr *i18n.Localizer,
 		return nil, err
 	}
 
+	partialCloneFilter := getPartialCloneFilter(osCommand)
+
 	gitCommand := &GitCommand{
 		Log:                log,
 		OSCommand:          osCommand,
@@ -138,9 +170,13 @@ func NewGitCommand(log *logrus.Entry, osCommand *OSCommand, tr *i18n.Localizer,
 		Config:             config,
 		getGlobalGitConfig: gitconfig.Global,
 		getLocalGitConfig:  gitconfig.Local,
-		removeFile:         os.RemoveAll,
+		removeFile:         osCommand.Discard,
 		DotGitDir:          dotGitDir,
 		PushToCurrent:      pushToCurrent,
+		UsingFsmonitor:     usingFsmonitor,
+		PartialCloneFilter: partialCloneFilter,
+		DiffOptions:        NewDiffOptions(config),
+		Prefix:             prefix,
 	}
 
 	gitCommand.PatchManager = NewPatchManager(log, gitCommand.ApplyPatch)
@@ -170,11 +206,19 @@ func findDotGitDir(stat func(string) (os.FileInfo, error), readFile func(filenam
 }
 
 func (c *GitCommand) getUnfilteredStashEntries() []*StashEntry {
-	unescaped := "git stash list --pretty='%gs'"
+	unescaped := fmt.Sprintf("git stash list --pretty='%%ct%s%%gs'", SEPARATION_CHAR)
 	rawString, _ := c.OSCommand.RunCommandWithOutput(unescaped)
 	stashEntries := []*StashEntry{}
 	for i, line := range utils.SplitLines(rawString) {
-		stashEntries = append(stashEntries, stashEntryFromLine(line, i))
+		stashEntry := stashEntryFromLine(line, i)
+		split := strings.SplitN(line, SEPARATION_CHAR, 2)
+		if len(split) == 2 {
+			if timestamp, err := strconv.ParseInt(split[0], 10, 64); err == nil {
+				stashEntry.Name = split[1]
+				stashEntry.UnixTimestamp = timestamp
+			}
+		}
+		stashEntries = append(stashEntries, stashEntry)
 	}
 	return stashEntries
 }
@@ -227,7 +271,7 @@ func stashEntryFromLine(line string, index int) *StashEntry {
 
 // GetStashEntryDiff stash diff
 func (c *GitCommand) ShowStashEntryCmdStr(index int) string {
-	return fmt.Sprintf("git stash show -p --color=%s stash@{%d}", c.colorArg(), index)
+	return fmt.Sprintf("git stash show -p --color=%s%s stash@{%d}", c.colorArg(), c.DiffFlags(), index)
 }
 
 // GetStatusFiles git status files
@@ -260,6 +304,58 @@ func (c *GitCommand) GetStatusFiles() []*File {
 		}
 		files = append(files, file)
 	}
+	c.setSubmoduleStatuses(files, c.GetSubmodulePaths())
+	c.setHunkCounts(files)
+	return files
+}
+
+// GetFilesChangedAgainstRef returns the files in the working tree whose
+// contents differ from the given ref. Files that also appear in git status keep
+// their status so they can still be staged, and untracked files are kept because
+// git diff doesn't know about them
+func (c *GitCommand) GetFilesChangedAgainstRef(ref string, statusFiles []*File) []*File {
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "diff", "--name-status", "--no-renames", ref, "--")
+	if err != nil {
+		c.Log.Error(err)
+		return statusFiles
+	}
+
+	statusFilesByName := map[string]*File{}
+	for _, file := range statusFiles {
+		statusFilesByName[file.Name] = file
+	}
+
+	files := []*File{}
+	seen := map[string]bool{}
+	for _, line := range utils.SplitLines(output) {
+		split := strings.SplitN(line, "\t", 2)
+		if len(split) != 2 {
+			continue
+		}
+		name := c.OSCommand.Unquote(split[1])
+		seen[name] = true
+		if file, ok := statusFilesByName[name]; ok {
+			files = append(files, file)
+			continue
+		}
+
+		shortStatus := split[0][0:1] + " "
+		files = append(files, &File{
+			Name:          name,
+			DisplayString: shortStatus + " " + name,
+			Tracked:       true,
+			Deleted:       split[0] == "D",
+			Type:          c.OSCommand.FileType(name),
+			ShortStatus:   shortStatus,
+		})
+	}
+
+	for _, file := range statusFiles {
+		if !seen[file.Name] && !file.Tracked && !file.HasStagedChanges {
+			files = append(files, file)
+		}
+	}
+
 	return files
 }
 
@@ -268,10 +364,32 @@ func (c *GitCommand) StashDo(index int, method string) error {
 	return c.OSCommand.RunCommand("git stash %s stash@{%d}", method, index)
 }
 
+// StashBranch creates a new branch from the commit the stash was based on and applies the stash to it, dropping it if it applies cleanly
+func (c *GitCommand) StashBranch(index int, branchName string) error {
+	return c.OSCommand.RunCommandArgs("git", "stash", "branch", branchName, fmt.Sprintf("stash@{%d}", index))
+}
+
 // StashSave save stash
 // TODO: before calling this, check if there is anything to save
 func (c *GitCommand) StashSave(message string) error {
-	return c.OSCommand.RunCommand("git stash save %s", c.OSCommand.Quote(message))
+	return c.OSCommand.RunCommandArgs("git", "stash", "save", message)
+}
+
+// RenameStash changes the message of a stash entry. Git has no way to do this
+// in place, so we store the same commit again with the new message, meaning it
+// ends up at the top of the stash list, and only then drop the old entry, which
+// the store has pushed down by one. That way a failed store loses nothing
+func (c *GitCommand) RenameStash(index int, message string) error {
+	sha, err := c.OSCommand.RunCommandWithOutput("git rev-parse stash@{%d}", index)
+	if err != nil {
+		return err
+	}
+
+	if err := c.OSCommand.RunCommandArgs("git", "stash", "store", "-m", message, strings.TrimSpace(sha)); err != nil {
+		return err
+	}
+
+	return c.StashDo(index+1, "drop")
 }
 
 // MergeStatusFiles merge status files
@@ -347,7 +465,7 @@ func (c *GitCommand) GetCommitDifferences(from, to string) (string, string) {
 
 // RenameCommit renames the topmost commit with the given name
 func (c *GitCommand) RenameCommit(name string) error {
-	return c.OSCommand.RunCommand("git commit --allow-empty --amend -m %s", c.OSCommand.Quote(name))
+	return c.OSCommand.RunCommandArgs("git", "commit", "--allow-empty", "--amend", "-m", name)
 }
 
 // RebaseBranch interactive rebases onto a branch
@@ -360,14 +478,24 @@ func (c *GitCommand) RebaseBranch(branchName string) error {
 	return c.OSCommand.RunPreparedCommand(cmd)
 }
 
-// Fetch fetch git repo
-func (c *GitCommand) Fetch(unamePassQuestion func(string) string, canAskForCredentials bool) error {
-	return c.OSCommand.DetectUnamePass("git fetch", func(question string) string {
+// Fetch fetch git repo, giving up if ctx is cancelled first. It returns what
+// was fetched, or nil if nothing was
+func (c *GitCommand) Fetch(ctx context.Context, unamePassQuestion func(string) string, canAskForCredentials bool) (*TransferStats, error) {
+	start := time.Now()
+	output, err := c.OSCommand.DetectUnamePassWithOutput(ctx, "git fetch --progress", func(question string) string {
 		if canAskForCredentials {
 			return unamePassQuestion(question)
 		}
+		if question == "hostKey" {
+			// ssh keeps asking until it gets a yes or no
+			return "no\n"
+		}
 		return "\n"
 	})
+	if err != nil {
+		return nil, err
+	}
+	return c.transferStats("fetch", start, output), nil
 }
 
 // ResetToCommit reset to commit
@@ -375,6 +503,16 @@ func (c *GitCommand) ResetToCommit(sha string, strength string, options RunComma
 	return c.OSCommand.RunCommandWithOptions(fmt.Sprintf("git reset --%s %s", strength, sha), options)
 }
 
+// GetFilesChangedSince returns the tracked files whose contents in the working
+// tree differ from the given ref i.e. those that a hard reset to it would touch
+func (c *GitCommand) GetFilesChangedSince(ref string) ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git diff --name-only %s", ref)
+	if err != nil {
+		return nil, err
+	}
+	return utils.SplitLines(output), nil
+}
+
 // NewBranch create new branch
 func (c *GitCommand) NewBranch(name string, baseBranch string) error {
 	return c.OSCommand.RunCommand("git checkout -b %s %s", name, baseBranch)
@@ -432,6 +570,19 @@ func (c *GitCommand) AbortMerge() error {
 	return c.OSCommand.RunCommand("git merge --abort")
 }
 
+// getConfigValue returns the value of a git config key, preferring the repo's
+// local config over the global one
+func (c *GitCommand) getConfigValue(key string) string {
+	if value, ok := c.gitConfigOverride(key); ok {
+		return value
+	}
+	value, _ := c.getLocalGitConfig(key)
+	if value == "" {
+		value, _ = c.getGlobalGitConfig(key)
+	}
+	return value
+}
+
 // usingGpg tells us whether the user has gpg enabled so that we can know
 // whether we need to run a subprocess to allow them to enter their password
 func (c *GitCommand) usingGpg() bool {
@@ -444,9 +595,47 @@ func (c *GitCommand) usingGpg() bool {
 	return value == "true" || value == "1" || value == "yes" || value == "on"
 }
 
+// GetUserIdentity returns the committer identity in the form used by trailers
+// like Signed-off-by, e.g. 'Jesse Duffield <jessedduffield@gmail.com>'
+func (c *GitCommand) GetUserIdentity() string {
+	return fmt.Sprintf("%s <%s>", c.getConfigValue("user.name"), c.getConfigValue("user.email"))
+}
+
+// AutoTrailers returns the trailers the user has configured to be added to
+// every commit, with the {{userName}} and {{userEmail}} placeholders filled in
+func (c *GitCommand) AutoTrailers() []string {
+	trailers := c.Config.GetUserConfig().GetStringSlice("git.autoTrailers")
+	templateValues := map[string]string{
+		"userName":  c.getConfigValue("user.name"),
+		"userEmail": c.getConfigValue("user.email"),
+	}
+	resolved := make([]string, len(trailers))
+	for i, trailer := range trailers {
+		resolved[i] = utils.ResolvePlaceholderString(trailer, templateValues)
+	}
+	return resolved
+}
+
+// AddTrailers adds trailers to a commit message using git interpret-trailers,
+// so they end up formatted exactly as git would format them. A trailer that
+// is already present with the same value is not added again
+func (c *GitCommand) AddTrailers(message string, trailers []string) (string, error) {
+	trailerArgs := ""
+	for _, trailer := range trailers {
+		trailerArgs += " --trailer " + c.OSCommand.Quote(trailer)
+	}
+	cmd := c.OSCommand.ExecutableFromString("git interpret-trailers --if-exists addIfDifferent" + trailerArgs)
+	cmd.Stdin = strings.NewReader(message)
+	output, err := c.OSCommand.RunExecutableWithOutput(cmd)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
 // Commit commits to git
 func (c *GitCommand) Commit(message string, flags string) (*exec.Cmd, error) {
-	command := fmt.Sprintf("git commit %s -m %s", flags, c.OSCommand.Quote(message))
+	command := c.CommitCmdStr(message, flags)
 	if c.usingGpg() {
 		return c.OSCommand.PrepareSubProcess(c.OSCommand.Platform.shell, c.OSCommand.Platform.shellArg, command), nil
 	}
@@ -454,6 +643,31 @@ func (c *GitCommand) Commit(message string, flags string) (*exec.Cmd, error) {
 	return nil, c.OSCommand.RunCommand(command)
 }
 
+// CommitCmdStr is the command that Commit runs
+func (c *GitCommand) CommitCmdStr(message string, flags string) string {
+	return fmt.Sprintf("git commit %s -m %s", flags, c.OSCommand.Quote(message))
+}
+
+// CanStreamCommitHooks tells us whether a commit will run hooks whose output
+// is worth showing as it happens. When gpg is in use the commit has to run as
+// a subprocess instead so the user can enter their passphrase
+func (c *GitCommand) CanStreamCommitHooks() bool {
+	if c.usingGpg() {
+		return false
+	}
+
+	hooksDir := c.getConfigValue("core.hooksPath")
+	if hooksDir == "" {
+		hooksDir = filepath.Join(c.DotGitDir, "hooks")
+	}
+	for _, hook := range []string{"pre-commit", "prepare-commit-msg", "commit-msg"} {
+		if exists, _ := c.OSCommand.FileExists(filepath.Join(hooksDir, hook)); exists {
+			return true
+		}
+	}
+	return false
+}
+
 // Get the subject of the HEAD commit
 func (c *GitCommand) GetHeadCommitMessage() (string, error) {
 	cmdStr := "git log -1 --pretty=%s"
@@ -463,7 +677,31 @@ func (c *GitCommand) GetHeadCommitMessage() (string, error) {
 
 // AmendHead amends HEAD with whatever is staged in your working tree
 func (c *GitCommand) AmendHead() (*exec.Cmd, error) {
+	return c.AmendHeadWithOptions(AmendOptions{})
+}
+
+// AmendOptions are the things you can change about HEAD when amending it,
+// other than its contents and message
+type AmendOptions struct {
+	ResetAuthor bool   // make the current user the author
+	ResetDate   bool   // set the author date to now
+	Author      string // in the form 'Name <email>'
+}
+
+// AmendHeadWithOptions amends HEAD with whatever is staged in your working
+// tree, keeping the commit message
+func (c *GitCommand) AmendHeadWithOptions(options AmendOptions) (*exec.Cmd, error) {
 	command := "git commit --amend --no-edit --allow-empty"
+	if options.ResetAuthor {
+		command += " --reset-author"
+	}
+	if options.ResetDate {
+		command += " --date=now"
+	}
+	if options.Author != "" {
+		command += " --author=" + c.OSCommand.Quote(options.Author)
+	}
+
 	if c.usingGpg() {
 		return c.OSCommand.PrepareSubProcess(c.OSCommand.Platform.shell, c.OSCommand.Platform.shellArg, command), nil
 	}
@@ -471,18 +709,38 @@ func (c *GitCommand) AmendHead() (*exec.Cmd, error) {
 	return nil, c.OSCommand.RunCommand(command)
 }
 
-// Pull pulls from repo
-func (c *GitCommand) Pull(args string, ask func(string) string) error {
-	return c.OSCommand.DetectUnamePass("git pull --no-edit "+args, ask)
+// Pull pulls from repo, giving up if ctx is cancelled first. It returns what
+// was fetched, or nil if nothing was
+func (c *GitCommand) Pull(ctx context.Context, args string, ask func(string) string) (*TransferStats, error) {
+	start := time.Now()
+	output, err := c.OSCommand.DetectUnamePassWithOutput(ctx, "git pull --progress --no-edit "+c.pullModeArgs()+args, ask)
+	if err != nil {
+		return nil, err
+	}
+	return c.transferStats("pull", start, output), nil
+}
+
+// pullModeArgs returns the flag for how git.pull.mode says to reconcile the
+// branches, or nothing to leave it to git's own pull.rebase and pull.ff
+func (c *GitCommand) pullModeArgs() string {
+	switch c.Config.GetUserConfig().GetString("git.pull.mode") {
+	case "merge":
+		return "--no-rebase "
+	case "rebase":
+		return "--rebase "
+	case "ff-only":
+		return "--ff-only "
+	}
+	return ""
 }
 
 // PullWithoutPasswordCheck assumes that the pull will not prompt the user for a password
 func (c *GitCommand) PullWithoutPasswordCheck(args string) error {
-	return c.OSCommand.RunCommand("git pull --no-edit " + args)
+	return c.OSCommand.RunCommandWithOptions("git pull --no-edit "+args, RunCommandOptions{Timeout: c.OSCommand.networkTimeout()})
 }
 
-// Push pushes to a branch
-func (c *GitCommand) Push(branchName string, force bool, upstream string, args string, ask func(string) string) error {
+// Push pushes to a branch, returning what was pushed, or nil if nothing was
+func (c *GitCommand) Push(branchName string, force bool, upstream string, args string, ask func(string) string) (*TransferStats, error) {
 	forceFlag := ""
 	if force {
 		forceFlag = "--force-with-lease"
@@ -493,18 +751,28 @@ func (c *GitCommand) Push(branchName string, force bool, upstream string, args s
 		setUpstreamArg = "--set-upstream " + upstream
 	}
 
-	cmd := fmt.Sprintf("git push --follow-tags %s %s %s", forceFlag, setUpstreamArg, args)
-	return c.OSCommand.DetectUnamePass(cmd, ask)
+	signedFlag := ""
+	if c.Config.GetUserConfig().GetBool("git.signedPush") {
+		signedFlag = "--signed"
+	}
+
+	cmd := fmt.Sprintf("git push --progress --follow-tags %s %s %s %s", forceFlag, signedFlag, setUpstreamArg, args)
+	start := time.Now()
+	output, err := c.OSCommand.DetectUnamePassWithOutput(context.Background(), cmd, ask)
+	if err != nil {
+		return nil, err
+	}
+	return c.transferStats("push", start, output), nil
 }
 
 // CatFile obtains the content of a file
 func (c *GitCommand) CatFile(fileName string) (string, error) {
-	return c.OSCommand.RunCommandWithOutput("%s %s", c.OSCommand.Platform.catCmd, c.OSCommand.Quote(fileName))
+	return c.OSCommand.RunCommandArgsWithOutput(c.OSCommand.Platform.catCmd, fileName)
 }
 
 // StageFile stages a file
 func (c *GitCommand) StageFile(fileName string) error {
-	return c.OSCommand.RunCommand("git add %s", c.OSCommand.Quote(fileName))
+	return c.OSCommand.RunCommandArgs("git", "add", fileName)
 }
 
 // StageAll stages all files
@@ -512,6 +780,44 @@ func (c *GitCommand) StageAll() error {
 	return c.OSCommand.RunCommand("git add -A")
 }
 
+// StageByPattern stages every change (including deletions and untracked files)
+// to paths matching the given pathspec e.g. '*.go' or 'src/**/*.test.ts'
+func (c *GitCommand) StageByPattern(pattern string) error {
+	return c.OSCommand.RunCommandArgs("git", "add", "-A", "--", pattern)
+}
+
+// UnstageByPattern unstages the paths matching the given pathspec
+func (c *GitCommand) UnstageByPattern(pattern string) error {
+	return c.OSCommand.RunCommandArgs("git", "reset", "-q", "--", pattern)
+}
+
+// FilesToStageByPattern returns the paths that StageByPattern would stage
+func (c *GitCommand) FilesToStageByPattern(pattern string) ([]string, error) {
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "add", "-A", "--dry-run", "--", pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// each line looks like "add 'path'" or "remove 'path'"
+	re := regexp.MustCompile(`^\w+ '(.*)'$`)
+	files := []string{}
+	for _, line := range utils.SplitLines(output) {
+		if match := re.FindStringSubmatch(line); match != nil {
+			files = append(files, match[1])
+		}
+	}
+	return files, nil
+}
+
+// FilesToUnstageByPattern returns the paths that UnstageByPattern would unstage
+func (c *GitCommand) FilesToUnstageByPattern(pattern string) ([]string, error) {
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "diff", "--cached", "--name-only", "--", pattern)
+	if err != nil {
+		return nil, err
+	}
+	return utils.SplitLines(output), nil
+}
+
 // UnstageAll stages all files
 func (c *GitCommand) UnstageAll() error {
 	return c.OSCommand.RunCommand("git reset")
@@ -519,15 +825,15 @@ func (c *GitCommand) UnstageAll() error {
 
 // UnStageFile unstages a file
 func (c *GitCommand) UnStageFile(fileName string, tracked bool) error {
-	command := "git rm --cached %s"
+	command := []string{"git", "rm", "--cached"}
 	if tracked {
-		command = "git reset HEAD %s"
+		command = []string{"git", "reset", "HEAD"}
 	}
 
 	// renamed files look like "file1 -> file2"
 	fileNames := strings.Split(fileName, " -> ")
 	for _, name := range fileNames {
-		if err := c.OSCommand.RunCommand(command, c.OSCommand.Quote(name)); err != nil {
+		if err := c.OSCommand.RunCommandArgs(append(command, name)...); err != nil {
 			return err
 		}
 	}
@@ -536,7 +842,15 @@ func (c *GitCommand) UnStageFile(fileName string, tracked bool) error {
 
 // GitStatus returns the plaintext short status of the repo
 func (c *GitCommand) GitStatus() (string, error) {
-	return c.OSCommand.RunCommandWithOutput("git status --untracked-files=all --porcelain")
+	cmdStr := "git status --untracked-files=all --porcelain"
+	if c.UsingFsmonitor {
+		// we normally stop git taking the index lock for optional writes so we
+		// don't get in the way of the user's own git commands. But with a
+		// filesystem monitor those writes record which files the monitor has
+		// already accounted for, and without them every status is a full scan
+		return c.OSCommand.RunCommandWithOutputWithOptions(cmdStr, RunCommandOptions{EnvVars: []string{"GIT_OPTIONAL_LOCKS=1"}})
+	}
+	return c.OSCommand.RunCommandWithOutput(cmdStr)
 }
 
 // IsInMergeState states whether we are still mid-merge
@@ -565,9 +879,8 @@ func (c *GitCommand) RebaseMode() (string, error) {
 // DiscardAllFileChanges directly
 func (c *GitCommand) DiscardAllFileChanges(file *File) error {
 	// if the file isn't tracked, we assume you want to delete it
-	quotedFileName := c.OSCommand.Quote(file.Name)
 	if file.HasStagedChanges || file.HasMergeConflicts {
-		if err := c.OSCommand.RunCommand("git reset -- %s", quotedFileName); err != nil {
+		if err := c.OSCommand.RunCommandArgs("git", "reset", "--", file.Name); err != nil {
 			return err
 		}
 	}
@@ -580,8 +893,7 @@ func (c *GitCommand) DiscardAllFileChanges(file *File) error {
 
 // DiscardUnstagedFileChanges directly
 func (c *GitCommand) DiscardUnstagedFileChanges(file *File) error {
-	quotedFileName := c.OSCommand.Quote(file.Name)
-	return c.OSCommand.RunCommand("git checkout -- %s", quotedFileName)
+	return c.OSCommand.RunCommandArgs("git", "checkout", "--", file.Name)
 }
 
 // Checkout checks out a branch (or commit), with --force if you set the force arg to true
@@ -603,6 +915,22 @@ func (c *GitCommand) PrepareCommitSubProcess() *exec.Cmd {
 	return c.OSCommand.PrepareSubProcess("git", "commit")
 }
 
+// PrepareDirDiffSubProcess prepares a subprocess that has git copy the files
+// that differ between the two refs into a pair of temp directories and open
+// them in a directory diff tool. An empty right ref means the working tree. If
+// the user hasn't configured a tool we leave it to git's diff.tool
+func (c *GitCommand) PrepareDirDiffSubProcess(left string, right string) *exec.Cmd {
+	args := []string{"difftool", "--dir-diff", "--no-prompt"}
+	if tool := c.Config.GetUserConfig().GetString("git.dirDiffTool"); tool != "" {
+		args = append(args, "--extcmd="+tool)
+	}
+	args = append(args, left)
+	if right != "" {
+		args = append(args, right)
+	}
+	return c.OSCommand.PrepareSubProcess("git", args...)
+}
+
 // PrepareCommitAmendSubProcess prepares a subprocess for `git commit --amend --allow-empty`
 func (c *GitCommand) PrepareCommitAmendSubProcess() *exec.Cmd {
 	return c.OSCommand.PrepareSubProcess("git", "commit", "--amend", "--allow-empty")
@@ -631,7 +959,13 @@ func (c *GitCommand) ShowCmdStr(sha string, filterPath string) string {
 	if filterPath != "" {
 		filterPathArg = fmt.Sprintf(" -- %s", c.OSCommand.Quote(filterPath))
 	}
-	return fmt.Sprintf("git show --color=%s --no-renames --stat -p %s %s", c.colorArg(), sha, filterPathArg)
+	return fmt.Sprintf("git show --color=%s%s --no-renames --stat -p %s %s", c.colorArg(), c.DiffFlags(), sha, filterPathArg)
+}
+
+// GetCommitMessage returns the full message (subject and body) of a commit
+func (c *GitCommand) GetCommitMessage(sha string) (string, error) {
+	message, err := c.OSCommand.RunCommandWithOutput("git log -1 --format=%%B %s", sha)
+	return strings.TrimSpace(message), err
 }
 
 func (c *GitCommand) GetBranchGraphCmdStr(branchName string) string {
@@ -642,10 +976,59 @@ func (c *GitCommand) GetBranchGraphCmdStr(branchName string) string {
 	return utils.ResolvePlaceholderString(branchLogCmdTemplate, templateValues)
 }
 
-// GetRemoteURL returns current repo remote url
+// GetRemoteURL returns the url origin is fetched from, with any insteadOf
+// rewrites applied
 func (c *GitCommand) GetRemoteURL() string {
-	url, _ := c.OSCommand.RunCommandWithOutput("git config --get remote.origin.url")
-	return utils.TrimTrailingNewline(url)
+	url, _, err := c.GetRemoteURLs("origin")
+	if err != nil {
+		return ""
+	}
+	return url
+}
+
+// GetCredentialHelper returns the credential helper git will use to store
+// credentials, or an empty string if none is configured
+func (c *GitCommand) GetCredentialHelper() string {
+	return strings.TrimSpace(c.getConfigValue("credential.helper"))
+}
+
+// ClearCachedCredentials asks the credential helper to forget whatever it has
+// stored for the given url (this is what erases an entry from e.g. the
+// osxkeychain or manager helpers). With the in-memory cache helper we also
+// stop the cache daemon so nothing stale lingers
+func (c *GitCommand) ClearCachedCredentials(url string) error {
+	cmd := c.OSCommand.ExecutableFromString("git credential reject")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("url=%s\n\n", url))
+	if err := c.OSCommand.RunExecutable(cmd); err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(c.GetCredentialHelper(), "cache") {
+		return c.OSCommand.RunCommand("git credential-cache exit")
+	}
+	return nil
+}
+
+// TraceCommand reruns a git command with GIT_TRACE and GIT_CURL_VERBOSE enabled
+// and writes everything it printed to a temp file, returning the file's path.
+// Git redacts auth headers from curl traces itself, so the file is safe to
+// attach to a bug report
+func (c *GitCommand) TraceCommand(command string) (string, error) {
+	output, _ := c.OSCommand.RunCommandWithOutputWithOptions(command, RunCommandOptions{
+		// we can't hand credential prompts to the user here so we'd rather they fail
+		EnvVars: []string{"GIT_TRACE=1", "GIT_CURL_VERBOSE=1", "GIT_TERMINAL_PROMPT=0"},
+	})
+
+	file, err := ioutil.TempFile("", "lazygit-trace-*.log")
+	if err != nil {
+		return "", WrapError(err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(fmt.Sprintf("$ %s\n\n%s", command, output)); err != nil {
+		return "", WrapError(err)
+	}
+	return file.Name(), nil
 }
 
 // CheckRemoteBranchExists Returns remote branch
@@ -677,30 +1060,65 @@ func (c *GitCommand) DiffCmdStr(file *File, plain bool, cached bool) string {
 	if !file.Tracked && !file.HasStagedChanges && !cached {
 		trackedArg = "--no-index /dev/null"
 	}
+	diffFlags := c.DiffFlags()
 	if plain {
 		colorArg = "never"
+		diffFlags = ""
+	} else if file.IsSubmodule {
+		// list the commits the submodule has moved by rather than just the two shas
+		diffFlags += " --submodule=log"
 	}
 
-	return fmt.Sprintf("git diff --color=%s %s %s %s", colorArg, cachedArg, trackedArg, fileName)
+	return fmt.Sprintf("git diff --color=%s%s %s %s %s", colorArg, diffFlags, cachedArg, trackedArg, fileName)
+}
+
+// TranscodedDiff returns the diff of a file that isn't UTF-8, converted to
+// UTF-8 for display. We pass --text because git treats UTF-16 as binary
+func (c *GitCommand) TranscodedDiff(file *File, cached bool, encoding string) string {
+	cmdStr := strings.Replace(c.DiffCmdStr(file, false, cached), "git diff ", "git diff --text ", 1)
+	s, _ := c.OSCommand.RunCommandWithOutput(cmdStr)
+	return TranscodeDiff(s, encoding)
+}
+
+// HasOnlyLineEndingChanges tells us whether a file's diff would be empty if we
+// ignored carriage returns, i.e. all that changed was CRLF vs LF
+func (c *GitCommand) HasOnlyLineEndingChanges(file *File, cached bool) bool {
+	if !file.Tracked && !file.HasStagedChanges {
+		return false
+	}
+	args := []string{"git", "diff", "--quiet", "--ignore-cr-at-eol"}
+	if cached {
+		args = append(args, "--cached")
+	}
+	split := strings.Split(file.Name, " -> ")
+	return c.OSCommand.RunCommandArgs(append(args, "--", split[len(split)-1])...) == nil
+}
+
+// DiffAgainstRefCmdStr shows how a file in the working tree differs from the given ref
+func (c *GitCommand) DiffAgainstRefCmdStr(ref string, file *File) string {
+	return fmt.Sprintf("git diff --color=%s%s %s -- %s", c.colorArg(), c.DiffFlags(), c.OSCommand.Quote(ref), c.OSCommand.Quote(file.Name))
 }
 
 func (c *GitCommand) ApplyPatch(patch string, flags ...string) error {
 	c.Log.Warn(patch)
-	filepath := filepath.Join(c.Config.GetUserConfigDir(), utils.GetCurrentRepoName(), time.Now().Format("Jan _2 15.04.05.000000000")+".patch")
+	filepath := filepath.Join(c.Config.GetCacheDir(), "patches", utils.GetCurrentRepoName(), time.Now().Format("Jan _2 15.04.05.000000000")+".patch")
 	if err := c.OSCommand.CreateFileWithContent(filepath, patch); err != nil {
 		return err
 	}
 
-	flagStr := ""
+	args := []string{"git", "apply"}
 	for _, flag := range flags {
-		flagStr += " --" + flag
+		args = append(args, "--"+flag)
 	}
 
-	return c.OSCommand.RunCommand("git apply %s %s", flagStr, c.OSCommand.Quote(filepath))
+	return c.OSCommand.RunCommandArgs(append(args, filepath)...)
 }
 
 func (c *GitCommand) FastForward(branchName string, remoteName string, remoteBranchName string) error {
-	return c.OSCommand.RunCommand("git fetch %s %s:%s", remoteName, remoteBranchName, branchName)
+	command := fmt.Sprintf("git fetch %s %s:%s", remoteName, remoteBranchName, branchName)
+	return c.OSCommand.withNetworkRetries(context.Background(), command, nil, func() error {
+		return c.OSCommand.RunCommandWithOptions(command, RunCommandOptions{Timeout: c.OSCommand.networkTimeout()})
+	})
 }
 
 func (c *GitCommand) RunSkipEditorCommand(command string) error {
@@ -811,7 +1229,7 @@ func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string
 		gitSequenceEditor = "true"
 	}
 
-	cmd.Env = os.Environ()
+	cmd.Env = c.OSCommand.Environ()
 	cmd.Env = append(
 		cmd.Env,
 		"LAZYGIT_CLIENT_COMMAND=INTERACTIVE_REBASE",
@@ -929,6 +1347,35 @@ func (c *GitCommand) Revert(sha string) error {
 	return c.OSCommand.RunCommand("git revert %s", sha)
 }
 
+// GetCommitsUniqueToEachSide returns the commits reachable from left but not
+// right, and those reachable from right but not left, newest first
+func (c *GitCommand) GetCommitsUniqueToEachSide(left string, right string) ([]*Commit, []*Commit, error) {
+	output, err := c.OSCommand.RunCommandWithOutput(
+		"git log --left-right --pretty=format:%%m%s%%H%s%%s %s...%s",
+		SEPARATION_CHAR, SEPARATION_CHAR, c.OSCommand.Quote(left), c.OSCommand.Quote(right),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leftCommits := []*Commit{}
+	rightCommits := []*Commit{}
+	for _, line := range utils.SplitLines(output) {
+		split := strings.SplitN(line, SEPARATION_CHAR, 3)
+		if len(split) < 3 {
+			continue
+		}
+		commit := &Commit{Sha: split[1], Name: split[2]}
+		if split[0] == "<" {
+			leftCommits = append(leftCommits, commit)
+		} else {
+			rightCommits = append(rightCommits, commit)
+		}
+	}
+
+	return leftCommits, rightCommits, nil
+}
+
 // CherryPickCommits begins an interactive rebase with the given shas being cherry picked onto HEAD
 func (c *GitCommand) CherryPickCommits(commits []*Commit) error {
 	todo := ""
@@ -946,14 +1393,21 @@ func (c *GitCommand) CherryPickCommits(commits []*Commit) error {
 
 // GetCommitFiles get the specified commit files
 func (c *GitCommand) GetCommitFiles(commitSha string, patchManager *PatchManager) ([]*CommitFile, error) {
-	files, err := c.OSCommand.RunCommandWithOutput("git diff-tree --no-commit-id --name-only -r --no-renames %s", commitSha)
+	// numstat reports '-' for the line counts of a binary change, which saves
+	// asking git about each file as it's selected
+	files, err := c.OSCommand.RunCommandWithOutput("git diff-tree --no-commit-id --numstat -r --no-renames %s", commitSha)
 	if err != nil {
 		return nil, err
 	}
 
 	commitFiles := make([]*CommitFile, 0)
 
-	for _, file := range strings.Split(strings.TrimRight(files, "\n"), "\n") {
+	for _, line := range strings.Split(strings.TrimRight(files, "\n"), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		file := fields[2]
 		status := UNSELECTED
 		if patchManager != nil && patchManager.CommitSha == commitSha {
 			status = patchManager.GetFileStatus(file)
@@ -964,6 +1418,7 @@ func (c *GitCommand) GetCommitFiles(commitSha string, patchManager *PatchManager
 			Name:          file,
 			DisplayString: file,
 			Status:        status,
+			IsBinary:      fields[0] == "-" && fields[1] == "-",
 		})
 	}
 
@@ -978,11 +1433,13 @@ func (c *GitCommand) ShowCommitFile(commitSha, fileName string, plain bool) (str
 
 func (c *GitCommand) ShowCommitFileCmdStr(commitSha, fileName string, plain bool) string {
 	colorArg := c.colorArg()
+	diffFlags := c.DiffFlags()
 	if plain {
 		colorArg = "never"
+		diffFlags = ""
 	}
 
-	return fmt.Sprintf("git show --no-renames --color=%s %s -- %s", colorArg, commitSha, fileName)
+	return fmt.Sprintf("git show --no-renames --color=%s%s %s -- %s", colorArg, diffFlags, commitSha, fileName)
 }
 
 // CheckoutFile checks out the file for the given commit
@@ -990,6 +1447,18 @@ func (c *GitCommand) CheckoutFile(commitSha, fileName string) error {
 	return c.OSCommand.RunCommand("git checkout %s %s", commitSha, fileName)
 }
 
+// RestoreFileToWorkingTree puts the version of a file from the given commit in
+// the working tree, leaving the index alone
+func (c *GitCommand) RestoreFileToWorkingTree(commitSha, fileName string) error {
+	return c.OSCommand.RunCommandArgs("git", "restore", "--source="+commitSha, "--worktree", "--", fileName)
+}
+
+// CheckoutFileDiffCmdStr returns the command for showing how checking out a
+// file at the given commit would change the file in the working tree
+func (c *GitCommand) CheckoutFileDiffCmdStr(commitSha, fileName string) string {
+	return fmt.Sprintf("git diff --color%s -R %s -- %s", c.DiffFlags(), commitSha, c.OSCommand.Quote(fileName))
+}
+
 // DiscardOldFileChanges discards changes to a file from an old commit
 func (c *GitCommand) DiscardOldFileChanges(commits []*Commit, commitIndex int, fileName string) error {
 	if err := c.BeginInteractiveRebaseForCommit(commits, commitIndex); err != nil {
@@ -1031,9 +1500,27 @@ func (c *GitCommand) RemoveTrackedFiles(name string) error {
 	return c.OSCommand.RunCommand("git rm -r --cached %s", name)
 }
 
-// RemoveUntrackedFiles runs `git clean -fd`
+// RemoveUntrackedFiles runs `git clean -fd`, or moves the files it would
+// remove to the trash if git.trashDiscardedFiles is on
 func (c *GitCommand) RemoveUntrackedFiles() error {
-	return c.OSCommand.RunCommand("git clean -fd")
+	if !c.Config.GetUserConfig().GetBool("git.trashDiscardedFiles") {
+		return c.OSCommand.RunCommand("git clean -fd")
+	}
+
+	// untracked directories are listed whole, like git clean removes them
+	output, err := c.OSCommand.RunCommandWithOutput("git ls-files --others --exclude-standard --directory -z")
+	if err != nil {
+		return err
+	}
+	for _, path := range strings.Split(output, "\x00") {
+		if path == "" {
+			continue
+		}
+		if err := c.OSCommand.MoveToTrash(strings.TrimSuffix(path, "/")); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ResetHardHead runs `git reset --hard`
@@ -1148,8 +1635,10 @@ func (c *GitCommand) IsHeadDetached() bool {
 	return err != nil
 }
 
-func (c *GitCommand) DeleteRemoteBranch(remoteName string, branchName string) error {
-	return c.OSCommand.RunCommand("git push %s --delete %s", remoteName, branchName)
+// DeleteRemoteBranch deletes the branch on the remote, asking for whatever
+// credentials the remote needs like Push does
+func (c *GitCommand) DeleteRemoteBranch(remoteName string, branchName string, ask func(string) string) error {
+	return c.OSCommand.DetectUnamePass(fmt.Sprintf("git push %s --delete %s", remoteName, branchName), ask)
 }
 
 func (c *GitCommand) SetBranchUpstream(remoteName string, remoteBranchName string, branchName string) error {
@@ -1172,12 +1661,58 @@ func (c *GitCommand) DeleteTag(tagName string) error {
 	return c.OSCommand.RunCommand("git tag -d %s", tagName)
 }
 
-func (c *GitCommand) PushTag(remoteName string, tagName string) error {
-	return c.OSCommand.RunCommand("git push %s %s", remoteName, tagName)
+// PushTag pushes the tag to the remote, asking for whatever credentials the
+// remote needs like Push does. It returns what was pushed, or nil if nothing was
+func (c *GitCommand) PushTag(remoteName string, tagName string, ask func(string) string) (*TransferStats, error) {
+	start := time.Now()
+	output, err := c.OSCommand.DetectUnamePassWithOutput(context.Background(), fmt.Sprintf("git push --progress %s %s", remoteName, tagName), ask)
+	if err != nil {
+		return nil, err
+	}
+	return c.transferStats("push", start, output), nil
 }
 
-func (c *GitCommand) FetchRemote(remoteName string) error {
-	return c.OSCommand.RunCommand("git fetch %s", remoteName)
+// FetchRemote fetches the given remote, giving up if ctx is cancelled first or
+// git.networkTimeout passes, because a flaky remote can otherwise hang forever.
+// If onProgress is given it's passed git's progress output line by line. It
+// returns what was fetched, or nil if nothing was
+func (c *GitCommand) FetchRemote(ctx context.Context, remoteName string, onProgress func(string)) (*TransferStats, error) {
+	return c.fetch(ctx, remoteName, nil, onProgress)
+}
+
+// FetchRemoteBranch fetches just the one branch from the remote, into its
+// remote-tracking branch, which on a remote with a great many branches is far
+// quicker than fetching them all
+func (c *GitCommand) FetchRemoteBranch(ctx context.Context, remoteName string, branchName string, onProgress func(string)) (*TransferStats, error) {
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/%s/%s", branchName, remoteName, branchName)
+	return c.fetch(ctx, remoteName, []string{refspec}, onProgress)
+}
+
+// fetch fetches the given refspecs from the remote, or whatever its configured
+// refspecs are if there are none, like FetchRemote
+func (c *GitCommand) fetch(ctx context.Context, remoteName string, refspecs []string, onProgress func(string)) (*TransferStats, error) {
+	options := RunCommandOptions{Timeout: c.OSCommand.networkTimeout()}
+	if onProgress != nil {
+		onChunk, flush := NewLineSplitter(onProgress)
+		defer flush()
+		options.OnOutput = onChunk
+	}
+	// we always ask for progress, which is where we find out what was fetched
+	command := fmt.Sprintf("git fetch --progress %s", remoteName)
+	for _, refspec := range refspecs {
+		command += " " + c.OSCommand.Quote(refspec)
+	}
+	start := time.Now()
+	var output string
+	err := c.OSCommand.withNetworkRetries(ctx, command, nil, func() error {
+		var err error
+		output, err = c.OSCommand.runCommandWithOptions(ctx, command, options)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.transferStats("fetch", start, output), nil
 }
 
 // GetReflogCommits only returns the new reflog commits since the given lastReflogCommit
@@ -1256,6 +1791,9 @@ func (c *GitCommand) GetPager(width int) string {
 }
 
 func (c *GitCommand) colorArg() string {
+	if theme.NoColor {
+		return "never"
+	}
 	return c.Config.GetUserConfig().GetString("git.paging.colorArg")
 }
 
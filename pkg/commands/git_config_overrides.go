@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/config"
+)
+
+// GitConfigOverride is a git config entry from git.config in the user's
+// config, which applies to every git command we run. It's how a config
+// profile can e.g. commit with a work email and signing key
+type GitConfigOverride struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+}
+
+func getGitConfigOverrides(config config.AppConfigurer) ([]GitConfigOverride, error) {
+	overrides := []GitConfigOverride{}
+	err := config.GetUserConfig().UnmarshalKey("git.config", &overrides)
+	return overrides, err
+}
+
+// withGitConfigOverrides adds to env what makes git treat the entries of
+// git.config as if each had been passed with -c, so that every git process we
+// start picks them up, including those started by the user's editor or custom
+// commands
+func (c *OSCommand) withGitConfigOverrides(env []string) []string {
+	overrides, err := getGitConfigOverrides(c.Config)
+	if err != nil || len(overrides) == 0 {
+		// NewGitCommand has already told the user about a malformed git.config
+		return env
+	}
+	return append(env, gitConfigOverrideEnv(env, overrides, c.GitVersionAtLeast(2, 31))...)
+}
+
+// gitConfigOverrideEnv returns the variables to add to env to give git the
+// overrides. From git 2.31 that's GIT_CONFIG_COUNT and its keys and values,
+// which we number after any the user has set themselves. Older versions
+// ignore those, but have long read GIT_CONFIG_PARAMETERS, which is where git
+// itself passes -c entries on to the git processes it starts
+func gitConfigOverrideEnv(env []string, overrides []GitConfigOverride, hasConfigCount bool) []string {
+	if !hasConfigCount {
+		params := []string{}
+		if existing := getEnvValue(env, "GIT_CONFIG_PARAMETERS"); existing != "" {
+			params = append(params, existing)
+		}
+		for _, override := range overrides {
+			params = append(params, singleQuote(override.Key+"="+override.Value))
+		}
+		return []string{"GIT_CONFIG_PARAMETERS=" + strings.Join(params, " ")}
+	}
+
+	count, _ := strconv.Atoi(getEnvValue(env, "GIT_CONFIG_COUNT"))
+	result := []string{fmt.Sprintf("GIT_CONFIG_COUNT=%d", count+len(overrides))}
+	for i, override := range overrides {
+		result = append(result,
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", count+i, override.Key),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", count+i, override.Value),
+		)
+	}
+	return result
+}
+
+// getEnvValue returns the value env gives the variable, going by the last
+// entry for it as exec does
+func getEnvValue(env []string, name string) string {
+	for i := len(env) - 1; i >= 0; i-- {
+		if strings.HasPrefix(env[i], name+"=") {
+			return strings.TrimPrefix(env[i], name+"=")
+		}
+	}
+	return ""
+}
+
+// singleQuote quotes the string the way git quotes the entries of
+// GIT_CONFIG_PARAMETERS
+func singleQuote(str string) string {
+	return "'" + strings.Replace(str, "'", `'\''`, -1) + "'"
+}
+
+// gitConfigOverride returns the value git.config gives the key, if any. Git
+// config keys are case insensitive, apart from the middle part of three part
+// keys like branch.<name>.remote, which we don't expect here
+func (c *GitCommand) gitConfigOverride(key string) (string, bool) {
+	overrides, _ := getGitConfigOverrides(c.Config)
+	for i := len(overrides) - 1; i >= 0; i-- {
+		if strings.EqualFold(overrides[i].Key, key) {
+			return overrides[i].Value, true
+		}
+	}
+	return "", false
+}
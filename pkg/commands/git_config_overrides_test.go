@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitConfigOverrideEnv is a function.
+func TestGitConfigOverrideEnv(t *testing.T) {
+	overrides := []GitConfigOverride{
+		{Key: "user.email", Value: "me@work.com"},
+		{Key: "user.name", Value: "Jesse O'Duffield"},
+	}
+
+	type scenario struct {
+		testName       string
+		env            []string
+		hasConfigCount bool
+		expected       []string
+	}
+
+	scenarios := []scenario{
+		{
+			"git 2.31 or later",
+			[]string{"HOME=/home/me"},
+			true,
+			[]string{
+				"GIT_CONFIG_COUNT=2",
+				"GIT_CONFIG_KEY_0=user.email",
+				"GIT_CONFIG_VALUE_0=me@work.com",
+				"GIT_CONFIG_KEY_1=user.name",
+				"GIT_CONFIG_VALUE_1=Jesse O'Duffield",
+			},
+		},
+		{
+			"entries the user exported come first",
+			[]string{"GIT_CONFIG_COUNT=1", "GIT_CONFIG_KEY_0=core.editor", "GIT_CONFIG_VALUE_0=vim"},
+			true,
+			[]string{
+				"GIT_CONFIG_COUNT=3",
+				"GIT_CONFIG_KEY_1=user.email",
+				"GIT_CONFIG_VALUE_1=me@work.com",
+				"GIT_CONFIG_KEY_2=user.name",
+				"GIT_CONFIG_VALUE_2=Jesse O'Duffield",
+			},
+		},
+		{
+			"older git",
+			[]string{"HOME=/home/me"},
+			false,
+			[]string{`GIT_CONFIG_PARAMETERS='user.email=me@work.com' 'user.name=Jesse O'\''Duffield'`},
+		},
+		{
+			"older git with parameters of its own",
+			[]string{"GIT_CONFIG_PARAMETERS='core.editor=vim'"},
+			false,
+			[]string{`GIT_CONFIG_PARAMETERS='core.editor=vim' 'user.email=me@work.com' 'user.name=Jesse O'\''Duffield'`},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, gitConfigOverrideEnv(s.env, overrides, s.hasConfigCount))
+		})
+	}
+}
+
+// TestOSCommandEnvironWithGitConfig is a function.
+func TestOSCommandEnvironWithGitConfig(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("git.config", []map[string]interface{}{
+		{"key": "user.email", "value": "me@work.com"},
+	})
+	cmd := osCommand.ExecutableFromString("git config user.email")
+	output, err := cmd.Output()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "me@work.com\n", string(output))
+	assert.NotContains(t, os.Environ(), "GIT_CONFIG_KEY_0=user.email", "our own environment is left alone")
+}
+
+// TestGitCommandGetConfigValueWithOverride is a function.
+func TestGitCommandGetConfigValueWithOverride(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.getLocalGitConfig = func(string) (string, error) { return "me@home.com", nil }
+
+	assert.EqualValues(t, "me@home.com", gitCmd.getConfigValue("user.email"))
+
+	gitCmd.Config.GetUserConfig().Set("git.config", []map[string]interface{}{
+		{"key": "user.email", "value": "me@work.com"},
+	})
+	assert.EqualValues(t, "me@work.com", gitCmd.getConfigValue("user.email"))
+	assert.EqualValues(t, "me@work.com", gitCmd.getConfigValue("user.Email"), "keys are case insensitive")
+	assert.EqualValues(t, "me@home.com", gitCmd.getConfigValue("user.name"))
+}
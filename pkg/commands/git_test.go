@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -255,6 +256,24 @@ func TestNewGitCommand(t *testing.T) {
 			},
 			func(gitCmd *GitCommand, err error) {
 				assert.NoError(t, err)
+				assert.Equal(t, "", gitCmd.Prefix)
+			},
+		},
+		{
+			"New GitCommand object created from a subdirectory",
+			func() {
+				assert.NoError(t, os.RemoveAll("/tmp/lazygit-test"))
+				_, err := gogit.PlainInit("/tmp/lazygit-test", false)
+				assert.NoError(t, err)
+				assert.NoError(t, os.MkdirAll("/tmp/lazygit-test/pkg/gui", 0755))
+				assert.NoError(t, os.Chdir("/tmp/lazygit-test/pkg/gui"))
+			},
+			func(gitCmd *GitCommand, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "pkg/gui", gitCmd.Prefix)
+				wd, err := os.Getwd()
+				assert.NoError(t, err)
+				assert.Equal(t, "/tmp/lazygit-test", wd)
 			},
 		},
 	}
@@ -288,17 +307,19 @@ func TestGitCommandGetStashEntries(t *testing.T) {
 		{
 			"Several stash entries found",
 			func(string, ...string) *exec.Cmd {
-				return exec.Command("echo", "WIP on add-pkg-commands-test: 55c6af2 increase parallel build\nWIP on master: bb86a3f update github template")
+				return exec.Command("echo", "1588206694|WIP on add-pkg-commands-test: 55c6af2 increase parallel build\n1588206512|WIP on master: bb86a3f update github template")
 			},
 			func(entries []*StashEntry) {
 				expected := []*StashEntry{
 					{
 						0,
 						"WIP on add-pkg-commands-test: 55c6af2 increase parallel build",
+						1588206694,
 					},
 					{
 						1,
 						"WIP on master: bb86a3f update github template",
+						1588206512,
 					},
 				}
 
@@ -425,6 +446,30 @@ func TestGitCommandGetStatusFiles(t *testing.T) {
 	}
 }
 
+// TestGitCommandGetFilesChangedAgainstRef is a function.
+func TestGitCommandGetFilesChangedAgainstRef(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git diff --name-status --no-renames develop --",
+			Replace: "echo 'M\tchanged-locally.txt\nA\tadded-since-develop.txt\nD\tdeleted-since-develop.txt'",
+		},
+	})
+
+	changedLocally := &File{Name: "changed-locally.txt", DisplayString: " M changed-locally.txt", HasUnstagedChanges: true, Tracked: true, ShortStatus: " M"}
+	untracked := &File{Name: "untracked.txt", DisplayString: "?? untracked.txt", HasUnstagedChanges: true, ShortStatus: "??"}
+	modifiedBackToRef := &File{Name: "same-as-develop.txt", DisplayString: " M same-as-develop.txt", HasUnstagedChanges: true, Tracked: true, ShortStatus: " M"}
+
+	files := gitCmd.GetFilesChangedAgainstRef("develop", []*File{changedLocally, untracked, modifiedBackToRef})
+
+	assert.EqualValues(t, []*File{
+		changedLocally,
+		{Name: "added-since-develop.txt", DisplayString: "A  added-since-develop.txt", Tracked: true, Type: "other", ShortStatus: "A "},
+		{Name: "deleted-since-develop.txt", DisplayString: "D  deleted-since-develop.txt", Tracked: true, Deleted: true, Type: "other", ShortStatus: "D "},
+		untracked,
+	}, files)
+}
+
 // TestGitCommandStashDo is a function.
 func TestGitCommandStashDo(t *testing.T) {
 	gitCmd := NewDummyGitCommand()
@@ -438,6 +483,19 @@ func TestGitCommandStashDo(t *testing.T) {
 	assert.NoError(t, gitCmd.StashDo(1, "drop"))
 }
 
+// TestGitCommandStashBranch is a function.
+func TestGitCommandStashBranch(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"stash", "branch", "recovered-work", "stash@{2}"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.StashBranch(2, "recovered-work"))
+}
+
 // TestGitCommandStashSave is a function.
 func TestGitCommandStashSave(t *testing.T) {
 	gitCmd := NewDummyGitCommand()
@@ -451,6 +509,58 @@ func TestGitCommandStashSave(t *testing.T) {
 	assert.NoError(t, gitCmd.StashSave("A stash message"))
 }
 
+// TestGitCommandRenameStash is a function.
+func TestGitCommandRenameStash(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git rev-parse stash@{2}",
+			Replace: "echo 48d2ac4fb7bc1d82b5a5b790e02fe2d9d7d3e0b1",
+		},
+		{
+			Expect:  `git stash store -m "new message" 48d2ac4fb7bc1d82b5a5b790e02fe2d9d7d3e0b1`,
+			Replace: "echo",
+		},
+		{
+			Expect:  "git stash drop stash@{3}",
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.RenameStash(2, "new message"))
+}
+
+// TestGitCommandRenameStashStoreFails is a function.
+func TestGitCommandRenameStashStoreFails(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git rev-parse stash@{2}",
+			Replace: "echo 48d2ac4fb7bc1d82b5a5b790e02fe2d9d7d3e0b1",
+		},
+		{
+			Expect:  `git stash store -m "new message" 48d2ac4fb7bc1d82b5a5b790e02fe2d9d7d3e0b1`,
+			Replace: "false",
+		},
+	})
+
+	// the entry is left alone, rather than dropped with nothing to replace it
+	assert.Error(t, gitCmd.RenameStash(2, "new message"))
+}
+
+// TestGitCommandRestoreSnapshot is a function.
+func TestGitCommandRestoreSnapshot(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git restore --overlay --source=48d2ac4fb7bc1d82b5a5b790e02fe2d9d7d3e0b1 --worktree -- .",
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.RestoreSnapshot("48d2ac4fb7bc1d82b5a5b790e02fe2d9d7d3e0b1"))
+}
+
 // TestGitCommandCommitAmend is a function.
 func TestGitCommandCommitAmend(t *testing.T) {
 	gitCmd := NewDummyGitCommand()
@@ -630,6 +740,21 @@ func TestGitCommandResetToCommit(t *testing.T) {
 	assert.NoError(t, gitCmd.ResetToCommit("78976bc", "hard", RunCommandOptions{}))
 }
 
+// TestGitCommandGetFilesChangedSince is a function.
+func TestGitCommandGetFilesChangedSince(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"diff", "--name-only", "78976bc"}, args)
+
+		return exec.Command("echo", "README.md\npkg/main.go")
+	}
+
+	files, err := gitCmd.GetFilesChangedSince("78976bc")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"README.md", "pkg/main.go"}, files)
+}
+
 // TestGitCommandNewBranch is a function.
 func TestGitCommandNewBranch(t *testing.T) {
 	gitCmd := NewDummyGitCommand()
@@ -891,6 +1016,41 @@ func TestGitCommandCommit(t *testing.T) {
 	}
 }
 
+// TestGitCommandAddTrailers is a function.
+func TestGitCommandAddTrailers(t *testing.T) {
+	type scenario struct {
+		testName string
+		trailers []string
+		command  func(string, ...string) *exec.Cmd
+		test     func(string, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Adds each trailer",
+			[]string{"Signed-off-by: Jesse <jesse@example.com>", "Reviewed-by: Tom <tom@example.com>"},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"interpret-trailers", "--if-exists", "addIfDifferent", "--trailer", "Signed-off-by: Jesse <jesse@example.com>", "--trailer", "Reviewed-by: Tom <tom@example.com>"}, args)
+
+				return exec.Command("echo", "test\n\nSigned-off-by: Jesse <jesse@example.com>\nReviewed-by: Tom <tom@example.com>\n")
+			},
+			func(output string, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "test\n\nSigned-off-by: Jesse <jesse@example.com>\nReviewed-by: Tom <tom@example.com>", output)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.AddTrailers("test", s.trailers))
+		})
+	}
+}
+
 // TestGitCommandAmendHead is a function.
 func TestGitCommandAmendHead(t *testing.T) {
 	type scenario struct {
@@ -961,13 +1121,64 @@ func TestGitCommandAmendHead(t *testing.T) {
 	}
 }
 
+// TestGitCommandAmendHeadWithOptions is a function.
+func TestGitCommandAmendHeadWithOptions(t *testing.T) {
+	type scenario struct {
+		testName string
+		options  AmendOptions
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"no options",
+			AmendOptions{},
+			[]string{"commit", "--amend", "--no-edit", "--allow-empty"},
+		},
+		{
+			"reset author",
+			AmendOptions{ResetAuthor: true},
+			[]string{"commit", "--amend", "--no-edit", "--allow-empty", "--reset-author"},
+		},
+		{
+			"reset date",
+			AmendOptions{ResetDate: true},
+			[]string{"commit", "--amend", "--no-edit", "--allow-empty", "--date=now"},
+		},
+		{
+			"set author",
+			AmendOptions{Author: "Jesse Duffield <jesse@example.com>"},
+			[]string{"commit", "--amend", "--no-edit", "--allow-empty", "--author=Jesse Duffield <jesse@example.com>"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGlobalGitConfig = func(string) (string, error) {
+				return "false", nil
+			}
+			gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expected, args)
+
+				return exec.Command("echo")
+			}
+			cmd, err := gitCmd.AmendHeadWithOptions(s.options)
+			assert.Nil(t, cmd)
+			assert.NoError(t, err)
+		})
+	}
+}
+
 // TestGitCommandPush is a function.
 func TestGitCommandPush(t *testing.T) {
 	type scenario struct {
-		testName  string
-		command   func(string, ...string) *exec.Cmd
-		forcePush bool
-		test      func(error)
+		testName   string
+		command    func(string, ...string) *exec.Cmd
+		forcePush  bool
+		signedPush bool
+		test       func(error)
 	}
 
 	scenarios := []scenario{
@@ -975,11 +1186,12 @@ func TestGitCommandPush(t *testing.T) {
 			"Push with force disabled",
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
-				assert.EqualValues(t, []string{"push", "--follow-tags"}, args)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags"}, args)
 
 				return exec.Command("echo")
 			},
 			false,
+			false,
 			func(err error) {
 				assert.NoError(t, err)
 			},
@@ -988,11 +1200,26 @@ func TestGitCommandPush(t *testing.T) {
 			"Push with force enabled",
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
-				assert.EqualValues(t, []string{"push", "--follow-tags", "--force-with-lease"}, args)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags", "--force-with-lease"}, args)
 
 				return exec.Command("echo")
 			},
 			true,
+			false,
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Push with signed push enabled",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags", "--signed"}, args)
+
+				return exec.Command("echo")
+			},
+			false,
+			true,
 			func(err error) {
 				assert.NoError(t, err)
 			},
@@ -1001,10 +1228,11 @@ func TestGitCommandPush(t *testing.T) {
 			"Push with an error occurring",
 			func(cmd string, args ...string) *exec.Cmd {
 				assert.EqualValues(t, "git", cmd)
-				assert.EqualValues(t, []string{"push", "--follow-tags"}, args)
+				assert.EqualValues(t, []string{"push", "--progress", "--follow-tags"}, args)
 				return exec.Command("test")
 			},
 			false,
+			false,
 			func(err error) {
 				assert.Error(t, err)
 			},
@@ -1015,7 +1243,8 @@ func TestGitCommandPush(t *testing.T) {
 		t.Run(s.testName, func(t *testing.T) {
 			gitCmd := NewDummyGitCommand()
 			gitCmd.OSCommand.command = s.command
-			err := gitCmd.Push("test", s.forcePush, "", "", func(passOrUname string) string {
+			gitCmd.Config.GetUserConfig().Set("git.signedPush", s.signedPush)
+			_, err := gitCmd.Push("test", s.forcePush, "", "", func(passOrUname string) string {
 				return "\n"
 			})
 			s.test(err)
@@ -1058,6 +1287,62 @@ func TestGitCommandStageFile(t *testing.T) {
 	assert.NoError(t, gitCmd.StageFile("test.txt"))
 }
 
+// TestGitCommandStageByPattern is a function.
+func TestGitCommandStageByPattern(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"add", "-A", "--", "src/**/*.test.ts"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.StageByPattern("src/**/*.test.ts"))
+}
+
+// TestGitCommandUnstageByPattern is a function.
+func TestGitCommandUnstageByPattern(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"reset", "-q", "--", "*.go"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.UnstageByPattern("*.go"))
+}
+
+// TestGitCommandFilesToStageByPattern is a function.
+func TestGitCommandFilesToStageByPattern(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"add", "-A", "--dry-run", "--", "*.go"}, args)
+
+		return exec.Command("echo", "add 'main.go'\nremove 'pkg/old file.go'")
+	}
+
+	files, err := gitCmd.FilesToStageByPattern("*.go")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"main.go", "pkg/old file.go"}, files)
+}
+
+// TestGitCommandFilesToUnstageByPattern is a function.
+func TestGitCommandFilesToUnstageByPattern(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"diff", "--cached", "--name-only", "--", "*.go"}, args)
+
+		return exec.Command("echo", "main.go\npkg/gui/gui.go")
+	}
+
+	files, err := gitCmd.FilesToUnstageByPattern("*.go")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"main.go", "pkg/gui/gui.go"}, files)
+}
+
 // TestGitCommandUnstageFile is a function.
 func TestGitCommandUnstageFile(t *testing.T) {
 	type scenario struct {
@@ -1723,6 +2008,19 @@ func TestGitCommandCheckoutFile(t *testing.T) {
 	}
 }
 
+// TestGitCommandRestoreFileToWorkingTree is a function.
+func TestGitCommandRestoreFileToWorkingTree(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"restore", "--source=11af912", "--worktree", "--", "test 999.txt"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.RestoreFileToWorkingTree("11af912", "test 999.txt"))
+}
+
 // TestGitCommandDiscardOldFileChanges is a function.
 func TestGitCommandDiscardOldFileChanges(t *testing.T) {
 	type scenario struct {
@@ -1867,15 +2165,15 @@ func TestGitCommandGetCommitFiles(t *testing.T) {
 			"123456",
 			test.CreateMockCommand(t, []*test.CommandSwapper{
 				{
-					Expect:  "git diff-tree --no-commit-id --name-only -r --no-renames 123456",
-					Replace: "echo 'hello\nworld'",
+					Expect:  "git diff-tree --no-commit-id --numstat -r --no-renames 123456",
+					Replace: "echo '1\t2\thello\n-\t-\tworld.png'",
 				},
 			}),
 			func(commitFiles []*CommitFile, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t, []*CommitFile{
 					{Sha: "123456", Name: "hello", DisplayString: "hello"},
-					{Sha: "123456", Name: "world", DisplayString: "world"},
+					{Sha: "123456", Name: "world.png", DisplayString: "world.png", IsBinary: true},
 				}, commitFiles)
 			},
 		},
@@ -1891,6 +2189,51 @@ func TestGitCommandGetCommitFiles(t *testing.T) {
 	}
 }
 
+// TestGitCommandGetCommitsUniqueToEachSide is a function.
+func TestGitCommandGetCommitsUniqueToEachSide(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func([]*Commit, []*Commit, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"commits are split by side",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"log", "--left-right", "--pretty=format:%m|%H|%s", "master...feature"}, args)
+				return exec.Command("echo", "<|aaa|fix the thing\n>|bbb|add a|pipe\n<|ccc|tidy up")
+			},
+			func(left []*Commit, right []*Commit, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*Commit{{Sha: "aaa", Name: "fix the thing"}, {Sha: "ccc", Name: "tidy up"}}, left)
+				assert.EqualValues(t, []*Commit{{Sha: "bbb", Name: "add a|pipe"}}, right)
+			},
+		},
+		{
+			"returns the error when git log fails",
+			func(string, ...string) *exec.Cmd {
+				return exec.Command("test")
+			},
+			func(left []*Commit, right []*Commit, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, left)
+				assert.Nil(t, right)
+			},
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			s.test(gitCmd.GetCommitsUniqueToEachSide("master", "feature"))
+		})
+	}
+}
+
 // TestGitCommandDiscardUnstagedFileChanges is a function.
 func TestGitCommandDiscardUnstagedFileChanges(t *testing.T) {
 	type scenario struct {
@@ -2174,3 +2517,104 @@ func TestFindDotGitDir(t *testing.T) {
 		})
 	}
 }
+
+// TestGitCommandClearCachedCredentials is a function.
+func TestGitCommandClearCachedCredentials(t *testing.T) {
+	type scenario struct {
+		testName           string
+		getGlobalGitConfig func(string) (string, error)
+		command            func(string, ...string) *exec.Cmd
+		test               func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"rejects the credentials for the url",
+			func(string) (string, error) {
+				return "osxkeychain", nil
+			},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git credential reject",
+					Replace: "echo",
+				},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"also stops the cache daemon when using the cache helper",
+			func(string) (string, error) {
+				return "cache --timeout=3600", nil
+			},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  "git credential reject",
+					Replace: "echo",
+				},
+				{
+					Expect:  "git credential-cache exit",
+					Replace: "echo",
+				},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd.OSCommand.command = s.command
+			gitCmd.getGlobalGitConfig = s.getGlobalGitConfig
+			s.test(gitCmd.ClearCachedCredentials("https://github.com/jesseduffield/lazygit.git"))
+		})
+	}
+}
+
+// TestGitCommandPullMode is a function.
+func TestGitCommandPullMode(t *testing.T) {
+	type scenario struct {
+		mode         string
+		expectedArgs []string
+	}
+
+	scenarios := []scenario{
+		{"", []string{"pull", "--progress", "--no-edit"}},
+		{"merge", []string{"pull", "--progress", "--no-edit", "--no-rebase"}},
+		{"rebase", []string{"pull", "--progress", "--no-edit", "--rebase"}},
+		{"ff-only", []string{"pull", "--progress", "--no-edit", "--ff-only"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.mode, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.Config.GetUserConfig().Set("git.pull.mode", s.mode)
+			gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+				return exec.Command("echo")
+			})
+
+			_, err := gitCmd.Pull(context.Background(), "", func(string) string { return "" })
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestGitCommandPushTag is a function.
+func TestGitCommandPushTag(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"push", "--progress", "origin", "v1.0.0"}, args)
+		return exec.Command("echo")
+	})
+
+	stats, err := gitCmd.PushTag("origin", "v1.0.0", func(string) string { return "" })
+	assert.NoError(t, err)
+	assert.Nil(t, stats)
+}
@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/go-errors/errors"
+)
+
+// ForgePullRequest is an open pull request on the service the repo is on
+type ForgePullRequest struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"html_url"`
+	Head   struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	// CIStatus is the combined status of the checks on the head commit: one of
+	// pending, success, error, failure or warning, or empty if there are none
+	CIStatus string `json:"-"`
+}
+
+// giteaAPI talks to the API of a Gitea or Forgejo instance, which share it,
+// about a single repo
+type giteaAPI struct {
+	// repoURL is the API's URL for the repo, e.g.
+	// https://codeberg.org/api/v1/repos/owner/repo
+	repoURL string
+	token   string
+}
+
+// giteaAPI returns a client for the service's API, with the token from
+// git.gitea.tokenSecret. Without one we can still read public repos
+func (pr *PullRequest) giteaAPI(service *Service, repoInfo *RepoInformation) (*giteaAPI, error) {
+	token, err := pr.apiToken("git.gitea.tokenSecret")
+	if err != nil {
+		return nil, err
+	}
+
+	return &giteaAPI{
+		repoURL: fmt.Sprintf("%s/repos/%s/%s", service.APIURL, url.PathEscape(repoInfo.Owner), url.PathEscape(repoInfo.Repository)),
+		token:   token,
+	}, nil
+}
+
+// ListOpen returns the repo's open pull requests along with the CI status of
+// each, for services whose API we talk to
+func (pr *PullRequest) ListOpen() ([]*ForgePullRequest, error) {
+	service, repoInfo, err := pr.getService()
+	if err != nil {
+		return nil, err
+	}
+	if service.Type != "gitea" {
+		return nil, errors.New(pr.GitCommand.Tr.SLocalize("NoPullRequestAPI"))
+	}
+	api, err := pr.giteaAPI(service, repoInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequests, err := api.listOpenPullRequests()
+	if err != nil {
+		return nil, err
+	}
+
+	// the statuses come one request per pull request, so we ask in parallel
+	wg := sync.WaitGroup{}
+	for _, pullRequest := range pullRequests {
+		wg.Add(1)
+		go func(pullRequest *ForgePullRequest) {
+			defer wg.Done()
+			// a pull request whose status we can't get is shown without one
+			pullRequest.CIStatus, _ = api.ciStatus(pullRequest.Head.Sha)
+		}(pullRequest)
+	}
+	wg.Wait()
+
+	return pullRequests, nil
+}
+
+// listOpenPullRequests returns all of the repo's open pull requests, going
+// through as many pages of them as the API splits them into
+func (api *giteaAPI) listOpenPullRequests() ([]*ForgePullRequest, error) {
+	pullRequests := []*ForgePullRequest{}
+	for pageURL := api.repoURL + "/pulls?state=open"; pageURL != ""; {
+		page := []*ForgePullRequest{}
+		header, err := doJSONRequest("GET", pageURL, api.authorization(), nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		pullRequests = append(pullRequests, page...)
+		pageURL = nextPageURL(header)
+	}
+	return pullRequests, nil
+}
+
+func (api *giteaAPI) ciStatus(sha string) (string, error) {
+	status := struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}{}
+	if err := api.do("GET", "/commits/"+url.PathEscape(sha)+"/status", nil, &status); err != nil {
+		return "", err
+	}
+	if status.TotalCount == 0 {
+		return "", nil
+	}
+	return status.State, nil
+}
+
+// createPullRequest creates a pull request from the branch into base,
+// returning the link to it
+func (api *giteaAPI) createPullRequest(branch string, base string, description *PullRequestDescription) (string, error) {
+	body := map[string]string{
+		"head":  branch,
+		"base":  base,
+		"title": description.Title,
+		"body":  description.Body,
+	}
+	created := &ForgePullRequest{}
+	if err := api.do("POST", "/pulls", body, created); err != nil {
+		return "", err
+	}
+	return created.URL, nil
+}
+
+// do sends a request to the repo's API, decoding the JSON response into
+// result
+func (api *giteaAPI) do(method string, path string, body interface{}, result interface{}) error {
+	_, err := doJSONRequest(method, api.repoURL+path, api.authorization(), body, result)
+	return err
+}
+
+func (api *giteaAPI) authorization() string {
+	if api.token == "" {
+		return ""
+	}
+	return "token " + api.token
+}
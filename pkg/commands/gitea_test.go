@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newDummyGiteaPullRequest returns a PullRequest for a repo on a Gitea
+// instance whose API is served by handler
+func newDummyGiteaPullRequest(t *testing.T, handler http.HandlerFunc, openLink func(string)) *PullRequest {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	gitCommand := NewDummyGitCommand()
+	gitCommand.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		if strings.HasPrefix(cmd, "git") {
+			return exec.Command("echo", "git@gitea.home.net:peter/calculator.git")
+		}
+		assert.Equal(t, "open", cmd)
+		openLink(args[0])
+		return exec.Command("echo")
+	}
+	gitCommand.OSCommand.Config.GetUserConfig().Set("os.openLinkCommand", "open {{link}}")
+	gitCommand.OSCommand.getenv = func(name string) string {
+		if name == "GITEA_TOKEN" {
+			return "s3cret"
+		}
+		return ""
+	}
+	gitCommand.Config.GetUserConfig().Set("git.gitea.tokenSecret", "GITEA_TOKEN")
+
+	service := NewService("gitea", "gitea.home.net", "gitea.home.net")
+	service.APIURL = server.URL + "/api/v1"
+	return &PullRequest{GitServices: []*Service{service}, GitCommand: gitCommand}
+}
+
+// TestListOpenPullRequests is a function.
+func TestListOpenPullRequests(t *testing.T) {
+	pr := newDummyGiteaPullRequest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token s3cret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/v1/repos/peter/calculator/pulls":
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+			_, _ = w.Write([]byte(`[
+				{"number": 2, "title": "Add sums", "html_url": "https://gitea.home.net/peter/calculator/pulls/2", "head": {"ref": "sums", "sha": "aaa"}, "base": {"ref": "main"}},
+				{"number": 3, "title": "Fix overflow", "html_url": "https://gitea.home.net/peter/calculator/pulls/3", "head": {"ref": "overflow", "sha": "bbb"}, "base": {"ref": "main"}}
+			]`))
+		case "/api/v1/repos/peter/calculator/commits/aaa/status":
+			_, _ = w.Write([]byte(`{"state": "failure", "total_count": 2}`))
+		case "/api/v1/repos/peter/calculator/commits/bbb/status":
+			_, _ = w.Write([]byte(`{"state": "pending", "total_count": 0}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}, nil)
+
+	pullRequests, err := pr.ListOpen()
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 2)
+	assert.EqualValues(t, 2, pullRequests[0].Number)
+	assert.EqualValues(t, "sums", pullRequests[0].Head.Ref)
+	assert.EqualValues(t, "main", pullRequests[0].Base.Ref)
+	assert.EqualValues(t, "failure", pullRequests[0].CIStatus)
+	// a commit without any checks has no status
+	assert.EqualValues(t, "", pullRequests[1].CIStatus)
+}
+
+// TestListOpenPullRequestsPages is a function.
+func TestListOpenPullRequestsPages(t *testing.T) {
+	var serverURL string
+	pr := newDummyGiteaPullRequest(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/peter/calculator/pulls":
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"number": 3, "head": {"sha": "bbb"}}]`))
+				return
+			}
+			w.Header().Set("Link", `<`+serverURL+`/api/v1/repos/peter/calculator/pulls?state=open&page=2>; rel="next", <`+serverURL+`/api/v1/repos/peter/calculator/pulls?state=open&page=2>; rel="last"`)
+			_, _ = w.Write([]byte(`[{"number": 2, "head": {"sha": "aaa"}}]`))
+		default:
+			_, _ = w.Write([]byte(`{"state": "success", "total_count": 1}`))
+		}
+	}, nil)
+	serverURL = strings.TrimSuffix(pr.GitServices[0].APIURL, "/api/v1")
+
+	pullRequests, err := pr.ListOpen()
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 2)
+	assert.EqualValues(t, 2, pullRequests[0].Number)
+	assert.EqualValues(t, 3, pullRequests[1].Number)
+}
+
+// TestListOpenPullRequestsError is a function.
+func TestListOpenPullRequestsError(t *testing.T) {
+	pr := newDummyGiteaPullRequest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message": "token does not have the required scope"}`))
+	}, nil)
+
+	_, err := pr.ListOpen()
+	assert.EqualError(t, err, "token does not have the required scope")
+}
+
+// TestCreatePullRequestWithGiteaAPI is a function.
+func TestCreatePullRequestWithGiteaAPI(t *testing.T) {
+	opened := ""
+	pr := newDummyGiteaPullRequest(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/api/v1/repos/peter/calculator/pulls", r.URL.Path)
+		body := map[string]string{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.EqualValues(t, map[string]string{"head": "sums", "base": "main", "title": "Add sums", "body": "- Add sums"}, body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number": 4, "html_url": "https://gitea.home.net/peter/calculator/pulls/4"}`))
+	}, func(link string) { opened = link })
+
+	description := &PullRequestDescription{Title: "Add sums", Body: "- Add sums"}
+	prefilled, err := pr.CreateWithDescription(&Branch{Name: "sums"}, "main", description)
+	assert.NoError(t, err)
+	assert.True(t, prefilled)
+	assert.EqualValues(t, "https://gitea.home.net/peter/calculator/pulls/4", opened)
+}
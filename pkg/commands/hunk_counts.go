@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// setHunkCounts records how many of each partially staged file's hunks are
+// staged, so the files panel can show how far through staging it you are. We
+// only look at files with both staged and unstaged changes because for every
+// other file it's all or nothing
+func (c *GitCommand) setHunkCounts(files []*File) {
+	if !c.Config.GetUserConfig().GetBool("gui.showHunkCounts") {
+		return
+	}
+
+	partialFiles := map[string]*File{}
+	paths := []string{}
+	for _, file := range files {
+		if file.HasStagedChanges && file.HasUnstagedChanges && file.Tracked && !file.HasMergeConflicts && !file.IsSubmodule {
+			partialFiles[file.NewName()] = file
+			// a rename's staged diff needs both sides to be seen as a rename
+			paths = append(paths, strings.Split(file.Name, " -> ")...)
+		}
+	}
+	if len(partialFiles) == 0 {
+		return
+	}
+
+	// the prefixes are given explicitly because diff.noprefix and
+	// diff.mnemonicPrefix would otherwise change them
+	diffArgs := []string{"git", "diff", "--no-ext-diff", "--no-color", "--src-prefix=a/", "--dst-prefix=b/"}
+	stagedDiff, err := c.OSCommand.RunCommandArgsWithOutput(append(append(diffArgs, "--cached", "--"), paths...)...)
+	if err != nil {
+		c.Log.Error(err)
+		return
+	}
+	unstagedDiff, err := c.OSCommand.RunCommandArgsWithOutput(append(append(diffArgs, "--"), paths...)...)
+	if err != nil {
+		c.Log.Error(err)
+		return
+	}
+
+	staged := countHunksByFile(stagedDiff)
+	unstaged := countHunksByFile(unstagedDiff)
+	for name, file := range partialFiles {
+		file.StagedHunks = staged[name]
+		file.TotalHunks = staged[name] + unstaged[name]
+	}
+}
+
+// countHunksByFile counts the hunks for each file in a multi-file diff, going
+// by a renamed file's new name
+func countHunksByFile(diff string) map[string]int {
+	counts := map[string]int{}
+	currentFile := ""
+	inHeader := false
+	for _, line := range utils.SplitLines(diff) {
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			currentFile = ""
+			inHeader = true
+		case strings.HasPrefix(line, "@@ ") && currentFile != "":
+			inHeader = false
+			counts[currentFile]++
+		case !inHeader:
+			// a hunk's removed and added lines can look like headers too
+		case strings.HasPrefix(line, "--- "):
+			currentFile = diffHeaderPath(strings.TrimPrefix(line, "--- "), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			// a deleted file's new side is /dev/null, so we keep its old name
+			if path := diffHeaderPath(strings.TrimPrefix(line, "+++ "), "b/"); path != "" {
+				currentFile = path
+			}
+		}
+	}
+	return counts
+}
+
+// diffHeaderPath returns the path on a diff's '---' or '+++' line without its
+// prefix, or nothing for /dev/null. Git quotes paths with unusual characters in
+// them, and adds a tab after those with spaces
+func diffHeaderPath(path string, prefix string) string {
+	path = unquotePath(strings.TrimSuffix(path, "\t"))
+	if !strings.HasPrefix(path, prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
+
+// HunkSummary describes how much of a partially staged file is staged
+func (f *File) HunkSummary() string {
+	if f.TotalHunks == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d hunks staged", f.StagedHunks, f.TotalHunks)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCountHunksByFile is a function.
+func TestCountHunksByFile(t *testing.T) {
+	diff := `diff --git a/a.txt b/a.txt
+index 1111111..2222222 100644
+--- a/a.txt
++++ b/a.txt
+@@ -1,3 +1,3 @@
+-a
++b
+@@ -10,3 +10,3 @@
+-c
++d
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+--- a/gone.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-bye
+diff --git a/old.txt b/new.txt
+similarity index 90%
+rename from old.txt
+rename to new.txt
+--- a/old.txt
++++ b/new.txt
+@@ -1,3 +1,3 @@
+--- not a header
++++ b/nor is this
+diff --git "a/caf\303\251.txt" "b/caf\303\251.txt"
+--- "a/caf\303\251.txt"
++++ "b/caf\303\251.txt"
+@@ -1 +1 @@
+-a
++b
+diff --git a/with space.txt b/with space.txt
+--- a/with space.txt	
++++ b/with space.txt	
+@@ -1 +1 @@
+-a
++b
+`
+	assert.EqualValues(t, map[string]int{"a.txt": 2, "gone.txt": 1, "new.txt": 1, "café.txt": 1, "with space.txt": 1}, countHunksByFile(diff))
+	assert.EqualValues(t, map[string]int{}, countHunksByFile(""))
+}
+
+// TestFileHunkSummary is a function.
+func TestFileHunkSummary(t *testing.T) {
+	assert.EqualValues(t, "", (&File{}).HunkSummary())
+	assert.EqualValues(t, "3/7 hunks staged", (&File{StagedHunks: 3, TotalHunks: 7}).HunkSummary())
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// IndexLockPath is where git locks the index while it's writing to it. A git
+// command killed part way through, e.g. a cancelled pull, can leave the lock
+// behind, and until it's gone every other command that touches the index fails
+func (c *GitCommand) IndexLockPath() string {
+	return filepath.Join(c.DotGitDir, "index.lock")
+}
+
+// HasIndexLock returns whether the index is locked
+func (c *GitCommand) HasIndexLock() bool {
+	_, err := os.Stat(c.IndexLockPath())
+	return err == nil
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandHasIndexLock is a function.
+func TestGitCommandHasIndexLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-index-lock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+	assert.EqualValues(t, filepath.Join(dir, "index.lock"), gitCmd.IndexLockPath())
+	assert.False(t, gitCmd.HasIndexLock())
+
+	assert.NoError(t, ioutil.WriteFile(gitCmd.IndexLockPath(), nil, 0644))
+	assert.True(t, gitCmd.HasIndexLock())
+}
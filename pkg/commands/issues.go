@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Issue is an issue from the user's issue tracker
+type Issue struct {
+	ID    string
+	Title string
+	URL   string
+}
+
+// ParseIssues parses the output of git.issues.listCommand: one issue per line,
+// with the id, title and (optionally) url separated by tabs. Issues without a
+// url get one from urlTemplate, if there is one
+func ParseIssues(output string, urlTemplate string) []*Issue {
+	issues := []*Issue{}
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 2 {
+			continue
+		}
+		issue := &Issue{ID: strings.TrimPrefix(strings.TrimSpace(fields[0]), "#"), Title: strings.TrimSpace(fields[1])}
+		if len(fields) > 2 {
+			issue.URL = strings.TrimSpace(fields[2])
+		}
+		if issue.URL == "" && urlTemplate != "" {
+			issue.URL = utils.ResolvePlaceholderString(urlTemplate, map[string]string{"id": issue.ID})
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// GetAssignedIssues runs git.issues.listCommand in the user's shell to get the
+// issues assigned to them. By default that asks GitHub via the gh CLI
+func (c *GitCommand) GetAssignedIssues() ([]*Issue, error) {
+	userConfig := c.Config.GetUserConfig()
+	command := userConfig.GetString("git.issues.listCommand")
+	cmd := c.OSCommand.ShellExecutable(command)
+	if err := c.OSCommand.WithSecrets(cmd, command); err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, errors.New(message)
+		}
+		return nil, WrapError(err)
+	}
+	return ParseIssues(string(output), userConfig.GetString("git.issues.urlTemplate")), nil
+}
+
+var nonSlugCharsRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// issueSlug turns an issue title into something fit for a branch name, e.g.
+// 'Crash when opening a file!' becomes 'crash-when-opening-a-file'
+func issueSlug(title string) string {
+	slug := strings.Trim(nonSlugCharsRegexp.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	// long titles get cut down to their first few words
+	if len(slug) > 50 {
+		if i := strings.LastIndex(slug[:51], "-"); i > 0 {
+			slug = slug[:i]
+		} else {
+			slug = slug[:50]
+		}
+	}
+	return slug
+}
+
+// IssueBranchName fills in git.issues.branchNameTemplate for an issue
+func (c *GitCommand) IssueBranchName(issue *Issue) string {
+	return utils.ResolvePlaceholderString(c.Config.GetUserConfig().GetString("git.issues.branchNameTemplate"), map[string]string{
+		"id":   issue.ID,
+		"slug": issueSlug(issue.Title),
+	})
+}
+
+// IssueBranchDescription is the branch description linking a branch to the
+// issue it was created for
+func IssueBranchDescription(issue *Issue) string {
+	if issue.URL == "" {
+		return issue.Title
+	}
+	return issue.Title + "\n\n" + issue.URL
+}
+
+// SetBranchDescription sets the description git shows for a branch, e.g. in
+// git branch --edit-description and format-patch cover letters
+func (c *GitCommand) SetBranchDescription(branchName string, description string) error {
+	return c.OSCommand.RunCommandArgs("git", "config", "branch."+branchName+".description", description)
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseIssues is a function.
+func TestParseIssues(t *testing.T) {
+	output := "12\tCrash when opening a file\thttps://github.com/org/repo/issues/12\n#7\tAdd a flag\n\nnot an issue\n"
+
+	assert.EqualValues(t, []*Issue{
+		{ID: "12", Title: "Crash when opening a file", URL: "https://github.com/org/repo/issues/12"},
+		{ID: "7", Title: "Add a flag", URL: "https://tracker.example.com/browse/7"},
+	}, ParseIssues(output, "https://tracker.example.com/browse/{{id}}"))
+}
+
+// TestGitCommandIssueBranchName is a function.
+func TestGitCommandIssueBranchName(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+
+	issue := &Issue{ID: "12", Title: "Crash when opening a file with a very long name, like really really long!"}
+	assert.EqualValues(t, "12-crash-when-opening-a-file-with-a-very-long-name", gitCmd.IssueBranchName(issue))
+
+	gitCmd.Config.GetUserConfig().Set("git.issues.branchNameTemplate", "feature/PROJ-{{id}}")
+	assert.EqualValues(t, "feature/PROJ-12", gitCmd.IssueBranchName(issue))
+}
+
+// TestIssueBranchDescription is a function.
+func TestIssueBranchDescription(t *testing.T) {
+	assert.EqualValues(t, "Add a flag", IssueBranchDescription(&Issue{ID: "7", Title: "Add a flag"}))
+	assert.EqualValues(t, "Add a flag\n\nhttps://x/7", IssueBranchDescription(&Issue{ID: "7", Title: "Add a flag", URL: "https://x/7"}))
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+// IsBinaryFile uses the same heuristic as git: a file is binary if there's a
+// null byte near the start of it. UTF-16 text is full of null bytes, so we
+// rule that out first
+func IsBinaryFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	sample := make([]byte, encodingSampleSize)
+	n, _ := f.Read(sample)
+	sample = sample[:n]
+
+	if bytes.IndexByte(sample, 0) == -1 {
+		return false
+	}
+	encoding := DetectEncoding(sample)
+	return encoding != EncodingUTF16LE && encoding != EncodingUTF16BE
+}
+
+// LfsInstalled tells us whether the git-lfs extension is available
+func (c *GitCommand) LfsInstalled() bool {
+	return c.OSCommand.RunCommand("git lfs version") == nil
+}
+
+// IsTrackedByLfs tells us whether a file already goes through the lfs filter
+func (c *GitCommand) IsTrackedByLfs(fileName string) bool {
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "check-attr", "filter", "--", fileName)
+	if err != nil {
+		return false
+	}
+	return strings.HasSuffix(strings.TrimSpace(output), ": filter: lfs")
+}
+
+// LfsTrack stores files matching the pattern with git-lfs from now on, and
+// stages the resulting change to .gitattributes
+func (c *GitCommand) LfsTrack(pattern string) error {
+	if err := c.OSCommand.RunCommandArgs("git", "lfs", "track", pattern); err != nil {
+		return err
+	}
+	return c.StageFile(".gitattributes")
+}
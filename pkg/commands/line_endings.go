@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LineEndingInsight : the config and attributes that can make git consider a
+// file modified without there being any visible change to its content
+type LineEndingInsight struct {
+	AutoCRLF        string
+	Text            string
+	Eol             string
+	Filter          string
+	OnlyLineEndings bool
+}
+
+// GetLineEndingInsight gathers everything that might explain a file showing
+// up as modified when its diff looks empty
+func (c *GitCommand) GetLineEndingInsight(file *File) LineEndingInsight {
+	fileName := file.NewName()
+
+	insight := LineEndingInsight{
+		AutoCRLF:        c.getConfigValue("core.autocrlf"),
+		OnlyLineEndings: c.HasOnlyLineEndingChanges(file, !file.HasUnstagedChanges),
+	}
+
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "check-attr", "text", "eol", "filter", "--", fileName)
+	if err != nil {
+		return insight
+	}
+	// each line looks like '<path>: <attribute>: <value>'
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.Split(line, ": ")
+		if len(parts) < 3 {
+			continue
+		}
+		value := parts[len(parts)-1]
+		if value == "unspecified" {
+			value = ""
+		}
+		switch parts[len(parts)-2] {
+		case "text":
+			insight.Text = value
+		case "eol":
+			insight.Eol = value
+		case "filter":
+			insight.Filter = value
+		}
+	}
+	return insight
+}
+
+// RenormalizeFile restages a file (or '.' for everything) after applying the
+// current line ending settings to it
+func (c *GitCommand) RenormalizeFile(fileName string) error {
+	return c.OSCommand.RunCommandArgs("git", "add", "--renormalize", "--", fileName)
+}
+
+// AddGitAttribute appends a line to the repo's .gitattributes file
+func (c *GitCommand) AddGitAttribute(pattern string, attributes string) error {
+	return c.OSCommand.AppendLineToFile(".gitattributes", fmt.Sprintf("%s %s", pattern, attributes))
+}
@@ -42,6 +42,8 @@ func (c *GitCommand) GetRemotes() ([]*Remote, error) {
 		}
 	}
 
+	c.setFetchTimes(remotes)
+
 	// now lets sort our remotes by name alphabetically
 	sort.Slice(remotes, func(i, j int) bool {
 		// we want origin at the top because we'll be most likely to want it
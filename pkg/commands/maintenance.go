@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaintenanceTask : a housekeeping command we can run against the repo
+type MaintenanceTask struct {
+	Key     string // used to look up the task's description
+	Command string
+}
+
+// MaintenanceTasks are the tasks offered in the repo maintenance menu, in the
+// order they're run when running all of them. We leave pruning and repacking to
+// gc, which only prunes objects old enough that no other git process can still
+// be about to reference them
+var MaintenanceTasks = []MaintenanceTask{
+	{Key: "fsck", Command: "git fsck --progress"},
+	{Key: "gc", Command: "git gc"},
+	{Key: "commitGraph", Command: "git commit-graph write --reachable --progress"},
+}
+
+var gitVersionRegexp = regexp.MustCompile(`git version (\d+)\.(\d+)`)
+
+// parseGitVersion pulls the major and minor version out of `git version`
+func parseGitVersion(output string) (int, int) {
+	match := gitVersionRegexp.FindStringSubmatch(output)
+	if match == nil {
+		return 0, 0
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major, minor
+}
+
+// GitVersionAtLeast tells us whether the installed git is at least the given version
+func (c *GitCommand) GitVersionAtLeast(major int, minor int) bool {
+	return c.OSCommand.GitVersionAtLeast(major, minor)
+}
+
+// GitVersionAtLeast tells us whether the installed git is at least the given
+// version. We run git with our own environment to find out, rather than the one
+// Environ builds, because what goes in that can depend on the version
+func (c *OSCommand) GitVersionAtLeast(major int, minor int) bool {
+	c.gitVersionOnce.Do(func() {
+		output, err := c.command("git", "version").Output()
+		if err == nil {
+			c.gitMajor, c.gitMinor = parseGitVersion(string(output))
+		}
+	})
+	return c.gitMajor > major || (c.gitMajor == major && c.gitMinor >= minor)
+}
+
+// SupportsBackgroundMaintenance tells us whether git can schedule maintenance
+// itself. `git maintenance start` arrived in git 2.31
+func (c *GitCommand) SupportsBackgroundMaintenance() bool {
+	return c.GitVersionAtLeast(2, 31)
+}
+
+// BackgroundMaintenanceRegistered tells us whether this repo is in the list of
+// repos git's scheduled maintenance runs against
+func (c *GitCommand) BackgroundMaintenanceRegistered() bool {
+	repos, err := c.OSCommand.RunCommandWithOutput("git config --global --get-all maintenance.repo")
+	if err != nil {
+		return false
+	}
+	currentPath, err := c.OSCommand.RunCommandWithOutput("git rev-parse --show-toplevel")
+	if err != nil {
+		return false
+	}
+	for _, repo := range strings.Split(repos, "\n") {
+		if strings.TrimSpace(repo) == strings.TrimSpace(currentPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartBackgroundMaintenanceCmdStr registers the repo for scheduled maintenance
+// and makes sure the scheduler is running
+func (c *GitCommand) StartBackgroundMaintenanceCmdStr() string {
+	return "git maintenance start"
+}
+
+// StopBackgroundMaintenance removes the repo from scheduled maintenance
+func (c *GitCommand) StopBackgroundMaintenance() error {
+	return c.OSCommand.RunCommand("git maintenance unregister")
+}
@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseGitVersion is a function.
+func TestParseGitVersion(t *testing.T) {
+	type scenario struct {
+		output        string
+		expectedMajor int
+		expectedMinor int
+	}
+
+	scenarios := []scenario{
+		{"git version 2.31.1\n", 2, 31},
+		{"git version 2.24.3 (Apple Git-128)\n", 2, 24},
+		{"git version 2.30.0.windows.1", 2, 30},
+		{"not git", 0, 0},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.output, func(t *testing.T) {
+			major, minor := parseGitVersion(s.output)
+			assert.EqualValues(t, s.expectedMajor, major)
+			assert.EqualValues(t, s.expectedMinor, minor)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetCommitParents returns the shas of a commit's parents, first parent first
+func (c *GitCommand) GetCommitParents(sha string) ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-list --parents -n 1 %s", sha)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields[1:], nil
+}
+
+// MergeShowCmdStr is like ShowCmdStr but with the given flag deciding how a
+// merge commit's diff is shown: '--cc' for the combined diff with trivial
+// hunks left out, or '-m' for a diff against each parent in turn
+func (c *GitCommand) MergeShowCmdStr(sha string, mergeFlag string, filterPath string) string {
+	filterPathArg := ""
+	if filterPath != "" {
+		filterPathArg = fmt.Sprintf(" -- %s", c.OSCommand.Quote(filterPath))
+	}
+	return fmt.Sprintf("git show --color=%s%s --no-renames --stat -p %s %s %s", c.colorArg(), c.DiffFlags(), mergeFlag, sha, filterPathArg)
+}
+
+// DiffAgainstParentCmdStr shows what a merge commit changed relative to one of
+// its parents, where parent 1 is the branch that was merged into
+func (c *GitCommand) DiffAgainstParentCmdStr(sha string, parent int, filterPath string) string {
+	filterPathArg := ""
+	if filterPath != "" {
+		filterPathArg = fmt.Sprintf(" -- %s", c.OSCommand.Quote(filterPath))
+	}
+	return fmt.Sprintf("git diff --color=%s%s --no-renames --stat -p %s^%d %s %s", c.colorArg(), c.DiffFlags(), sha, parent, sha, filterPathArg)
+}
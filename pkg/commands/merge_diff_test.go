@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetCommitParents is a function.
+func TestGitCommandGetCommitParents(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"rev-list", "--parents", "-n", "1", "merge"}, args)
+		return exec.Command("echo", "merge parent1 parent2")
+	})
+
+	parents, err := gitCmd.GetCommitParents("merge")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"parent1", "parent2"}, parents)
+}
+
+// TestGitCommandMergeDiffCmdStrs is a function.
+func TestGitCommandMergeDiffCmdStrs(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+
+	assert.EqualValues(t, "git show --color=always --no-renames --stat -p --cc merge ", gitCmd.MergeShowCmdStr("merge", "--cc", ""))
+	assert.EqualValues(t, "git show --color=always --no-renames --stat -p -m merge  -- 'a.go'", gitCmd.MergeShowCmdStr("merge", "-m", "a.go"))
+	assert.EqualValues(t, "git diff --color=always --no-renames --stat -p merge^2 merge ", gitCmd.DiffAgainstParentCmdStr("merge", 2, ""))
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// NetworkRetry tells the UI that a network git command failed and is about to
+// be tried again
+type NetworkRetry struct {
+	// Command is the git command, e.g. 'git fetch'
+	Command string
+	// Attempt is the attempt about to be made, counting the first, and
+	// Attempts how many we'll make in all
+	Attempt  int
+	Attempts int
+	// Delay is how long we wait before trying again
+	Delay time.Duration
+	Err   error
+}
+
+// networkErrorRegexp matches what git, curl and ssh say when the network or
+// the remote's server let us down, as opposed to the remote turning us away
+var networkErrorRegexp = regexp.MustCompile(`(?i)could not resolve host|temporary failure in name resolution|connection timed out|operation timed out|connection reset|connection refused|connection closed by remote host|network is unreachable|failed to connect to|remote end hung up unexpectedly|early eof|rpc failed|unexpected disconnect|gnutls_handshake\(\) failed|ssl_error_syscall|broken pipe|the requested url returned error: 5\d\d`)
+
+// authErrorRegexp matches what git says when the remote turns us away. Those
+// usually come along with 'remote end hung up unexpectedly', and would only
+// fail again
+var authErrorRegexp = regexp.MustCompile(`(?i)authentication failed|permission denied|could not read username|access denied|the requested url returned error: 40[13]`)
+
+// isNetworkError tells us whether a command failed in a way that trying again
+// a little later might fix
+func isNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return networkErrorRegexp.MatchString(message) && !authErrorRegexp.MatchString(message)
+}
+
+// SetOnNetworkRetry sets the function told when a network git command is
+// about to be retried, so the UI can say so
+func (c *OSCommand) SetOnNetworkRetry(onNetworkRetry func(NetworkRetry)) {
+	c.onNetworkRetry = onNetworkRetry
+}
+
+// withNetworkRetries runs a git command which talks to a remote, like a fetch,
+// pull or push, running it again if it fails on the network. We try up to
+// git.networkRetry.attempts times, waiting git.networkRetry.delay seconds
+// before the first retry and twice as long before each one after that. run has
+// to start the command afresh each time. If mayRetry is given, we only retry
+// while it says we may
+func (c *OSCommand) withNetworkRetries(ctx context.Context, command string, mayRetry func() bool, run func() error) error {
+	userConfig := c.Config.GetUserConfig()
+	attempts := userConfig.GetInt("git.networkRetry.attempts")
+	delay := time.Duration(userConfig.GetFloat64("git.networkRetry.delay") * float64(time.Second))
+
+	err := run()
+	for attempt := 2; attempt <= attempts && isNetworkError(err) && (mayRetry == nil || mayRetry()); attempt++ {
+		c.Log.Warnf("%s failed on the network, retrying in %s: %s", command, delay, err)
+		if c.onNetworkRetry != nil {
+			c.onNetworkRetry(NetworkRetry{
+				Command:  networkCommandName(command),
+				Attempt:  attempt,
+				Attempts: attempts,
+				Delay:    delay,
+				Err:      err,
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+		err = run()
+	}
+	return err
+}
+
+// networkCommandName shortens a command like 'git fetch --progress origin' to
+// 'git fetch', for the status bar
+func networkCommandName(command string) string {
+	words := strings.Fields(command)
+	if len(words) > 2 {
+		words = words[:2]
+	}
+	return strings.Join(words, " ")
+}
+
+// networkTimeout is how long git.networkTimeout gives a command which talks
+// to a remote before it's given up on, or 0 for as long as it takes
+func (c *OSCommand) networkTimeout() time.Duration {
+	return time.Duration(c.Config.GetUserConfig().GetInt64("git.networkTimeout")) * time.Second
+}
+
+// promptTimeout times out a command that may prompt the user for credentials.
+// The clock stops while the user is answering a prompt, since we've no idea
+// how long they'll take, and starts again from the beginning once they have
+type promptTimeout struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+	timer   *time.Timer
+	expired int32
+}
+
+// newPromptTimeout returns a timeout whose context is cancelled once timeout
+// passes, or never if timeout is 0
+func newPromptTimeout(parent context.Context, timeout time.Duration) *promptTimeout {
+	t := &promptTimeout{timeout: timeout}
+	t.ctx, t.cancel = context.WithCancel(parent)
+	if timeout > 0 {
+		t.timer = time.AfterFunc(timeout, func() {
+			atomic.StoreInt32(&t.expired, 1)
+			t.cancel()
+		})
+	}
+	return t
+}
+
+// whileAsking stops the clock while ask waits on the user
+func (t *promptTimeout) whileAsking(ask func() string) string {
+	if t.timer == nil {
+		return ask()
+	}
+	t.timer.Stop()
+	answer := ask()
+	if t.ctx.Err() == nil {
+		t.timer.Reset(t.timeout)
+	}
+	return answer
+}
+
+// stop releases the timeout once the command has finished
+func (t *promptTimeout) stop() {
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	t.cancel()
+}
+
+// timedOut returns whether the command was killed for running out of time
+func (t *promptTimeout) timedOut() bool {
+	return atomic.LoadInt32(&t.expired) == 1
+}
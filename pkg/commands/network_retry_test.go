@@ -0,0 +1,131 @@
+package commands
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsNetworkError is a function.
+func TestIsNetworkError(t *testing.T) {
+	type scenario struct {
+		testName string
+		err      error
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{"no error", nil, false},
+		{"unresolved host", errors.New("fatal: unable to access 'https://github.com/a/b.git/': Could not resolve host: github.com"), true},
+		{"hung up", errors.New("error: RPC failed; curl 56 GnuTLS recv error (-9)\nfatal: the remote end hung up unexpectedly"), true},
+		{"server error", errors.New("fatal: unable to access 'https://example.com/a/b.git/': The requested URL returned error: 502"), true},
+		{"ssh timeout", errors.New("ssh: connect to host github.com port 22: Connection timed out"), true},
+		{"bad password", errors.New("remote: Invalid username or password.\nfatal: Authentication failed for 'https://github.com/a/b.git/'"), false},
+		{"bad ssh key", errors.New("git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository."), false},
+		{"rejected push", errors.New("! [rejected] master -> master (fetch first)\nerror: failed to push some refs"), false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, isNetworkError(s.err))
+		})
+	}
+}
+
+// TestOSCommandRetriesNetworkCommands is a function.
+func TestOSCommandRetriesNetworkCommands(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("git.networkRetry.attempts", 3)
+	osCommand.Config.GetUserConfig().Set("git.networkRetry.delay", 0.001)
+	attempts := 0
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		attempts++
+		if attempts < 3 {
+			return exec.Command("sh", "-c", "echo 'fatal: unable to access: Could not resolve host: github.com' >&2; exit 128")
+		}
+		return exec.Command("echo", "done")
+	})
+	retries := []NetworkRetry{}
+	osCommand.SetOnNetworkRetry(func(retry NetworkRetry) {
+		retries = append(retries, retry)
+	})
+
+	err := osCommand.DetectUnamePass("git fetch origin", func(string) string { return "" })
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, attempts)
+	assert.Len(t, retries, 2)
+	assert.EqualValues(t, "git fetch", retries[0].Command)
+	assert.EqualValues(t, 2, retries[0].Attempt)
+	assert.EqualValues(t, 3, retries[1].Attempt)
+	assert.EqualValues(t, 2*retries[0].Delay, retries[1].Delay)
+}
+
+// TestOSCommandGivesUpOnNetworkCommands is a function.
+func TestOSCommandGivesUpOnNetworkCommands(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("git.networkRetry.attempts", 2)
+	osCommand.Config.GetUserConfig().Set("git.networkRetry.delay", 0.001)
+	attempts := 0
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		attempts++
+		return exec.Command("sh", "-c", "echo 'ssh: connect to host github.com port 22: Connection timed out' >&2; exit 128")
+	})
+
+	err := osCommand.DetectUnamePass("git push origin master", func(string) string { return "" })
+	assert.Error(t, err)
+	assert.EqualValues(t, 2, attempts)
+}
+
+// TestOSCommandDoesntRetryAfterPrompting is a function.
+func TestOSCommandDoesntRetryAfterPrompting(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("git.networkRetry.attempts", 3)
+	osCommand.Config.GetUserConfig().Set("git.networkRetry.delay", 0.001)
+	attempts := 0
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		attempts++
+		return exec.Command("sh", "-c", "printf \"Password for 'https://github.com': \"; read answer; echo 'fatal: the remote end hung up unexpectedly' >&2; exit 128")
+	})
+
+	asked := 0
+	err := osCommand.DetectUnamePass("git push origin master", func(string) string {
+		asked++
+		return "hunter2\n"
+	})
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, attempts)
+	assert.EqualValues(t, 1, asked)
+}
+
+// TestOSCommandTimesOutNetworkCommands is a function.
+func TestOSCommandTimesOutNetworkCommands(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("git.networkTimeout", 1)
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		return exec.Command("sleep", "10")
+	})
+
+	start := time.Now()
+	err := osCommand.DetectUnamePass("git fetch origin", func(string) string { return "" })
+	assert.IsType(t, &TimeoutError{}, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "the command should have been killed")
+}
+
+// TestOSCommandNetworkTimeoutSparesPrompts is a function.
+func TestOSCommandNetworkTimeoutSparesPrompts(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("git.networkTimeout", 1)
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		return exec.Command("sh", "-c", "printf \"Password for 'https://github.com': \"; read answer")
+	})
+
+	// the user takes longer to answer than the command is given
+	err := osCommand.DetectUnamePass("git push origin master", func(string) string {
+		time.Sleep(1500 * time.Millisecond)
+		return "hunter2\n"
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/mgutz/str"
+)
+
+// MergeConflictExpected tells us whether merging two refs would conflict,
+// without touching the index or working tree. It needs git 2.38 or newer
+func (c *GitCommand) MergeConflictExpected(ours string, theirs string) (bool, error) {
+	cmd := c.OSCommand.ExecutableFromString(fmt.Sprintf("git merge-tree --write-tree --no-messages %s %s", ours, theirs))
+	output, err := cmd.Output()
+	if err == nil {
+		return false, nil
+	}
+	// on a conflict git still prints the resulting tree, whereas on any other
+	// failure (e.g. an unknown ref) it prints nothing to stdout
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 && strings.TrimSpace(string(output)) != "" {
+		return true, nil
+	}
+	return false, WrapError(err)
+}
+
+// FindExpectedOctopusConflict checks each branch against HEAD and against each
+// other, returning the first pair expected to conflict. An octopus merge
+// can't stop for conflicts to be resolved, so any conflict rules it out
+func (c *GitCommand) FindExpectedOctopusConflict(branchNames []string) (string, string, error) {
+	refs := append([]string{"HEAD"}, branchNames...)
+	for i := 0; i < len(refs); i++ {
+		for j := i + 1; j < len(refs); j++ {
+			conflict, err := c.MergeConflictExpected(refs[i], refs[j])
+			if err != nil {
+				return "", "", err
+			}
+			if conflict {
+				return refs[i], refs[j], nil
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// OctopusMerge merges several branches into the checked out branch with a
+// single merge commit. The branch names are passed as arguments of their own
+// so that none of them can be taken for anything else
+func (c *GitCommand) OctopusMerge(branchNames []string) error {
+	mergeArgs := str.ToArgv(c.Config.GetUserConfig().GetString("git.merging.args"))
+	args := append([]string{"git", "merge", "--no-edit"}, mergeArgs...)
+	return c.OSCommand.RunCommandArgs(append(args, branchNames...)...)
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandMergeConflictExpected is a function.
+func TestGitCommandMergeConflictExpected(t *testing.T) {
+	type scenario struct {
+		testName         string
+		command          func(string, ...string) *exec.Cmd
+		expectedConflict bool
+		expectedErr      bool
+	}
+
+	scenarios := []scenario{
+		{
+			"clean merge",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, []string{"merge-tree", "--write-tree", "--no-messages", "HEAD", "feature"}, args)
+				return exec.Command("echo", "3be22be77da4887e869c981806d8452f034dd014")
+			},
+			false,
+			false,
+		},
+		{
+			"conflict",
+			func(string, ...string) *exec.Cmd {
+				return exec.Command("sh", "-c", "echo c4270090e8976b8b0f3f266b81add9e3e1fb9829; exit 1")
+			},
+			true,
+			false,
+		},
+		{
+			"unknown ref",
+			func(string, ...string) *exec.Cmd {
+				return exec.Command("sh", "-c", "echo 'merge-tree: feature - not something we can merge' >&2; exit 1")
+			},
+			false,
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.SetCommand(s.command)
+			conflict, err := gitCmd.MergeConflictExpected("HEAD", "feature")
+			assert.EqualValues(t, s.expectedConflict, conflict)
+			assert.EqualValues(t, s.expectedErr, err != nil)
+		})
+	}
+}
+
+// TestGitCommandFindExpectedOctopusConflict is a function.
+func TestGitCommandFindExpectedOctopusConflict(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	checked := []string{}
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		pair := args[3] + " " + args[4]
+		checked = append(checked, pair)
+		if pair == "a b" {
+			return exec.Command("sh", "-c", "echo c4270090e8976b8b0f3f266b81add9e3e1fb9829; exit 1")
+		}
+		return exec.Command("echo", "3be22be77da4887e869c981806d8452f034dd014")
+	})
+
+	ours, theirs, err := gitCmd.FindExpectedOctopusConflict([]string{"a", "b", "c"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, "a", ours)
+	assert.EqualValues(t, "b", theirs)
+	assert.EqualValues(t, []string{"HEAD a", "HEAD b", "HEAD c", "a b"}, checked)
+}
+
+// TestGitCommandOctopusMerge is a function.
+func TestGitCommandOctopusMerge(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"merge", "--no-edit", "feature/a", "feature;b", "c"}, args)
+		return exec.Command("echo")
+	})
+
+	assert.NoError(t, gitCmd.OctopusMerge([]string{"feature/a", "feature;b", "c"}))
+}
@@ -2,14 +2,18 @@ package commands
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-errors/errors"
 
@@ -22,14 +26,12 @@ import (
 
 // Platform stores the os state
 type Platform struct {
-	os                   string
-	catCmd               string
-	shell                string
-	shellArg             string
-	escapedQuote         string
-	openCommand          string
-	openLinkCommand      string
-	fallbackEscapedQuote string
+	os              string
+	catCmd          string
+	shell           string
+	shellArg        string
+	openCommand     string
+	openLinkCommand string
 }
 
 // OSCommand holds all the os commands
@@ -41,6 +43,40 @@ type OSCommand struct {
 	beforeExecuteCmd   func(*exec.Cmd)
 	getGlobalGitConfig func(string) (string, error)
 	getenv             func(string) string
+	lookPath           func(string) (string, error)
+	// where escape sequences for the terminal, like OSC 52, are written
+	terminal io.Writer
+
+	// the most recent git command to fail, so that it can be rerun with tracing enabled
+	lastFailure      commandFailure
+	lastFailureMutex sync.Mutex
+
+	// the secrets looked up so far, by name, and why the lookups which failed
+	// did, so that we don't ask the user's keychain again for every command
+	secretValues map[string]string
+	secretErrors map[string]error
+	secretsMutex sync.Mutex
+
+	// git commands which write to the repo take this exclusively, read-only
+	// ones share it
+	queue               sync.RWMutex
+	indexLockRetryDelay time.Duration
+
+	// told when a network git command is about to be retried
+	onNetworkRetry func(NetworkRetry)
+
+	// the commands we've run most recently
+	history commandHistory
+
+	// the version of git, which we only need to ask for once
+	gitVersionOnce sync.Once
+	gitMajor       int
+	gitMinor       int
+}
+
+type commandFailure struct {
+	command string
+	message string
 }
 
 // NewOSCommand os command runner
@@ -53,6 +89,10 @@ func NewOSCommand(log *logrus.Entry, config config.AppConfigurer) *OSCommand {
 		beforeExecuteCmd:   func(*exec.Cmd) {},
 		getGlobalGitConfig: gitconfig.Global,
 		getenv:             os.Getenv,
+		lookPath:           exec.LookPath,
+		terminal:           os.Stdout,
+
+		indexLockRetryDelay: defaultIndexLockRetryDelay,
 	}
 }
 
@@ -68,13 +108,96 @@ func (c *OSCommand) SetBeforeExecuteCmd(cmd func(*exec.Cmd)) {
 
 type RunCommandOptions struct {
 	EnvVars []string
+	// OnOutput, if set, is passed the command's output as it's written, on top
+	// of it being returned once the command is done
+	OnOutput func(OutputChunk)
+	// Timeout, if set, is how long the command gets before it's killed along
+	// with any processes it started, in which case a *TimeoutError is returned
+	Timeout time.Duration
+}
+
+// TimeoutError is returned when a command is killed for running past the
+// timeout in its RunCommandOptions
+type TimeoutError struct {
+	Command string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("'%s' was stopped because it took longer than %s", e.Command, e.Timeout)
 }
 
 func (c *OSCommand) RunCommandWithOutputWithOptions(command string, options RunCommandOptions) (string, error) {
+	return c.runCommandWithOptions(context.Background(), command, options)
+}
+
+// RunCommandWithOptionsContext is RunCommandWithOptions for a command that can
+// also be cancelled through ctx
+func (c *OSCommand) RunCommandWithOptionsContext(ctx context.Context, command string, options RunCommandOptions) error {
+	_, err := c.runCommandWithOptions(ctx, command, options)
+	return err
+}
+
+func (c *OSCommand) runCommandWithOptions(ctx context.Context, command string, options RunCommandOptions) (string, error) {
+	var output string
+	err := c.queued(ctx, str.ToArgv(command), func() error {
+		var err error
+		output, err = c.runCommandWithOptionsOnce(ctx, command, c.ExecutableFromString(command), options)
+		return err
+	})
+	return output, err
+}
+
+// RunShellCommandWithOutputWithOptions runs one of the user's commands through
+// their shell, with the secrets it mentions in its environment
+func (c *OSCommand) RunShellCommandWithOutputWithOptions(command string, options RunCommandOptions) (string, error) {
+	cmd := c.ShellExecutable(command)
+	if err := c.WithSecrets(cmd, command); err != nil {
+		return "", err
+	}
+	return c.runCommandWithOptionsOnce(context.Background(), command, cmd, options)
+}
+
+func (c *OSCommand) runCommandWithOptionsOnce(ctx context.Context, command string, cmd *exec.Cmd, options RunCommandOptions) (string, error) {
 	c.Log.WithField("command", command).Info("RunCommand")
-	cmd := c.ExecutableFromString(command)
+	start := time.Now()
 	cmd.Env = append(cmd.Env, options.EnvVars...)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if options.OnOutput != nil {
+		// the chunk writers share a mutex, so they can share the buffer too
+		var mutex sync.Mutex
+		onChunk := func(chunk OutputChunk) {
+			output.WriteString(chunk.Text)
+			options.OnOutput(chunk)
+		}
+		cmd.Stdout = &chunkWriter{stream: Stdout, mutex: &mutex, onChunk: onChunk}
+		cmd.Stderr = &chunkWriter{stream: Stderr, mutex: &mutex, onChunk: onChunk}
+	}
+	err := runWithContext(ctx, cmd)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		if ctxErr == context.DeadlineExceeded && options.Timeout > 0 {
+			err = &TimeoutError{Command: command, Timeout: options.Timeout}
+			c.recordFailure(command, err)
+			c.recordCommand(command, cmd, start, output.String()+err.Error(), err)
+			return "", err
+		}
+		c.recordCommand(command, cmd, start, output.String(), ctxErr)
+		return "", ctxErr
+	}
+
+	outputString, err := sanitisedCommandOutput(output.Bytes(), err)
+	c.recordFailure(command, err)
+	c.recordCommand(command, cmd, start, outputString, err)
+	return outputString, err
 }
 
 func (c *OSCommand) RunCommandWithOptions(command string, options RunCommandOptions) error {
@@ -94,14 +217,100 @@ func (c *OSCommand) RunCommandWithOutput(formatString string, formatArgs ...inte
 		command = fmt.Sprintf(formatString, formatArgs...)
 	}
 	c.Log.WithField("command", command).Info("RunCommand")
-	cmd := c.ExecutableFromString(command)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	var output string
+	err := c.queued(context.Background(), str.ToArgv(command), func() error {
+		start := time.Now()
+		cmd := c.ExecutableFromString(command)
+		var err error
+		output, err = sanitisedCommandOutput(cmd.CombinedOutput())
+		c.recordCommand(command, cmd, start, output, err)
+		return err
+	})
+	c.recordFailure(command, err)
+	return output, err
+}
+
+// RunCommandWithOutputContext is RunCommandWithOutput for commands that may
+// take a while, e.g. talking to a slow remote. If ctx is cancelled before the
+// command finishes, the command is killed and ctx's error returned
+func (c *OSCommand) RunCommandWithOutputContext(ctx context.Context, formatString string, formatArgs ...interface{}) (string, error) {
+	command := formatString
+	if formatArgs != nil {
+		command = fmt.Sprintf(formatString, formatArgs...)
+	}
+	return c.runCommandWithOptions(ctx, command, RunCommandOptions{})
+}
+
+// RunCommandWithContext is RunCommand for commands that can be cancelled
+func (c *OSCommand) RunCommandWithContext(ctx context.Context, formatString string, formatArgs ...interface{}) error {
+	_, err := c.RunCommandWithOutputContext(ctx, formatString, formatArgs...)
+	return err
+}
+
+// runWithContext starts the command and waits for it, killing it if ctx is
+// cancelled in the meantime
+func runWithContext(ctx context.Context, cmd *exec.Cmd) error {
+	start := cmd.Start
+	if ctx.Done() != nil {
+		// so that we can kill whatever the command starts too, e.g. the
+		// ssh process behind a git fetch
+		start = func() error { return StartInProcessGroup(cmd) }
+	}
+	if err := start(); err != nil {
+		return err
+	}
+	return waitWithContext(ctx, cmd)
+}
+
+// waitWithContext waits for a started command, killing it and its children if
+// ctx is cancelled in the meantime
+func waitWithContext(ctx context.Context, cmd *exec.Cmd) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = Kill(cmd)
+		case <-done:
+		}
+	}()
+	return cmd.Wait()
+}
+
+// recordFailure remembers a failed git command so that we can offer to rerun it
+// with tracing enabled
+func (c *OSCommand) recordFailure(command string, err error) {
+	if err == nil || !strings.HasPrefix(command, "git ") {
+		return
+	}
+
+	c.lastFailureMutex.Lock()
+	defer c.lastFailureMutex.Unlock()
+	c.lastFailure = commandFailure{command: command, message: err.Error()}
+}
+
+// LastFailedCommand returns the git command which produced the given error
+// message, or an empty string if the message did not come from the most
+// recent failed command
+func (c *OSCommand) LastFailedCommand(errMessage string) string {
+	c.lastFailureMutex.Lock()
+	defer c.lastFailureMutex.Unlock()
+	if errMessage == "" || c.lastFailure.message != errMessage {
+		return ""
+	}
+	return c.lastFailure.command
 }
 
 // RunExecutableWithOutput runs an executable file and returns its output
 func (c *OSCommand) RunExecutableWithOutput(cmd *exec.Cmd) (string, error) {
 	c.beforeExecuteCmd(cmd)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	// a command can only be run once, so we can't retry it if the index is locked
+	done, _ := c.waitForTurn(context.Background(), cmd.Args)
+	defer done()
+	start := time.Now()
+	output, err := sanitisedCommandOutput(cmd.CombinedOutput())
+	c.recordCommand(strings.Join(cmd.Args, " "), cmd, start, output, err)
+	return output, err
 }
 
 // RunExecutable runs an executable file and returns an error if there was one
@@ -114,39 +323,169 @@ func (c *OSCommand) RunExecutable(cmd *exec.Cmd) error {
 func (c *OSCommand) ExecutableFromString(commandStr string) *exec.Cmd {
 	splitCmd := str.ToArgv(commandStr)
 	cmd := c.command(splitCmd[0], splitCmd[1:]...)
-	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+	cmd.Env = append(c.Environ(), "GIT_OPTIONAL_LOCKS=0")
 	return cmd
 }
 
+// Environ returns the environment the commands we run get: our own, with the
+// variables from the env config added, so that e.g. GIT_SSH_COMMAND can be
+// set for lazygit alone, and the entries of git.config passed on to git
+func (c *OSCommand) Environ() []string {
+	return c.withGitConfigOverrides(c.withEnv(os.Environ(), c.Config.GetUserConfig().GetStringSlice("env")))
+}
+
+// AddEnv adds variables, given like 'NAME=value', to the command's
+// environment, as for a task's env
+func (c *OSCommand) AddEnv(cmd *exec.Cmd, entries []string) {
+	if cmd.Env == nil {
+		cmd.Env = c.Environ()
+	}
+	cmd.Env = c.withEnv(cmd.Env, entries)
+}
+
+// withEnv appends the entries to env, expanding any variables in them, as in
+// 'GNUPGHOME=$HOME/.gnupg-work'. Later entries win over earlier ones
+func (c *OSCommand) withEnv(env []string, entries []string) []string {
+	for _, entry := range entries {
+		if !strings.Contains(entry, "=") {
+			c.Log.Warnf("ignoring env entry without an '=': %s", entry)
+			continue
+		}
+		env = append(env, os.Expand(entry, c.getenv))
+	}
+	return env
+}
+
+// RunCommandWithOutputStream runs a command, passing each line of its stdout
+// and stderr to onLine as soon as it is written
+func (c *OSCommand) RunCommandWithOutputStream(ctx context.Context, command string, onLine func(string)) error {
+	onChunk, flush := NewLineSplitter(onLine)
+	err := c.runExecutableWithOutputChunks(ctx, command, c.ExecutableFromString(command), onChunk, true)
+	flush()
+	return err
+}
+
+// RunExecutableWithOutputStream is RunCommandWithOutputStream for a command
+// that has already been prepared
+func (c *OSCommand) RunExecutableWithOutputStream(ctx context.Context, cmd *exec.Cmd, onLine func(string)) error {
+	onChunk, flush := NewLineSplitter(onLine)
+	err := c.runExecutableWithOutputChunks(ctx, strings.Join(cmd.Args, " "), cmd, onChunk, true)
+	flush()
+	return err
+}
+
+// scanLinesAndCarriageReturns is bufio.ScanLines except that it also ends a
+// line at a lone carriage return, which is how git redraws its progress meters
+func scanLinesAndCarriageReturns(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+			return i + 2, data[:i], nil
+		}
+		if data[i] == '\r' && i+1 == len(data) && !atEOF {
+			// we need the next byte to know whether this is a CRLF
+			return 0, nil, nil
+		}
+		return i + 1, data[:i], nil
+	}
+	return bufio.ScanLines(data, atEOF)
+}
+
+// ExitCode returns the exit code of the command that produced the error, or -1
+// if the error didn't come from a command exiting
+func ExitCode(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
 // RunCommandWithOutputLive runs RunCommandWithOutputLiveWrapper
 func (c *OSCommand) RunCommandWithOutputLive(command string, output func(string) string) error {
-	return RunCommandWithOutputLiveWrapper(c, command, output)
+	return c.RunCommandWithOutputLiveContext(context.Background(), command, output)
+}
+
+// RunCommandWithOutputLiveContext is RunCommandWithOutputLive for a command
+// that can be cancelled
+func (c *OSCommand) RunCommandWithOutputLiveContext(ctx context.Context, command string, output func(string) string) error {
+	_, err := c.runCommandLive(ctx, command, nil, output)
+	return err
+}
+
+// runCommandLive is RunCommandWithOutputLiveContext, returning what the
+// command wrote to stderr, and passing its output to onChunk if given, as
+// RunCommandWithOutputLiveWrapper does
+func (c *OSCommand) runCommandLive(ctx context.Context, command string, onChunk func(OutputChunk), output func(string) string) (string, error) {
+	// these may be waiting on the user to answer a prompt for a long while, so
+	// we don't hold up other commands with them, retrying them instead if
+	// git finds the index locked
+	var stderr string
+	err := c.retryWhileIndexLocked(func() error {
+		var err error
+		stderr, err = RunCommandWithOutputLiveWrapper(ctx, c, command, onChunk, output)
+		return err
+	})
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	c.recordFailure(command, err)
+	return stderr, err
 }
 
 // DetectUnamePass detect a username / password question in a command
 // ask is a function that gets executen when this function detect you need to fillin a password
-// The ask argument will be "username" or "password" and expects the user's password or username back
+// The ask argument will be "username", "password", "passphrase", "otp" (a
+// one-time code) or "hostKey" (whether to trust a host ssh hasn't seen
+// before) and expects the user's input back
 func (c *OSCommand) DetectUnamePass(command string, ask func(string) string) error {
-	ttyText := ""
-	errMessage := c.RunCommandWithOutputLive(command, func(word string) string {
-		ttyText = ttyText + " " + word
-
-		prompts := map[string]string{
-			`.+'s password:`:         "password",
-			`Password\s*for\s*'.+':`: "password",
-			`Username\s*for\s*'.+':`: "username",
-		}
+	return c.DetectUnamePassWithContext(context.Background(), command, ask)
+}
+
+// DetectUnamePassWithContext is DetectUnamePass for a command that can be
+// cancelled, e.g. a fetch that's hanging on an unresponsive remote
+func (c *OSCommand) DetectUnamePassWithContext(ctx context.Context, command string, ask func(string) string) error {
+	_, err := c.DetectUnamePassWithOutput(ctx, command, ask)
+	return err
+}
+
+// DetectUnamePassWithOutput is DetectUnamePassWithContext, returning what the
+// command wrote to stderr, e.g. the progress of a fetch run with --progress
+func (c *OSCommand) DetectUnamePassWithOutput(ctx context.Context, command string, ask func(string) string) (string, error) {
+	return c.DetectUnamePassWithOutputChunks(ctx, command, ask, nil)
+}
 
-		for pattern, askFor := range prompts {
-			if match, _ := regexp.MatchString(pattern, ttyText); match {
-				ttyText = ""
-				return ask(askFor)
+// DetectUnamePassWithOutputChunks is DetectUnamePassWithOutput for a command
+// whose output we want as it's written, e.g. a push reporting on each ref with
+// --porcelain, which onChunk is passed
+func (c *OSCommand) DetectUnamePassWithOutputChunks(ctx context.Context, command string, ask func(string) string, onChunk func(OutputChunk)) (string, error) {
+	prompts, err := c.getCredentialPrompts()
+	if err != nil {
+		return "", err
+	}
+	// these commands all talk to a remote, so they're worth retrying when
+	// the network lets them down, and giving up on if the remote never answers.
+	// Once the user has answered a prompt though, retrying would only have
+	// them answer it all over again, which a one-time code won't survive
+	var stderr string
+	var prompted int32
+	mayRetry := func() bool { return atomic.LoadInt32(&prompted) == 0 }
+	err = c.withNetworkRetries(ctx, command, mayRetry, func() error {
+		timeout := newPromptTimeout(ctx, c.networkTimeout())
+		defer timeout.stop()
+		var err error
+		stderr, err = c.runCommandLive(timeout.ctx, command, onChunk, func(text string) string {
+			if askFor, ok := detectCredentialPrompt(prompts, text); ok {
+				atomic.StoreInt32(&prompted, 1)
+				return timeout.whileAsking(func() string { return ask(askFor) })
 			}
+			return ""
+		})
+		if timeout.timedOut() {
+			err = &TimeoutError{Command: command, Timeout: timeout.timeout}
+			c.recordFailure(command, err)
 		}
-
-		return ""
+		return err
 	})
-	return errMessage
+	return stderr, err
 }
 
 // RunCommand runs a command and just returns the error
@@ -171,10 +510,11 @@ func (c *OSCommand) FileType(path string) string {
 func (c *OSCommand) RunDirectCommand(command string) (string, error) {
 	c.Log.WithField("command", command).Info("RunDirectCommand")
 
-	return sanitisedCommandOutput(
-		c.command(c.Platform.shell, c.Platform.shellArg, command).
-			CombinedOutput(),
-	)
+	start := time.Now()
+	cmd := c.ShellExecutable(command)
+	output, err := sanitisedCommandOutput(cmd.CombinedOutput())
+	c.recordCommand(command, cmd, start, output, err)
+	return output, err
 }
 
 func sanitisedCommandOutput(output []byte, err error) (string, error) {
@@ -190,9 +530,13 @@ func sanitisedCommandOutput(output []byte, err error) (string, error) {
 	return outputString, nil
 }
 
-// OpenFile opens a file with the given
+// OpenFile opens a file with os.openCommand, or if that's empty, with the
+// desktop's default app for it
 func (c *OSCommand) OpenFile(filename string) error {
 	commandTemplate := c.Config.GetUserConfig().GetString("os.openCommand")
+	if commandTemplate == "" {
+		return c.openWithDefaultApp(filename, false)
+	}
 	templateValues := map[string]string{
 		"filename": c.Quote(filename),
 	}
@@ -202,9 +546,13 @@ func (c *OSCommand) OpenFile(filename string) error {
 	return err
 }
 
-// OpenLink opens a file with the given
+// OpenLink opens a link with os.openLinkCommand, or if that's empty, with the
+// desktop's default browser
 func (c *OSCommand) OpenLink(link string) error {
 	commandTemplate := c.Config.GetUserConfig().GetString("os.openLinkCommand")
+	if commandTemplate == "" {
+		return c.openWithDefaultApp(link, true)
+	}
 	templateValues := map[string]string{
 		"link": c.Quote(link),
 	}
@@ -237,30 +585,45 @@ func (c *OSCommand) EditFile(filename string) (*exec.Cmd, error) {
 	return c.PrepareSubProcess(editor, filename), nil
 }
 
+// PageFile opens a file in a subprocess using the user's $PAGER, falling back
+// to less
+func (c *OSCommand) PageFile(filename string) *exec.Cmd {
+	pager := c.getenv("PAGER")
+	if pager == "" {
+		pager = "less"
+	}
+
+	cmd := c.ExecutableFromString(fmt.Sprintf("%s %s", pager, c.Quote(filename)))
+	if c.getenv("LESS") == "" {
+		// the content is coloured, so like git we tell less to pass escape codes through
+		cmd.Env = append(cmd.Env, "LESS=R")
+	}
+	return cmd
+}
+
 // PrepareSubProcess iniPrepareSubProcessrocess then tells the Gui to switch to it
 // TODO: see if this needs to exist, given that ExecutableFromString does the same things
 func (c *OSCommand) PrepareSubProcess(cmdName string, commandArgs ...string) *exec.Cmd {
 	cmd := c.command(cmdName, commandArgs...)
 	if cmd != nil {
-		cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0")
+		cmd.Env = append(c.Environ(), "GIT_OPTIONAL_LOCKS=0")
 	}
 	return cmd
 }
 
-// Quote wraps a message in platform-specific quotation marks
+// Quote quotes an argument for a command string which ExecutableFromString
+// will split up, so that the command gets it just as it is, whatever's in it.
+// Commands are better given as their arguments to RunCommandArgs, which needs
+// no quoting at all, and commands run in the user's shell need ShellQuote
 func (c *OSCommand) Quote(message string) string {
-	message = strings.Replace(message, "`", "\\`", -1)
-	escapedQuote := c.Platform.escapedQuote
-	if strings.Contains(message, c.Platform.escapedQuote) {
-		escapedQuote = c.Platform.fallbackEscapedQuote
-	}
-	return escapedQuote + message + escapedQuote
+	return quoteArg(message)
 }
 
-// Unquote removes wrapping quotations marks if they are present
-// this is needed for removing quotes from staged filenames with spaces
+// Unquote undoes the quoting git does to paths in its output when they have
+// spaces, quotes, control characters or, unless core.quotePath is off,
+// non-ASCII characters in them
 func (c *OSCommand) Unquote(message string) string {
-	return strings.Replace(message, `"`, "", -1)
+	return unquotePath(message)
 }
 
 // AppendLineToFile adds a new line in file
@@ -335,9 +698,11 @@ func (c *OSCommand) FileExists(path string) (bool, error) {
 // before running it
 func (c *OSCommand) RunPreparedCommand(cmd *exec.Cmd) error {
 	c.beforeExecuteCmd(cmd)
+	start := time.Now()
 	out, err := cmd.CombinedOutput()
 	outString := string(out)
 	c.Log.Info(outString)
+	c.recordCommand(strings.Join(cmd.Args, " "), cmd, start, outString, err)
 	if err != nil {
 		if len(outString) == 0 {
 			return err
@@ -356,6 +721,14 @@ func (c *OSCommand) GetLazygitPath() string {
 	return `"` + filepath.ToSlash(ex) + `"`
 }
 
+// ShellExecutable returns a command that runs the given string in the user's
+// shell, so that it can use pipes, '&&' and the like
+func (c *OSCommand) ShellExecutable(command string) *exec.Cmd {
+	cmd := c.command(c.Platform.shell, c.Platform.shellArg, command)
+	cmd.Env = c.Environ()
+	return cmd
+}
+
 // RunCustomCommand returns the pointer to a custom command
 func (c *OSCommand) RunCustomCommand(command string) *exec.Cmd {
 	return c.PrepareSubProcess(c.Platform.shell, c.Platform.shellArg, command)
@@ -421,15 +794,36 @@ func (c *OSCommand) PipeCommands(commandStrings ...string) error {
 	return nil
 }
 
+// StartInProcessGroup starts the command in a process group of its own, or a
+// job object on Windows, so that Kill takes down whatever it starts as well,
+// like the pager behind a diff, or the editor or hook script behind a git
+// command. Anything that needs the terminal to itself shouldn't be started this
+// way, since it won't be in the terminal's foreground process group
+func StartInProcessGroup(cmd *exec.Cmd) error {
+	return startInProcessGroup(cmd)
+}
+
+// Kill kills the command, along with everything it started if it was started
+// with StartInProcessGroup
 func Kill(cmd *exec.Cmd) error {
 	if cmd.Process == nil {
 		// somebody got to it before we were able to, poor bastard
 		return nil
 	}
+	if err := killProcessGroup(cmd); err == nil {
+		return nil
+	}
+	// the command doesn't lead a process group after all
 	return cmd.Process.Kill()
 }
 
 func RunLineOutputCmd(cmd *exec.Cmd, onLine func(line string) (bool, error)) error {
+	return RunLineOutputCmdWithContext(context.Background(), cmd, onLine)
+}
+
+// RunLineOutputCmdWithContext is RunLineOutputCmd for a command that can be
+// cancelled, e.g. a git log on a huge repo, returning ctx's error if it was
+func RunLineOutputCmdWithContext(ctx context.Context, cmd *exec.Cmd, onLine func(line string) (bool, error)) error {
 	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -441,6 +835,16 @@ func RunLineOutputCmd(cmd *exec.Cmd, onLine func(line string) (bool, error)) err
 		return err
 	}
 
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = Kill(cmd)
+		case <-done:
+		}
+	}()
+
 	for scanner.Scan() {
 		line := scanner.Text()
 		stop, err := onLine(line)
@@ -454,16 +858,5 @@ func RunLineOutputCmd(cmd *exec.Cmd, onLine func(line string) (bool, error)) err
 	}
 
 	cmd.Wait()
-	return nil
-}
-
-func (c *OSCommand) CopyToClipboard(str string) error {
-	commandTemplate := c.Config.GetUserConfig().GetString("os.copyToClipboardCommand")
-	templateValues := map[string]string{
-		"str": c.Quote(str),
-	}
-
-	command := utils.ResolvePlaceholderString(commandTemplate, templateValues)
-
-	return c.RunCommand(command)
+	return ctx.Err()
 }
@@ -3,18 +3,35 @@
 package commands
 
 import (
+	"os/exec"
 	"runtime"
+	"syscall"
 )
 
 func getPlatform() *Platform {
 	return &Platform{
-		os:                   runtime.GOOS,
-		catCmd:               "cat",
-		shell:                "bash",
-		shellArg:             "-c",
-		escapedQuote:         "'",
-		openCommand:          "open {{filename}}",
-		openLinkCommand:      "open {{link}}",
-		fallbackEscapedQuote: "\"",
+		os:              runtime.GOOS,
+		catCmd:          "cat",
+		shell:           "bash",
+		shellArg:        "-c",
+		openCommand:     "open {{filename}}",
+		openLinkCommand: "open {{link}}",
 	}
 }
+
+// startInProcessGroup starts the command as the leader of a new process
+// group, so that Kill can take any processes it starts down with it
+func startInProcessGroup(cmd *exec.Cmd) error {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+	return cmd.Start()
+}
+
+// killProcessGroup kills the command's process group, failing if it doesn't
+// lead one. Commands started in a pty lead their own session, so this works
+// for them as well
+func killProcessGroup(cmd *exec.Cmd) error {
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -0,0 +1,44 @@
+// +build !windows
+
+package commands
+
+import (
+	"bufio"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestKillProcessGroup is a function.
+func TestKillProcessGroup(t *testing.T) {
+	// the shell starts a sleep, tells us its pid, and waits for it
+	cmd := exec.Command("sh", "-c", "sleep 30 & echo $!; wait")
+	stdout, err := cmd.StdoutPipe()
+	assert.NoError(t, err)
+	assert.NoError(t, StartInProcessGroup(cmd))
+
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	assert.NoError(t, err)
+	sleepPid, err := strconv.Atoi(strings.TrimSpace(line))
+	assert.NoError(t, err)
+
+	assert.NoError(t, Kill(cmd))
+	assert.Error(t, cmd.Wait())
+
+	// the sleep is the shell's child, so it's gone once something reaps it,
+	// which isn't up to us
+	assert.Eventually(t, func() bool {
+		return syscall.Kill(sleepPid, 0) != nil || isZombie(sleepPid)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// isZombie tells us whether the process has exited but not been reaped
+func isZombie(pid int) bool {
+	output, err := exec.Command("ps", "-o", "stat=", "-p", strconv.Itoa(pid)).Output()
+	return err == nil && strings.HasPrefix(strings.TrimSpace(string(output)), "Z")
+}
@@ -1,11 +1,15 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"testing"
+	"time"
 
+	"github.com/mgutz/str"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -37,6 +41,98 @@ func TestOSCommandRunCommandWithOutput(t *testing.T) {
 	}
 }
 
+// TestOSCommandRunCommandWithOutputContext is a function.
+func TestOSCommandRunCommandWithOutputContext(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+
+	output, err := osCommand.RunCommandWithOutputContext(context.Background(), "echo -n '123'")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "123", output)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = osCommand.RunCommandWithOutputContext(ctx, "sleep 10")
+	assert.EqualValues(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "the command should have been killed")
+}
+
+// TestRunLineOutputCmdWithContext is a function.
+func TestRunLineOutputCmdWithContext(t *testing.T) {
+	lines := []string{}
+	err := RunLineOutputCmdWithContext(context.Background(), exec.Command("printf", "a\\nb\\n"), func(line string) (bool, error) {
+		lines = append(lines, line)
+		return false, nil
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"a", "b"}, lines)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = RunLineOutputCmdWithContext(ctx, exec.Command("sleep", "10"), func(line string) (bool, error) {
+		return false, nil
+	})
+	assert.EqualValues(t, context.DeadlineExceeded, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "the command should have been killed")
+}
+
+// TestOSCommandRunCommandWithTimeout is a function.
+func TestOSCommandRunCommandWithTimeout(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+
+	assert.NoError(t, osCommand.RunCommandWithOptions("echo 123", RunCommandOptions{Timeout: 5 * time.Second}))
+
+	start := time.Now()
+	// the shell's sleep would hold on to the output pipe if only the shell were killed
+	err := osCommand.RunCommandWithOptions("bash -c 'sleep 10; echo done'", RunCommandOptions{Timeout: 100 * time.Millisecond})
+	assert.IsType(t, &TimeoutError{}, err)
+	assert.EqualValues(t, "'bash -c 'sleep 10; echo done'' was stopped because it took longer than 100ms", err.Error())
+	assert.True(t, time.Since(start) < 5*time.Second, "the command and its children should have been killed")
+}
+
+// TestOSCommandLastFailedCommand is a function.
+func TestOSCommandLastFailedCommand(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		return exec.Command("rmdir", "unexisting-folder")
+	}
+
+	err := osCommand.RunCommand("git fetch origin")
+	assert.Error(t, err)
+	assert.EqualValues(t, "git fetch origin", osCommand.LastFailedCommand(err.Error()))
+
+	_ = osCommand.RunCommand("rmdir unexisting-folder")
+	assert.EqualValues(t, "git fetch origin", osCommand.LastFailedCommand(err.Error()), "only git commands are recorded")
+
+	assert.EqualValues(t, "", osCommand.LastFailedCommand("some other error"))
+}
+
+// TestOSCommandRunCommandWithOutputStream is a function.
+func TestOSCommandRunCommandWithOutputStream(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		return exec.Command("sh", "-c", "echo 'running lint'; echo 'lint failed' >&2; exit 3")
+	}
+
+	lines := []string{}
+	err := osCommand.RunCommandWithOutputStream(context.Background(), "git commit -m 'test'", func(line string) {
+		lines = append(lines, line)
+	})
+
+	assert.Error(t, err)
+	assert.EqualValues(t, 3, ExitCode(err))
+	assert.EqualValues(t, []string{"running lint", "lint failed"}, lines)
+	assert.EqualValues(t, "git commit -m 'test'", osCommand.LastFailedCommand(err.Error()))
+}
+
+// TestExitCode is a function.
+func TestExitCode(t *testing.T) {
+	assert.EqualValues(t, -1, ExitCode(nil))
+	assert.EqualValues(t, -1, ExitCode(errors.New("not from a command")))
+	assert.EqualValues(t, 1, ExitCode(exec.Command("false").Run()))
+}
+
 // TestOSCommandRunCommand is a function.
 func TestOSCommandRunCommand(t *testing.T) {
 	type scenario struct {
@@ -109,6 +205,48 @@ func TestOSCommandOpenFile(t *testing.T) {
 	}
 }
 
+// TestOSCommandPageFile is a function.
+func TestOSCommandPageFile(t *testing.T) {
+	type scenario struct {
+		getenv func(string) string
+		test   func(*exec.Cmd)
+	}
+
+	scenarios := []scenario{
+		{
+			func(env string) string {
+				return ""
+			},
+			func(cmd *exec.Cmd) {
+				assert.EqualValues(t, []string{"less", "content.diff"}, cmd.Args)
+				assert.Contains(t, cmd.Env, "LESS=R")
+			},
+		},
+		{
+			func(env string) string {
+				if env == "PAGER" {
+					return "most -s"
+				}
+				return "FRX"
+			},
+			func(cmd *exec.Cmd) {
+				assert.EqualValues(t, []string{"most", "-s", "content.diff"}, cmd.Args)
+				assert.NotContains(t, cmd.Env, "LESS=R")
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		OSCmd := NewDummyOSCommand()
+		OSCmd.command = func(name string, arg ...string) *exec.Cmd {
+			return exec.Command(name, arg...)
+		}
+		OSCmd.getenv = s.getenv
+
+		s.test(OSCmd.PageFile("content.diff"))
+	}
+}
+
 // TestOSCommandEditFile is a function.
 func TestOSCommandEditFile(t *testing.T) {
 	type scenario struct {
@@ -243,11 +381,19 @@ func TestOSCommandEditFile(t *testing.T) {
 func TestOSCommandQuote(t *testing.T) {
 	osCommand := NewDummyOSCommand()
 
-	actual := osCommand.Quote("hello `test`")
-
-	expected := osCommand.Platform.escapedQuote + "hello \\`test\\`" + osCommand.Platform.escapedQuote
-
-	assert.EqualValues(t, expected, actual)
+	for _, message := range []string{
+		"hello",
+		"hello `test`",
+		"hello 'test'",
+		`hello "test"`,
+		`it's "both"`,
+		`back\slash\`,
+		"$HOME $(rm -rf /)",
+		"two\nlines",
+		"",
+	} {
+		assert.EqualValues(t, []string{"echo", message}, str.ToArgv("echo "+osCommand.Quote(message)))
+	}
 }
 
 // TestOSCommandQuoteSingleQuote tests the quote function with ' quotes explicitly for Linux
@@ -258,7 +404,7 @@ func TestOSCommandQuoteSingleQuote(t *testing.T) {
 
 	actual := osCommand.Quote("hello 'test'")
 
-	expected := osCommand.Platform.fallbackEscapedQuote + "hello 'test'" + osCommand.Platform.fallbackEscapedQuote
+	expected := `'hello \'test\''`
 
 	assert.EqualValues(t, expected, actual)
 }
@@ -271,20 +417,44 @@ func TestOSCommandQuoteDoubleQuote(t *testing.T) {
 
 	actual := osCommand.Quote(`hello "test"`)
 
-	expected := osCommand.Platform.escapedQuote + "hello \"test\"" + osCommand.Platform.escapedQuote
+	expected := `'hello "test"'`
 
 	assert.EqualValues(t, expected, actual)
 }
 
+// TestOSCommandShellQuote is a function.
+func TestOSCommandShellQuote(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Platform.os = "linux"
+
+	for _, message := range []string{"hello", "it's", "$HOME `whoami`", `back\slash "quoted"`, ""} {
+		output, err := exec.Command("sh", "-c", "printf %s "+osCommand.ShellQuote(message)).Output()
+		assert.NoError(t, err)
+		assert.EqualValues(t, message, string(output))
+	}
+}
+
 // TestOSCommandUnquote is a function.
 func TestOSCommandUnquote(t *testing.T) {
 	osCommand := NewDummyOSCommand()
 
-	actual := osCommand.Unquote(`hello "test"`)
-
-	expected := "hello test"
+	scenarios := []struct {
+		quoted   string
+		expected string
+	}{
+		{`file.txt`, `file.txt`},
+		{`"hello world.txt"`, `hello world.txt`},
+		{`"say \"hi\".txt"`, `say "hi".txt`},
+		{`"caf\303\251.txt"`, "caf\u00e9.txt"},
+		{`"tab\there"`, "tab\there"},
+		{`old.txt -> "new name.txt"`, `old.txt -> new name.txt`},
+		{`"old name.txt" -> new.txt`, `old name.txt -> new.txt`},
+		{`"a -> b.txt" -> "c.txt"`, `a -> b.txt -> c.txt`},
+	}
 
-	assert.EqualValues(t, expected, actual)
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, osCommand.Unquote(s.quoted))
+	}
 }
 
 // TestOSCommandFileType is a function.
@@ -375,3 +545,25 @@ func TestOSCommandCreateTempFile(t *testing.T) {
 		})
 	}
 }
+
+// TestOSCommandEnviron is a function.
+func TestOSCommandEnviron(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.getenv = func(name string) string {
+		if name == "HOME" {
+			return "/home/me"
+		}
+		return ""
+	}
+	osCommand.Config.GetUserConfig().Set("env", []string{"GIT_SSH_COMMAND=ssh -i $HOME/key", "NOT_AN_ENTRY"})
+
+	cmd := osCommand.ExecutableFromString("git fetch")
+	assert.Contains(t, cmd.Env, "GIT_SSH_COMMAND=ssh -i /home/me/key")
+	assert.Contains(t, cmd.Env, "GIT_OPTIONAL_LOCKS=0")
+	assert.NotContains(t, cmd.Env, "NOT_AN_ENTRY")
+
+	task := exec.Command("make")
+	osCommand.AddEnv(task, []string{"CI=true"})
+	assert.Contains(t, task.Env, "GIT_SSH_COMMAND=ssh -i /home/me/key")
+	assert.Equal(t, "CI=true", task.Env[len(task.Env)-1])
+}
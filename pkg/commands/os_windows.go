@@ -1,12 +1,145 @@
 package commands
 
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"sync"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
 func getPlatform() *Platform {
 	return &Platform{
-		os:                   "windows",
-		catCmd:               "type",
-		shell:                "cmd",
-		shellArg:             "/c",
-		escapedQuote:         `\"`,
-		fallbackEscapedQuote: "\\'",
+		os:       "windows",
+		catCmd:   "type",
+		shell:    "cmd",
+		shellArg: "/c",
+	}
+}
+
+// jobs holds the job object of each command started with startInProcessGroup,
+// by pid, until the command exits
+var jobs sync.Map
+
+// startInProcessGroup starts the command and puts it in a job object of its
+// own, which anything it starts from then on joins too, so that Kill can take
+// them down with it. The command starts suspended and is only let go once it's
+// in the job, so nothing it starts straight away can slip out. If we can't make
+// the job, Kill falls back on taskkill
+func startInProcessGroup(cmd *exec.Cmd) error {
+	job, err := newJobObject()
+	if err != nil {
+		return cmd.Start()
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= windows.CREATE_SUSPENDED
+	if err := cmd.Start(); err != nil {
+		windows.CloseHandle(job)
+		return err
+	}
+
+	pid := uint32(cmd.Process.Pid)
+	process, err := assignToJobObject(job, pid)
+	if err != nil {
+		windows.CloseHandle(job)
+		if err := resumeProcess(pid); err != nil {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			return WrapError(err)
+		}
+		return nil
+	}
+	if err := resumeProcess(pid); err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		windows.CloseHandle(job)
+		windows.CloseHandle(process)
+		return WrapError(err)
+	}
+
+	jobs.Store(pid, job)
+	go func() {
+		// once the command's gone there's nothing left to cancel, and
+		// anything it left running in the background is meant to be
+		_, _ = windows.WaitForSingleObject(process, windows.INFINITE)
+		jobs.Delete(pid)
+		windows.CloseHandle(job)
+		windows.CloseHandle(process)
+	}()
+	return nil
+}
+
+// assignToJobObject puts the process in the job, returning a handle to the
+// process for waiting on it
+func assignToJobObject(job windows.Handle, pid uint32) (windows.Handle, error) {
+	process, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE|windows.SYNCHRONIZE, false, pid)
+	if err != nil {
+		return 0, err
+	}
+	if err := windows.AssignProcessToJobObject(job, process); err != nil {
+		windows.CloseHandle(process)
+		return 0, err
+	}
+	return process, nil
+}
+
+// resumeProcess lets a process started suspended run. exec doesn't hand us
+// the handle of its main thread, so we look for the process's threads, of
+// which a suspended process has just the one
+func resumeProcess(pid uint32) error {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPTHREAD, 0)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(snapshot)
+
+	entry := windows.ThreadEntry32{Size: uint32(unsafe.Sizeof(windows.ThreadEntry32{}))}
+	for err = windows.Thread32First(snapshot, &entry); err == nil; err = windows.Thread32Next(snapshot, &entry) {
+		if entry.OwnerProcessID != pid {
+			continue
+		}
+		thread, err := windows.OpenThread(windows.THREAD_SUSPEND_RESUME, false, entry.ThreadID)
+		if err != nil {
+			return err
+		}
+		_, err = windows.ResumeThread(thread)
+		windows.CloseHandle(thread)
+		return err
+	}
+	return fmt.Errorf("no thread found for process %d", pid)
+}
+
+// newJobObject makes a job object which lets its processes start others
+// outside of it if they ask to, as some installers and updaters do
+func newJobObject() (windows.Handle, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_BREAKAWAY_OK,
+		},
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		windows.CloseHandle(job)
+		return 0, err
+	}
+	return job, nil
+}
+
+// killProcessGroup kills the command along with any processes it started,
+// through its job object if it has one and otherwise by having taskkill look
+// for its children, which only finds those whose parent is still running
+func killProcessGroup(cmd *exec.Cmd) error {
+	if job, ok := jobs.Load(uint32(cmd.Process.Pid)); ok {
+		return windows.TerminateJobObject(job.(windows.Handle), 1)
 	}
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
 }
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutputStream says which of a command's outputs a chunk was written to
+type OutputStream int
+
+const (
+	Stdout OutputStream = iota
+	Stderr
+)
+
+// OutputChunk is a piece of a command's output, exactly as it was written.
+// It may end partway through a line
+type OutputChunk struct {
+	Stream OutputStream
+	Text   string
+}
+
+// RunCommandWithOutputChunks runs a command, passing what it writes to stdout
+// and stderr to onChunk as soon as it's written rather than once the command
+// is done, so that e.g. the progress of a fetch can be shown as it happens
+func (c *OSCommand) RunCommandWithOutputChunks(ctx context.Context, command string, onChunk func(OutputChunk)) error {
+	return c.runExecutableWithOutputChunks(ctx, command, c.ExecutableFromString(command), onChunk, false)
+}
+
+// RunExecutableWithOutputChunks is RunCommandWithOutputChunks for a command
+// that has already been prepared
+func (c *OSCommand) RunExecutableWithOutputChunks(ctx context.Context, cmd *exec.Cmd, onChunk func(OutputChunk)) error {
+	return c.runExecutableWithOutputChunks(ctx, strings.Join(cmd.Args, " "), cmd, onChunk, false)
+}
+
+// runExecutableWithOutputChunks never calls onChunk while it's still handling
+// the previous chunk, and kills the command if ctx is cancelled before it's done.
+// With combined, stdout and stderr share a pipe and everything is reported as
+// stdout. That's the only way to get the two in the order they were written
+func (c *OSCommand) runExecutableWithOutputChunks(ctx context.Context, command string, cmd *exec.Cmd, onChunk func(OutputChunk), combined bool) error {
+	c.Log.WithField("command", command).Info("RunCommand")
+	start := time.Now()
+
+	// the chunk writers share a mutex, so they can share the tail too
+	tail := &outputTail{maxLength: c.Config.GetUserConfig().GetInt("commandHistory.maxOutputLength")}
+	var mutex sync.Mutex
+	onChunkAndTail := func(chunk OutputChunk) {
+		tail.add(chunk.Text)
+		onChunk(chunk)
+	}
+	cmd.Stdout = &chunkWriter{stream: Stdout, mutex: &mutex, onChunk: onChunkAndTail}
+	cmd.Stderr = &chunkWriter{stream: Stderr, mutex: &mutex, onChunk: onChunkAndTail}
+	if combined {
+		cmd.Stderr = cmd.Stdout
+	}
+
+	err := runWithContext(ctx, cmd)
+	if ctx.Err() != nil {
+		c.recordCommand(command, cmd, start, tail.String(), ctx.Err())
+		return ctx.Err()
+	}
+	c.recordFailure(command, err)
+	c.recordCommand(command, cmd, start, tail.String(), err)
+	return err
+}
+
+// chunkWriter passes everything written to it on to onChunk
+type chunkWriter struct {
+	stream  OutputStream
+	mutex   *sync.Mutex
+	onChunk func(OutputChunk)
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.onChunk(OutputChunk{Stream: w.stream, Text: string(p)})
+	return len(p), nil
+}
+
+// NewLineSplitter turns output chunks into whole lines for onLine, keeping
+// stdout and stderr apart so that a line from one never gets mixed into a
+// line from the other. Lines also end at a lone carriage return, which is how
+// git redraws its progress meters. Call flush once the command is done to get
+// any last line that had no line ending
+func NewLineSplitter(onLine func(string)) (onChunk func(OutputChunk), flush func()) {
+	pending := map[OutputStream][]byte{}
+
+	emitLines := func(stream OutputStream, atEOF bool) {
+		data := pending[stream]
+		for len(data) > 0 {
+			advance, token, _ := scanLinesAndCarriageReturns(data, atEOF)
+			if advance == 0 {
+				break
+			}
+			onLine(string(token))
+			data = data[advance:]
+		}
+		pending[stream] = data
+	}
+
+	onChunk = func(chunk OutputChunk) {
+		pending[chunk.Stream] = append(pending[chunk.Stream], chunk.Text...)
+		emitLines(chunk.Stream, false)
+	}
+	flush = func() {
+		emitLines(Stdout, true)
+		emitLines(Stderr, true)
+	}
+	return onChunk, flush
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandRunCommandWithOutputChunks is a function.
+func TestOSCommandRunCommandWithOutputChunks(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		return exec.Command("sh", "-c", "echo 'fetching'; echo 'remote hung up' >&2")
+	}
+
+	stdout := ""
+	stderr := ""
+	err := osCommand.RunCommandWithOutputChunks(context.Background(), "git fetch", func(chunk OutputChunk) {
+		if chunk.Stream == Stdout {
+			stdout += chunk.Text
+		} else {
+			stderr += chunk.Text
+		}
+	})
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, "fetching\n", stdout)
+	assert.EqualValues(t, "remote hung up\n", stderr)
+}
+
+// TestNewLineSplitter is a function.
+func TestNewLineSplitter(t *testing.T) {
+	lines := []string{}
+	onChunk, flush := NewLineSplitter(func(line string) {
+		lines = append(lines, line)
+	})
+
+	onChunk(OutputChunk{Stream: Stderr, Text: "Receiving objects:  50%\rReceiving"})
+	onChunk(OutputChunk{Stream: Stdout, Text: "From origin\n"})
+	onChunk(OutputChunk{Stream: Stderr, Text: " objects: 100%\r\n"})
+	onChunk(OutputChunk{Stream: Stdout, Text: "no newline at the end"})
+	flush()
+
+	assert.EqualValues(t, []string{
+		"Receiving objects:  50%",
+		"From origin",
+		"Receiving objects: 100%",
+		"no newline at the end",
+	}, lines)
+}
@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// noLazyFetchEnvVar stops git from fetching objects missing from a partial
+// clone on demand. We set it when rendering diffs so that flicking through
+// commits doesn't quietly download half the repo
+const noLazyFetchEnvVar = "GIT_NO_LAZY_FETCH=1"
+
+// NoLazyFetchEnv returns the environment for a command showing a diff, which
+// in a partial clone stops it fetching the objects it's missing. Git only
+// honours this from 2.44, and before that there's no stopping it
+func (c *GitCommand) NoLazyFetchEnv() []string {
+	if c.PartialCloneFilter == "" || !c.GitVersionAtLeast(2, 44) {
+		return nil
+	}
+	return []string{noLazyFetchEnvVar}
+}
+
+// getPartialCloneFilter returns the object filter the repo was cloned with,
+// e.g. 'blob:none' for a blobless clone or 'tree:0' for a treeless one. Repos
+// with a promisor remote but no recorded filter get 'promisor', and full
+// clones get an empty string
+func getPartialCloneFilter(osCommand *OSCommand) string {
+	output, err := osCommand.RunCommandWithOutput(`git config --get-regexp ^remote\..*\.(partialclonefilter|promisor)$`)
+	if err != nil {
+		return ""
+	}
+	return parsePartialCloneFilter(output)
+}
+
+func parsePartialCloneFilter(output string) string {
+	filter := ""
+	for _, line := range utils.SplitLines(output) {
+		// each line looks like 'remote.origin.partialclonefilter blob:none'
+		split := strings.SplitN(line, " ", 2)
+		if len(split) != 2 {
+			continue
+		}
+		if strings.HasSuffix(split[0], ".partialclonefilter") {
+			return split[1]
+		}
+		if strings.HasSuffix(split[0], ".promisor") && split[1] == "true" {
+			filter = "promisor"
+		}
+	}
+	return filter
+}
+
+// FetchMissingObjects shows a commit with lazy fetching allowed, which pulls
+// down whatever objects its diff needs that a partial clone left out. The diff
+// itself goes nowhere: we only want the objects. The fetch may need
+// credentials, which ask is for, as with Push
+func (c *GitCommand) FetchMissingObjects(sha string, ask func(string) string) error {
+	return c.OSCommand.DetectUnamePass(fmt.Sprintf("git --no-pager show --no-color -p %s", sha), ask)
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParsePartialCloneFilter is a function.
+func TestParsePartialCloneFilter(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{"full clone", "", ""},
+		{"blobless clone", "remote.origin.promisor true\nremote.origin.partialclonefilter blob:none\n", "blob:none"},
+		{"treeless clone", "remote.origin.partialclonefilter tree:0\n", "tree:0"},
+		{"promisor without a filter", "remote.origin.promisor true\n", "promisor"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parsePartialCloneFilter(s.output))
+		})
+	}
+}
+
+// TestGitCommandNoLazyFetchEnv is a function.
+func TestGitCommandNoLazyFetchEnv(t *testing.T) {
+	type scenario struct {
+		testName           string
+		partialCloneFilter string
+		gitVersion         string
+		expected           []string
+	}
+
+	scenarios := []scenario{
+		{"full clone", "", "git version 2.44.0", nil},
+		{"partial clone", "blob:none", "git version 2.44.0", []string{"GIT_NO_LAZY_FETCH=1"}},
+		{"partial clone with git too old to stop lazy fetching", "blob:none", "git version 2.43.2", nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.PartialCloneFilter = s.partialCloneFilter
+			gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				return exec.Command("echo", s.gitVersion)
+			}
+			assert.EqualValues(t, s.expected, gitCmd.NoLazyFetchEnv())
+		})
+	}
+}
@@ -96,38 +96,38 @@ func (l *PatchLine) render(selected bool, included bool) string {
 	if l.Kind == HUNK_HEADER {
 		re := regexp.MustCompile("(@@.*?@@)(.*)")
 		match := re.FindStringSubmatch(content)
-		return coloredString(color.FgCyan, match[1], selected, included) + coloredString(theme.DefaultTextColor, match[2], selected, false)
+		return coloredString([]color.Attribute{color.FgCyan}, match[1], selected, included) + coloredString([]color.Attribute{theme.DefaultTextColor}, match[2], selected, false)
 	}
 
-	var colorAttr color.Attribute
+	var attributes []color.Attribute
 	switch l.Kind {
 	case PATCH_HEADER:
-		colorAttr = color.Bold
+		attributes = []color.Attribute{color.Bold}
 	case ADDITION:
-		colorAttr = color.FgGreen
+		attributes = theme.DiffAddedAttributes
 	case DELETION:
-		colorAttr = color.FgRed
+		attributes = theme.DiffDeletedAttributes
 	case COMMIT_SHA:
-		colorAttr = color.FgYellow
+		attributes = []color.Attribute{color.FgYellow}
 	default:
-		colorAttr = theme.DefaultTextColor
+		attributes = []color.Attribute{theme.DefaultTextColor}
 	}
 
-	return coloredString(colorAttr, content, selected, included)
+	return coloredString(attributes, content, selected, included)
 }
 
-func coloredString(colorAttr color.Attribute, str string, selected bool, included bool) string {
+func coloredString(lineAttributes []color.Attribute, str string, selected bool, included bool) string {
 	var cl *color.Color
-	attributes := []color.Attribute{colorAttr}
+	attributes := append([]color.Attribute{}, lineAttributes...)
 	if selected {
 		attributes = append(attributes, theme.SelectedRangeBgColor)
 	}
-	cl = color.New(attributes...)
+	cl = theme.NewColor(attributes...)
 	var clIncluded *color.Color
 	if included {
-		clIncluded = color.New(append(attributes, color.BgGreen)...)
+		clIncluded = theme.NewColor(append(attributes, theme.IncludedLineAttributes...)...)
 	} else {
-		clIncluded = color.New(attributes...)
+		clIncluded = theme.NewColor(attributes...)
 	}
 
 	if len(str) < 2 {
@@ -201,6 +201,29 @@ func (p *PatchParser) Render(firstLineIndex int, lastLineIndex int, incLineIndic
 	return result
 }
 
+// RangeContent returns the raw text of the lines between the two indices
+// (inclusive). Without prefixes we drop the hunk headers and the leading
+// +/-/space of each line so that what's left can be pasted as a plain snippet
+func (p *PatchParser) RangeContent(firstLineIndex int, lastLineIndex int, withPrefixes bool) string {
+	lines := []string{}
+	for _, patchLine := range p.PatchLines[firstLineIndex : lastLineIndex+1] {
+		if withPrefixes {
+			lines = append(lines, patchLine.Content)
+			continue
+		}
+
+		switch patchLine.Kind {
+		case ADDITION, DELETION, CONTEXT:
+			if len(patchLine.Content) > 0 {
+				lines = append(lines, patchLine.Content[1:])
+			} else {
+				lines = append(lines, "")
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // GetNextStageableLineIndex takes a line index and returns the line index of the next stageable line
 // note this will actually include the current index if it is stageable
 func (p *PatchParser) GetNextStageableLineIndex(currentIndex int) int {
@@ -211,3 +234,14 @@ func (p *PatchParser) GetNextStageableLineIndex(currentIndex int) int {
 	}
 	return p.StageableLines[len(p.StageableLines)-1]
 }
+
+// GetPrevStageableLineIndex is the counterpart of GetNextStageableLineIndex,
+// returning the last stageable line at or before the given index
+func (p *PatchParser) GetPrevStageableLineIndex(currentIndex int) int {
+	for i := len(p.StageableLines) - 1; i >= 0; i-- {
+		if p.StageableLines[i] <= currentIndex {
+			return p.StageableLines[i]
+		}
+	}
+	return p.StageableLines[0]
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const simplePatch = `diff --git a/filename b/filename
+index dcd3485..1ba5540 100644
+--- a/filename
++++ b/filename
+@@ -1,3 +1,3 @@
+ apple
+-orange
++grape
+ pear
+`
+
+// TestPatchParserRangeContent is a function.
+func TestPatchParserRangeContent(t *testing.T) {
+	type scenario struct {
+		testName       string
+		firstLineIndex int
+		lastLineIndex  int
+		withPrefixes   bool
+		expected       string
+	}
+
+	scenarios := []scenario{
+		{
+			"changed lines with prefixes",
+			6,
+			7,
+			true,
+			"-orange\n+grape",
+		},
+		{
+			"changed lines without prefixes",
+			6,
+			7,
+			false,
+			"orange\ngrape",
+		},
+		{
+			"hunk header is dropped without prefixes",
+			4,
+			6,
+			false,
+			"apple\norange",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			patchParser, err := NewPatchParser(NewDummyLog(), simplePatch)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, patchParser.RangeContent(s.firstLineIndex, s.lastLineIndex, s.withPrefixes))
+		})
+	}
+}
+
+// TestPatchParserStageableLineIndices is a function.
+func TestPatchParserStageableLineIndices(t *testing.T) {
+	patchParser, err := NewPatchParser(NewDummyLog(), simplePatch)
+	assert.NoError(t, err)
+
+	assert.EqualValues(t, 6, patchParser.GetNextStageableLineIndex(0))
+	assert.EqualValues(t, 7, patchParser.GetNextStageableLineIndex(7))
+	assert.EqualValues(t, 7, patchParser.GetNextStageableLineIndex(8))
+	assert.EqualValues(t, 6, patchParser.GetPrevStageableLineIndex(0))
+	assert.EqualValues(t, 6, patchParser.GetPrevStageableLineIndex(6))
+	assert.EqualValues(t, 7, patchParser.GetPrevStageableLineIndex(8))
+}
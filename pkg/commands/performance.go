@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// PerformanceFeature : a git setting that speeds up status or log in big repos
+type PerformanceFeature struct {
+	Key       string // used to look up the feature's description
+	Enabled   bool
+	Supported bool // whether this git/platform can use it at all
+}
+
+// TrackedFileCount reads the number of entries from the header of the index,
+// which is far cheaper than listing the files in a big repo. The header is
+// the signature 'DIRC', a version number and then the entry count
+func (c *GitCommand) TrackedFileCount() int {
+	f, err := os.Open(filepath.Join(c.DotGitDir, "index"))
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	header := make([]byte, 12)
+	if n, _ := f.Read(header); n < 12 || string(header[:4]) != "DIRC" {
+		return 0
+	}
+	return int(binary.BigEndian.Uint32(header[8:12]))
+}
+
+// GetPerformanceFeatures reports which of the features that help with big
+// repos are switched on
+func (c *GitCommand) GetPerformanceFeatures() []PerformanceFeature {
+	return []PerformanceFeature{
+		{
+			Key:       "untrackedCache",
+			Enabled:   c.getConfigValue("core.untrackedCache") == "true",
+			Supported: true,
+		},
+		{
+			Key:     "fsmonitor",
+			Enabled: c.UsingFsmonitor,
+			// the builtin daemon arrived in 2.36 and doesn't support linux
+			Supported: runtime.GOOS != "linux" && c.GitVersionAtLeast(2, 36),
+		},
+		{
+			Key:       "commitGraph",
+			Enabled:   c.commitGraphExists() && c.getConfigValue("fetch.writeCommitGraph") == "true",
+			Supported: true,
+		},
+	}
+}
+
+// commitGraphExists tells us whether a commit-graph has been written, either
+// as a single file or, as fetch.writeCommitGraph does, as a chain of split
+// graphs. Objects are shared by all of a repo's worktrees so they live in the
+// common git dir
+func (c *GitCommand) commitGraphExists() bool {
+	infoDir := filepath.Join(c.commonGitDir(), "objects", "info")
+	for _, path := range []string{
+		filepath.Join(infoDir, "commit-graph"),
+		filepath.Join(infoDir, "commit-graphs", "commit-graph-chain"),
+	} {
+		if exists, _ := c.OSCommand.FileExists(path); exists {
+			return true
+		}
+	}
+	return false
+}
+
+// EnablePerformanceFeature switches on one of the features from GetPerformanceFeatures
+func (c *GitCommand) EnablePerformanceFeature(key string) error {
+	switch key {
+	case "untrackedCache":
+		if err := c.OSCommand.RunCommand("git config core.untrackedCache true"); err != nil {
+			return err
+		}
+		return c.OSCommand.RunCommand("git update-index --untracked-cache")
+	case "fsmonitor":
+		if err := c.OSCommand.RunCommand("git config core.fsmonitor true"); err != nil {
+			return err
+		}
+		c.UsingFsmonitor = true
+		return c.StartFsmonitorDaemon()
+	case "commitGraph":
+		if err := c.OSCommand.RunCommand("git config fetch.writeCommitGraph true"); err != nil {
+			return err
+		}
+		return c.OSCommand.RunCommand("git commit-graph write --reachable")
+	}
+	return nil
+}
+
+// isFsmonitorValue tells us whether a core.fsmonitor value switches the monitor
+// on. Besides a boolean for the builtin daemon it can be the path of a hook
+// that talks to an external monitor like watchman
+func isFsmonitorValue(value string) bool {
+	return value != "" && value != "false"
+}
+
+// UsingBuiltinFsmonitor tells us whether the filesystem monitor is git's own
+// daemon rather than a hook
+func (c *GitCommand) UsingBuiltinFsmonitor() bool {
+	return c.getConfigValue("core.fsmonitor") == "true"
+}
+
+// FsmonitorDaemonRunning tells us whether git's builtin filesystem monitor
+// daemon is running for this repo
+func (c *GitCommand) FsmonitorDaemonRunning() bool {
+	return c.OSCommand.RunCommand("git fsmonitor--daemon status") == nil
+}
+
+// StartFsmonitorDaemon starts the builtin filesystem monitor now rather than
+// waiting for the next status to start it
+func (c *GitCommand) StartFsmonitorDaemon() error {
+	return c.OSCommand.RunCommand("git fsmonitor--daemon start")
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsFsmonitorValue is a function.
+func TestIsFsmonitorValue(t *testing.T) {
+	assert.True(t, isFsmonitorValue("true"))
+	assert.True(t, isFsmonitorValue(".git/hooks/fsmonitor-watchman"))
+	assert.False(t, isFsmonitorValue("false"))
+	assert.False(t, isFsmonitorValue(""))
+}
+
+// TestGitCommandCommitGraphExists is a function.
+func TestGitCommandCommitGraphExists(t *testing.T) {
+	type scenario struct {
+		testName string
+		path     string
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{
+			"no commit-graph",
+			"",
+			false,
+		},
+		{
+			"single file",
+			"objects/info/commit-graph",
+			true,
+		},
+		{
+			"split graphs",
+			"objects/info/commit-graphs/commit-graph-chain",
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lazygit-commit-graph")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			// a worktree's git dir points at the common one
+			commonDir := filepath.Join(dir, "repo")
+			worktreeDir := filepath.Join(commonDir, "worktrees", "feature")
+			assert.NoError(t, os.MkdirAll(worktreeDir, 0755))
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(worktreeDir, "commondir"), []byte("../..\n"), 0644))
+			if s.path != "" {
+				path := filepath.Join(commonDir, filepath.FromSlash(s.path))
+				assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				assert.NoError(t, ioutil.WriteFile(path, []byte{}, 0644))
+			}
+
+			gitCmd := NewDummyGitCommand()
+			gitCmd.DotGitDir = worktreeDir
+			assert.EqualValues(t, s.expected, gitCmd.commitGraphExists())
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// PostCheckoutCommand is a command the user wants run after a checkout or pull
+// changes any of the given paths, e.g. 'npm install' when package-lock.json
+// changes. With no paths it runs after every checkout or pull that moves HEAD
+type PostCheckoutCommand struct {
+	Command string   `mapstructure:"command"`
+	Paths   []string `mapstructure:"paths"`
+}
+
+// GetPostCheckoutCommands returns the user's configured post-checkout commands
+func (c *GitCommand) GetPostCheckoutCommands() ([]PostCheckoutCommand, error) {
+	postCheckoutCommands := []PostCheckoutCommand{}
+	err := c.Config.GetUserConfig().UnmarshalKey("git.postCheckoutCommands", &postCheckoutCommands)
+	return postCheckoutCommands, err
+}
+
+// ShouldRun tells us whether any of the changed files match one of the
+// command's paths. A path matches a file if it's a glob matching the whole
+// file path or, for paths without a slash, just its base name, or if it's a
+// directory containing the file
+func (p PostCheckoutCommand) ShouldRun(changedFiles []string) bool {
+	if len(p.Paths) == 0 {
+		return true
+	}
+
+	for _, pattern := range p.Paths {
+		dir := strings.TrimSuffix(pattern, "/") + "/"
+		for _, file := range changedFiles {
+			if matched, _ := filepath.Match(pattern, file); matched {
+				return true
+			}
+			if !strings.Contains(pattern, "/") {
+				if matched, _ := filepath.Match(pattern, filepath.Base(file)); matched {
+					return true
+				}
+			}
+			if strings.HasPrefix(file, dir) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HeadSha is the sha HEAD currently points at, or blank if there is none yet
+func (c *GitCommand) HeadSha() string {
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-parse HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// ChangedFilesBetween lists the files that differ between two commits
+func (c *GitCommand) ChangedFilesBetween(from string, to string) ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git diff --no-color --name-only %s %s", from, to)
+	if err != nil {
+		return nil, err
+	}
+	return utils.SplitLines(output), nil
+}
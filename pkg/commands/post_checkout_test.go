@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPostCheckoutCommandShouldRun is a function.
+func TestPostCheckoutCommandShouldRun(t *testing.T) {
+	type scenario struct {
+		testName     string
+		paths        []string
+		changedFiles []string
+		expected     bool
+	}
+
+	scenarios := []scenario{
+		{
+			"no paths means always",
+			nil,
+			[]string{"README.md"},
+			true,
+		},
+		{
+			"exact path",
+			[]string{"package-lock.json"},
+			[]string{"src/index.js", "package-lock.json"},
+			true,
+		},
+		{
+			"base name matches in a subdirectory",
+			[]string{"package-lock.json"},
+			[]string{"web/package-lock.json"},
+			true,
+		},
+		{
+			"glob",
+			[]string{"*.lock"},
+			[]string{"Gemfile.lock"},
+			true,
+		},
+		{
+			"path with a slash must match in full",
+			[]string{"web/package.json"},
+			[]string{"api/web/package.json"},
+			false,
+		},
+		{
+			"directory",
+			[]string{"migrations/"},
+			[]string{"migrations/001_init.sql"},
+			true,
+		},
+		{
+			"nothing matches",
+			[]string{"go.sum", "vendor"},
+			[]string{"pkg/gui/gui.go", "vendored.txt"},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, PostCheckoutCommand{Command: "true", Paths: s.paths}.ShouldRun(s.changedFiles))
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// PullRequestDescription is a title and body for a pull request
+type PullRequestDescription struct {
+	Title string
+	Body  string
+}
+
+// String is the description as you'd paste it somewhere: the title, a blank
+// line, then the body
+func (d *PullRequestDescription) String() string {
+	if d.Body == "" {
+		return d.Title
+	}
+	return d.Title + "\n\n" + d.Body
+}
+
+var trailerRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*: \S`)
+
+// splitTrailers splits a commit message into its paragraphs before the trailer
+// block and the trailers themselves, e.g. 'Co-authored-by: Jane <jane@x.com>'
+func splitTrailers(message string) (string, []string) {
+	message = strings.TrimSpace(message)
+	paragraphs := strings.Split(message, "\n\n")
+	if len(paragraphs) < 2 {
+		return message, nil
+	}
+
+	lastParagraph := paragraphs[len(paragraphs)-1]
+	trailers := strings.Split(lastParagraph, "\n")
+	for _, line := range trailers {
+		if !trailerRegexp.MatchString(line) {
+			return message, nil
+		}
+	}
+	return strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"), trailers
+}
+
+// BuildPullRequestDescription assembles a pull request description from the
+// messages of a branch's commits, oldest first. With one commit we use its
+// message as is. With several the first commit's subject is the title and the
+// body lists every subject, leaving out fixups that will be squashed away.
+// Either way the commits' trailers are collected at the end of the body
+func BuildPullRequestDescription(messages []string) *PullRequestDescription {
+	if len(messages) == 0 {
+		return &PullRequestDescription{}
+	}
+
+	paragraphs := []string{}
+	trailers := []string{}
+	seenTrailers := map[string]bool{}
+	bullets := []string{}
+	title := ""
+
+	for _, message := range messages {
+		content, messageTrailers := splitTrailers(message)
+		for _, trailer := range messageTrailers {
+			if !seenTrailers[trailer] {
+				seenTrailers[trailer] = true
+				trailers = append(trailers, trailer)
+			}
+		}
+
+		subject := strings.SplitN(content, "\n", 2)[0]
+		if strings.HasPrefix(subject, "fixup! ") || strings.HasPrefix(subject, "squash! ") {
+			continue
+		}
+		if title == "" {
+			title = subject
+		}
+		bullets = append(bullets, fmt.Sprintf("- %s", subject))
+
+		if len(messages) == 1 {
+			if split := strings.SplitN(content, "\n\n", 2); len(split) == 2 {
+				paragraphs = append(paragraphs, split[1])
+			}
+		}
+	}
+
+	if len(messages) > 1 {
+		paragraphs = append(paragraphs, strings.Join(bullets, "\n"))
+	}
+	if len(trailers) > 0 {
+		paragraphs = append(paragraphs, strings.Join(trailers, "\n"))
+	}
+
+	return &PullRequestDescription{
+		Title: title,
+		Body:  strings.Join(paragraphs, "\n\n"),
+	}
+}
+
+// PullRequestBase guesses the branch a pull request from the given branch
+// would be merged into: develop for feature branches, otherwise main or master
+func (c *GitCommand) PullRequestBase(branchName string) string {
+	candidates := []string{"main", "master"}
+	if strings.HasPrefix(branchName, "feature/") {
+		candidates = append([]string{"develop"}, candidates...)
+	}
+	for _, candidate := range candidates {
+		if _, err := c.OSCommand.RunCommandWithOutput("git rev-parse --verify --quiet refs/heads/%s", candidate); err == nil {
+			return candidate
+		}
+	}
+	return "master"
+}
+
+// GetBranchCommitMessages returns the full messages of the commits on branchName
+// that aren't on base, oldest first
+func (c *GitCommand) GetBranchCommitMessages(branchName string, base string) ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git log --reverse --no-color --format=%%B%%x00 %s..%s --", base, branchName)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []string{}
+	for _, message := range strings.Split(output, "\x00") {
+		if message = strings.TrimSpace(message); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildPullRequestDescription is a function.
+func TestBuildPullRequestDescription(t *testing.T) {
+	type scenario struct {
+		testName string
+		messages []string
+		expected *PullRequestDescription
+	}
+
+	scenarios := []scenario{
+		{
+			"no commits",
+			[]string{},
+			&PullRequestDescription{},
+		},
+		{
+			"a single commit keeps its body",
+			[]string{"Add sums\n\nSums are added left to right.\n\nSigned-off-by: Peter <peter@example.com>"},
+			&PullRequestDescription{
+				Title: "Add sums",
+				Body:  "Sums are added left to right.\n\nSigned-off-by: Peter <peter@example.com>",
+			},
+		},
+		{
+			"several commits become bullets, with their trailers collected once each",
+			[]string{
+				"Add sums\n\nCo-authored-by: Jane <jane@example.com>",
+				"fixup! Add sums",
+				"Handle overflow\n\nWe saturate rather than wrapping.\n\nCo-authored-by: Jane <jane@example.com>\nFixes: #12",
+			},
+			&PullRequestDescription{
+				Title: "Add sums",
+				Body:  "- Add sums\n- Handle overflow\n\nCo-authored-by: Jane <jane@example.com>\nFixes: #12",
+			},
+		},
+		{
+			"a last paragraph that isn't all trailers is left alone",
+			[]string{"Add sums\n\nNote: this is slow\nbut correct"},
+			&PullRequestDescription{
+				Title: "Add sums",
+				Body:  "Note: this is slow\nbut correct",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, BuildPullRequestDescription(s.messages))
+		})
+	}
+}
+
+// TestPullRequestDescriptionString is a function.
+func TestPullRequestDescriptionString(t *testing.T) {
+	assert.EqualValues(t, "Add sums", (&PullRequestDescription{Title: "Add sums"}).String())
+	assert.EqualValues(t, "Add sums\n\n- Add sums", (&PullRequestDescription{Title: "Add sums", Body: "- Add sums"}).String())
+}
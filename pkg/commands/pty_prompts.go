@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+// the most output we hold on to while waiting for a prompt, which is plenty
+// for ssh's host key message, the longest prompt we know of
+const maxPendingPromptText = 4096
+
+// terminalSequenceRegexp matches the escape sequences a terminal is sent to
+// move the cursor, colour text and the like, which Windows' pseudo consoles
+// write plenty of around a prompt
+var terminalSequenceRegexp = regexp.MustCompile(`\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\a\x1b]*(?:\a|\x1b\\)|[@-Z\\-_])`)
+
+// answerPrompts reads what a command writes to its terminal until there's no
+// more. Whenever the command stops writing partway through a line, as it does
+// when waiting on a prompt, output is called with everything written since the
+// last prompt was answered, so that prompts spanning several lines are seen
+// whole. What output returns, unless it's empty, is written back to the
+// terminal as the answer
+func answerPrompts(terminal io.ReadWriter, output func(string) string) {
+	pending := ""
+	buf := make([]byte, 1024)
+	for {
+		n, err := terminal.Read(buf)
+		if n > 0 {
+			text := terminalSequenceRegexp.ReplaceAllString(string(buf[:n]), "")
+			pending += strings.Replace(text, "\r\n", "\n", -1)
+			if len(pending) > maxPendingPromptText {
+				pending = pending[len(pending)-maxPendingPromptText:]
+			}
+			if pending != "" && !strings.HasSuffix(pending, "\n") {
+				if answer := output(pending); answer != "" {
+					pending = ""
+					_, _ = io.WriteString(terminal, answer)
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
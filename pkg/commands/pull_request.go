@@ -2,16 +2,34 @@ package commands
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // Service is a service that repository is on (Github, Bitbucket, ...)
 type Service struct {
-	Name           string
+	Name string
+	// Type is the kind of service, with GitHub Enterprise counting as github
+	// and Forgejo as gitea, as they work the same
+	Type           string
 	PullRequestURL string
+	CommitURL      string
+	// IssueURL is the link to an issue, or on Azure DevOps a work item, given
+	// the owner, repo and issue number
+	IssueURL string
+	// APIURL is the root of the service's API, for the services whose API we
+	// talk to directly, which are Gitea, Forgejo and Azure DevOps
+	APIURL string
+	// DescriptionParams are the query params the service uses to pre-fill a new
+	// pull request's title and body, if it has any
+	DescriptionParams string
+	// BaseParam is the query param the service uses to pick the branch a new
+	// pull request is to be merged into, if it has one
+	BaseParam string
 }
 
 // PullRequest opens a link in browser to create new pull request
@@ -27,25 +45,60 @@ type RepoInformation struct {
 	Repository string
 }
 
-// NewService builds a Service based on the host type
+// NewService builds a Service based on the host type. Self-hosted forges
+// (GitHub Enterprise, GitLab, Gitea) are told apart from the public ones only
+// by their domains
 func NewService(typeName string, repositoryDomain string, siteDomain string) *Service {
 	var service *Service
 
 	switch typeName {
-	case "github":
+	case "github", "github-enterprise":
 		service = &Service{
-			Name:           repositoryDomain,
-			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/compare/%s?expand=1"),
+			Name:              repositoryDomain,
+			Type:              "github",
+			PullRequestURL:    fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/compare/%s?expand=1"),
+			CommitURL:         fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/commit/%s"),
+			IssueURL:          fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/issues/%s"),
+			DescriptionParams: "title=%s&body=%s",
 		}
 	case "bitbucket":
 		service = &Service{
 			Name:           repositoryDomain,
+			Type:           "bitbucket",
 			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/pull-requests/new?source=%s&t=1"),
+			CommitURL:      fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/commits/%s"),
+			IssueURL:       fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/issues/%s"),
 		}
 	case "gitlab":
+		service = &Service{
+			Name:              repositoryDomain,
+			Type:              "gitlab",
+			PullRequestURL:    fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/merge_requests/new?merge_request[source_branch]=%s"),
+			CommitURL:         fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/-/commit/%s"),
+			IssueURL:          fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/-/issues/%s"),
+			DescriptionParams: "merge_request[title]=%s&merge_request[description]=%s",
+		}
+	case "gitea", "forgejo":
+		// comparing against just the branch compares it with the default branch
+		service = &Service{
+			Name:           repositoryDomain,
+			Type:           "gitea",
+			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/compare/%s"),
+			CommitURL:      fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/commit/%s"),
+			IssueURL:       fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/issues/%s"),
+			APIURL:         fmt.Sprintf("https://%s/api/v1", siteDomain),
+		}
+	case "azuredevops":
+		// the owner is the organization and project, e.g. 'contoso/web'
 		service = &Service{
 			Name:           repositoryDomain,
-			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/%s/merge_requests/new?merge_request[source_branch]=%s"),
+			Type:           "azuredevops",
+			PullRequestURL: fmt.Sprintf("https://%s%s", siteDomain, "/%s/_git/%s/pullrequestcreate?sourceRef=%s"),
+			CommitURL:      fmt.Sprintf("https://%s%s", siteDomain, "/%s/_git/%s/commit/%s"),
+			// work items belong to the project rather than the repo
+			IssueURL:  fmt.Sprintf("https://%s%s", siteDomain, "/%[1]s/_workitems/edit/%[3]s"),
+			APIURL:    fmt.Sprintf("https://%s", siteDomain),
+			BaseParam: "targetRef=%s",
 		}
 	}
 
@@ -57,12 +110,20 @@ func getServices(config config.AppConfigurer) []*Service {
 		NewService("github", "github.com", "github.com"),
 		NewService("bitbucket", "bitbucket.org", "bitbucket.org"),
 		NewService("gitlab", "gitlab.com", "gitlab.com"),
+		NewService("gitea", "gitea.com", "gitea.com"),
+		NewService("forgejo", "codeberg.org", "codeberg.org"),
+		NewService("azuredevops", "dev.azure.com", "dev.azure.com"),
+		NewService("azuredevops", "visualstudio.com", "dev.azure.com"),
 	}
 
 	configServices := config.GetUserConfig().GetStringMapString("services")
 
 	for repoDomain, typeAndDomain := range configServices {
 		splitData := strings.Split(typeAndDomain, ":")
+		if len(splitData) == 1 {
+			// the web interface is on the same domain as git
+			splitData = append(splitData, repoDomain)
+		}
 		if len(splitData) != 2 {
 			// TODO log this misconfiguration
 			continue
@@ -90,54 +151,157 @@ func NewPullRequest(gitCommand *GitCommand) *PullRequest {
 
 // Create opens link to new pull request in browser
 func (pr *PullRequest) Create(branch *Branch) error {
+	_, err := pr.CreateWithDescription(branch, "", nil)
+	return err
+}
+
+// CreateWithDescription opens a link to a new pull request into base in the
+// browser, with its title and body pre-filled if the service lets us. On
+// services whose API we have a token for, the pull request is created through
+// the API and the link is to the new pull request. It returns whether the
+// description made it into the pull request
+func (pr *PullRequest) CreateWithDescription(branch *Branch, base string, description *PullRequestDescription) (bool, error) {
 	branchExistsOnRemote := pr.GitCommand.CheckRemoteBranchExists(branch)
 
 	if !branchExistsOnRemote {
-		return errors.New(pr.GitCommand.Tr.SLocalize("NoBranchOnRemote"))
+		return false, errors.New(pr.GitCommand.Tr.SLocalize("NoBranchOnRemote"))
 	}
 
-	repoURL := pr.GitCommand.GetRemoteURL()
-	var gitService *Service
+	gitService, repoInfo, err := pr.getService()
+	if err != nil {
+		return false, err
+	}
 
-	for _, service := range pr.GitServices {
-		if strings.Contains(repoURL, service.Name) {
-			gitService = service
-			break
+	if description != nil {
+		link, created, err := pr.createWithAPI(gitService, repoInfo, branch.Name, base, description)
+		if err != nil {
+			return false, err
+		}
+		if created {
+			return true, pr.GitCommand.OSCommand.OpenLink(link)
 		}
 	}
 
-	if gitService == nil {
-		return errors.New(pr.GitCommand.Tr.SLocalize("UnsupportedGitService"))
+	link := fmt.Sprintf(gitService.PullRequestURL, repoInfo.Owner, repoInfo.Repository, branch.Name)
+	if base != "" && gitService.BaseParam != "" {
+		link += "&" + fmt.Sprintf(gitService.BaseParam, url.QueryEscape(base))
+	}
+	prefilled := description != nil && gitService.DescriptionParams != ""
+	if prefilled {
+		link += "&" + fmt.Sprintf(gitService.DescriptionParams, url.QueryEscape(description.Title), url.QueryEscape(description.Body))
 	}
 
-	repoInfo := getRepoInfoFromURL(repoURL)
+	return prefilled, pr.GitCommand.OSCommand.OpenLink(link)
+}
+
+// createWithAPI creates the pull request through the service's API, if it is
+// one whose API we talk to and we have a token for it, returning the link to
+// the new pull request and whether it was created
+func (pr *PullRequest) createWithAPI(service *Service, repoInfo *RepoInformation, branch string, base string, description *PullRequestDescription) (string, bool, error) {
+	if base == "" {
+		base = pr.GitCommand.PullRequestBase(branch)
+	}
 
-	return pr.GitCommand.OSCommand.OpenLink(fmt.Sprintf(
-		gitService.PullRequestURL, repoInfo.Owner, repoInfo.Repository, branch.Name,
-	))
+	switch service.Type {
+	case "gitea":
+		api, err := pr.giteaAPI(service, repoInfo)
+		if err != nil || api.token == "" {
+			return "", false, err
+		}
+		link, err := api.createPullRequest(branch, base, description)
+		return link, err == nil, err
+	case "azuredevops":
+		api, err := pr.azureDevOpsAPI(service, repoInfo)
+		if err != nil || api.token == "" {
+			return "", false, err
+		}
+		link, err := api.createPullRequest(branch, base, description)
+		return link, err == nil, err
+	}
+	return "", false, nil
 }
 
-func getRepoInfoFromURL(url string) *RepoInformation {
-	isHTTP := strings.HasPrefix(url, "http")
+// apiToken returns the API token from the secret named by the given config
+// key, or failing that from the environment variable of that name
+func (pr *PullRequest) apiToken(configKey string) (string, error) {
+	name := pr.GitCommand.Config.GetUserConfig().GetString(configKey)
+	if name == "" {
+		return "", nil
+	}
+	token, err := pr.GitCommand.OSCommand.SecretValue(name)
+	if err != nil || token != "" {
+		return token, err
+	}
+	return pr.GitCommand.OSCommand.getenv(name), nil
+}
 
-	if isHTTP {
-		splits := strings.Split(url, "/")
-		owner := strings.Join(splits[3:len(splits)-1], "/")
-		repo := strings.TrimSuffix(splits[len(splits)-1], ".git")
+// OpenCommit opens the commit's page on the service in the browser
+func (pr *PullRequest) OpenCommit(sha string) error {
+	gitService, repoInfo, err := pr.getService()
+	if err != nil {
+		return err
+	}
 
-		return &RepoInformation{
-			Owner:      owner,
-			Repository: repo,
-		}
+	return pr.GitCommand.OSCommand.OpenLink(fmt.Sprintf(gitService.CommitURL, repoInfo.Owner, repoInfo.Repository, sha))
+}
+
+// RepoLinks builds links to the repo's commits and issues on the service it's
+// on, e.g. for showing shas and issue references as hyperlinks
+type RepoLinks struct {
+	service          *Service
+	repoInfo         *RepoInformation
+	issueURLTemplate string
+}
+
+// RepoLinks returns the links for the repo. When it isn't on a service we
+// know there are no links to commits, and issues only have links if
+// git.issues.urlTemplate says where they are
+func (pr *PullRequest) RepoLinks() *RepoLinks {
+	links := &RepoLinks{
+		issueURLTemplate: pr.GitCommand.Config.GetUserConfig().GetString("git.issues.urlTemplate"),
+	}
+	links.service, links.repoInfo, _ = pr.getService()
+	return links
+}
+
+// Commit returns the link to the commit, or nothing if there isn't one
+func (l *RepoLinks) Commit(sha string) string {
+	if l.service == nil {
+		return ""
 	}
+	return fmt.Sprintf(l.service.CommitURL, l.repoInfo.Owner, l.repoInfo.Repository, sha)
+}
 
-	tmpSplit := strings.Split(url, ":")
-	splits := strings.Split(tmpSplit[1], "/")
-	owner := strings.Join(splits[0:len(splits)-1], "/")
-	repo := strings.TrimSuffix(splits[len(splits)-1], ".git")
+// Issue returns the link to the issue with the given number, or nothing if
+// there isn't one
+func (l *RepoLinks) Issue(id string) string {
+	if l.issueURLTemplate != "" {
+		return utils.ResolvePlaceholderString(l.issueURLTemplate, map[string]string{"id": id})
+	}
+	if l.service == nil || l.service.IssueURL == "" {
+		return ""
+	}
+	return fmt.Sprintf(l.service.IssueURL, l.repoInfo.Owner, l.repoInfo.Repository, id)
+}
 
-	return &RepoInformation{
-		Owner:      owner,
-		Repository: repo,
+// getService returns the service the origin remote is on, along with the
+// repo's owner and name there
+func (pr *PullRequest) getService() (*Service, *RepoInformation, error) {
+	repoURL := pr.GitCommand.GetRemoteURL()
+	remoteURL, err := ParseRemoteURL(repoURL)
+	if err != nil {
+		return nil, nil, errors.New(pr.GitCommand.Tr.SLocalize("UnsupportedGitService"))
 	}
+	// services are matched on the host, so that e.g. a repo called github.com
+	// on some other host isn't taken for one on GitHub
+	for _, service := range pr.GitServices {
+		if remoteURL.IsOnDomain(service.Name) {
+			if service.Type == "azuredevops" {
+				return service, remoteURL.AzureDevOpsRepoInformation(), nil
+			}
+			return service, remoteURL.RepoInformation(), nil
+		}
+	}
+
+	return nil, nil, errors.New(pr.GitCommand.Tr.SLocalize("UnsupportedGitService"))
 }
@@ -37,7 +37,9 @@ func TestGetRepoInfoFromURL(t *testing.T) {
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
-			s.test(getRepoInfoFromURL(s.repoURL))
+			remoteURL, err := ParseRemoteURL(s.repoURL)
+			assert.NoError(t, err)
+			s.test(remoteURL.RepoInformation())
 		})
 	}
 }
@@ -159,3 +161,173 @@ func TestCreatePullRequest(t *testing.T) {
 		})
 	}
 }
+
+// TestCreatePullRequestWithDescription is a function.
+func TestCreatePullRequestWithDescription(t *testing.T) {
+	type scenario struct {
+		testName          string
+		remoteURL         string
+		expectedLink      string
+		expectedPrefilled bool
+	}
+
+	scenarios := []scenario{
+		{
+			"Pre-fills the title and body on github",
+			"git@github.com:peter/calculator.git",
+			"https://github.com/peter/calculator/compare/feature/sum?expand=1&title=Add+sums&body=-+Add+sums%0A-+Fix+%27overflow%27",
+			true,
+		},
+		{
+			"Pre-fills the title and description on gitlab",
+			"git@gitlab.com:peter/calculator.git",
+			"https://gitlab.com/peter/calculator/merge_requests/new?merge_request[source_branch]=feature/sum&merge_request[title]=Add+sums&merge_request[description]=-+Add+sums%0A-+Fix+%27overflow%27",
+			true,
+		},
+		{
+			"Opens a plain link on bitbucket, which has no way to pre-fill the description",
+			"git@bitbucket.org:peter/calculator.git",
+			"https://bitbucket.org/peter/calculator/pull-requests/new?source=feature/sum&t=1",
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCommand := NewDummyGitCommand()
+			gitCommand.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				if strings.HasPrefix(cmd, "git") {
+					return exec.Command("echo", s.remoteURL)
+				}
+
+				assert.Equal(t, "open", cmd)
+				assert.Equal(t, []string{s.expectedLink}, args)
+				return exec.Command("echo")
+			}
+			gitCommand.OSCommand.Config.GetUserConfig().Set("os.openLinkCommand", "open {{link}}")
+
+			description := &PullRequestDescription{Title: "Add sums", Body: "- Add sums\n- Fix 'overflow'"}
+			prefilled, err := NewPullRequest(gitCommand).CreateWithDescription(&Branch{Name: "feature/sum"}, "master", description)
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedPrefilled, prefilled)
+		})
+	}
+}
+
+// TestOpenCommit is a function.
+func TestOpenCommit(t *testing.T) {
+	type scenario struct {
+		testName     string
+		remoteURL    string
+		expectedLink string
+	}
+
+	scenarios := []scenario{
+		{
+			"Opens the commit on github",
+			"git@github.com:peter/calculator.git",
+			"https://github.com/peter/calculator/commit/abc123",
+		},
+		{
+			"Opens the commit on a self-hosted gitlab with a separate web domain",
+			"git@git.work.com:team/calculator.git",
+			"https://code.work.com/team/calculator/-/commit/abc123",
+		},
+		{
+			"Opens the commit on github enterprise",
+			"https://github.corp.com/team/calculator.git",
+			"https://github.corp.com/team/calculator/commit/abc123",
+		},
+		{
+			"Opens the commit on gitea",
+			"git@gitea.home.net:peter/calculator.git",
+			"https://gitea.home.net/peter/calculator/commit/abc123",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCommand := NewDummyGitCommand()
+			gitCommand.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				if strings.HasPrefix(cmd, "git") {
+					return exec.Command("echo", s.remoteURL)
+				}
+
+				assert.Equal(t, "open", cmd)
+				assert.Equal(t, []string{s.expectedLink}, args)
+				return exec.Command("echo")
+			}
+			gitCommand.OSCommand.Config.GetUserConfig().Set("os.openLinkCommand", "open {{link}}")
+			gitCommand.Config.GetUserConfig().Set("services", map[string]string{
+				"git.work.com":    "gitlab:code.work.com",
+				"github.corp.com": "github-enterprise",
+				"gitea.home.net":  "gitea",
+			})
+
+			assert.NoError(t, NewPullRequest(gitCommand).OpenCommit("abc123"))
+		})
+	}
+}
+
+// TestRepoLinks is a function.
+func TestRepoLinks(t *testing.T) {
+	type scenario struct {
+		testName           string
+		remoteURL          string
+		issueURLTemplate   string
+		expectedCommitLink string
+		expectedIssueLink  string
+	}
+
+	scenarios := []scenario{
+		{
+			"Links to github",
+			"git@github.com:peter/calculator.git",
+			"",
+			"https://github.com/peter/calculator/commit/abc123",
+			"https://github.com/peter/calculator/issues/42",
+		},
+		{
+			"Links to gitlab",
+			"https://gitlab.com/team/sub/calculator.git",
+			"",
+			"https://gitlab.com/team/sub/calculator/-/commit/abc123",
+			"https://gitlab.com/team/sub/calculator/-/issues/42",
+		},
+		{
+			"Links to azure devops work items",
+			"https://contoso@dev.azure.com/contoso/web/_git/calculator",
+			"",
+			"https://dev.azure.com/contoso/web/_git/calculator/commit/abc123",
+			"https://dev.azure.com/contoso/web/_workitems/edit/42",
+		},
+		{
+			"Links to issues in the configured tracker",
+			"git@github.com:peter/calculator.git",
+			"https://example.atlassian.net/browse/CALC-{{id}}",
+			"https://github.com/peter/calculator/commit/abc123",
+			"https://example.atlassian.net/browse/CALC-42",
+		},
+		{
+			"Has no links for an unknown service",
+			"git@git.unknown.com:peter/calculator.git",
+			"",
+			"",
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCommand := NewDummyGitCommand()
+			gitCommand.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+				return exec.Command("echo", s.remoteURL)
+			}
+			gitCommand.Config.GetUserConfig().Set("git.issues.urlTemplate", s.issueURLTemplate)
+
+			links := NewPullRequest(gitCommand).RepoLinks()
+			assert.EqualValues(t, s.expectedCommitLink, links.Commit("abc123"))
+			assert.EqualValues(t, s.expectedIssueLink, links.Issue("42"))
+		})
+	}
+}
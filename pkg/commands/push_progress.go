@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PushRefResult is git's verdict on one of the refs in a push, as reported by
+// 'git push --porcelain'
+type PushRefResult struct {
+	Flag    string // one of ' ', '+', '-', '*', '!' and '='
+	From    string
+	To      string
+	Summary string // e.g. '[rejected] (non-fast-forward)' or 'a1b2c3d..e4f5a6b'
+}
+
+// Rejected tells us whether the remote refused the ref
+func (r *PushRefResult) Rejected() bool {
+	return r.Flag == "!"
+}
+
+// UpToDate tells us whether the remote already had the ref
+func (r *PushRefResult) UpToDate() bool {
+	return r.Flag == "="
+}
+
+// Name is the short name of the ref on the remote, e.g. 'v1.0' for refs/tags/v1.0
+func (r *PushRefResult) Name() string {
+	name := r.To
+	if name == "" {
+		name = r.From
+	}
+	for _, prefix := range []string{"refs/heads/", "refs/tags/"} {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix)
+		}
+	}
+	return name
+}
+
+// ParsePushRefResult parses a per-ref line of 'git push --porcelain' output,
+// which looks like '<flag>\t<from>:<to>\t<summary>'. Any other line (the
+// 'To <url>' header, progress meters, 'Done') gives nil
+func ParsePushRefResult(line string) *PushRefResult {
+	split := strings.SplitN(line, "\t", 3)
+	if len(split) != 3 || len(split[0]) != 1 || !strings.Contains(" +-*!=", split[0]) {
+		return nil
+	}
+
+	refs := strings.SplitN(split[1], ":", 2)
+	if len(refs) != 2 {
+		return nil
+	}
+
+	return &PushRefResult{
+		Flag:    split[0],
+		From:    refs[0],
+		To:      refs[1],
+		Summary: split[2],
+	}
+}
+
+// PushAllTags pushes every tag to the given remote, passing git's report on
+// each tag to onLine as it goes, along with its progress. The remote may need
+// credentials, which ask is for, as with Push. It returns what was pushed, or
+// nil if nothing was
+func (c *GitCommand) PushAllTags(ctx context.Context, remoteName string, onLine func(string), ask func(string) string) (*TransferStats, error) {
+	onChunk, flush := NewLineSplitter(onLine)
+	defer flush()
+	command := fmt.Sprintf("git push --porcelain --progress %s --tags", c.OSCommand.Quote(remoteName))
+	start := time.Now()
+	output, err := c.OSCommand.DetectUnamePassWithOutputChunks(ctx, command, ask, onChunk)
+	if err != nil {
+		return nil, err
+	}
+	return c.transferStats("push", start, output), nil
+}
+
+// GetUpstreamRemote returns the remote the branch pulls from, defaulting to
+// origin when it doesn't track one
+func (c *GitCommand) GetUpstreamRemote(branchName string) string {
+	if remote, _ := c.getLocalGitConfig(fmt.Sprintf("branch.%s.remote", branchName)); remote != "" {
+		return remote
+	}
+	return "origin"
+}
@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParsePushRefResult is a function.
+func TestParsePushRefResult(t *testing.T) {
+	type scenario struct {
+		line     string
+		expected *PushRefResult
+	}
+
+	scenarios := []scenario{
+		{
+			"To github.com:jesseduffield/lazygit.git",
+			nil,
+		},
+		{
+			"Writing objects: 100% (3/3), 284 bytes | 284.00 KiB/s, done.",
+			nil,
+		},
+		{
+			"Done",
+			nil,
+		},
+		{
+			"*\trefs/tags/v1.0:refs/tags/v1.0\t[new tag]",
+			&PushRefResult{Flag: "*", From: "refs/tags/v1.0", To: "refs/tags/v1.0", Summary: "[new tag]"},
+		},
+		{
+			"=\trefs/tags/v0.9:refs/tags/v0.9\t[up to date]",
+			&PushRefResult{Flag: "=", From: "refs/tags/v0.9", To: "refs/tags/v0.9", Summary: "[up to date]"},
+		},
+		{
+			"!\trefs/heads/master:refs/heads/master\t[rejected] (non-fast-forward)",
+			&PushRefResult{Flag: "!", From: "refs/heads/master", To: "refs/heads/master", Summary: "[rejected] (non-fast-forward)"},
+		},
+		{
+			"-\t:refs/heads/old\t[deleted]",
+			&PushRefResult{Flag: "-", From: "", To: "refs/heads/old", Summary: "[deleted]"},
+		},
+	}
+
+	for _, s := range scenarios {
+		assert.EqualValues(t, s.expected, ParsePushRefResult(s.line))
+	}
+}
+
+// TestPushRefResultName is a function.
+func TestPushRefResultName(t *testing.T) {
+	assert.EqualValues(t, "v1.0", (&PushRefResult{To: "refs/tags/v1.0"}).Name())
+	assert.EqualValues(t, "feature/thing", (&PushRefResult{To: "refs/heads/feature/thing"}).Name())
+	assert.EqualValues(t, "refs/notes/commits", (&PushRefResult{To: "refs/notes/commits"}).Name())
+}
+
+// TestGitCommandGetUpstreamRemote is a function.
+func TestGitCommandGetUpstreamRemote(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.getLocalGitConfig = func(key string) (string, error) {
+		if key == "branch.feature.remote" {
+			return "upstream", nil
+		}
+		return "", nil
+	}
+
+	assert.EqualValues(t, "upstream", gitCmd.GetUpstreamRemote("feature"))
+	assert.EqualValues(t, "origin", gitCmd.GetUpstreamRemote("untracked"))
+}
+
+// TestGitCommandPushAllTags is a function.
+func TestGitCommandPushAllTags(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"push", "--porcelain", "--progress", "origin", "--tags"}, args)
+		// git writes its progress to stderr and its report on each ref to stdout
+		return exec.Command("sh", "-c", `echo 'Writing objects: 100% (3/3), 284 bytes | 284.00 KiB/s, done.' >&2; printf '*\trefs/tags/v1.0:refs/tags/v1.0\t[new tag]\nDone\n'`)
+	})
+
+	lines := []string{}
+	stats, err := gitCmd.PushAllTags(context.Background(), "origin", func(line string) { lines = append(lines, line) }, func(string) string { return "" })
+	assert.NoError(t, err)
+	assert.Contains(t, lines, "*\trefs/tags/v1.0:refs/tags/v1.0\t[new tag]")
+	if assert.NotNil(t, stats) {
+		assert.EqualValues(t, "push", stats.Operation)
+		assert.EqualValues(t, 3, stats.Objects)
+		assert.EqualValues(t, 284, stats.Bytes)
+	}
+}
@@ -4,6 +4,9 @@ package commands
 type RemoteBranch struct {
 	Name       string
 	RemoteName string
+	// FetchedAt is the unix timestamp of when we last fetched the branch, as
+	// best we can tell, or 0 if we can't
+	FetchedAt int64
 }
 
 func (r *RemoteBranch) FullName() string {
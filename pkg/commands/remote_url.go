@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// RemoteURL is a remote's url taken apart. Remotes can be given as urls like
+// ssh://git@review.example.com:29418/project, as scp-like ssh addresses like
+// git@github.com:owner/repo.git, or as local paths, whose scheme is file
+type RemoteURL struct {
+	Scheme string
+	User   string
+	Host   string
+	Port   string
+	// Path is the path on the host, without the leading slash
+	Path string
+}
+
+// scpLikeRegexp matches git's scp-like syntax, [user@]host:path, and its
+// bracketed form, [[user@]host[:port]]:path, which allows for a port
+var scpLikeRegexp = regexp.MustCompile(`^(?:\[([^\]/]+)\]|(?:([^@/\[\]]+)@)?([^:/\[\]]+)):(.*)$`)
+
+// ParseRemoteURL parses a remote url the way git does: anything with a '://'
+// is a url, anything with a colon before the first slash is scp-like, and the
+// rest are local paths
+func ParseRemoteURL(rawURL string) (*RemoteURL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, errors.New("empty remote url")
+	}
+
+	if strings.Contains(rawURL, "://") {
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		remoteURL := &RemoteURL{
+			Scheme: strings.ToLower(parsed.Scheme),
+			Host:   parsed.Hostname(),
+			Port:   parsed.Port(),
+			Path:   strings.TrimPrefix(parsed.Path, "/"),
+		}
+		if parsed.User != nil {
+			remoteURL.User = parsed.User.Username()
+		}
+		return remoteURL, nil
+	}
+
+	// a single letter before the colon is a Windows drive, as in C:\repo
+	if match := scpLikeRegexp.FindStringSubmatch(rawURL); match != nil && len(match[1]+match[3]) > 1 {
+		remoteURL := &RemoteURL{Scheme: "ssh", User: match[2], Host: match[3], Path: strings.TrimPrefix(match[4], "/")}
+		if bracketed := match[1]; bracketed != "" {
+			if i := strings.LastIndex(bracketed, "@"); i != -1 {
+				remoteURL.User, bracketed = bracketed[:i], bracketed[i+1:]
+			}
+			if i := strings.LastIndex(bracketed, ":"); i != -1 {
+				bracketed, remoteURL.Port = bracketed[:i], bracketed[i+1:]
+			}
+			remoteURL.Host = bracketed
+		}
+		return remoteURL, nil
+	}
+
+	return &RemoteURL{Scheme: "file", Path: rawURL}, nil
+}
+
+// RepoInformation returns the repo's name, which is the last part of the
+// path, and its owner, which is everything before it, e.g. a GitLab group and
+// subgroup
+func (r *RemoteURL) RepoInformation() *RepoInformation {
+	path := strings.TrimSuffix(strings.Trim(r.Path, "/"), ".git")
+	i := strings.LastIndex(path, "/")
+	if i == -1 {
+		return &RepoInformation{Repository: path}
+	}
+	return &RepoInformation{
+		Owner:      path[:i],
+		Repository: path[i+1:],
+	}
+}
+
+// IsOnDomain returns whether the remote is on the domain or one of its
+// subdomains, e.g. ssh.dev.azure.com is on dev.azure.com but notgithub.com
+// isn't on github.com. A domain with a port only matches that port
+func (r *RemoteURL) IsOnDomain(domain string) bool {
+	host := strings.ToLower(r.Host)
+	domain = strings.ToLower(domain)
+	if strings.Contains(domain, ":") {
+		host += ":" + r.Port
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// GetRemoteURLs returns the urls git fetches the remote from and pushes it to,
+// as git will use them: with any url.<base>.insteadOf and pushInsteadOf
+// rewrites in the git config applied, and with all of the remote's pushurls
+func (c *GitCommand) GetRemoteURLs(remoteName string) (string, []string, error) {
+	fetchURL, err := c.OSCommand.RunCommandArgsWithOutput("git", "remote", "get-url", remoteName)
+	if err != nil {
+		return "", nil, err
+	}
+	pushURLs, err := c.OSCommand.RunCommandArgsWithOutput("git", "remote", "get-url", "--push", "--all", remoteName)
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.TrimSpace(fetchURL), utils.SplitLines(pushURLs), nil
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Secret is an entry in the user's secrets config: a token an integration
+// needs, like a GitHub or GitLab API token, which is read from the OS keychain
+// or printed by a command like 'pass show github/token' rather than being
+// written out in the config. Commands that use an environment variable of the
+// secret's name get the secret in it
+type Secret struct {
+	Name     string `mapstructure:"name"`
+	Command  string `mapstructure:"command"`
+	Keychain string `mapstructure:"keychain"`
+}
+
+func (c *OSCommand) getSecrets() ([]Secret, error) {
+	secrets := []Secret{}
+	err := c.Config.GetUserConfig().UnmarshalKey("secrets", &secrets)
+	return secrets, err
+}
+
+// WithSecrets adds the secrets which the command string mentions to the
+// environment of cmd. Each secret is only looked up the first time a command
+// needs it, because doing so may well ask the user to unlock their keychain
+func (c *OSCommand) WithSecrets(cmd *exec.Cmd, command string) error {
+	secrets, err := c.getSecrets()
+	if err != nil {
+		return err
+	}
+	for _, secret := range secrets {
+		if secret.Name == "" || !mentionsVariable(command, secret.Name) {
+			continue
+		}
+		value, err := c.secretValue(secret)
+		if err != nil {
+			return err
+		}
+		if cmd.Env == nil {
+			cmd.Env = c.Environ()
+		}
+		cmd.Env = append(cmd.Env, secret.Name+"="+value)
+	}
+	return nil
+}
+
+// SecretValue returns the value of the secret with the given name, or an
+// empty string if the user hasn't listed one by that name
+func (c *OSCommand) SecretValue(name string) (string, error) {
+	secrets, err := c.getSecrets()
+	if err != nil {
+		return "", err
+	}
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return c.secretValue(secret)
+		}
+	}
+	return "", nil
+}
+
+// mentionsVariable returns whether the command refers to the environment
+// variable, as $NAME or ${NAME}, or %NAME% on Windows. Mentioning GH_TOKEN
+// doesn't count as mentioning GH
+func mentionsVariable(command string, name string) bool {
+	quoted := regexp.QuoteMeta(name)
+	return regexp.MustCompile(`\$(` + quoted + `\b|\{` + quoted + `\})|%` + quoted + `%`).MatchString(command)
+}
+
+// secretValue looks up the secret the first time it's asked for. If that
+// fails, it stays failed until lazygit is restarted, because a status bar
+// segment mentioning the secret would otherwise have the user's keychain or
+// gpg asked again on every refresh
+func (c *OSCommand) secretValue(secret Secret) (string, error) {
+	c.secretsMutex.Lock()
+	defer c.secretsMutex.Unlock()
+
+	if value, ok := c.secretValues[secret.Name]; ok {
+		return value, nil
+	}
+	if err, ok := c.secretErrors[secret.Name]; ok {
+		return "", err
+	}
+	value, err := c.lookUpSecret(secret)
+	if err != nil {
+		err = fmt.Errorf("Could not get secret %s: %v", secret.Name, err)
+		if c.secretErrors == nil {
+			c.secretErrors = map[string]error{}
+		}
+		c.secretErrors[secret.Name] = err
+		return "", err
+	}
+	if c.secretValues == nil {
+		c.secretValues = map[string]string{}
+	}
+	c.secretValues[secret.Name] = value
+	return value, nil
+}
+
+func (c *OSCommand) lookUpSecret(secret Secret) (string, error) {
+	var cmd *exec.Cmd
+	switch {
+	case secret.Command != "":
+		cmd = c.ShellExecutable(secret.Command)
+	case secret.Keychain != "":
+		keychainCmd, err := c.keychainLookup(secret.Keychain)
+		if err != nil {
+			return "", err
+		}
+		cmd = keychainCmd
+	default:
+		return "", errors.New("secrets need either a command or a keychain entry")
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", errors.New(message)
+		}
+		return "", err
+	}
+	// like git credential helpers, only the first line is the secret, so that
+	// e.g. pass entries can keep notes below it
+	value := strings.TrimSpace(strings.SplitN(string(output), "\n", 2)[0])
+	if value == "" {
+		return "", errors.New("it is empty")
+	}
+	return value, nil
+}
+
+// keychainLookup returns the command which prints the password stored in the
+// OS keychain under the given service name
+func (c *OSCommand) keychainLookup(service string) (*exec.Cmd, error) {
+	switch c.Platform.os {
+	case "darwin":
+		return c.command("security", "find-generic-password", "-s", service, "-w"), nil
+	case "windows":
+		return nil, errors.New("the Windows credential manager isn't supported, use a command instead")
+	default:
+		// anything speaking the freedesktop secret service API, like GNOME
+		// Keyring or KWallet
+		return c.command("secret-tool", "lookup", "service", service), nil
+	}
+}
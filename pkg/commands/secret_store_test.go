@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandWithSecrets is a function.
+func TestOSCommandWithSecrets(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("secrets", []map[string]interface{}{
+		{"name": "GITHUB_TOKEN", "command": "pass show github"},
+		{"name": "GITLAB_TOKEN", "command": "pass show gitlab"},
+	})
+	lookups := 0
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "pass show github", args[len(args)-1])
+		lookups++
+		return exec.Command("printf", "abc123\\nnotes\\n")
+	})
+
+	for i := 0; i < 2; i++ {
+		cmd := exec.Command("gh", "issue", "list")
+		assert.NoError(t, osCommand.WithSecrets(cmd, "GH_TOKEN=$GITHUB_TOKEN gh issue list"))
+		assert.Contains(t, cmd.Env, "GITHUB_TOKEN=abc123")
+	}
+	assert.EqualValues(t, 1, lookups, "secrets are only looked up once")
+
+	cmd := exec.Command("make")
+	assert.NoError(t, osCommand.WithSecrets(cmd, "make"))
+	assert.Nil(t, cmd.Env, "commands that don't mention a secret don't get it")
+}
+
+// TestOSCommandWithSecretsFailedLookup is a function.
+func TestOSCommandWithSecretsFailedLookup(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+	osCommand.Config.GetUserConfig().Set("secrets", []map[string]interface{}{
+		{"name": "GITHUB_TOKEN", "command": "pass show github"},
+	})
+	lookups := 0
+	osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		lookups++
+		return exec.Command("sh", "-c", "echo 'gpg: decryption failed' >&2; exit 2")
+	})
+
+	for i := 0; i < 2; i++ {
+		err := osCommand.WithSecrets(exec.Command("gh"), "$GITHUB_TOKEN")
+		assert.EqualError(t, err, "Could not get secret GITHUB_TOKEN: gpg: decryption failed")
+	}
+	assert.EqualValues(t, 1, lookups, "failed lookups aren't tried again")
+}
+
+// TestMentionsVariable is a function.
+func TestMentionsVariable(t *testing.T) {
+	type scenario struct {
+		command  string
+		name     string
+		expected bool
+	}
+
+	scenarios := []scenario{
+		{"GH_TOKEN=$GH gh issue list", "GH", true},
+		{"echo ${GH}", "GH", true},
+		{"echo %GH%", "GH", true},
+		{"echo $GH_TOKEN", "GH", false},
+		{"echo $GHOST", "GH", false},
+		{"echo ${GHOST}", "GH", false},
+		{"gh issue list", "GH", false},
+		{"echo GH", "GH", false},
+		{"echo $GH.", "GH", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.command, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, mentionsVariable(s.command, s.name))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// SecretFinding : an added line in a diff that looks like it contains a secret
+type SecretFinding struct {
+	FileName string
+	Line     int
+	Kind     string
+	Content  string
+}
+
+var secretPatterns = []struct {
+	kind   string
+	regexp *regexp.Regexp
+}{
+	{"AWS access key", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY( BLOCK)?-----`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`)},
+}
+
+// candidates for the entropy check: long runs of characters that show up in
+// base64 and hex encoded keys respectively
+var (
+	base64CandidateRegexp = regexp.MustCompile(`[A-Za-z0-9+/=_-]{20,}`)
+	hexCandidateRegexp    = regexp.MustCompile(`[0-9a-fA-F]{20,}`)
+)
+
+// hex keys have letters as well as digits, which tells them apart from long
+// numbers like timestamps and ids
+var (
+	hexLetterRegexp = regexp.MustCompile(`[a-fA-F]`)
+	hexDigitRegexp  = regexp.MustCompile(`[0-9]`)
+)
+
+// the Shannon entropy (in bits per character) above which we consider a string
+// random enough to be a key. It depends on the alphabet, because a string can
+// only have as many bits per character as its alphabet allows: at most 4 for
+// hex and 6 for base64. English text and identifiers sit well below either
+const (
+	hexEntropyThreshold    = 3.0
+	base64EntropyThreshold = 4.5
+)
+
+// ScanStagedChangesForSecrets looks through the lines being added in the
+// staged changes for things that look like credentials
+func (c *GitCommand) ScanStagedChangesForSecrets() ([]SecretFinding, error) {
+	diff, err := c.OSCommand.RunCommandWithOutput("git diff --cached --no-color --no-ext-diff --unified=0")
+	if err != nil {
+		return nil, err
+	}
+	return ScanDiffForSecrets(diff), nil
+}
+
+// ScanDiffForSecrets checks each added line of a diff against our known token
+// patterns, falling back to flagging high entropy strings
+func ScanDiffForSecrets(diff string) []SecretFinding {
+	findings := []SecretFinding{}
+	fileName := ""
+	lineNumber := 0
+	for _, line := range utils.SplitLines(diff) {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			fileName = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+		case strings.HasPrefix(line, "@@ "):
+			if match := hunkHeaderRegexp.FindStringSubmatch(line); match != nil {
+				lineNumber, _ = strconv.Atoi(match[2])
+			}
+		case strings.HasPrefix(line, "+"):
+			content := line[1:]
+			if kind := secretKind(content); kind != "" {
+				findings = append(findings, SecretFinding{FileName: fileName, Line: lineNumber, Kind: kind, Content: strings.TrimSpace(content)})
+			}
+			lineNumber++
+		case strings.HasPrefix(line, " "):
+			lineNumber++
+		}
+	}
+	return findings
+}
+
+func secretKind(content string) string {
+	for _, pattern := range secretPatterns {
+		if pattern.regexp.MatchString(content) {
+			return pattern.kind
+		}
+	}
+	for _, candidate := range base64CandidateRegexp.FindAllString(content, -1) {
+		if shannonEntropy(candidate) > base64EntropyThreshold {
+			return "high entropy string"
+		}
+	}
+	for _, candidate := range hexCandidateRegexp.FindAllString(content, -1) {
+		if hexLetterRegexp.MatchString(candidate) && hexDigitRegexp.MatchString(candidate) && shannonEntropy(candidate) > hexEntropyThreshold {
+			return "high entropy string"
+		}
+	}
+	return ""
+}
+
+func shannonEntropy(str string) float64 {
+	counts := map[rune]int{}
+	for _, r := range str {
+		counts[r]++
+	}
+	entropy := 0.0
+	length := float64(len(str))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SigningKeyStatus tells us whether the key git signs commits with is still usable
+type SigningKeyStatus struct {
+	Missing   bool
+	Expired   bool
+	Revoked   bool
+	ExpiresAt time.Time // zero if the key never expires
+}
+
+// GetSigningKeyStatus looks up the key that git will sign commits with. It
+// returns nil when commit signing isn't enabled or there is nothing we can
+// check, e.g. x509 keys or ssh keys held by an agent
+func (c *GitCommand) GetSigningKeyStatus() *SigningKeyStatus {
+	if !c.usingGpg() {
+		return nil
+	}
+
+	key := c.getConfigValue("user.signingkey")
+
+	switch c.getConfigValue("gpg.format") {
+	case "", "openpgp":
+		if key == "" {
+			key = c.getConfigValue("user.email")
+		}
+		program := c.getConfigValue("gpg.program")
+		if program == "" {
+			program = "gpg"
+		}
+		output, err := c.OSCommand.RunCommandArgsWithOutput(program, "--list-secret-keys", "--with-colons", key)
+		if err != nil {
+			return &SigningKeyStatus{Missing: true}
+		}
+		return parseGpgKeyStatus(output)
+	case "ssh":
+		// literal keys (key::...) and agent-held keys have no file to look for
+		if key == "" || strings.HasPrefix(key, "key::") {
+			return nil
+		}
+		if strings.HasPrefix(key, "~/") {
+			home, _ := os.UserHomeDir()
+			key = filepath.Join(home, key[2:])
+		}
+		if exists, _ := c.OSCommand.FileExists(key); !exists {
+			return &SigningKeyStatus{Missing: true}
+		}
+		return &SigningKeyStatus{}
+	default:
+		return nil
+	}
+}
+
+// parseGpgKeyStatus reads the first secret key record from the output of
+// `gpg --list-secret-keys --with-colons`. The second field holds the key's
+// validity and the seventh its expiry as a unix timestamp
+func parseGpgKeyStatus(output string) *SigningKeyStatus {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) < 7 || fields[0] != "sec" {
+			continue
+		}
+
+		status := &SigningKeyStatus{
+			Expired: fields[1] == "e",
+			Revoked: fields[1] == "r",
+		}
+		if seconds, err := strconv.ParseInt(fields[6], 10, 64); err == nil {
+			status.ExpiresAt = time.Unix(seconds, 0)
+		}
+		return status
+	}
+
+	return &SigningKeyStatus{Missing: true}
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseGpgKeyStatus is a function.
+func TestParseGpgKeyStatus(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		test     func(*SigningKeyStatus)
+	}
+
+	scenarios := []scenario{
+		{
+			"key which never expires",
+			"sec:u:4096:1:1234567890ABCDEF:1546300800:::u:::scESC:::+:::23::0:\nfpr:::::::::0123456789ABCDEF0123456789ABCDEF:",
+			func(status *SigningKeyStatus) {
+				assert.EqualValues(t, &SigningKeyStatus{}, status)
+			},
+		},
+		{
+			"key with an expiry date",
+			"sec:u:4096:1:1234567890ABCDEF:1546300800:1609459200::u:::scESC:::+:::23::0:",
+			func(status *SigningKeyStatus) {
+				assert.False(t, status.Expired)
+				assert.EqualValues(t, time.Unix(1609459200, 0), status.ExpiresAt)
+			},
+		},
+		{
+			"expired key",
+			"sec:e:4096:1:1234567890ABCDEF:1546300800:1577836800::u:::sc:::+:::23::0:",
+			func(status *SigningKeyStatus) {
+				assert.True(t, status.Expired)
+			},
+		},
+		{
+			"revoked key",
+			"sec:r:4096:1:1234567890ABCDEF:1546300800:::u:::sc:::+:::23::0:",
+			func(status *SigningKeyStatus) {
+				assert.True(t, status.Revoked)
+			},
+		},
+		{
+			"no secret key",
+			"",
+			func(status *SigningKeyStatus) {
+				assert.True(t, status.Missing)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			s.test(parseGpgKeyStatus(s.output))
+		})
+	}
+}
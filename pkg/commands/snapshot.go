@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// SnapshotRef is where working tree snapshots are stored. It lives outside of
+// refs/heads so snapshots never show up as branches, and each snapshot is an
+// entry in the ref's reflog
+const SnapshotRef = "refs/lazygit/snapshots"
+
+// Snapshot : A commit recording the state of the working tree (including
+// untracked files) at some point in time
+type Snapshot struct {
+	Sha           string
+	UnixTimestamp int64
+	Message       string
+}
+
+// CreateSnapshot commits the working tree to SnapshotRef without touching the
+// index, the working tree, or the checked out branch. It returns false if
+// there was nothing new to snapshot
+func (c *GitCommand) CreateSnapshot(message string) (bool, error) {
+	indexPath := filepath.Join(c.DotGitDir, "lazygit-snapshot-index")
+	defer func() { _ = c.OSCommand.Remove(indexPath) }()
+
+	// using a separate index file means we can stage everything without
+	// disturbing whatever the user has staged in the real index
+	options := RunCommandOptions{EnvVars: []string{"GIT_INDEX_FILE=" + indexPath}}
+
+	hasHead := true
+	if err := c.OSCommand.RunCommandWithOptions("git read-tree HEAD", options); err != nil {
+		hasHead = false
+	}
+	if err := c.OSCommand.RunCommandWithOptions("git add -A", options); err != nil {
+		return false, err
+	}
+	tree, err := c.OSCommand.RunCommandWithOutputWithOptions("git write-tree", options)
+	if err != nil {
+		return false, err
+	}
+	tree = strings.TrimSpace(tree)
+
+	// no point recording a snapshot if nothing has changed since the last one,
+	// or if there are no uncommitted changes at all
+	for _, ref := range []string{SnapshotRef, "HEAD"} {
+		existingTree, err := c.OSCommand.RunCommandWithOutput("git rev-parse -q --verify %s^{tree}", ref)
+		if err == nil && strings.TrimSpace(existingTree) == tree {
+			return false, nil
+		}
+	}
+
+	args := []string{"git", "commit-tree", tree}
+	if hasHead {
+		args = append(args, "-p", "HEAD")
+	}
+	sha, err := c.OSCommand.RunCommandArgsWithOutput(append(args, "-m", message)...)
+	if err != nil {
+		return false, err
+	}
+
+	return true, c.OSCommand.RunCommandArgs("git", "update-ref", "--create-reflog", "-m", message, SnapshotRef, strings.TrimSpace(sha))
+}
+
+// GetSnapshots returns the recorded snapshots, newest first
+func (c *GitCommand) GetSnapshots() []*Snapshot {
+	output, err := c.OSCommand.RunCommandWithOutput("git log -g --pretty=format:%%H|%%ct|%%s %s", SnapshotRef)
+	if err != nil {
+		// the ref won't exist until the first snapshot is taken
+		return nil
+	}
+
+	snapshots := []*Snapshot{}
+	for _, line := range utils.SplitLines(output) {
+		split := strings.SplitN(line, "|", 3)
+		if len(split) != 3 {
+			continue
+		}
+		timestamp, err := strconv.ParseInt(split[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, &Snapshot{
+			Sha:           split[0],
+			UnixTimestamp: timestamp,
+			Message:       split[2],
+		})
+	}
+
+	return snapshots
+}
+
+// RestoreSnapshot overwrites the working tree's files with their contents in
+// the snapshot. Files created since the snapshot was taken are left alone,
+// which is what --overlay is for: without it git restore deletes any tracked
+// file the snapshot doesn't have
+func (c *GitCommand) RestoreSnapshot(sha string) error {
+	return c.OSCommand.RunCommand("git restore --overlay --source=%s --worktree -- .", sha)
+}
@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandCreateSnapshot is a function.
+func TestGitCommandCreateSnapshot(t *testing.T) {
+	type scenario struct {
+		testName       string
+		swappers       []*test.CommandSwapper
+		expectedResult bool
+	}
+
+	scenarios := []scenario{
+		{
+			"new changes since the last snapshot",
+			[]*test.CommandSwapper{
+				{Expect: "git read-tree HEAD", Replace: "echo"},
+				{Expect: "git add -A", Replace: "echo"},
+				{Expect: "git write-tree", Replace: "echo 1111111111111111111111111111111111111111"},
+				{Expect: "git rev-parse -q --verify refs/lazygit/snapshots^{tree}", Replace: "echo 2222222222222222222222222222222222222222"},
+				{Expect: "git rev-parse -q --verify HEAD^{tree}", Replace: "echo 3333333333333333333333333333333333333333"},
+				{Expect: `git commit-tree 1111111111111111111111111111111111111111 -p HEAD -m "snapshot on master"`, Replace: "echo 4444444444444444444444444444444444444444"},
+				{Expect: `git update-ref --create-reflog -m "snapshot on master" refs/lazygit/snapshots 4444444444444444444444444444444444444444`, Replace: "echo"},
+			},
+			true,
+		},
+		{
+			"nothing changed since the last snapshot",
+			[]*test.CommandSwapper{
+				{Expect: "git read-tree HEAD", Replace: "echo"},
+				{Expect: "git add -A", Replace: "echo"},
+				{Expect: "git write-tree", Replace: "echo 1111111111111111111111111111111111111111"},
+				{Expect: "git rev-parse -q --verify refs/lazygit/snapshots^{tree}", Replace: "echo 1111111111111111111111111111111111111111"},
+			},
+			false,
+		},
+		{
+			"no uncommitted changes and no previous snapshot",
+			[]*test.CommandSwapper{
+				{Expect: "git read-tree HEAD", Replace: "echo"},
+				{Expect: "git add -A", Replace: "echo"},
+				{Expect: "git write-tree", Replace: "echo 1111111111111111111111111111111111111111"},
+				{Expect: "git rev-parse -q --verify refs/lazygit/snapshots^{tree}", Replace: "false"},
+				{Expect: "git rev-parse -q --verify HEAD^{tree}", Replace: "echo 1111111111111111111111111111111111111111"},
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.command = test.CreateMockCommand(t, s.swappers)
+
+			created, err := gitCmd.CreateSnapshot("snapshot on master")
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedResult, created)
+		})
+	}
+}
+
+// TestGitCommandGetSnapshots is a function.
+func TestGitCommandGetSnapshots(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git log -g --pretty=format:%H|%ct|%s refs/lazygit/snapshots",
+			Replace: "echo '4444444444444444444444444444444444444444|1600000100|snapshot on master\n5555555555555555555555555555555555555555|1600000000|snapshot on feature|with a pipe'",
+		},
+	})
+
+	assert.EqualValues(t, []*Snapshot{
+		{Sha: "4444444444444444444444444444444444444444", UnixTimestamp: 1600000100, Message: "snapshot on master"},
+		{Sha: "5555555555555555555555555555555555555555", UnixTimestamp: 1600000000, Message: "snapshot on feature|with a pipe"},
+	}, gitCmd.GetSnapshots())
+}
@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// StandupBranch is a branch along with the subjects of the commits the user
+// made on it, newest first
+type StandupBranch struct {
+	Name     string
+	Subjects []string
+}
+
+// StandupActivity is what the user got up to in one repo
+type StandupActivity struct {
+	RepoName string
+	Branches []*StandupBranch
+	// SwitchedTo lists the branches the user checked out, in the order they
+	// first did so, which catches work that didn't result in a commit
+	SwitchedTo []string
+}
+
+// IsEmpty tells us whether there's anything to report
+func (a *StandupActivity) IsEmpty() bool {
+	return len(a.Branches) == 0 && len(a.SwitchedTo) == 0
+}
+
+// StandupSince turns a date in any format git understands, e.g. 'yesterday' or
+// 'last friday', into a unix timestamp
+func (c *GitCommand) StandupSince(since string) (int64, error) {
+	output, err := c.OSCommand.RunCommandArgsWithOutput("git", "rev-parse", "--since="+since)
+	if err != nil {
+		return 0, err
+	}
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(output), "--max-age="), 10, 64)
+	if err != nil {
+		return 0, errors.New(c.Tr.TemplateLocalize("InvalidStandupDate", map[string]interface{}{"date": since}))
+	}
+	return timestamp, nil
+}
+
+// GetStandupActivity collects the commits the author made on any local branch
+// of the repo at repoPath since the given time, along with the branches they
+// switched to
+func (c *GitCommand) GetStandupActivity(repoPath string, since int64, author string) (*StandupActivity, error) {
+	if strings.HasPrefix(repoPath, "~/") {
+		home, _ := os.UserHomeDir()
+		repoPath = filepath.Join(home, repoPath[2:])
+	}
+	absPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+
+	logOutput, err := c.OSCommand.RunCommandArgsWithOutput("git", "-C", repoPath, "log", "--branches", "--no-merges", "--author="+author, fmt.Sprintf("--max-age=%d", since), "--format=%h%x09%S%x09%s")
+	if err != nil {
+		return nil, err
+	}
+	// a repo with no commits has no reflog either, which is fine
+	reflogOutput, _ := c.OSCommand.RunCommandArgsWithOutput("git", "-C", repoPath, "log", "-g", "--date=unix", "--format=%gs%x09%gd", "HEAD")
+
+	return &StandupActivity{
+		RepoName:   filepath.Base(absPath),
+		Branches:   parseStandupLog(logOutput),
+		SwitchedTo: parseBranchSwitches(reflogOutput, since),
+	}, nil
+}
+
+// parseStandupLog groups the lines of a git log in the format
+// '<short sha>\t<branch it was reached from>\t<subject>' by branch
+func parseStandupLog(output string) []*StandupBranch {
+	branches := []*StandupBranch{}
+	byName := map[string]*StandupBranch{}
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 3 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "refs/heads/")
+		branch, ok := byName[name]
+		if !ok {
+			branch = &StandupBranch{Name: name}
+			byName[name] = branch
+			branches = append(branches, branch)
+		}
+		branch.Subjects = append(branch.Subjects, fmt.Sprintf("%s (%s)", fields[2], fields[0]))
+	}
+	return branches
+}
+
+// matches e.g. 'HEAD@{1592000000}', the selector of a reflog entry when
+// listed with --date=unix
+var reflogTimestampRegexp = regexp.MustCompile(`@\{(\d+)\}$`)
+
+// parseBranchSwitches finds the branches checked out since the given time in
+// a reflog listed newest first as '<reflog subject>\t<selector>'
+func parseBranchSwitches(output string, since int64) []string {
+	switches := []string{}
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.SplitN(line, "\t", 2)
+		if len(fields) < 2 || !strings.HasPrefix(fields[0], "checkout: moving from ") {
+			continue
+		}
+		match := reflogTimestampRegexp.FindStringSubmatch(fields[1])
+		if match == nil {
+			continue
+		}
+		if timestamp, _ := strconv.ParseInt(match[1], 10, 64); timestamp < since {
+			// entries are newest first, so the rest are too old as well
+			break
+		}
+		if split := strings.Split(fields[0], " to "); len(split) == 2 {
+			switches = append(switches, split[1])
+		}
+	}
+
+	ordered := []string{}
+	seen := map[string]bool{}
+	for i := len(switches) - 1; i >= 0; i-- {
+		if !seen[switches[i]] {
+			seen[switches[i]] = true
+			ordered = append(ordered, switches[i])
+		}
+	}
+	return ordered
+}
+
+// BuildStandupReport renders a plain text report of the user's activity across
+// repos, ready to be pasted into a chat
+func BuildStandupReport(title string, activities []*StandupActivity) string {
+	lines := []string{title}
+	for _, activity := range activities {
+		if activity.IsEmpty() {
+			continue
+		}
+		lines = append(lines, "", activity.RepoName)
+		for _, branch := range activity.Branches {
+			lines = append(lines, "  "+branch.Name)
+			for _, subject := range branch.Subjects {
+				lines = append(lines, "    - "+subject)
+			}
+		}
+		if len(activity.SwitchedTo) > 0 {
+			lines = append(lines, "  branches visited: "+strings.Join(activity.SwitchedTo, ", "))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
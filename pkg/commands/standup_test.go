@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandStandupSince is a function.
+func TestGitCommandStandupSince(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"rev-parse", "--since=yesterday.midnight"}, args)
+		return exec.Command("echo", "--max-age=1600000000")
+	})
+
+	since, err := gitCmd.StandupSince("yesterday.midnight")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1600000000, since)
+}
+
+// TestParseStandupLog is a function.
+func TestParseStandupLog(t *testing.T) {
+	output := "aaa\trefs/heads/feature\tAdd flag\nbbb\trefs/heads/master\tFix typo\nccc\trefs/heads/feature\tStart feature\n"
+
+	assert.EqualValues(t, []*StandupBranch{
+		{Name: "feature", Subjects: []string{"Add flag (aaa)", "Start feature (ccc)"}},
+		{Name: "master", Subjects: []string{"Fix typo (bbb)"}},
+	}, parseStandupLog(output))
+}
+
+// TestParseBranchSwitches is a function.
+func TestParseBranchSwitches(t *testing.T) {
+	output := `checkout: moving from feature to master	HEAD@{300}
+commit: Add flag	HEAD@{250}
+checkout: moving from master to feature	HEAD@{200}
+checkout: moving from hotfix to master	HEAD@{150}
+checkout: moving from master to hotfix	HEAD@{50}
+`
+
+	assert.EqualValues(t, []string{"master", "feature"}, parseBranchSwitches(output, 100))
+}
+
+// TestBuildStandupReport is a function.
+func TestBuildStandupReport(t *testing.T) {
+	activities := []*StandupActivity{
+		{
+			RepoName: "lazygit",
+			Branches: []*StandupBranch{
+				{Name: "feature", Subjects: []string{"Add flag (aaa)"}},
+			},
+			SwitchedTo: []string{"master", "feature"},
+		},
+		{RepoName: "idle", Branches: []*StandupBranch{}, SwitchedTo: []string{}},
+	}
+
+	expected := `Since yesterday
+
+lazygit
+  feature
+    - Add flag (aaa)
+  branches visited: master, feature
+`
+
+	assert.EqualValues(t, expected, BuildStandupReport("Since yesterday", activities))
+}
@@ -1,13 +1,37 @@
 package commands
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 // StashEntry : A git stash entry
 type StashEntry struct {
-	Index int
-	Name  string
+	Index         int
+	Name          string
+	UnixTimestamp int64
 }
 
 func (s *StashEntry) RefName() string {
 	return fmt.Sprintf("stash@{%d}", s.Index)
 }
+
+var stashNameRegexp = regexp.MustCompile(`(?:WIP on|On) ([^:]+): (.*)$`)
+
+// Message is the stash's message without git's 'On <branch>: ' prefix
+func (s *StashEntry) Message() string {
+	match := stashNameRegexp.FindStringSubmatch(s.Name)
+	if match == nil {
+		return s.Name
+	}
+	return match[2]
+}
+
+// Branch is the branch that was checked out when the stash was created, if known
+func (s *StashEntry) Branch() string {
+	match := stashNameRegexp.FindStringSubmatch(s.Name)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
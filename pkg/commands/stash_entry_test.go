@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStashEntryMessageAndBranch is a function.
+func TestStashEntryMessageAndBranch(t *testing.T) {
+	type scenario struct {
+		testName        string
+		name            string
+		expectedMessage string
+		expectedBranch  string
+	}
+
+	scenarios := []scenario{
+		{
+			"stash with a message",
+			"On master: fix the flux capacitor",
+			"fix the flux capacitor",
+			"master",
+		},
+		{
+			"stash without a message",
+			"WIP on feature/test: 48d2ac4 add tests",
+			"48d2ac4 add tests",
+			"feature/test",
+		},
+		{
+			"line from git stash list",
+			"stash@{1}: On develop: something: with colons",
+			"something: with colons",
+			"develop",
+		},
+		{
+			"stash stored with a custom message",
+			"my renamed stash",
+			"my renamed stash",
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			stashEntry := &StashEntry{Name: s.name}
+			assert.EqualValues(t, s.expectedMessage, stashEntry.Message())
+			assert.EqualValues(t, s.expectedBranch, stashEntry.Branch())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// LastFetchTime is when the repo was last fetched, going by when FETCH_HEAD
+// was last written. It's the zero time if the repo has never been fetched
+func (c *GitCommand) LastFetchTime() time.Time {
+	info, err := os.Stat(filepath.Join(c.DotGitDir, "FETCH_HEAD"))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// matches the runs of spaces left behind by empty segments
+var repeatedSpacesRegexp = regexp.MustCompile(` {2,}`)
+
+// RenderStatusBar fills in the {{segment}} placeholders of a status bar
+// template. Like in a shell prompt, an empty segment takes the space next to
+// it with it, rather than leaving a gap
+func RenderStatusBar(template string, segments map[string]string) string {
+	rendered := utils.ResolvePlaceholderString(template, segments)
+	return strings.TrimSpace(repeatedSpacesRegexp.ReplaceAllString(rendered, " "))
+}
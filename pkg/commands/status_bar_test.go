@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandLastFetchTime is a function.
+func TestGitCommandLastFetchTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-fetch-head")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+	assert.True(t, gitCmd.LastFetchTime().IsZero())
+
+	fetchedAt := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	fetchHead := filepath.Join(dir, "FETCH_HEAD")
+	assert.NoError(t, ioutil.WriteFile(fetchHead, []byte{}, 0644))
+	assert.NoError(t, os.Chtimes(fetchHead, fetchedAt, fetchedAt))
+	assert.True(t, gitCmd.LastFetchTime().Equal(fetchedAt))
+}
+
+// TestRenderStatusBar is a function.
+func TestRenderStatusBar(t *testing.T) {
+	type scenario struct {
+		testName string
+		template string
+		expected string
+	}
+
+	segments := map[string]string{
+		"branch":      "master",
+		"aheadBehind": "↑1↓0",
+		"state":       "",
+		"lastFetch":   "5m",
+	}
+
+	scenarios := []scenario{
+		{
+			"all segments filled in",
+			"{{branch}} {{aheadBehind}} fetched {{lastFetch}} ago",
+			"master ↑1↓0 fetched 5m ago",
+		},
+		{
+			"empty segment in the middle",
+			"{{branch}} {{state}} {{aheadBehind}}",
+			"master ↑1↓0",
+		},
+		{
+			"empty segment at the end",
+			"{{branch}} {{state}}",
+			"master",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, RenderStatusBar(s.template, segments))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetSubmodulePaths returns the paths of the submodules configured in .gitmodules
+func (c *GitCommand) GetSubmodulePaths() []string {
+	if exists, _ := c.OSCommand.FileExists(".gitmodules"); !exists {
+		return nil
+	}
+
+	output, err := c.OSCommand.RunCommandWithOutput("git config --file .gitmodules --get-regexp path")
+	if err != nil {
+		return nil
+	}
+
+	paths := []string{}
+	for _, line := range utils.SplitLines(output) {
+		// each line looks like 'submodule.<name>.path <path>'
+		split := strings.SplitN(line, " ", 2)
+		if len(split) == 2 {
+			paths = append(paths, split[1])
+		}
+	}
+	return paths
+}
+
+// setSubmoduleStatuses marks which of the given files are submodules, and what
+// kind of change each one has. Plain porcelain status just reports a submodule
+// as modified, so we ask for porcelain v2 which distinguishes a moved commit
+// from changes inside the submodule's working tree
+func (c *GitCommand) setSubmoduleStatuses(files []*File, submodulePaths []string) {
+	if len(submodulePaths) == 0 {
+		return
+	}
+
+	submoduleFiles := map[string]*File{}
+	args := []string{"git", "status", "--porcelain=v2", "--"}
+	for _, file := range files {
+		if utils.IncludesString(submodulePaths, file.Name) {
+			submoduleFiles[file.Name] = file
+			args = append(args, file.Name)
+		}
+	}
+	if len(submoduleFiles) == 0 {
+		return
+	}
+
+	output, err := c.OSCommand.RunCommandArgsWithOutput(args...)
+	if err != nil {
+		c.Log.Error(err)
+		return
+	}
+
+	for _, line := range utils.SplitLines(output) {
+		// ordinary changed entries look like
+		// '1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>'
+		// where <sub> is 'S<c><m><u>' for submodules
+		split := strings.SplitN(line, " ", 9)
+		if len(split) != 9 || split[0] != "1" || !strings.HasPrefix(split[2], "S") {
+			continue
+		}
+		file, ok := submoduleFiles[c.OSCommand.Unquote(split[8])]
+		if !ok {
+			continue
+		}
+		sub := split[2]
+		file.IsSubmodule = true
+		file.SubmoduleHasNewCommits = sub[1] == 'C'
+		file.SubmoduleHasModifiedContent = sub[2] == 'M'
+		file.SubmoduleHasUntrackedContent = sub[3] == 'U'
+	}
+}
+
+// UpdateSubmodule checks out the commit the superproject records for the submodule
+func (c *GitCommand) UpdateSubmodule(path string) error {
+	return c.OSCommand.RunCommandArgs("git", "submodule", "update", "--init", "--", path)
+}
+
+// StashSubmoduleChanges stashes the changes inside the submodule's own working
+// tree, including untracked files
+func (c *GitCommand) StashSubmoduleChanges(path string) error {
+	return c.OSCommand.RunCommandArgs("git", "-C", path, "stash", "--include-untracked")
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandSetSubmoduleStatuses is a function.
+func TestGitCommandSetSubmoduleStatuses(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git status --porcelain=v2 -- vendor/lib 'my sub'",
+			Replace: "echo '1 .M SC.. 160000 160000 160000 aaaa bbbb vendor/lib\n1 .M S.MU 160000 160000 160000 cccc cccc my sub'",
+		},
+	})
+
+	file := &File{Name: "file.txt"}
+	lib := &File{Name: "vendor/lib"}
+	sub := &File{Name: "my sub"}
+	gitCmd.setSubmoduleStatuses([]*File{file, lib, sub}, []string{"vendor/lib", "my sub", "not/changed"})
+
+	assert.EqualValues(t, &File{Name: "file.txt"}, file)
+	assert.EqualValues(t, &File{Name: "vendor/lib", IsSubmodule: true, SubmoduleHasNewCommits: true}, lib)
+	assert.EqualValues(t, &File{Name: "my sub", IsSubmodule: true, SubmoduleHasModifiedContent: true, SubmoduleHasUntrackedContent: true}, sub)
+}
+
+// TestGitCommandSetSubmoduleStatusesNoSubmodules is a function.
+func TestGitCommandSetSubmoduleStatusesNoSubmodules(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.Fail(t, "no command should be run when there are no changed submodules")
+		return exec.Command("echo")
+	}
+
+	file := &File{Name: "file.txt"}
+	gitCmd.setSubmoduleStatuses([]*File{file}, []string{"vendor/lib"})
+	gitCmd.setSubmoduleStatuses([]*File{file}, nil)
+
+	assert.False(t, file.IsSubmodule)
+}
+
+// TestGitCommandUpdateSubmodule is a function.
+func TestGitCommandUpdateSubmodule(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"submodule", "update", "--init", "--", "vendor/lib"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.UpdateSubmodule("vendor/lib"))
+}
+
+// TestGitCommandStashSubmoduleChanges is a function.
+func TestGitCommandStashSubmoduleChanges(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"-C", "vendor/lib", "stash", "--include-untracked"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.StashSubmoduleChanges("vendor/lib"))
+}
@@ -3,4 +3,11 @@ package commands
 // Tag : A git tag
 type Tag struct {
 	Name string
+	// ObjectSha is the sha of the tag object for annotated tags, or of the
+	// commit for lightweight ones
+	ObjectSha string
+	Signed    bool
+	// Verification is only set for signed tags: one of "" (not checked yet),
+	// "good", "bad" or "unknownKey" (signed with a key gpg doesn't have)
+	Verification string
 }
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// TagSignature is what for-each-ref tells us about a tag without verifying it
+type TagSignature struct {
+	ObjectSha string
+	Signed    bool
+}
+
+// GetTagSignatures finds out which tags carry a signature, keyed by tag name.
+// This doesn't run gpg so it's cheap enough to do on every refresh
+func (c *GitCommand) GetTagSignatures() map[string]TagSignature {
+	signatures := map[string]TagSignature{}
+	format := "%(refname:short)%09%(objectname)%09%(if)%(contents:signature)%(then)signed%(end)"
+	output, err := c.OSCommand.RunCommandWithOutput("git for-each-ref --format=%s refs/tags", format)
+	if err != nil {
+		return signatures
+	}
+	for _, line := range utils.SplitLines(output) {
+		fields := strings.Split(line, "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		signatures[fields[0]] = TagSignature{ObjectSha: fields[1], Signed: fields[2] == "signed"}
+	}
+	return signatures
+}
+
+// SetTagSignatures fills in each tag's object sha and whether it's signed
+func SetTagSignatures(tags []*Tag, signatures map[string]TagSignature) {
+	for _, tag := range tags {
+		signature := signatures[tag.Name]
+		tag.ObjectSha = signature.ObjectSha
+		tag.Signed = signature.Signed
+	}
+}
+
+// VerifyTag checks a tag's signature, returning one of "good", "bad" or
+// "unknownKey", going by the status gpg reports rather than by whether it
+// succeeded, because it fails just the same when it doesn't have the key
+func (c *GitCommand) VerifyTag(tagName string) string {
+	output, _ := c.OSCommand.RunCommandArgsWithOutput("git", "verify-tag", "--raw", tagName)
+	return parseTagVerification(output)
+}
+
+// parseTagVerification makes sense of the '[GNUPG:] <keyword> ...' status
+// lines gpg writes when git verify-tag is run with --raw
+func parseTagVerification(output string) string {
+	keywords := map[string]bool{}
+	for _, line := range utils.SplitLines(output) {
+		if !strings.HasPrefix(line, "[GNUPG:] ") {
+			continue
+		}
+		if fields := strings.Fields(strings.TrimPrefix(line, "[GNUPG:] ")); len(fields) > 0 {
+			keywords[fields[0]] = true
+		}
+	}
+	switch {
+	case keywords["BADSIG"]:
+		return "bad"
+	case keywords["NO_PUBKEY"]:
+		return "unknownKey"
+	case keywords["GOODSIG"] && keywords["VALIDSIG"]:
+		return "good"
+	default:
+		return "bad"
+	}
+}
+
+// TagVerificationOutput is what git verify-tag has to say about a tag's
+// signature, for showing to the user
+func (c *GitCommand) TagVerificationOutput(tagName string) string {
+	output, _ := c.OSCommand.RunCommandArgsWithOutput("git", "verify-tag", tagName)
+	return strings.TrimSpace(output)
+}
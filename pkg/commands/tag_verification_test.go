@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetTagSignatures is a function.
+func TestGitCommandGetTagSignatures(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"for-each-ref", "--format=%(refname:short)%09%(objectname)%09%(if)%(contents:signature)%(then)signed%(end)", "refs/tags"}, args)
+		return exec.Command("printf", "light\\tc804c9f\\t\\nv1\\t6339435\\t\\nv2\\tc09d038\\tsigned\\n")
+	})
+
+	tags := []*Tag{{Name: "light"}, {Name: "v1"}, {Name: "v2"}, {Name: "v3"}}
+	SetTagSignatures(tags, gitCmd.GetTagSignatures())
+
+	assert.EqualValues(t, []*Tag{
+		{Name: "light", ObjectSha: "c804c9f"},
+		{Name: "v1", ObjectSha: "6339435"},
+		{Name: "v2", ObjectSha: "c09d038", Signed: true},
+		{Name: "v3"},
+	}, tags)
+}
+
+// TestGitCommandVerifyTag is a function.
+func TestGitCommandVerifyTag(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, []string{"verify-tag", "--raw", "v2"}, args)
+		return exec.Command("sh", "-c", "echo '[GNUPG:] BADSIG 1234ABCD T <t@t>' >&2; exit 1")
+	})
+
+	assert.EqualValues(t, "bad", gitCmd.VerifyTag("v2"))
+}
+
+// TestParseTagVerification is a function.
+func TestParseTagVerification(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"good signature",
+			"[GNUPG:] NEWSIG\n[GNUPG:] GOODSIG 1234ABCD T <t@t>\n[GNUPG:] VALIDSIG 5678 2020-01-01\n",
+			"good",
+		},
+		{
+			"bad signature",
+			"[GNUPG:] NEWSIG\n[GNUPG:] BADSIG 1234ABCD T <t@t>\n",
+			"bad",
+		},
+		{
+			"missing public key",
+			"[GNUPG:] NEWSIG\n[GNUPG:] ERRSIG 1234ABCD 1 8 00 1577836800 9\n[GNUPG:] NO_PUBKEY 1234ABCD\n",
+			"unknownKey",
+		},
+		{
+			"no status at all",
+			"error: no signature found\n",
+			"bad",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, parseTagVerification(s.output))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TransferStats sums up what a fetch, pull or push sent or received, going by
+// the progress git reports when run with --progress
+type TransferStats struct {
+	// Operation is 'fetch', 'pull' or 'push'
+	Operation string
+	// Remote is the name of the remote, or its url if it isn't one of ours
+	Remote   string
+	Start    time.Time
+	Duration time.Duration
+	// Objects is how many objects were sent or received, and Bytes how much
+	// that came to over the wire, or 0 if git didn't say
+	Objects int
+	Bytes   int64
+	// CompressedObjects is how many objects were compressed for the transfer,
+	// by us for a push and by the remote otherwise. Deltas is how many of the
+	// objects were sent as deltas against others, which is where most of the
+	// saving comes from, and Reused how many went as they were already packed
+	CompressedObjects int
+	Deltas            int
+	Reused            int
+}
+
+var (
+	// e.g. 'Receiving objects: 100% (10/10), 2.34 KiB | 1.17 MiB/s, done.'
+	transferredObjectsRegexp = regexp.MustCompile(`(?:Receiving|Writing|Unpacking) objects: +\d+% \((\d+)/(\d+)\)(?:, ([\d.]+) (bytes|KiB|MiB|GiB))?`)
+	// e.g. 'remote: Compressing objects: 100% (7/7), done.'
+	compressedObjectsRegexp = regexp.MustCompile(`Compressing objects: +\d+% \((\d+)/(\d+)\)`)
+	// e.g. 'Total 10 (delta 3), reused 0 (delta 0), pack-reused 0'
+	transferTotalRegexp = regexp.MustCompile(`Total (\d+) \(delta (\d+)\), reused (\d+)`)
+	// the 'From <url>' or 'To <url>' line naming the remote
+	transferUrlRegexp = regexp.MustCompile(`(?m)^(?:From|To) (\S+)\s*$`)
+	// the lines of git's progress, which say nothing once it's done
+	progressLineRegexp = regexp.MustCompile(`^(?:remote: )?(?:\w+ (?:objects|deltas): |Total \d+ \(delta \d+\))`)
+)
+
+var byteUnits = map[string]float64{
+	"bytes": 1,
+	"KiB":   1 << 10,
+	"MiB":   1 << 20,
+	"GiB":   1 << 30,
+}
+
+// ParseTransferStats reads what was transferred from git's progress output,
+// returning nil if nothing was, e.g. because everything was up to date
+func ParseTransferStats(output string) *TransferStats {
+	output = collapseProgress(output)
+	stats := &TransferStats{}
+
+	if match := lastMatch(transferredObjectsRegexp, output); match != nil {
+		stats.Objects, _ = strconv.Atoi(match[2])
+		if value, err := strconv.ParseFloat(match[3], 64); err == nil {
+			stats.Bytes = int64(value * byteUnits[match[4]])
+		}
+	}
+	if match := lastMatch(compressedObjectsRegexp, output); match != nil {
+		stats.CompressedObjects, _ = strconv.Atoi(match[2])
+	}
+	if match := lastMatch(transferTotalRegexp, output); match != nil {
+		total, _ := strconv.Atoi(match[1])
+		if stats.Objects == 0 {
+			stats.Objects = total
+		}
+		stats.Deltas, _ = strconv.Atoi(match[2])
+		stats.Reused, _ = strconv.Atoi(match[3])
+	}
+	if match := transferUrlRegexp.FindStringSubmatch(output); match != nil {
+		stats.Remote = match[1]
+	}
+
+	if stats.Objects == 0 {
+		return nil
+	}
+	return stats
+}
+
+func lastMatch(re *regexp.Regexp, text string) []string {
+	matches := re.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	return matches[len(matches)-1]
+}
+
+// collapseProgress leaves just the last of the updates git writes over each
+// other with carriage returns, so each progress meter says where it ended up
+func collapseProgress(output string) string {
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		lines[i] = line[strings.LastIndex(line, "\r")+1:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// withoutProgress drops git's progress from its output, leaving whatever else
+// it had to say, like why it failed
+func withoutProgress(output string) string {
+	lines := []string{}
+	for _, line := range strings.Split(collapseProgress(output), "\n") {
+		if !progressLineRegexp.MatchString(line) {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Size is how much was transferred, e.g. '4.5 KB', or '?' if git didn't say
+func (s *TransferStats) Size() string {
+	if s.Bytes <= 0 {
+		return "?"
+	}
+	return formatSize(s.Bytes)
+}
+
+// String is e.g. 'push 1.2s: 12 objects, 4.5 KB, 8 compressed, 3 deltas, 0 reused'
+func (s *TransferStats) String() string {
+	return fmt.Sprintf("%s %s: %d objects, %s, %d compressed, %d deltas, %d reused", s.Operation, s.Duration.Round(time.Millisecond), s.Objects, s.Size(), s.CompressedObjects, s.Deltas, s.Reused)
+}
+
+// transferStats makes sense of a fetch, pull or push which has just finished,
+// adding it to the remote's transfer log if anything was transferred
+func (c *GitCommand) transferStats(operation string, start time.Time, output string) *TransferStats {
+	stats := ParseTransferStats(output)
+	if stats == nil {
+		return nil
+	}
+	stats.Operation = operation
+	stats.Start = start
+	stats.Duration = time.Since(start)
+	if remoteName, ok := c.remoteNameForUrl(stats.Remote); ok {
+		stats.Remote = remoteName
+		if err := c.appendTransferLog(stats); err != nil {
+			c.Log.Warn(err)
+		}
+	}
+	return stats
+}
+
+// remoteNameForUrl returns the name of the remote with the given url, as git
+// writes it in 'From <url>' or 'To <url>', if we have one. That's the url
+// after any insteadOf rewrites, so that's what we compare it with
+func (c *GitCommand) remoteNameForUrl(url string) (string, bool) {
+	if url == "" || c.Repo == nil {
+		return "", false
+	}
+	remotes, err := c.Repo.Remotes()
+	if err != nil {
+		return "", false
+	}
+	for _, remote := range remotes {
+		name := remote.Config().Name
+		fetchURL, pushURLs, err := c.GetRemoteURLs(name)
+		if err != nil {
+			continue
+		}
+		for _, remoteUrl := range append([]string{fetchURL}, pushURLs...) {
+			if normaliseRemoteUrl(remoteUrl) == normaliseRemoteUrl(url) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// transferLogPath is where we keep the transfer log of a remote, in the git
+// dir alongside the remote's refs
+func (c *GitCommand) transferLogPath(remoteName string) string {
+	return filepath.Join(c.commonGitDir(), "lazygit-transfers", filepath.FromSlash(remoteName)+".log")
+}
+
+// appendTransferLog adds a transfer to its remote's log, keeping just the last
+// git.transferLog.size of them
+func (c *GitCommand) appendTransferLog(stats *TransferStats) error {
+	size := c.Config.GetUserConfig().GetInt("git.transferLog.size")
+	if size <= 0 {
+		return nil
+	}
+
+	entries := append(c.TransferLog(stats.Remote), stats.Start.Format("2006-01-02 15:04:05")+" "+stats.String())
+	if len(entries) > size {
+		entries = entries[len(entries)-size:]
+	}
+
+	path := c.transferLogPath(stats.Remote)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return WrapError(err)
+	}
+	return WrapError(ioutil.WriteFile(path, []byte(strings.Join(entries, "\n")+"\n"), 0644))
+}
+
+// TransferLog returns the remote's log of transfers, oldest first, one line
+// per fetch, pull or push
+func (c *GitCommand) TransferLog(remoteName string) []string {
+	content, err := ioutil.ReadFile(c.transferLogPath(remoteName))
+	if err != nil || strings.TrimSpace(string(content)) == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(content)), "\n")
+}
@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	gogitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseTransferStats is a function.
+func TestParseTransferStats(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		expected *TransferStats
+	}
+
+	scenarios := []scenario{
+		{
+			"fetch",
+			"remote: Enumerating objects: 10, done.\n" +
+				"remote: Counting objects:  50% (5/10)\rremote: Counting objects: 100% (10/10), done.\n" +
+				"remote: Compressing objects: 100% (7/7), done.\n" +
+				"remote: Total 10 (delta 3), reused 8 (delta 2), pack-reused 0\n" +
+				"Unpacking objects:  40% (4/10)\rUnpacking objects: 100% (10/10), 2.50 KiB | 1.25 MiB/s, done.\n" +
+				"From github.com:user/repo\n" +
+				"   abc1234..def5678  master     -> origin/master\n",
+			&TransferStats{Remote: "github.com:user/repo", Objects: 10, Bytes: 2560, CompressedObjects: 7, Deltas: 3, Reused: 8},
+		},
+		{
+			"push",
+			"Enumerating objects: 5, done.\n" +
+				"Counting objects: 100% (5/5), done.\n" +
+				"Delta compression using up to 8 threads\n" +
+				"Compressing objects: 100% (3/3), done.\n" +
+				"Writing objects: 100% (3/3), 312 bytes | 312.00 KiB/s, done.\n" +
+				"Total 3 (delta 2), reused 0 (delta 0), pack-reused 0\n" +
+				"To https://github.com/user/repo.git\n" +
+				"   abc1234..def5678  master -> master\n",
+			&TransferStats{Remote: "https://github.com/user/repo.git", Objects: 3, Bytes: 312, CompressedObjects: 3, Deltas: 2},
+		},
+		{
+			"nothing to push",
+			"Everything up-to-date\n",
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, ParseTransferStats(s.output))
+		})
+	}
+}
+
+// TestWithoutProgress is a function.
+func TestWithoutProgress(t *testing.T) {
+	output := "Counting objects: 100% (5/5), done.\n" +
+		"Writing objects:  33% (1/3)\rWriting objects: 100% (3/3), 312 bytes | 312.00 KiB/s, done.\n" +
+		"Total 3 (delta 2), reused 0 (delta 0), pack-reused 0\n" +
+		"To github.com:user/repo.git\n" +
+		" ! [rejected]        master -> master (fetch first)\n" +
+		"error: failed to push some refs to 'github.com:user/repo.git'"
+
+	assert.EqualValues(t, "To github.com:user/repo.git\n"+
+		" ! [rejected]        master -> master (fetch first)\n"+
+		"error: failed to push some refs to 'github.com:user/repo.git'", withoutProgress(output))
+}
+
+// TestGitCommandAppendTransferLog is a function.
+func TestGitCommandAppendTransferLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-transfer-log")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+	gitCmd.Config.GetUserConfig().Set("git.transferLog.size", 2)
+
+	assert.Len(t, gitCmd.TransferLog("origin"), 0)
+
+	start := time.Date(2020, 3, 1, 12, 0, 0, 0, time.Local)
+	for i, operation := range []string{"fetch", "pull", "push"} {
+		assert.NoError(t, gitCmd.appendTransferLog(&TransferStats{
+			Operation: operation,
+			Remote:    "origin",
+			Start:     start.Add(time.Duration(i) * time.Minute),
+			Duration:  1500 * time.Millisecond,
+			Objects:   i + 1,
+			Bytes:     2048,
+		}))
+	}
+
+	assert.EqualValues(t, []string{
+		"2020-03-01 12:01:00 pull 1.5s: 2 objects, 2.0 KB, 0 compressed, 0 deltas, 0 reused",
+		"2020-03-01 12:02:00 push 1.5s: 3 objects, 2.0 KB, 0 compressed, 0 deltas, 0 reused",
+	}, gitCmd.TransferLog("origin"))
+	assert.Len(t, gitCmd.TransferLog("upstream"), 0)
+}
+
+// TestGitCommandRemoteNameForUrl is a function.
+func TestGitCommandRemoteNameForUrl(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-remote-name")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	repo, err := gogit.PlainInit(dir, false)
+	assert.NoError(t, err)
+	// 'gh:' is rewritten by a url.<base>.insteadOf, which git applies before
+	// writing the url it fetched from or pushed to
+	_, err = repo.CreateRemote(&gogitconfig.RemoteConfig{Name: "origin", URLs: []string{"gh:user/repo"}})
+	assert.NoError(t, err)
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Repo = repo
+	gitCmd.OSCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		if args[len(args)-2] == "--all" {
+			return exec.Command("echo", "git@github.com:user/repo-push.git")
+		}
+		return exec.Command("echo", "git@github.com:user/repo.git")
+	})
+
+	type scenario struct {
+		testName     string
+		url          string
+		expectedName string
+		expectedOk   bool
+	}
+
+	scenarios := []scenario{
+		{
+			"fetch url after the rewrite",
+			"github.com:user/repo",
+			"origin",
+			true,
+		},
+		{
+			"push url",
+			"github.com:user/repo-push.git",
+			"origin",
+			true,
+		},
+		{
+			"url of a repo we have no remote for",
+			"github.com:someone/else",
+			"",
+			false,
+		},
+		{
+			"no url",
+			"",
+			"",
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			name, ok := gitCmd.remoteNameForUrl(s.url)
+			assert.EqualValues(t, s.expectedName, name)
+			assert.EqualValues(t, s.expectedOk, ok)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Discard removes a file or directory the user has discarded from the working
+// tree. If git.trashDiscardedFiles is on it goes to the trash instead, where
+// it can be recovered from
+func (c *OSCommand) Discard(path string) error {
+	if !c.Config.GetUserConfig().GetBool("git.trashDiscardedFiles") {
+		return c.Remove(path)
+	}
+	return c.MoveToTrash(path)
+}
+
+// MoveToTrash moves a file or directory to the trash: the Finder's trash on
+// macOS, the Recycle Bin on Windows and the freedesktop.org trash elsewhere,
+// which is the one file managers like Nautilus and Dolphin use
+func (c *OSCommand) MoveToTrash(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	switch c.Platform.os {
+	case "darwin":
+		// going through the Finder means 'Put Back' works
+		script := fmt.Sprintf(`tell application "Finder" to delete POSIX file "%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(absPath))
+		return c.RunExecutable(c.command("osascript", "-e", script))
+	case "windows":
+		method := "DeleteFile"
+		if info, err := os.Stat(absPath); err == nil && info.IsDir() {
+			method = "DeleteDirectory"
+		}
+		script := fmt.Sprintf(
+			"Add-Type -AssemblyName Microsoft.VisualBasic; [Microsoft.VisualBasic.FileIO.FileSystem]::%s('%s', 'OnlyErrorDialogs', 'SendToRecycleBin')",
+			method, strings.Replace(absPath, "'", "''", -1),
+		)
+		return c.RunExecutable(c.command("powershell", "-NoProfile", "-NonInteractive", "-Command", script))
+	default:
+		return c.moveToXDGTrash(absPath)
+	}
+}
+
+// moveToXDGTrash follows the freedesktop.org trash spec: the file goes in the
+// trash's files directory and a .trashinfo file of the same name in its info
+// directory records where it came from, so that it can be restored
+func (c *OSCommand) moveToXDGTrash(absPath string) error {
+	dataHome := c.getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = filepath.Join(c.getenv("HOME"), ".local", "share")
+	}
+	trashDir := filepath.Join(dataHome, "Trash")
+	for _, dir := range []string{"files", "info"} {
+		if err := os.MkdirAll(filepath.Join(trashDir, dir), 0700); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	info := fmt.Sprintf(
+		"[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+		(&url.URL{Path: absPath}).EscapedPath(), time.Now().Format("2006-01-02T15:04:05"),
+	)
+
+	// the info file is created first, exclusively, to claim the name
+	base := filepath.Base(absPath)
+	for i := 1; ; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s.%d", base, i)
+		}
+		infoPath := filepath.Join(trashDir, "info", name+".trashinfo")
+		infoFile, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return WrapError(err)
+		}
+		_, err = infoFile.WriteString(info)
+		infoFile.Close()
+		if err == nil {
+			// this fails for files on another filesystem than the trash. We'd
+			// rather say so than delete them after all
+			err = os.Rename(absPath, filepath.Join(trashDir, "files", name))
+		}
+		if err != nil {
+			_ = os.Remove(infoPath)
+			return WrapError(err)
+		}
+		return nil
+	}
+}
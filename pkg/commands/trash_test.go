@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandMoveToXDGTrash is a function.
+func TestOSCommandMoveToXDGTrash(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-trash-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dataHome := filepath.Join(dir, "data")
+	osCommand := NewDummyOSCommand()
+	osCommand.Platform.os = "linux"
+	osCommand.getenv = func(name string) string {
+		if name == "XDG_DATA_HOME" {
+			return dataHome
+		}
+		return ""
+	}
+
+	// two discarded files of the same name get different names in the trash
+	for _, content := range []string{"first", "second"} {
+		path := filepath.Join(dir, "my notes.txt")
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		assert.NoError(t, osCommand.MoveToTrash(path))
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	}
+
+	trashed, err := ioutil.ReadFile(filepath.Join(dataHome, "Trash", "files", "my notes.txt"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "first", string(trashed))
+	trashed, err = ioutil.ReadFile(filepath.Join(dataHome, "Trash", "files", "my notes.txt.2"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, "second", string(trashed))
+
+	info, err := ioutil.ReadFile(filepath.Join(dataHome, "Trash", "info", "my notes.txt.2.trashinfo"))
+	assert.NoError(t, err)
+	lines := strings.Split(string(info), "\n")
+	assert.EqualValues(t, "[Trash Info]", lines[0])
+	assert.EqualValues(t, "Path="+strings.Replace(filepath.Join(dir, "my notes.txt"), " ", "%20", -1), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "DeletionDate="))
+}
+
+// TestOSCommandDiscard is a function.
+func TestOSCommandDiscard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-trash-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dataHome := filepath.Join(dir, "data")
+	osCommand := NewDummyOSCommand()
+	osCommand.Platform.os = "linux"
+	osCommand.getenv = func(name string) string {
+		if name == "XDG_DATA_HOME" {
+			return dataHome
+		}
+		return ""
+	}
+
+	path := filepath.Join(dir, "scratch")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("scratch"), 0644))
+	assert.NoError(t, osCommand.Discard(path))
+	_, err = os.Stat(filepath.Join(dataHome, "Trash"))
+	assert.True(t, os.IsNotExist(err), "files are deleted outright by default")
+
+	osCommand.Config.GetUserConfig().Set("git.trashDiscardedFiles", true)
+	assert.NoError(t, ioutil.WriteFile(path, []byte("scratch"), 0644))
+	assert.NoError(t, osCommand.Discard(path))
+	_, err = os.Stat(filepath.Join(dataHome, "Trash", "files", "scratch"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// SemVer is a version parsed from a release tag like 'v1.2.3'. We keep the 'v'
+// prefix, if any, so that the next tag follows the same convention
+type SemVer struct {
+	Prefix string
+	Major  int
+	Minor  int
+	Patch  int
+}
+
+// pre-release and build tags (e.g. v1.2.3-rc1) aren't releases, so we ignore them
+var semVerTagRegexp = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)$`)
+
+// ParseSemVer parses a release tag, returning false if it isn't one
+func ParseSemVer(tagName string) (SemVer, bool) {
+	match := semVerTagRegexp.FindStringSubmatch(tagName)
+	if match == nil {
+		return SemVer{}, false
+	}
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+	return SemVer{Prefix: match[1], Major: major, Minor: minor, Patch: patch}, true
+}
+
+func (v SemVer) String() string {
+	return fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+}
+
+// LessThan compares two versions, ignoring their prefixes
+func (v SemVer) LessThan(other SemVer) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+// Bump returns the next version, where part is one of "major", "minor" or "patch"
+func (v SemVer) Bump(part string) SemVer {
+	switch part {
+	case "major":
+		return SemVer{Prefix: v.Prefix, Major: v.Major + 1}
+	case "minor":
+		return SemVer{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor + 1}
+	default:
+		return SemVer{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// LatestSemVer returns the highest release version among the given tags. If
+// there are none we start from v0.0.0
+func LatestSemVer(tags []*Tag) (SemVer, bool) {
+	latest := SemVer{Prefix: "v"}
+	found := false
+	for _, tag := range tags {
+		version, ok := ParseSemVer(tag.Name)
+		if ok && (!found || latest.LessThan(version)) {
+			latest = version
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// VersionTagMessage fills in the git.versionTagMessage template for a new
+// release tag
+func (c *GitCommand) VersionTagMessage(version SemVer, previous string) string {
+	template := c.Config.GetUserConfig().GetString("git.versionTagMessage")
+	return utils.ResolvePlaceholderString(template, map[string]string{
+		"version":         version.String(),
+		"previousVersion": previous,
+	})
+}
+
+// CreateAnnotatedTag tags HEAD with the given message
+func (c *GitCommand) CreateAnnotatedTag(tagName string, message string) error {
+	return c.OSCommand.RunCommandArgs("git", "tag", "-a", tagName, "-m", message)
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseSemVer is a function.
+func TestParseSemVer(t *testing.T) {
+	type scenario struct {
+		tagName  string
+		expected SemVer
+		ok       bool
+	}
+
+	scenarios := []scenario{
+		{"v1.2.3", SemVer{Prefix: "v", Major: 1, Minor: 2, Patch: 3}, true},
+		{"10.0.12", SemVer{Major: 10, Minor: 0, Patch: 12}, true},
+		{"v1.2.3-rc1", SemVer{}, false},
+		{"release-1", SemVer{}, false},
+		{"v1.2", SemVer{}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.tagName, func(t *testing.T) {
+			version, ok := ParseSemVer(s.tagName)
+			assert.EqualValues(t, s.ok, ok)
+			assert.EqualValues(t, s.expected, version)
+		})
+	}
+}
+
+// TestSemVerBump is a function.
+func TestSemVerBump(t *testing.T) {
+	version := SemVer{Prefix: "v", Major: 1, Minor: 2, Patch: 3}
+
+	assert.EqualValues(t, "v1.2.4", version.Bump("patch").String())
+	assert.EqualValues(t, "v1.3.0", version.Bump("minor").String())
+	assert.EqualValues(t, "v2.0.0", version.Bump("major").String())
+}
+
+// TestLatestSemVer is a function.
+func TestLatestSemVer(t *testing.T) {
+	latest, found := LatestSemVer([]*Tag{{Name: "v1.10.0"}, {Name: "v2.0.0-beta"}, {Name: "v1.9.3"}, {Name: "nightly"}})
+	assert.True(t, found)
+	assert.EqualValues(t, "v1.10.0", latest.String())
+
+	latest, found = LatestSemVer([]*Tag{{Name: "nightly"}})
+	assert.False(t, found)
+	assert.EqualValues(t, "v0.0.0", latest.String())
+}
+
+// TestGitCommandVersionTagMessage is a function.
+func TestGitCommandVersionTagMessage(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.Config.GetUserConfig().Set("git.versionTagMessage", "Release {{version}} (since {{previousVersion}})")
+
+	assert.EqualValues(t, "Release v1.3.0 (since v1.2.3)", gitCmd.VersionTagMessage(SemVer{Prefix: "v", Major: 1, Minor: 3}, "v1.2.3"))
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// the files marked as viewed while reviewing a commit are kept in the git dir,
+// one list per commit, so that review progress survives restarts without
+// touching the repo's history
+func (c *GitCommand) viewedFilesPath(sha string) string {
+	return filepath.Join(c.DotGitDir, "lazygit", "viewed", sha)
+}
+
+// GetViewedFiles returns the names of the files marked as viewed in a commit
+func (c *GitCommand) GetViewedFiles(sha string) map[string]bool {
+	viewed := map[string]bool{}
+	content, err := ioutil.ReadFile(c.viewedFilesPath(sha))
+	if err != nil {
+		return viewed
+	}
+	for _, name := range utils.SplitLines(string(content)) {
+		viewed[name] = true
+	}
+	return viewed
+}
+
+// SetFilesViewed marks or unmarks the given files in a commit as viewed
+func (c *GitCommand) SetFilesViewed(sha string, names []string, viewed bool) error {
+	viewedFiles := c.GetViewedFiles(sha)
+	for _, name := range names {
+		if viewed {
+			viewedFiles[name] = true
+		} else {
+			delete(viewedFiles, name)
+		}
+	}
+
+	path := c.viewedFilesPath(sha)
+	if len(viewedFiles) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return WrapError(err)
+		}
+		return nil
+	}
+
+	lines := make([]string, 0, len(viewedFiles))
+	for name := range viewedFiles {
+		lines = append(lines, name)
+	}
+	sort.Strings(lines)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return WrapError(err)
+	}
+	return WrapError(ioutil.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+}
+
+// SetViewedStatuses marks each of the given commit files as viewed or not,
+// with a directory counting as viewed once everything in it has been
+func SetViewedStatuses(files []*CommitFile, viewedFiles map[string]bool) {
+	for _, file := range files {
+		if !file.IsDirectory {
+			file.Viewed = viewedFiles[file.Name]
+		}
+	}
+	for _, file := range files {
+		if !file.IsDirectory {
+			continue
+		}
+		file.Viewed = true
+		for _, child := range FilesInDirectory(files, file.Name) {
+			if !child.Viewed {
+				file.Viewed = false
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandSetFilesViewed is a function.
+func TestGitCommandSetFilesViewed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-viewed")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dir
+	sha := "0123456789abcdef0123456789abcdef01234567"
+
+	assert.EqualValues(t, map[string]bool{}, gitCmd.GetViewedFiles(sha))
+
+	assert.NoError(t, gitCmd.SetFilesViewed(sha, []string{"pkg/b.go", "pkg/a.go", "README.md"}, true))
+	assert.EqualValues(t, map[string]bool{"pkg/a.go": true, "pkg/b.go": true, "README.md": true}, gitCmd.GetViewedFiles(sha))
+	assert.EqualValues(t, map[string]bool{}, gitCmd.GetViewedFiles("another sha"))
+
+	assert.NoError(t, gitCmd.SetFilesViewed(sha, []string{"pkg/b.go"}, false))
+	assert.EqualValues(t, map[string]bool{"pkg/a.go": true, "README.md": true}, gitCmd.GetViewedFiles(sha))
+
+	// once nothing is viewed we don't leave an empty file lying around
+	assert.NoError(t, gitCmd.SetFilesViewed(sha, []string{"pkg/a.go", "README.md"}, false))
+	_, err = os.Stat(gitCmd.viewedFilesPath(sha))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// TestSetViewedStatuses is a function.
+func TestSetViewedStatuses(t *testing.T) {
+	files := BuildCommitFileTree([]*CommitFile{
+		{Name: "pkg/a.go"},
+		{Name: "pkg/b.go"},
+		{Name: "pkg/sub/c.go"},
+		{Name: "README.md"},
+	})
+
+	SetViewedStatuses(files, map[string]bool{"pkg/sub/c.go": true, "pkg/a.go": true})
+
+	viewed := map[string]bool{}
+	for _, file := range files {
+		viewed[file.Name] = file.Viewed
+	}
+	assert.EqualValues(t, map[string]bool{
+		"pkg":          false,
+		"pkg/a.go":     true,
+		"pkg/b.go":     false,
+		"pkg/sub":      true,
+		"pkg/sub/c.go": true,
+		"README.md":    false,
+	}, viewed)
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetWorktreeBranches maps each branch that's checked out in a worktree other
+// than the current one to that worktree's path. Git won't let a branch be
+// checked out in two worktrees at once, so these are the branches we can't switch to
+func (c *GitCommand) GetWorktreeBranches() map[string]string {
+	output, err := c.OSCommand.RunCommandWithOutput("git worktree list --porcelain")
+	if err != nil {
+		return nil
+	}
+	currentPath, err := c.OSCommand.RunCommandWithOutput("git rev-parse --show-toplevel")
+	if err != nil {
+		return nil
+	}
+	return parseWorktreeBranches(output, strings.TrimSpace(currentPath))
+}
+
+// parseWorktreeBranches reads the output of `git worktree list --porcelain`,
+// which has a stanza per worktree like:
+//
+//	worktree /path/to/worktree
+//	HEAD <sha>
+//	branch refs/heads/<name>
+func parseWorktreeBranches(output string, currentPath string) map[string]string {
+	branches := map[string]string{}
+	path := ""
+	for _, line := range utils.SplitLines(output) {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			path = strings.TrimPrefix(line, "worktree ")
+		case strings.HasPrefix(line, "branch refs/heads/"):
+			if filepath.Clean(path) != filepath.Clean(currentPath) {
+				branches[strings.TrimPrefix(line, "branch refs/heads/")] = path
+			}
+		}
+	}
+	return branches
+}
+
+// setWorktreePaths records which of the given branches are checked out in
+// another worktree
+func (c *GitCommand) setWorktreePaths(branches []*Branch) {
+	worktreeBranches := c.GetWorktreeBranches()
+	for _, branch := range branches {
+		branch.WorktreePath = worktreeBranches[branch.Name]
+	}
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseWorktreeBranches is a function.
+func TestParseWorktreeBranches(t *testing.T) {
+	output := `worktree /repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/master
+
+worktree /repo-feature
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/feature/thing
+
+worktree /repo-detached
+HEAD 3333333333333333333333333333333333333333
+detached
+`
+
+	assert.EqualValues(t, map[string]string{"feature/thing": "/repo-feature"}, parseWorktreeBranches(output, "/repo"))
+	assert.EqualValues(t, map[string]string{"master": "/repo"}, parseWorktreeBranches(output, "/repo-feature/"))
+}
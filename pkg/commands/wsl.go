@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"strings"
+)
+
+// isWSL tells us whether we're running in the Windows Subsystem for Linux,
+// where files and links are best opened by Windows and the clipboard is
+// Windows' too
+func (c *OSCommand) isWSL() bool {
+	return c.Platform.os == "linux" && (c.getenv("WSL_DISTRO_NAME") != "" || c.getenv("WSL_INTEROP") != "")
+}
+
+// openWithDefaultApp opens a file or link with whatever the desktop opens it
+// with, for when the user hasn't set os.openCommand or os.openLinkCommand.
+// In WSL that means asking Windows, through wslview if it's installed
+func (c *OSCommand) openWithDefaultApp(target string, isLink bool) error {
+	switch {
+	case c.Platform.os == "darwin":
+		return c.RunExecutable(c.command("open", target))
+	case c.Platform.os == "windows":
+		// start takes its first quoted argument as the window's title
+		return c.RunExecutable(c.command("cmd", "/c", "start", "", target))
+	case !c.isWSL():
+		return c.RunExecutable(c.command("xdg-open", target))
+	}
+
+	if _, err := c.lookPath("wslview"); err == nil {
+		return c.RunExecutable(c.command("wslview", target))
+	}
+
+	if !isLink {
+		windowsPath, err := c.RunExecutableWithOutput(c.command("wslpath", "-w", target))
+		if err != nil {
+			return err
+		}
+		target = strings.TrimSpace(windowsPath)
+	}
+	// we'd use cmd.exe's start, but it makes a mess of links with &s in them
+	script := "Start-Process '" + strings.Replace(target, "'", "''", -1) + "'"
+	return c.RunExecutable(c.command("powershell.exe", "-NoProfile", "-NonInteractive", "-Command", script))
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandOpenWithDefaultApp is a function.
+func TestOSCommandOpenWithDefaultApp(t *testing.T) {
+	type scenario struct {
+		testName  string
+		os        string
+		env       map[string]string
+		available []string
+		open      func(*OSCommand) error
+		expected  [][]string
+	}
+
+	wsl := map[string]string{"WSL_DISTRO_NAME": "Ubuntu"}
+
+	scenarios := []scenario{
+		{
+			"macOS",
+			"darwin",
+			nil,
+			nil,
+			func(c *OSCommand) error { return c.OpenLink("https://example.com") },
+			[][]string{{"open", "https://example.com"}},
+		},
+		{
+			"Windows",
+			"windows",
+			nil,
+			nil,
+			func(c *OSCommand) error { return c.OpenFile(`C:\notes.md`) },
+			[][]string{{"cmd", "/c", "start", "", `C:\notes.md`}},
+		},
+		{
+			"Linux",
+			"linux",
+			nil,
+			[]string{"wslview"},
+			func(c *OSCommand) error { return c.OpenFile("/home/me/notes.md") },
+			[][]string{{"xdg-open", "/home/me/notes.md"}},
+		},
+		{
+			"WSL with wslview",
+			"linux",
+			wsl,
+			[]string{"wslview"},
+			func(c *OSCommand) error { return c.OpenFile("/home/me/notes.md") },
+			[][]string{{"wslview", "/home/me/notes.md"}},
+		},
+		{
+			"WSL without wslview translates file paths for Windows",
+			"linux",
+			wsl,
+			nil,
+			func(c *OSCommand) error { return c.OpenFile("/home/me/it's.md") },
+			[][]string{
+				{"wslpath", "-w", "/home/me/it's.md"},
+				{"powershell.exe", "-NoProfile", "-NonInteractive", "-Command", `Start-Process '\\wsl$\Ubuntu\home\me\it''s.md'`},
+			},
+		},
+		{
+			"WSL without wslview opens links as they are",
+			"linux",
+			wsl,
+			nil,
+			func(c *OSCommand) error { return c.OpenLink("https://example.com/?a=1&b=2") },
+			[][]string{
+				{"powershell.exe", "-NoProfile", "-NonInteractive", "-Command", "Start-Process 'https://example.com/?a=1&b=2'"},
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			osCommand := NewDummyOSCommand()
+			osCommand.Platform.os = s.os
+			osCommand.Config.GetUserConfig().Set("os.openCommand", "")
+			osCommand.Config.GetUserConfig().Set("os.openLinkCommand", "")
+			osCommand.getenv = func(name string) string { return s.env[name] }
+			osCommand.lookPath = func(name string) (string, error) {
+				for _, available := range s.available {
+					if name == available {
+						return "/usr/bin/" + name, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+			called := [][]string{}
+			osCommand.SetCommand(func(cmd string, args ...string) *exec.Cmd {
+				called = append(called, append([]string{cmd}, args...))
+				if cmd == "wslpath" {
+					return exec.Command("echo", `\\wsl$\Ubuntu\home\me\it's.md`)
+				}
+				return exec.Command("true")
+			})
+
+			assert.NoError(t, s.open(osCommand))
+			assert.EqualValues(t, s.expected, called)
+		})
+	}
+}
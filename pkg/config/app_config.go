@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -21,8 +22,14 @@ type AppConfig struct {
 	BuildSource   string `long:"build-source" env:"BUILD_SOURCE" default:""`
 	UserConfig    *viper.Viper
 	UserConfigDir string
+	Profile       string
+	StateDir      string
+	CacheDir      string
 	AppState      *AppState
 	IsNewRepo     bool
+	// StartupWarnings are the things that went wrong while loading the config
+	// which weren't worth failing over, for the app to log once it can
+	StartupWarnings []error
 }
 
 // AppConfigurer interface allows individual app config structs to inherit Fields
@@ -36,17 +43,26 @@ type AppConfigurer interface {
 	GetBuildSource() string
 	GetUserConfig() *viper.Viper
 	GetUserConfigDir() string
+	GetProfile() string
+	GetStateDir() string
+	GetCacheDir() string
 	GetAppState() *AppState
 	WriteToUserConfig(string, interface{}) error
 	SaveAppState() error
 	LoadAppState() error
 	SetIsNewRepo(bool)
 	GetIsNewRepo() bool
+	GetStartupWarnings() []error
 }
 
-// NewAppConfig makes a new app config
-func NewAppConfig(name, version, commit, date string, buildSource string, debuggingFlag bool) (*AppConfig, error) {
-	userConfig, userConfigPath, err := LoadConfig("config", true)
+// NewAppConfig makes a new app config. configDir overrides where lazygit keeps
+// its files, which is otherwise decided by GetDirs, and profile is the config
+// profile to layer over the user's config, if any
+func NewAppConfig(name, version, commit, date string, buildSource string, debuggingFlag bool, configDir string, profile string) (*AppConfig, error) {
+	dirs := GetDirs(configDir)
+	migrationErrors := migrateLegacyFiles(dirs)
+
+	userConfig, userConfigPath, err := LoadConfig(dirs.Config, "config", profile, true)
 	if err != nil {
 		return nil, err
 	}
@@ -56,16 +72,20 @@ func NewAppConfig(name, version, commit, date string, buildSource string, debugg
 	}
 
 	appConfig := &AppConfig{
-		Name:          "lazygit",
-		Version:       version,
-		Commit:        commit,
-		BuildDate:     date,
-		Debug:         debuggingFlag,
-		BuildSource:   buildSource,
-		UserConfig:    userConfig,
-		UserConfigDir: filepath.Dir(userConfigPath),
-		AppState:      &AppState{},
-		IsNewRepo:     false,
+		Name:            "lazygit",
+		Version:         version,
+		Commit:          commit,
+		BuildDate:       date,
+		Debug:           debuggingFlag,
+		BuildSource:     buildSource,
+		UserConfig:      userConfig,
+		UserConfigDir:   filepath.Dir(userConfigPath),
+		Profile:         profile,
+		StateDir:        dirs.State,
+		CacheDir:        dirs.Cache,
+		AppState:        &AppState{},
+		IsNewRepo:       false,
+		StartupWarnings: migrationErrors,
 	}
 
 	if err := appConfig.LoadAppState(); err != nil {
@@ -130,6 +150,28 @@ func (c *AppConfig) GetUserConfigDir() string {
 	return c.UserConfigDir
 }
 
+// GetProfile returns the name of the config profile in use, if any
+func (c *AppConfig) GetProfile() string {
+	return c.Profile
+}
+
+// GetStartupWarnings returns what went wrong while loading the config that
+// wasn't worth failing over
+func (c *AppConfig) GetStartupWarnings() []error {
+	return c.StartupWarnings
+}
+
+// GetStateDir returns where we keep what we remember between runs, e.g. the
+// recent repos
+func (c *AppConfig) GetStateDir() string {
+	return c.StateDir
+}
+
+// GetCacheDir returns where we keep files that can be deleted at any time
+func (c *AppConfig) GetCacheDir() string {
+	return c.CacheDir
+}
+
 func newViper(filename string) (*viper.Viper, error) {
 	v := viper.New()
 	v.SetConfigType("yaml")
@@ -137,8 +179,10 @@ func newViper(filename string) (*viper.Viper, error) {
 	return v, nil
 }
 
-// LoadConfig gets the user's config
-func LoadConfig(filename string, withDefaults bool) (*viper.Viper, string, error) {
+// LoadConfig gets the user's config from configDir, or if that's empty, from
+// the first of the standard config dirs that has the file, along with the
+// named profile if there is one
+func LoadConfig(configDir string, filename string, profile string, withDefaults bool) (*viper.Viper, string, error) {
 	v, err := newViper(filename)
 	if err != nil {
 		return nil, "", err
@@ -151,22 +195,73 @@ func LoadConfig(filename string, withDefaults bool) (*viper.Viper, string, error
 			return nil, "", err
 		}
 	}
-	configPath, err := LoadAndMergeFile(v, filename+".yml")
-	if err != nil {
+	configPath := ""
+	mergeUserConfig := func() error {
+		configPath, err = LoadAndMergeFile(v, configDir, filename+".yml")
+		if err != nil {
+			return err
+		}
+		if profile != "" {
+			return mergeProfile(v, filepath.Dir(configPath), profile)
+		}
+		return nil
+	}
+	if err := mergeUserConfig(); err != nil {
 		return nil, "", err
 	}
+	if withDefaults {
+		// the keymap goes between the defaults and the user's config, so we
+		// only know which one to load once we've read the user's config
+		keymap, err := GetKeymapConfig(v.GetString("gui.keymap"))
+		if err != nil {
+			return nil, "", err
+		}
+		if keymap != nil {
+			if err = LoadDefaults(v, keymap); err != nil {
+				return nil, "", err
+			}
+			if err := mergeUserConfig(); err != nil {
+				return nil, "", err
+			}
+		}
+	}
 	return v, configPath, nil
 }
 
+// mergeProfile layers profiles/<profile>.yml from the config dir over the
+// user's config, e.g. to use different keybindings or a different email at work
+func mergeProfile(v *viper.Viper, configDir string, profile string) error {
+	profilePath := filepath.Join(configDir, "profiles", profile+".yml")
+	profileConfig, err := ioutil.ReadFile(profilePath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("No config profile named '%s'. Create it at %s", profile, profilePath)
+	}
+	if err != nil {
+		return err
+	}
+	return v.MergeConfig(bytes.NewBuffer(profileConfig))
+}
+
 // LoadDefaults loads in the defaults defined in this file
 func LoadDefaults(v *viper.Viper, defaults []byte) error {
 	return v.MergeConfig(bytes.NewBuffer(defaults))
 }
 
-func prepareConfigFile(filename string) (string, error) {
-	// chucking my name there is not for vanity purposes, the xdg spec (and that
-	// function) requires a vendor name. May as well line up with github
-	configDirs := configdir.New("jesseduffield", "lazygit")
+func prepareConfigFile(dir string, filename string) (string, error) {
+	if dir != "" {
+		path := filepath.Join(dir, filename)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return "", err
+			}
+			if err := ioutil.WriteFile(path, []byte{}, 0644); err != nil {
+				return "", err
+			}
+		}
+		return path, nil
+	}
+
+	configDirs := newConfigDirs()
 	folder := configDirs.QueryFolderContainsFile(filename)
 	if folder == nil {
 		// create the file as empty
@@ -181,8 +276,8 @@ func prepareConfigFile(filename string) (string, error) {
 
 // LoadAndMergeFile Loads the config/state file, creating
 // the file has an empty one if it does not exist
-func LoadAndMergeFile(v *viper.Viper, filename string) (string, error) {
-	configPath, err := prepareConfigFile(filename)
+func LoadAndMergeFile(v *viper.Viper, dir string, filename string) (string, error) {
+	configPath, err := prepareConfigFile(dir, filename)
 	if err != nil {
 		return "", err
 	}
@@ -191,17 +286,41 @@ func LoadAndMergeFile(v *viper.Viper, filename string) (string, error) {
 	return configPath, v.MergeInConfig()
 }
 
-// WriteToUserConfig adds a key/value pair to the user's config and saves it
+// WriteToUserConfig adds a key/value pair to the user's config and saves it.
+// The rest of the file, comments included, is left as it was. If the profile
+// in use sets the key, that's where it goes, as otherwise the profile would
+// go on overriding it
 func (c *AppConfig) WriteToUserConfig(key string, value interface{}) error {
-	// reloading the user config directly (without defaults) so that we're not
-	// writing any defaults back to the user's config
-	v, _, err := LoadConfig("config", false)
+	configPath, err := prepareConfigFile(c.UserConfigDir, "config.yml")
+	if err != nil {
+		return err
+	}
+	if c.Profile != "" {
+		profilePath := filepath.Join(filepath.Dir(configPath), "profiles", c.Profile+".yml")
+		if profileContent, err := ioutil.ReadFile(profilePath); err == nil && yamlSetsKey(profileContent, key) {
+			configPath = profilePath
+		}
+	}
+	content, err := ioutil.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	newContent, err := SetYAMLValue(string(content), key, value)
 	if err != nil {
 		return err
 	}
+	return ioutil.WriteFile(configPath, []byte(newContent), 0644)
+}
 
-	v.Set(key, value)
-	return v.WriteConfig()
+// yamlSetsKey tells us whether the YAML sets the dot separated key
+func yamlSetsKey(content []byte, key string) bool {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewBuffer(content)); err != nil {
+		return false
+	}
+	return v.IsSet(key)
 }
 
 // SaveAppState marshalls the AppState struct and writes it to the disk
@@ -211,7 +330,7 @@ func (c *AppConfig) SaveAppState() error {
 		return err
 	}
 
-	filepath, err := prepareConfigFile("state.yml")
+	filepath, err := prepareConfigFile(c.StateDir, "state.yml")
 	if err != nil {
 		return err
 	}
@@ -221,7 +340,7 @@ func (c *AppConfig) SaveAppState() error {
 
 // LoadAppState loads recorded AppState from file
 func (c *AppConfig) LoadAppState() error {
-	filepath, err := prepareConfigFile("state.yml")
+	filepath, err := prepareConfigFile(c.StateDir, "state.yml")
 	if err != nil {
 		return err
 	}
@@ -246,8 +365,15 @@ func GetDefaultConfig() []byte {
   skipUnstageLineWarning: false
   skipStashWarning: true
   sidePanelWidth: 0.3333
+  minSidePanelWidth: 20
+  minMainPanelWidth: 20
+  keymap: 'default'
+  accessibility:
+    enabled: false
+    announceCommand: 'spd-say --cancel; spd-say {{text}}'
   theme:
     lightTheme: false
+    mode: 'default'
     activeBorderColor:
       - green
       - bold
@@ -261,6 +387,32 @@ func GetDefaultConfig() []byte {
       - blue
   commitLength:
     show: true
+  shaLength: 8
+  relativeDates: false
+  relativePaths: false
+  dateFormat: '02 Jan 06 15:04 MST'
+  hyperlinks: 'auto'
+  icons: ''
+  commitMessagePreview: false
+  commitFileTree: true
+  splitFileDiffs: false
+  showHunkCounts: true
+  showLastRefreshed: true
+  staleFetchAge: 86400
+  transferSummary: true
+  statusBar:
+    template: ''
+    segments: []
+    timeout: 5
+  commitRefs:
+    maxBadges: 3
+    maxLength: 20
+  typedConfirmation:
+    protectedBranches: ['main', 'master']
+    hardReset: true
+    forcePush: true
+    deleteBranch: true
+    discardFileThreshold: 20
 git:
   paging:
     colorArg: always
@@ -270,13 +422,68 @@ git:
     args: ""
   skipHookPrefix: 'WIP'
   autoFetch: true
+  pull:
+    mode: '' # can be: merge | rebase | ff-only, or empty to follow git's pull.rebase
+  config: []
+  credentialPrompts: []
+  autoRefresh: true
+  networkTimeout: 600
+  networkRetry:
+    attempts: 3
+    delay: 2
+  transferLog:
+    size: 100
+  postCheckoutCommands: []
+  signedPush: false
+  autoTrailers: []
+  commitMessageCommand: ''
+  versionTagMessage: 'Release {{version}}'
+  issues:
+    listCommand: "gh issue list --assignee @me --state open --json number,title,url --jq '.[] | [.number, .title, .url] | @tsv'"
+    urlTemplate: ''
+    branchNameTemplate: '{{id}}-{{slug}}'
+  gitea:
+    tokenSecret: 'GITEA_TOKEN'
+  azureDevOps:
+    tokenSecret: 'AZURE_DEVOPS_TOKEN'
+  trashDiscardedFiles: false
+  secretsScan: false
+  lfsPrompt:
+    enabled: true
+    minFileSizeMB: 5
+  performancePrompt:
+    enabled: false
+    minTrackedFiles: 20000
+  commitSizeGuard:
+    maxFileSizeMB: 10
+    maxFiles: 500
+  diff:
+    ignoreWhitespace: false
+    ignoreBlankLines: false
+    ignoreLineEndings: false
+    algorithm: ''
+  snapshots:
+    enabled: false
+    interval: 300
+  dirDiffTool: ''
   branchLogCmd: "git log --graph --color=always --abbrev-commit --decorate --date=relative --pretty=medium {{branchName}} --"
 update:
-  method: prompt # can be: prompt | background | never
+  method: prompt # can be: prompt | background | checkOnly | never
   days: 14 # how often a update is checked for
+  channel: stable # can be: stable | nightly
+  releasesSource: ''
+  verifySignature: false
 reporting: 'undetermined' # one of: 'on' | 'off' | 'undetermined'
 splashUpdatesIndex: 0
 confirmOnQuit: false
+tasks: []
+env: []
+commandHistory:
+  size: 200
+  maxOutputLength: 4096
+standup:
+  repos: []
+secrets: []
 keybinding:
   universal:
     quit: 'q'
@@ -313,11 +520,17 @@ keybinding:
     scrollDownMain-alt1: 'J'
     scrollUpMain-alt2: '<c-u>'
     scrollDownMain-alt2: '<c-d>'
+    scrollToTopMain: 'H'
+    scrollToBottomMain: 'G'
+    openMainInPager: '|'
+    openMainInEditor: '\'
     executeCustomCommand: ':'
     createRebaseOptionsMenu: 'm'
     pushFiles: 'P'
     pullFiles: 'p'
     refresh: 'R'
+    refreshPanel: '<f5>'
+    tasksMenu: 'X'
     createPatchOptionsMenu: '<c-p>'
     nextTab: ']'
     prevTab: '['
@@ -327,10 +540,25 @@ keybinding:
     redo: '<c-z>'
     filteringMenu: <c-s>
     diffingMenu: '<c-e>'
+    toggleWhitespaceInDiff: '<c-w>'
+    diffOptionsMenu: 'W'
+    timelineBack: '<c-b>'
+    timelineForward: '<c-f>'
+    setMark: '<c-n>'
+    jumpToMark: "'"
+    togglePinMainView: '<c-g>'
+    togglesMenu: '<c-l>'
     copyToClipboard: '<c-o>'
+    toggleGitTrace: '<f9>'
+    commandHistory: '<f6>'
   status:
     checkForUpdate: 'u'
     recentRepos: '<enter>'
+    viewCredentialOptions: 'c'
+    viewSnapshots: 's'
+    repoMaintenance: 'M'
+    standupReport: 'S'
+    settings: 'E'
   files:
     commitChanges: 'c'
     commitChangesWithoutHook: 'w'
@@ -341,20 +569,37 @@ keybinding:
     stashAllChanges: 's'
     viewStashOptions: 'S'
     toggleStagedAll: 'a'
+    stageByPattern: '*'
+    diffAgainstRef: '='
+    toggleSplitDiffs: 'v'
+    scrollUpStagedDiff: '<c-k>'
+    scrollDownStagedDiff: '<c-j>'
     viewResetOptions: 'D'
     fetch: 'f'
+    viewLineEndingOptions: 'E'
   branches:
     createPullRequest: 'o'
+    pullRequestDescription: 'O'
+    viewPullRequests: 'L'
     checkoutBranchByName: 'c'
     forceCheckoutBranch: 'F'
     rebaseBranch: 'r'
     renameBranch: 'R'
     mergeIntoCurrentBranch: 'M'
+    markForMerge: 'v'
+    branchFromIssue: 'I'
     viewGitFlowOptions: 'i'
     fastForward: 'f'
     pushTag: 'P'
+    pushAllTags: 'T'
+    generateChangelog: 'C'
+    createVersionTag: 'V'
+    verifyTag: 'v'
     setUpstream: 'u'
     fetchRemote: 'f'
+    fetchRemoteBranch: 'f'
+    setComparisonBase: 'B'
+    compareBranches: 'C'
   commits:
     squashDown: 's'
     renameCommit: 'r'
@@ -374,14 +619,33 @@ keybinding:
     tagCommit: 'T'
     checkoutCommit: '<space>'
     resetCherryPick: '<c-R>'
+    toggleMessagePreview: 'b'
+    viewRefs: '@'
+    fetchMissingObjects: 'O'
+    viewMergeDiffOptions: 'D'
+    annotateCommit: 'a'
+    filterCommits: 'L'
+    toggleFirstParent: 'M'
+    toggleMergeExpansion: 'E'
+    openInBrowser: 'o'
   stash:
     popStash: 'g'
+    renameStash: 'r'
   commitFiles:
     checkoutCommitFile: 'c'
+    toggleTreeView: '~'
+    toggleViewed: 'v'
+  commitMessage:
+    insertTrailer: '<c-t>'
+    suggestMessage: '<c-y>'
   main:
     toggleDragSelect: 'v'
     toggleDragSelect-alt: 'V'
     toggleSelectHunk: 'a'
+    stageSelection: 's'
+    prevHunkBoundary: '{'
+    nextHunkBoundary: '}'
+    startCount: '#'
     pickBothHunks: 'b'
 `)
 }
@@ -389,14 +653,16 @@ keybinding:
 // AppState stores data between runs of the app like when the last update check
 // was performed and which other repos have been checked out
 type AppState struct {
-	LastUpdateCheck int64
-	RecentRepos     []string
+	LastUpdateCheck             int64
+	RecentRepos                 []string
+	DismissedPerformancePrompts []string // repos we shouldn't suggest performance settings for again
 }
 
 func getDefaultAppState() []byte {
 	return []byte(`
     lastUpdateCheck: 0
     recentRepos: []
+    dismissedPerformancePrompts: []
   `)
 }
 
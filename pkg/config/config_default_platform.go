@@ -8,5 +8,5 @@ func GetPlatformDefaultConfig() []byte {
 		`os:
   openCommand: 'open {{filename}}'
   openLinkCommand: 'open {{link}}'
-  copyToClipboardCommand: 'bash -c "echo -n {{str}} | pbcopy"'`)
+  copyToClipboardCommand: '' # empty to pick whichever clipboard tool is available`)
 }
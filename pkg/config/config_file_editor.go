@@ -0,0 +1,183 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// SetYAMLValue sets the value of a dot separated key like 'gui.theme.mode' in
+// the given YAML, adding the key and any missing parents if need be. Unlike
+// unmarshalling and marshalling the whole file again, this leaves the rest of
+// the file as the user wrote it, comments and all. It only handles keys
+// nested in block mappings, which is how config files are written
+func SetYAMLValue(content string, key string, value interface{}) (string, error) {
+	formatted, err := formatYAMLValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(content, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	indentUnit := yamlIndentUnit(lines)
+
+	// the range of lines the next part of the key is to be found in
+	start, end, indent := 0, len(lines), 0
+	path := strings.Split(key, ".")
+	for i, name := range path {
+		lineIdx := findYAMLKey(lines[start:end], indent, name)
+		if lineIdx == -1 {
+			// append the rest of the key to the end of the mapping we're in,
+			// before any blank lines or comments that separate it from the next
+			insertAt := end
+			for insertAt > start && isYAMLBlankOrComment(lines[insertAt-1]) {
+				insertAt--
+			}
+			newLines := []string{}
+			for j, missing := range path[i:] {
+				prefix := strings.Repeat(" ", indent+j*indentUnit) + missing + ":"
+				if i+j == len(path)-1 {
+					prefix += " " + formatted
+				}
+				newLines = append(newLines, prefix)
+			}
+			lines = append(lines[:insertAt], append(newLines, lines[insertAt:]...)...)
+			return strings.Join(lines, "\n") + "\n", nil
+		}
+		lineIdx += start
+
+		// a list's items can be indented as far as its key
+		blockEnd := lineIdx + 1
+		for blockEnd < end && (isYAMLBlankOrComment(lines[blockEnd]) || yamlIndent(lines[blockEnd]) > indent || isYAMLListItem(lines[blockEnd])) {
+			blockEnd++
+		}
+		// trailing blank lines and comments belong to whatever comes next
+		for blockEnd > lineIdx+1 && isYAMLBlankOrComment(lines[blockEnd-1]) {
+			blockEnd--
+		}
+
+		if i == len(path)-1 {
+			line := strings.Repeat(" ", indent) + name + ": " + formatted
+			if comment := yamlTrailingComment(lines[lineIdx]); comment != "" {
+				line += " " + comment
+			}
+			// whatever was nested under the key is replaced along with it
+			lines = append(append(lines[:lineIdx], line), lines[blockEnd:]...)
+			return strings.Join(lines, "\n") + "\n", nil
+		}
+
+		rest := strings.TrimSuffix(yamlLineValue(lines[lineIdx]), yamlTrailingComment(lines[lineIdx]))
+		if strings.TrimSpace(rest) != "" {
+			return "", fmt.Errorf("Can't set %s: %s isn't a mapping", key, strings.Join(path[:i+1], "."))
+		}
+		start, end = lineIdx+1, blockEnd
+		indent += indentUnit
+		for j := start; j < end; j++ {
+			if !isYAMLBlankOrComment(lines[j]) {
+				if isYAMLListItem(lines[j]) {
+					return "", fmt.Errorf("Can't set %s: %s is a list", key, strings.Join(path[:i+1], "."))
+				}
+				indent = yamlIndent(lines[j])
+				break
+			}
+		}
+	}
+	return "", nil
+}
+
+func formatYAMLValue(value interface{}) (string, error) {
+	if list, ok := value.([]string); ok {
+		items := make([]string, len(list))
+		for i, item := range list {
+			formatted, err := formatYAMLValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = formatted
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	formatted := strings.TrimSuffix(string(out), "\n")
+	// a mapping marshals to e.g. 'mode: dark', which would be invalid after a key
+	var check map[string]interface{}
+	if strings.Contains(formatted, "\n") || yaml.Unmarshal([]byte("value: "+formatted), &check) != nil {
+		return "", fmt.Errorf("Can't write %v on a single line", value)
+	}
+	return formatted, nil
+}
+
+// findYAMLKey returns the index of the line holding the key at the given
+// indentation, or -1 if there isn't one
+func findYAMLKey(lines []string, indent int, name string) int {
+	for i, line := range lines {
+		if isYAMLBlankOrComment(line) || yamlIndent(line) != indent {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		for _, quote := range []string{"", "'", `"`} {
+			if strings.HasPrefix(trimmed, quote+name+quote+":") {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func yamlIndentUnit(lines []string) int {
+	for _, line := range lines {
+		if indent := yamlIndent(line); indent > 0 && !isYAMLBlankOrComment(line) {
+			return indent
+		}
+	}
+	return 2
+}
+
+func yamlIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func isYAMLBlankOrComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+func isYAMLListItem(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "-" || strings.HasPrefix(trimmed, "- ")
+}
+
+// yamlLineValue returns what follows the key on the line, comment included
+func yamlLineValue(line string) string {
+	split := strings.SplitN(line, ":", 2)
+	if len(split) < 2 {
+		return ""
+	}
+	return split[1]
+}
+
+// yamlTrailingComment returns the comment at the end of the line, if any,
+// skipping over '#'s in quoted strings
+func yamlTrailingComment(line string) string {
+	value := yamlLineValue(line)
+	quote := rune(0)
+	for i, r := range value {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '#' && (i == 0 || value[i-1] == ' '):
+			return value[i:]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetYAMLValue is a function.
+func TestSetYAMLValue(t *testing.T) {
+	type scenario struct {
+		testName      string
+		content       string
+		key           string
+		value         interface{}
+		expected      string
+		expectedError string
+	}
+
+	scenarios := []scenario{
+		{
+			"empty file",
+			"",
+			"gui.theme.mode",
+			"dark",
+			"gui:\n  theme:\n    mode: dark\n",
+			"",
+		},
+		{
+			"nested keys added under an existing parent",
+			"gui:\n  scrollHeight: 2\ngit:\n  autoFetch: true\n",
+			"gui.theme.mode",
+			"dark",
+			"gui:\n  scrollHeight: 2\n  theme:\n    mode: dark\ngit:\n  autoFetch: true\n",
+			"",
+		},
+		{
+			"the file's own indentation is kept",
+			"gui:\n    scrollHeight: 2\n",
+			"gui.theme.mode",
+			"dark",
+			"gui:\n    scrollHeight: 2\n    theme:\n        mode: dark\n",
+			"",
+		},
+		{
+			"existing value replaced, keeping comments",
+			"# my config\ngui:\n  # how far to scroll\n  scrollHeight: 2 # lines\n\n# git stuff\ngit:\n  autoFetch: true\n",
+			"gui.scrollHeight",
+			5,
+			"# my config\ngui:\n  # how far to scroll\n  scrollHeight: 5 # lines\n\n# git stuff\ngit:\n  autoFetch: true\n",
+			"",
+		},
+		{
+			"new key goes before the comments separating the next section",
+			"gui:\n  scrollHeight: 2\n\n# git stuff\ngit:\n  autoFetch: true\n",
+			"gui.mouseEvents",
+			false,
+			"gui:\n  scrollHeight: 2\n  mouseEvents: false\n\n# git stuff\ngit:\n  autoFetch: true\n",
+			"",
+		},
+		{
+			"a '#' in a quoted string isn't a comment",
+			"os:\n  editCommand: 'vim \"#\"' # my editor\n",
+			"os.editCommand",
+			"nano",
+			"os:\n  editCommand: nano # my editor\n",
+			"",
+		},
+		{
+			"quoted keys",
+			"gui:\n  'scrollHeight': 2\n",
+			"gui.scrollHeight",
+			3,
+			"gui:\n  scrollHeight: 3\n",
+			"",
+		},
+		{
+			"list value replaces the whole list, items indented as far as the key",
+			"git:\n  skipHookPrefix:\n  - WIP\n  - fixup\n  autoFetch: true\n",
+			"git.skipHookPrefix",
+			[]string{"WIP", "tmp"},
+			"git:\n  skipHookPrefix: [WIP, tmp]\n  autoFetch: true\n",
+			"",
+		},
+		{
+			"list value replaces the whole list, items indented further",
+			"git:\n  skipHookPrefix:\n    - WIP\n  autoFetch: true\n",
+			"git.skipHookPrefix",
+			[]string{},
+			"git:\n  skipHookPrefix: []\n  autoFetch: true\n",
+			"",
+		},
+		{
+			"parent written as a flow mapping",
+			"gui: {theme: {mode: dark}}\n",
+			"gui.theme.mode",
+			"light",
+			"",
+			"Can't set gui.theme.mode: gui isn't a mapping",
+		},
+		{
+			"parent with a scalar value",
+			"gui: 3\n",
+			"gui.theme.mode",
+			"light",
+			"",
+			"Can't set gui.theme.mode: gui isn't a mapping",
+		},
+		{
+			"parent is a list",
+			"customCommands:\n  - key: a\n    command: b\n",
+			"customCommands.key",
+			"c",
+			"",
+			"Can't set customCommands.key: customCommands is a list",
+		},
+		{
+			"parent is a list indented as far as its key",
+			"customCommands:\n- key: a\n  command: b\ngui:\n  scrollHeight: 2\n",
+			"customCommands.key",
+			"c",
+			"",
+			"Can't set customCommands.key: customCommands is a list",
+		},
+		{
+			"value which can't go on one line",
+			"",
+			"gui.theme",
+			map[string]string{"mode": "dark"},
+			"",
+			"Can't write map[mode:dark] on a single line",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			content, err := SetYAMLValue(s.content, s.key, s.value)
+			if s.expectedError != "" {
+				assert.EqualError(t, err, s.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expected, content)
+		})
+	}
+}
+
+// TestAppConfigWriteToUserConfig is a function.
+func TestAppConfigWriteToUserConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazygit-config")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "config.yml")
+	profilePath := filepath.Join(dir, "profiles", "work.yml")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(profilePath), 0755))
+	assert.NoError(t, ioutil.WriteFile(configPath, []byte("gui:\n  scrollHeight: 2\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(profilePath, []byte("gui:\n  theme:\n    mode: dark\n"), 0644))
+
+	appConfig := &AppConfig{UserConfigDir: dir, Profile: "work"}
+	// the profile sets this one, so it has to change there
+	assert.NoError(t, appConfig.WriteToUserConfig("gui.theme.mode", "light"))
+	// and it doesn't set this one
+	assert.NoError(t, appConfig.WriteToUserConfig("gui.scrollHeight", 5))
+
+	config, err := ioutil.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "gui:\n  scrollHeight: 5\n", string(config))
+	profile, err := ioutil.ReadFile(profilePath)
+	assert.NoError(t, err)
+	assert.EqualValues(t, "gui:\n  theme:\n    mode: light\n", string(profile))
+}
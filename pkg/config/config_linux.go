@@ -4,7 +4,7 @@ package config
 func GetPlatformDefaultConfig() []byte {
 	return []byte(
 		`os:
-  openCommand: 'sh -c "xdg-open {{filename}} >/dev/null"'
-  openLinkCommand: 'sh -c "xdg-open {{link}} >/dev/null"'
-  copyToClipboardCommand: 'bash -c "echo -n {{str}} | xclip -selection clipboard"'`)
+  openCommand: '' # empty to use xdg-open, or in WSL, wslview or Windows' default app
+  openLinkCommand: '' # empty to use xdg-open, or in WSL, wslview or Windows' default browser
+  copyToClipboardCommand: '' # empty to pick whichever clipboard tool is available`)
 }
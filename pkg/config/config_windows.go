@@ -6,5 +6,5 @@ func GetPlatformDefaultConfig() []byte {
 		`os:
   openCommand: 'cmd /c "start "" {{filename}}"'
   openLinkCommand: 'cmd /c "start "" {{link}}"'
-  copyToClipboardCommand: 'cmd \c "echo -n {{str}} > /dev/clipboard"'`)
+  copyToClipboardCommand: '' # empty to pick whichever clipboard tool is available`)
 }
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/shibukawa/configdir"
+)
+
+// Dirs are where lazygit keeps its files. Following the XDG base directory
+// spec, the user's config, the state we remember between runs and our caches
+// each get their own dir, so that e.g. the config dir can be kept in a
+// dotfiles repo without recent repos and old patches ending up in it
+type Dirs struct {
+	// Config is empty unless it was overridden, in which case the config file
+	// is read from the first of the standard config dirs that has it
+	Config string
+	State  string
+	Cache  string
+}
+
+// legacyStateFiles are the files we kept alongside the user's config before
+// state got a dir of its own
+var legacyStateFiles = []string{"state.yml", "annotations", "development.log"}
+
+func newConfigDirs() configdir.ConfigDir {
+	// chucking my name there is not for vanity purposes, the xdg spec (and that
+	// function) requires a vendor name. May as well line up with github
+	return configdir.New("jesseduffield", "lazygit")
+}
+
+// GetDirs works out where lazygit's files go. configDir, from --config-dir,
+// puts all of them in the one dir, e.g. for a separate setup to test with
+func GetDirs(configDir string) Dirs {
+	if configDir != "" {
+		return Dirs{
+			Config: configDir,
+			State:  configDir,
+			Cache:  filepath.Join(configDir, "cache"),
+		}
+	}
+
+	return Dirs{
+		State: stateDir(),
+		Cache: newConfigDirs().QueryCacheFolder().Path,
+	}
+}
+
+// legacyDir is the global config dir, where everything used to go
+func legacyDir() string {
+	return newConfigDirs().QueryFolders(configdir.Global)[0].Path
+}
+
+// stateDir is $XDG_STATE_HOME/jesseduffield/lazygit. macOS and Windows have
+// no equivalent, so there the state stays in the config dir
+func stateDir() string {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return legacyDir()
+	}
+
+	root := os.Getenv("XDG_STATE_HOME")
+	if root == "" {
+		home, _ := os.UserHomeDir()
+		root = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(root, "jesseduffield", "lazygit")
+}
+
+// migrateLegacyFiles moves state we used to keep in the config dir over to the
+// state dir, leaving alone anything that's already been moved. Nothing here is
+// worth refusing to start over, so rather than fail we return what went wrong
+// for it to be logged
+func migrateLegacyFiles(dirs Dirs) []error {
+	return migrateLegacyFilesFrom(legacyDir(), dirs)
+}
+
+func migrateLegacyFilesFrom(from string, dirs Dirs) []error {
+	if dirs.Config != "" || dirs.State == from {
+		return nil
+	}
+
+	errs := []error{}
+	for _, name := range legacyStateFiles {
+		oldPath := filepath.Join(from, name)
+		newPath := filepath.Join(dirs.State, name)
+		if _, err := os.Stat(oldPath); err != nil {
+			continue
+		}
+		if _, err := os.Stat(newPath); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(dirs.State, 0755); err != nil {
+			return append(errs, err)
+		}
+		if err := moveFile(oldPath, newPath); err != nil {
+			errs = append(errs, fmt.Errorf("Could not move %s to %s: %v", oldPath, newPath, err))
+		}
+	}
+	return errs
+}
+
+// renameFile is os.Rename, unless a test wants it to fail as though the paths
+// were on different filesystems
+var renameFile = os.Rename
+
+// moveFile moves a file or dir, copying it over and removing the original if
+// it can't just be renamed, as happens when the state and config dirs are on
+// different filesystems
+func moveFile(oldPath string, newPath string) error {
+	if err := renameFile(oldPath, newPath); err == nil {
+		return nil
+	}
+	if err := copyPath(oldPath, newPath); err != nil {
+		// so that we try again from scratch next time
+		_ = os.RemoveAll(newPath)
+		return err
+	}
+	return os.RemoveAll(oldPath)
+}
+
+func copyPath(oldPath string, newPath string) error {
+	info, err := os.Stat(oldPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return copyFile(oldPath, newPath, info.Mode())
+	}
+
+	if err := os.MkdirAll(newPath, info.Mode()); err != nil {
+		return err
+	}
+	entries, err := ioutil.ReadDir(oldPath)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := copyPath(filepath.Join(oldPath, entry.Name()), filepath.Join(newPath, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(oldPath string, newPath string, mode os.FileMode) error {
+	in, err := os.Open(oldPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(newPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
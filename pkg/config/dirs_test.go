@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMigrateLegacyFilesFrom is a function.
+func TestMigrateLegacyFilesFrom(t *testing.T) {
+	type scenario struct {
+		testName string
+		// renameFails acts as though the config and state dirs are on
+		// different filesystems
+		renameFails bool
+	}
+
+	scenarios := []scenario{
+		{"same filesystem", false},
+		{"different filesystems", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			if s.renameFails {
+				renameFile = func(string, string) error { return errors.New("invalid cross-device link") }
+				defer func() { renameFile = os.Rename }()
+			}
+
+			root, err := ioutil.TempDir("", "lazygit-dirs")
+			assert.NoError(t, err)
+			defer os.RemoveAll(root)
+
+			from := filepath.Join(root, "config")
+			dirs := Dirs{State: filepath.Join(root, "state")}
+			assert.NoError(t, os.MkdirAll(filepath.Join(from, "annotations", "repo"), 0755))
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(from, "state.yml"), []byte("recentrepos: []\n"), 0644))
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(from, "annotations", "repo", "abc"), []byte("note"), 0644))
+			// already moved, so the old one is left alone
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(from, "development.log"), []byte("old"), 0644))
+			assert.NoError(t, os.MkdirAll(dirs.State, 0755))
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(dirs.State, "development.log"), []byte("new"), 0644))
+
+			assert.Empty(t, migrateLegacyFilesFrom(from, dirs))
+
+			content, err := ioutil.ReadFile(filepath.Join(dirs.State, "state.yml"))
+			assert.NoError(t, err)
+			assert.EqualValues(t, "recentrepos: []\n", string(content))
+			content, err = ioutil.ReadFile(filepath.Join(dirs.State, "annotations", "repo", "abc"))
+			assert.NoError(t, err)
+			assert.EqualValues(t, "note", string(content))
+			content, err = ioutil.ReadFile(filepath.Join(dirs.State, "development.log"))
+			assert.NoError(t, err)
+			assert.EqualValues(t, "new", string(content))
+
+			_, err = os.Stat(filepath.Join(from, "state.yml"))
+			assert.True(t, os.IsNotExist(err))
+			_, err = os.Stat(filepath.Join(from, "annotations"))
+			assert.True(t, os.IsNotExist(err))
+			_, err = os.Stat(filepath.Join(from, "development.log"))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestMigrateLegacyFilesFromFailure is a function.
+func TestMigrateLegacyFilesFromFailure(t *testing.T) {
+	renameFile = func(string, string) error { return errors.New("invalid cross-device link") }
+	defer func() { renameFile = os.Rename }()
+
+	root, err := ioutil.TempDir("", "lazygit-dirs")
+	assert.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	from := filepath.Join(root, "config")
+	dirs := Dirs{State: filepath.Join(root, "state")}
+	assert.NoError(t, os.MkdirAll(from, 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(from, "state.yml"), []byte("recentrepos: []\n"), 0644))
+	// a dir with a broken symlink in it, so copying it fails partway through
+	assert.NoError(t, os.MkdirAll(filepath.Join(from, "annotations"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(from, "annotations", "abc"), []byte("note"), 0644))
+	if err := os.Symlink(filepath.Join(root, "nowhere"), filepath.Join(from, "annotations", "broken")); err != nil {
+		t.Skip("can't make symlinks here")
+	}
+
+	errs := migrateLegacyFilesFrom(from, dirs)
+	assert.Len(t, errs, 1)
+
+	// what could be moved was, and what couldn't is left where it was, with
+	// nothing half-copied in its place
+	_, err = os.Stat(filepath.Join(dirs.State, "state.yml"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(from, "annotations", "abc"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dirs.State, "annotations"))
+	assert.True(t, os.IsNotExist(err))
+}
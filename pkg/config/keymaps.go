@@ -0,0 +1,82 @@
+package config
+
+import "fmt"
+
+// keymaps are the alternatives to the default keybindings, selected with
+// gui.keymap. Each only lists the keybindings it changes, and keybindings set
+// in the user's config still win over them
+var keymaps = map[string]string{
+	// hjkl as the primary navigation keys, so that they're what's shown in the
+	// options bar and menus, with half-page scrolling on ctrl+u/ctrl+d
+	"vim": `keybinding:
+  universal:
+    prevItem: 'k'
+    nextItem: 'j'
+    prevItem-alt: '<up>'
+    nextItem-alt: '<down>'
+    prevBlock: 'h'
+    nextBlock: 'l'
+    prevBlock-alt: '<left>'
+    nextBlock-alt: '<right>'
+    scrollUpMain: '<c-u>'
+    scrollDownMain: '<c-d>'
+    scrollUpMain-alt2: '<pgup>'
+    scrollDownMain-alt2: '<pgdown>'`,
+
+	// ctrl+p/n/b/f to move, which means moving the keybindings that used them.
+	// There's no telling alt+v apart from escape followed by v, so page up is
+	// on page up, and scrolling the main panel by a line is left to K/J
+	"emacs": `keybinding:
+  universal:
+    prevItem: '<c-p>'
+    nextItem: '<c-n>'
+    prevItem-alt: '<up>'
+    nextItem-alt: '<down>'
+    prevBlock: '<c-b>'
+    nextBlock: '<c-f>'
+    prevBlock-alt: '<left>'
+    nextBlock-alt: '<right>'
+    prevPage: '<pgup>'
+    nextPage: '<c-v>'
+    scrollUpMain: 'K'
+    scrollDownMain: 'J'
+    createPatchOptionsMenu: '<c-x>'
+    setMark: '<c-space>'
+    timelineBack: '<f7>'
+    timelineForward: '<f8>'`,
+
+	// no letters for navigation at all: arrows to move, page up/down to page
+	// and home/end to jump to the top or bottom of a list. The main panel
+	// scrolls a line at a time on ctrl+b/f, like less, and half a page on
+	// ctrl+u/d as it does by default, so stepping through the view history
+	// moves to F7/F8 as in the emacs keymap
+	"arrows": `keybinding:
+  universal:
+    prevItem-alt: '<up>'
+    nextItem-alt: '<down>'
+    prevBlock-alt: '<left>'
+    nextBlock-alt: '<right>'
+    prevPage: '<pgup>'
+    nextPage: '<pgdown>'
+    gotoTop: '<home>'
+    gotoBottom: '<end>'
+    scrollUpMain: '<c-b>'
+    scrollDownMain: '<c-f>'
+    scrollUpMain-alt1: '<c-b>'
+    scrollDownMain-alt1: '<c-f>'
+    timelineBack: '<f7>'
+    timelineForward: '<f8>'`,
+}
+
+// GetKeymapConfig returns the keybindings of the named keymap, or nil for the
+// default one
+func GetKeymapConfig(name string) ([]byte, error) {
+	if name == "" || name == "default" {
+		return nil, nil
+	}
+	keymap, ok := keymaps[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown keymap '%s' in gui.keymap. Use one of: default, vim, emacs, arrows", name)
+	}
+	return []byte(keymap), nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// panelOverrides are the panel keybindings which are meant to take over a
+// global key while their panel has focus, keyed the way viper keys them
+var panelOverrides = map[string]bool{
+	"status.recentrepos":             true,
+	"branches.createpullrequest":     true,
+	"branches.renamebranch":          true,
+	"branches.pushtag":               true,
+	"commits.renamecommitwitheditor": true,
+	"commits.pickcommit":             true,
+	"commits.checkoutcommit":         true,
+	"commits.openinbrowser":          true,
+	// only bound while searching, when 'new' isn't
+	"universal.nextmatch": true,
+}
+
+var altSuffixRegexp = regexp.MustCompile(`-alt\d*$`)
+
+// differentAlternatives are the alternative keybindings which aren't just
+// another key for the same thing, keyed the way viper keys them
+var differentAlternatives = map[string]string{
+	"universal.scrollupmain-alt2":   "universal.halfpageupmain",
+	"universal.scrolldownmain-alt2": "universal.halfpagedownmain",
+}
+
+// TestKeymapsHaveNoGlobalCollisions is a function.
+func TestKeymapsHaveNoGlobalCollisions(t *testing.T) {
+	names := []string{"default"}
+	for name := range keymaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		v := viper.New()
+		v.SetConfigType("yaml")
+		assert.NoError(t, LoadDefaults(v, GetDefaultConfig()))
+		keymap, err := GetKeymapConfig(name)
+		assert.NoError(t, err)
+		if keymap != nil {
+			assert.NoError(t, LoadDefaults(v, keymap))
+		}
+
+		actionsByKey := map[string][]string{}
+		for section, bindings := range v.GetStringMap("keybinding") {
+			for action, key := range bindings.(map[string]interface{}) {
+				keyString := key.(string)
+				// multi-character keys like '<c-r>' are matched case-insensitively
+				if len(keyString) > 1 {
+					keyString = strings.ToLower(keyString)
+				}
+				name := section + "." + action
+				if different, ok := differentAlternatives[name]; ok {
+					name = different
+				}
+				actionsByKey[keyString] = append(actionsByKey[keyString], altSuffixRegexp.ReplaceAllString(name, ""))
+			}
+		}
+
+		for key, actions := range actionsByKey {
+			for _, global := range actions {
+				if !strings.HasPrefix(global, "universal.") {
+					continue
+				}
+				for _, other := range actions {
+					if other != global && !panelOverrides[other] && !panelOverrides[global] {
+						t.Errorf("%s keymap: '%s' is bound to both %s and %s", name, key, global, other)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// In accessibility mode we draw frames with plain ASCII, only show the focused
+// panel, and pass what the user moves to on to a screen reader by running
+// gui.accessibility.announceCommand, e.g. spd-say
+
+func (gui *Gui) accessibilityMode() bool {
+	return gui.Config.GetUserConfig().GetBool("gui.accessibility.enabled")
+}
+
+// announce says the text through the announce command, cutting off whatever
+// is still being said, because that's out of date once the user has moved on
+func (gui *Gui) announce(text string) {
+	if !gui.accessibilityMode() {
+		return
+	}
+	commandTemplate := gui.Config.GetUserConfig().GetString("gui.accessibility.announceCommand")
+	text = strings.Join(strings.Fields(strings.Map(withoutBoxDrawing, utils.Decolorise(text))), " ")
+	if commandTemplate == "" || text == "" {
+		return
+	}
+
+	gui.announcementMutex.Lock()
+	defer gui.announcementMutex.Unlock()
+
+	if gui.announcement != nil && gui.announcement.Process != nil {
+		_ = gui.announcement.Process.Kill()
+	}
+
+	cmdStr := utils.ResolvePlaceholderString(commandTemplate, map[string]string{"text": gui.OSCommand.ShellQuote(text)})
+	cmd := gui.OSCommand.ShellExecutable(cmdStr)
+	if err := cmd.Start(); err != nil {
+		gui.Log.Error(err)
+		gui.announcement = nil
+		return
+	}
+	gui.announcement = cmd
+	go func() { _ = cmd.Wait() }()
+}
+
+// announceFocus says which panel we've moved to and what's selected in it. For
+// popups, which have something to tell the user, we read out the whole thing
+func (gui *Gui) announceFocus(v *gocui.View) {
+	if !gui.accessibilityMode() {
+		return
+	}
+
+	text := v.Title
+	if len(v.Tabs) > 1 {
+		text += ", " + v.Tabs[v.TabIndex]
+	}
+	if listView := gui.getListViewFor(v.Name(), v.Context); listView != nil {
+		text += ": " + gui.selectedLineText(v, *listView.getSelectedLineIdxPtr())
+	} else if gui.isPopupPanel(v.Name()) {
+		text += ": " + strings.Join(v.BufferLines(), " ")
+	}
+	gui.announce(text)
+}
+
+// announceSelectedLine says the line the user has just moved to in a list
+func (gui *Gui) announceSelectedLine(v *gocui.View, selectedLine int) {
+	if !gui.accessibilityMode() {
+		return
+	}
+	gui.announce(gui.selectedLineText(v, selectedLine))
+}
+
+// withoutBoxDrawing drops the box-drawing and shape characters we use for
+// badges and the like, which a screen reader would otherwise read out by name
+func withoutBoxDrawing(r rune) rune {
+	if r >= 0x2500 && r <= 0x25FF {
+		return -1
+	}
+	return r
+}
+
+func (gui *Gui) selectedLineText(v *gocui.View, selectedLine int) string {
+	lines := v.BufferLines()
+	if selectedLine < 0 || selectedLine >= len(lines) {
+		return gui.Tr.SLocalize("AnnounceEmptyList")
+	}
+	return lines[selectedLine]
+}
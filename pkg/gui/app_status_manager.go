@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -79,3 +80,18 @@ func (gui *Gui) WithWaitingStatus(name string, f func() error) error {
 
 	return nil
 }
+
+// onNetworkRetry shows in the status bar that a fetch, pull or push failed on
+// the network and will be tried again, for as long as we're waiting to
+func (gui *Gui) onNetworkRetry(retry commands.NetworkRetry) {
+	status := gui.Tr.TemplateLocalize("RetryingNetworkCommandStatus", Teml{
+		"command":  retry.Command,
+		"attempt":  retry.Attempt,
+		"attempts": retry.Attempts,
+		"delay":    retry.Delay.String(),
+	})
+	_ = gui.WithWaitingStatus(status, func() error {
+		time.Sleep(retry.Delay)
+		return nil
+	})
+}
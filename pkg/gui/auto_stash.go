@@ -0,0 +1,88 @@
+package gui
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// popAutoStash reapplies the changes we stashed before a checkout or pull. If
+// they don't apply cleanly git leaves the conflicts in the working tree and
+// keeps the stash around, so we point the user at both rather than just
+// showing git's error
+func (gui *Gui) popAutoStash() error {
+	if err := gui.GitCommand.StashDo(0, "pop"); err != nil {
+		if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI}); err != nil {
+			return err
+		}
+		if strings.Contains(err.Error(), "CONFLICT") {
+			return gui.createErrorPanel(gui.Tr.SLocalize("AutoStashPopConflict"))
+		}
+		return gui.surfaceError(err)
+	}
+	return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI})
+}
+
+// isDirtyWorkingTreeError tells us whether a pull failed because of local
+// changes, in which case stashing them first would let it go through
+func isDirtyWorkingTreeError(err error) bool {
+	message := err.Error()
+	for _, str := range []string{
+		"would be overwritten by merge",
+		"You have unstaged changes",
+		"Your index contains uncommitted changes",
+	} {
+		if strings.Contains(message, str) {
+			return true
+		}
+	}
+	return false
+}
+
+func (gui *Gui) offerAutoStashPull(v *gocui.View, args string) error {
+	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("AutoStashTitle"), gui.Tr.SLocalize("AutoStashPullPrompt"), func(g *gocui.Gui, _ *gocui.View) error {
+		return gui.WithWaitingStatus(gui.Tr.SLocalize("PullWait"), func() error {
+			if err := gui.GitCommand.StashSave(gui.Tr.SLocalize("StashPrefixPull")); err != nil {
+				return err
+			}
+
+			unamePassOpend := false
+			previousHead := gui.GitCommand.HeadSha()
+			var stats *commands.TransferStats
+			err := gui.withCancellableCommand(v.Name(), func(ctx context.Context) error {
+				var err error
+				stats, err = gui.GitCommand.Pull(ctx, args, func(passOrUname string) string {
+					unamePassOpend = true
+					return gui.waitForPassUname(g, v, passOrUname)
+				})
+				return err
+			})
+			if unamePassOpend {
+				_, _ = g.SetViewOnBottom("credentials")
+			}
+			if err == context.Canceled {
+				err = errors.New(gui.Tr.SLocalize("CommandCancelled"))
+				if gui.GitCommand.HasIndexLock() {
+					err = errors.New(gui.Tr.TemplateLocalize("IndexLockLeftBehind", Teml{"path": gui.GitCommand.IndexLockPath()}))
+				}
+			}
+			if err != nil {
+				// we leave the stash alone so that reapplying it doesn't
+				// pile more conflicts on top of the failed pull
+				_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+				return gui.createErrorPanel(gui.Tr.TemplateLocalize("AutoStashPullFailed", Teml{"error": err.Error()}))
+			}
+
+			gui.afterCheckout(v, previousHead)
+			// a conflict reapplying the stash matters more than the summary, so
+			// we let its error take the summary's place
+			if err := gui.showTransferSummary(v, stats); err != nil {
+				return err
+			}
+			return gui.popAutoStash()
+		})
+	}, nil)
+}
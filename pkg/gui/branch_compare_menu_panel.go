@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// handleCompareBranches shows the commits that are only on the checked out
+// branch or only on the selected branch, with the combined diff of the two in
+// the main view. Commits from the selected branch can be cherry-picked straight
+// onto the checked out branch, and commits from the checked out branch can be
+// copied so they can be pasted after checking out the other branch
+func (gui *Gui) handleCompareBranches(g *gocui.Gui, v *gocui.View) error {
+	selectedBranch := gui.getSelectedBranch()
+	checkedOutBranch := gui.getCheckedOutBranch()
+	if selectedBranch == nil || checkedOutBranch == nil {
+		return nil
+	}
+	if selectedBranch.Name == checkedOutBranch.Name {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantCompareBranchWithItself"))
+	}
+
+	leftCommits, rightCommits, err := gui.GitCommand.GetCommitsUniqueToEachSide(checkedOutBranch.Name, selectedBranch.Name)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(leftCommits) == 0 && len(rightCommits) == 0 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoCommitsToCompare"))
+	}
+
+	_ = gui.showInMainView(func() error {
+		gui.getMainView().Title = gui.Tr.SLocalize("CompareTitle")
+		gui.State.SplitMainPanel = false
+		cmd := gui.OSCommand.ExecutableFromString(
+			fmt.Sprintf("git diff --color%s %s...%s", gui.GitCommand.DiffFlags(), gui.OSCommand.Quote(checkedOutBranch.Name), gui.OSCommand.Quote(selectedBranch.Name)),
+		)
+		if err := gui.newPtyTask("main", cmd); err != nil {
+			gui.Log.Error(err)
+		}
+		return nil
+	})
+
+	formatting := gui.formatting()
+	menuItems := []*menuItem{}
+	for _, commit := range leftCommits {
+		commit := commit
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{color.New(color.FgRed).Sprint("<"), color.New(color.FgYellow).Sprint(formatting.Sha(commit.Sha)), commit.Name},
+			onPress: func() error {
+				return gui.copyCommitForCherryPick(commit)
+			},
+		})
+	}
+	for _, commit := range rightCommits {
+		commit := commit
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{color.New(color.FgGreen).Sprint(">"), color.New(color.FgYellow).Sprint(formatting.Sha(commit.Sha)), commit.Name},
+			onPress: func() error {
+				return gui.cherryPickComparedCommit(commit)
+			},
+		})
+	}
+
+	title := gui.Tr.TemplateLocalize(
+		"CompareBranchesTitle",
+		Teml{
+			"checkedOutBranch": checkedOutBranch.Name,
+			"selectedBranch":   selectedBranch.Name,
+		},
+	)
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) cherryPickComparedCommit(commit *commands.Commit) error {
+	return gui.createConfirmationPanel(gui.g, gui.getBranchesView(), true, gui.Tr.SLocalize("CherryPick"), gui.Tr.SLocalize("SureCherryPickCommit"), func(g *gocui.Gui, v *gocui.View) error {
+		return gui.WithWaitingStatus(gui.Tr.SLocalize("CherryPickingStatus"), func() error {
+			err := gui.GitCommand.CherryPickCommits([]*commands.Commit{commit})
+			return gui.handleGenericMergeCommandResult(err)
+		})
+	}, nil)
+}
+
+func (gui *Gui) copyCommitForCherryPick(commit *commands.Commit) error {
+	if !gui.cherryPickedCommitShaMap()[commit.Sha] {
+		gui.State.CherryPickedCommits = append(gui.State.CherryPickedCommits, commit)
+	}
+
+	return gui.renderBranchCommitsWithSelection()
+}
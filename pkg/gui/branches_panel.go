@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -76,6 +77,7 @@ func (gui *Gui) refreshBranches() {
 		_ = gui.surfaceError(err)
 	}
 	gui.State.Branches = builder.Build()
+	gui.markRefreshed("branches")
 
 	// TODO: if we're in the remotes view and we've just deleted a remote we need to refresh accordingly
 	if gui.getBranchesView().Context == "local-branches" {
@@ -89,7 +91,7 @@ func (gui *Gui) renderLocalBranchesWithSelection() error {
 	branchesView := gui.getBranchesView()
 
 	gui.refreshSelectedLine(&gui.State.Panels.Branches.SelectedLine, len(gui.State.Branches))
-	displayStrings := presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Diff.Ref)
+	displayStrings := presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Diff.Ref, gui.State.MarkedBranches, gui.formatting())
 	gui.renderDisplayStrings(branchesView, displayStrings)
 	if gui.g.CurrentView() == branchesView {
 		if err := gui.handleBranchSelect(gui.g, branchesView); err != nil {
@@ -110,9 +112,21 @@ func (gui *Gui) handleBranchPress(g *gocui.Gui, v *gocui.View) error {
 		return gui.createErrorPanel(gui.Tr.SLocalize("AlreadyCheckedOutBranch"))
 	}
 	branch := gui.getSelectedBranch()
+	if branch.WorktreePath != "" {
+		return gui.handleBranchInOtherWorktree(branch)
+	}
 	return gui.handleCheckoutRef(branch.Name, handleCheckoutRefOptions{})
 }
 
+// handleBranchInOtherWorktree explains why we can't check out a branch that's
+// checked out in another worktree, and offers to switch to that worktree instead
+func (gui *Gui) handleBranchInOtherWorktree(branch *commands.Branch) error {
+	message := gui.Tr.TemplateLocalize("BranchCheckedOutInWorktree", Teml{"branch": branch.Name, "path": branch.WorktreePath})
+	return gui.createConfirmationPanel(gui.g, gui.getBranchesView(), true, gui.Tr.SLocalize("SwitchToWorktree"), message, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.switchToRepo(branch.WorktreePath)
+	}, nil)
+}
+
 func (gui *Gui) handleCreatePullRequestPress(g *gocui.Gui, v *gocui.View) error {
 	pullRequest := commands.NewPullRequest(gui.GitCommand)
 
@@ -125,12 +139,23 @@ func (gui *Gui) handleCreatePullRequestPress(g *gocui.Gui, v *gocui.View) error
 }
 
 func (gui *Gui) handleGitFetch(g *gocui.Gui, v *gocui.View) error {
-	if err := gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("FetchWait")); err != nil {
+	if err := gui.createCancellableLoaderPanel(v, gui.Tr.SLocalize("FetchWait")); err != nil {
 		return err
 	}
 	go func() {
-		unamePassOpend, err := gui.fetch(g, v, true)
-		gui.HandleCredentialsPopup(g, unamePassOpend, err)
+		_ = gui.withCancellableCommand(v.Name(), func(ctx context.Context) error {
+			stats, unamePassOpend, err := gui.fetch(ctx, g, v, true)
+			if err == context.Canceled {
+				return nil
+			}
+			gui.HandleCredentialsPopup(g, unamePassOpend, err)
+			if err == nil {
+				gui.g.Update(func(*gocui.Gui) error {
+					return gui.showTransferSummary(v, stats)
+				})
+			}
+			return nil
+		})
 	}()
 	return nil
 }
@@ -140,8 +165,11 @@ func (gui *Gui) handleForceCheckout(g *gocui.Gui, v *gocui.View) error {
 	message := gui.Tr.SLocalize("SureForceCheckout")
 	title := gui.Tr.SLocalize("ForceCheckoutBranch")
 	return gui.createConfirmationPanel(g, v, true, title, message, func(g *gocui.Gui, v *gocui.View) error {
+		previousHead := gui.GitCommand.HeadSha()
 		if err := gui.GitCommand.Checkout(branch.Name, commands.CheckoutOptions{Force: true}); err != nil {
 			_ = gui.surfaceError(err)
+		} else {
+			gui.afterCheckout(v, previousHead)
 		}
 		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
 	}, nil)
@@ -168,6 +196,7 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 	}
 
 	return gui.WithWaitingStatus(waitingStatus, func() error {
+		previousHead := gui.GitCommand.HeadSha()
 		if err := gui.GitCommand.Checkout(ref, cmdOptions); err != nil {
 			// note, this will only work for english-language git commands. If we force git to use english, and the error isn't this one, then the user will receive an english command they may not understand. I'm not sure what the best solution to this is. Running the command once in english and a second time in the native language is one option
 
@@ -183,13 +212,8 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 					}
 
 					onSuccess()
-					if err := gui.GitCommand.StashDo(0, "pop"); err != nil {
-						if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI}); err != nil {
-							return err
-						}
-						return gui.surfaceError(err)
-					}
-					return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI})
+					gui.afterCheckout(gui.getBranchesView(), previousHead)
+					return gui.popAutoStash()
 				}, nil)
 			}
 
@@ -198,6 +222,7 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 			}
 		}
 		onSuccess()
+		gui.afterCheckout(gui.getBranchesView(), previousHead)
 
 		return gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI})
 	})
@@ -267,15 +292,18 @@ func (gui *Gui) deleteNamedBranch(g *gocui.Gui, v *gocui.View, selectedBranch *c
 			"selectedBranchName": selectedBranch.Name,
 		},
 	)
+	branchesView := v
 	return gui.createConfirmationPanel(g, v, true, title, message, func(g *gocui.Gui, v *gocui.View) error {
-		if err := gui.GitCommand.DeleteBranch(selectedBranch.Name, force); err != nil {
-			errMessage := err.Error()
-			if !force && strings.Contains(errMessage, "is not fully merged") {
-				return gui.deleteNamedBranch(g, v, selectedBranch, true)
+		return gui.withTypedConfirmationIf(gui.needsTypedConfirmation("deleteBranch", selectedBranch.Name), branchesView, selectedBranch.Name, func() error {
+			if err := gui.GitCommand.DeleteBranch(selectedBranch.Name, force); err != nil {
+				errMessage := err.Error()
+				if !force && strings.Contains(errMessage, "is not fully merged") {
+					return gui.deleteNamedBranch(g, branchesView, selectedBranch, true)
+				}
+				return gui.createErrorPanel(errMessage)
 			}
-			return gui.createErrorPanel(errMessage)
-		}
-		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{BRANCHES}})
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{BRANCHES}})
+		})
 	}, nil)
 }
 
@@ -285,7 +313,7 @@ func (gui *Gui) mergeBranchIntoCheckedOutBranch(branchName string) error {
 	}
 
 	if gui.GitCommand.IsHeadDetached() {
-		return gui.createErrorPanel("Cannot merge branch in detached head state. You might have checked out a commit directly or a remote branch, in which case you should checkout the local branch you want to be on")
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantMergeInDetachedHead"))
 	}
 	checkedOutBranchName := gui.getCheckedOutBranch().Name
 	if checkedOutBranchName == branchName {
@@ -311,6 +339,10 @@ func (gui *Gui) handleMerge(g *gocui.Gui, v *gocui.View) error {
 		return err
 	}
 
+	if len(gui.State.MarkedBranches) > 0 {
+		return gui.handleOctopusMerge()
+	}
+
 	selectedBranchName := gui.getSelectedBranch().Name
 	return gui.mergeBranchIntoCheckedOutBranch(selectedBranchName)
 }
@@ -378,10 +410,12 @@ func (gui *Gui) handleFastForward(g *gocui.Gui, v *gocui.View) error {
 		_ = gui.createLoaderPanel(gui.g, v, message)
 
 		if gui.State.Panels.Branches.SelectedLine == 0 {
+			previousHead := gui.GitCommand.HeadSha()
 			if err := gui.GitCommand.PullWithoutPasswordCheck("--ff-only"); err != nil {
 				_ = gui.surfaceError(err)
 				return
 			}
+			gui.afterCheckout(v, previousHead)
 			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC})
 		} else {
 			if err := gui.GitCommand.FastForward(branch.Name, remoteName, remoteBranchName); err != nil {
@@ -419,6 +453,7 @@ func (gui *Gui) switchBranchesPanelContext(context string) error {
 	}
 
 	branchesView.TabIndex = contextTabIndexMap[context]
+	gui.announce(branchesView.Tabs[branchesView.TabIndex])
 
 	return gui.refreshBranchesViewWithSelection()
 }
@@ -0,0 +1,137 @@
+package gui
+
+import (
+	"context"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// runningCommand is a long running command the user can cancel, e.g. a fetch
+// from a slow remote, along with the side panel it was started from
+type runningCommand struct {
+	cancel   context.CancelFunc
+	viewName string
+}
+
+// withCancellableCommand runs f with a context that's cancelled if the user
+// presses escape on the command's loader panel or moves to another panel
+func (gui *Gui) withCancellableCommand(viewName string, f func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	command := &runningCommand{cancel: cancel, viewName: viewName}
+
+	gui.runningCommandMutex.Lock()
+	gui.runningCommand = command
+	gui.runningCommandMutex.Unlock()
+
+	defer func() {
+		cancel()
+		gui.runningCommandMutex.Lock()
+		defer gui.runningCommandMutex.Unlock()
+		if gui.runningCommand == command {
+			gui.runningCommand = nil
+		}
+	}()
+
+	return f(ctx)
+}
+
+func (gui *Gui) cancelRunningCommand() {
+	gui.runningCommandMutex.Lock()
+	defer gui.runningCommandMutex.Unlock()
+	if gui.runningCommand != nil {
+		gui.Log.Info("cancelling running command")
+		gui.runningCommand.cancel()
+		gui.runningCommand = nil
+	}
+}
+
+// cancelCommandOnPanelSwitch cancels the running command once the user moves
+// away from the panel they started it from, since they've lost interest in it
+func (gui *Gui) cancelCommandOnPanelSwitch(viewName string) {
+	gui.runningCommandMutex.Lock()
+	command := gui.runningCommand
+	gui.runningCommandMutex.Unlock()
+	if command != nil && command.viewName != viewName {
+		gui.cancelRunningCommand()
+	}
+	if viewName != "commits" {
+		gui.cancelLoadingFullLog()
+	}
+}
+
+// createCancellableLoaderPanel is a loader panel which cancels the running
+// command when the user closes it with escape
+func (gui *Gui) createCancellableLoaderPanel(v *gocui.View, prompt string) error {
+	return gui.createPopupPanel(gui.g, v, "", prompt, true, true, false, nil, func(*gocui.Gui, *gocui.View) error {
+		gui.cancelRunningCommand()
+		return nil
+	})
+}
+
+// warnAboutIndexLock tells the user if the index is still locked after we
+// cancelled a command. The lock may have been left behind by the command we
+// killed, or it may belong to some other git process that's still writing the
+// index, and we can't tell which, so we leave it to the user to remove it once
+// they're sure nothing is using it
+func (gui *Gui) warnAboutIndexLock() {
+	if gui.GitCommand.HasIndexLock() {
+		_ = gui.createErrorPanel(gui.Tr.TemplateLocalize("IndexLockLeftBehind", Teml{"path": gui.GitCommand.IndexLockPath()}))
+	}
+}
+
+// loadingLog is a load of the commits panel's log. Loading the full log of a
+// huge repo can take a long time, so the user can give up on that
+type loadingLog struct {
+	cancel context.CancelFunc
+	full   bool
+}
+
+// withCancellableLog runs f with a context that's cancelled if a newer load of
+// the commits panel's log supersedes this one, or if this is the full log and
+// the user gives up on it
+func (gui *Gui) withCancellableLog(full bool, f func(ctx context.Context) error) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	log := &loadingLog{cancel: cancel, full: full}
+
+	gui.runningCommandMutex.Lock()
+	if gui.loadingLog != nil {
+		gui.loadingLog.cancel()
+	}
+	gui.loadingLog = log
+	gui.runningCommandMutex.Unlock()
+
+	defer func() {
+		cancel()
+		gui.runningCommandMutex.Lock()
+		defer gui.runningCommandMutex.Unlock()
+		if gui.loadingLog == log {
+			gui.loadingLog = nil
+		}
+	}()
+
+	return f(ctx)
+}
+
+// cancelLoadingFullLog gives up on loading the full log, going back to only
+// loading the first few hundred commits, and returns whether there was one
+func (gui *Gui) cancelLoadingFullLog() bool {
+	gui.runningCommandMutex.Lock()
+	defer gui.runningCommandMutex.Unlock()
+	if gui.loadingLog == nil || !gui.loadingLog.full {
+		return false
+	}
+	gui.Log.Info("cancelling loading the full log")
+	gui.State.Panels.Commits.LimitCommits = true
+	gui.loadingLog.cancel()
+	gui.loadingLog = nil
+	return true
+}
+
+// handleReturn cancels loading the full log if we're in the middle of it, and
+// otherwise does what escape usually does
+func (gui *Gui) handleReturn(g *gocui.Gui, v *gocui.View) error {
+	if gui.cancelLoadingFullLog() {
+		return nil
+	}
+	return gui.handleQuit(g, v)
+}
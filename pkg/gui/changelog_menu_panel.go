@@ -0,0 +1,114 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+const changelogFile = "CHANGELOG.md"
+
+// handleCreateChangelogMenu offers the ranges we can generate a changelog
+// section for: the commits since the selected tag, the commits that went into
+// it, or a custom range
+func (gui *Gui) handleCreateChangelogMenu(g *gocui.Gui, v *gocui.View) error {
+	tag := gui.getSelectedTag()
+	if tag == nil {
+		return nil
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("changelogUnreleased"), fmt.Sprintf("%s..HEAD", tag.Name)},
+			onPress: func() error {
+				return gui.previewChangelog(tag.Name, "HEAD", gui.Tr.SLocalize("UnreleasedChangelogTitle"))
+			},
+		},
+	}
+
+	// tags are sorted by version, oldest first, so the one above the selected
+	// tag is the release before it
+	previousTag := ""
+	if selectedLine := gui.State.Panels.Tags.SelectedLine; selectedLine > 0 {
+		previousTag = gui.State.Tags[selectedLine-1].Name
+	}
+	tagRange := tag.Name
+	if previousTag != "" {
+		tagRange = fmt.Sprintf("%s..%s", previousTag, tag.Name)
+	}
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{gui.Tr.TemplateLocalize("changelogForTag", Teml{"tag": tag.Name}), tagRange},
+		onPress: func() error {
+			return gui.previewChangelog(previousTag, tag.Name, tag.Name)
+		},
+	})
+
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{gui.Tr.SLocalize("changelogCustomRange"), ""},
+		onPress: func() error {
+			return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("ChangelogRangePrompt"), fmt.Sprintf("%s..HEAD", tag.Name), func(g *gocui.Gui, promptView *gocui.View) error {
+				split := strings.SplitN(strings.TrimSpace(promptView.Buffer()), "..", 2)
+				from, to := "", split[0]
+				if len(split) == 2 {
+					from, to = split[0], split[1]
+				}
+				title := to
+				if to == "HEAD" {
+					title = gui.Tr.SLocalize("UnreleasedChangelogTitle")
+				}
+				// the prompt closes once we return, so the menu has to wait
+				g.Update(func(*gocui.Gui) error {
+					return gui.previewChangelog(from, to, title)
+				})
+				return nil
+			})
+		},
+	})
+
+	return gui.createMenu(gui.Tr.SLocalize("ChangelogMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// previewChangelog shows the changelog section for a range in the main view,
+// with a menu for copying it or writing it into the changelog file
+func (gui *Gui) previewChangelog(from string, to string, title string) error {
+	commits, err := gui.GitCommand.GetChangelogCommits(from, to)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(commits) == 0 {
+		return gui.createErrorPanel(gui.Tr.TemplateLocalize("NoCommitsForChangelog", Teml{"range": strings.TrimPrefix(fmt.Sprintf("%s..%s", from, to), "..")}))
+	}
+	section := commands.BuildChangelog(title, commits)
+
+	if err := gui.showInMainView(func() error {
+		gui.getMainView().Title = gui.Tr.SLocalize("ChangelogPreviewTitle")
+		return gui.newStringTask("main", section)
+	}); err != nil {
+		return err
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("copyChangelog"),
+			onPress: func() error {
+				return gui.OSCommand.CopyToClipboard(section)
+			},
+		},
+		{
+			displayString: gui.Tr.TemplateLocalize("writeChangelog", Teml{"file": changelogFile}),
+			onPress: func() error {
+				if err := gui.GitCommand.WriteChangelogSection(changelogFile, section); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+			},
+		},
+	}
+
+	gui.g.Update(func(*gocui.Gui) error {
+		return gui.createMenu(gui.Tr.SLocalize("ChangelogMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+	})
+	return nil
+}
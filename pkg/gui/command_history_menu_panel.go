@@ -0,0 +1,80 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleCreateCommandHistoryMenu lists the commands lazygit has run, newest
+// first. Picking one shows its output in the main view
+func (gui *Gui) handleCreateCommandHistoryMenu(g *gocui.Gui, v *gocui.View) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	records := gui.OSCommand.CommandHistory()
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("exportCommandHistory")},
+			onPress: func() error {
+				return gui.handleExportCommandHistory(v)
+			},
+		},
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		record := records[i]
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: gui.commandRecordDisplayStrings(record),
+			onPress: func() error {
+				return gui.showInMainView(func() error {
+					gui.getMainView().Title = record.Command
+					return gui.newStringTask("main", record.Output)
+				})
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CommandHistoryTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// commandRecordDisplayStrings is e.g. '14:02:31 120ms ✓ git status', or
+// '✗128' for a command which exited with status 128
+func (gui *Gui) commandRecordDisplayStrings(record commands.CommandRecord) []string {
+	icons := gui.icons()
+	status := utils.ColoredString(icons.Good, color.FgGreen)
+	if record.ExitCode > 0 {
+		status = utils.ColoredString(icons.Bad+strconv.Itoa(record.ExitCode), color.FgRed)
+	} else if record.Failed() {
+		status = utils.ColoredString(icons.Bad, color.FgRed)
+	}
+	return []string{
+		utils.ColoredString(record.Start.Format("15:04:05"), color.FgBlue),
+		record.Duration.Round(time.Millisecond).String(),
+		status,
+		record.Command,
+	}
+}
+
+// handleExportCommandHistory asks where to write the command history to, in
+// the temp directory unless told otherwise
+func (gui *Gui) handleExportCommandHistory(v *gocui.View) error {
+	defaultPath := filepath.Join(os.TempDir(), "lazygit-command-history.log")
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("ExportCommandHistoryPrompt"), defaultPath, func(g *gocui.Gui, promptView *gocui.View) error {
+		path := strings.TrimSpace(promptView.Buffer())
+		if path == "" {
+			return nil
+		}
+		if err := gui.OSCommand.ExportCommandHistory(path); err != nil {
+			return gui.surfaceError(err)
+		}
+		return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("CommandHistoryTitle"), gui.Tr.TemplateLocalize("CommandHistoryExported", Teml{"path": path}), nil, nil)
+	})
+}
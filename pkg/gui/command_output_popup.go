@@ -0,0 +1,134 @@
+package gui
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// streamCommandOutput runs a command in the background, showing its output in
+// a popup as it arrives. Once the command exits the popup is closed and onDone
+// is called on the gui goroutine with everything the command printed
+func (gui *Gui) streamCommandOutput(currentView *gocui.View, title string, cmdStr string, onDone func(output string, err error) error) error {
+	return gui.streamFormattedCommandOutput(currentView, title, cmdStr, nil, onDone)
+}
+
+// streamFormattedCommandOutput is streamCommandOutput but passes each line
+// through formatLine before showing it. Lines it formats as blank are left out
+// of the popup, though onDone still gets the unformatted output
+func (gui *Gui) streamFormattedCommandOutput(currentView *gocui.View, title string, cmdStr string, formatLine func(string) string, onDone func(output string, err error) error) error {
+	return gui.streamExecutableOutput(currentView, title, gui.OSCommand.ExecutableFromString(cmdStr), formatLine, onDone)
+}
+
+// streamExecutableOutput is streamFormattedCommandOutput for a command that has
+// already been prepared, e.g. one that needs to run in the user's shell
+func (gui *Gui) streamExecutableOutput(currentView *gocui.View, title string, cmd *exec.Cmd, formatLine func(string) string, onDone func(output string, err error) error) error {
+	return gui.streamOutput(currentView, title, formatLine, func(ctx context.Context, onLine func(string)) error {
+		return gui.OSCommand.RunExecutableWithOutputStream(ctx, cmd, onLine)
+	}, onDone)
+}
+
+// streamOutput shows the lines run passes to onLine in a popup as they arrive.
+// Closing the popup with escape cancels run's context, in which case onDone
+// isn't called
+func (gui *Gui) streamOutput(currentView *gocui.View, title string, formatLine func(string) string, run func(ctx context.Context, onLine func(string)) error, onDone func(output string, err error) error) error {
+	// we're on the gui goroutine here, so unlike with createPopupPanel, the popup
+	// is guaranteed to exist before any output arrives
+	gui.onNewPopupPanel()
+	if _, err := gui.prepareConfirmationPanel(currentView, title, "", true); err != nil {
+		return err
+	}
+	onClose := func(*gocui.Gui, *gocui.View) error {
+		gui.cancelRunningCommand()
+		return nil
+	}
+	if err := gui.setKeyBindings(gui.g, nil, onClose, true); err != nil {
+		return err
+	}
+
+	var mutex sync.Mutex
+	output := []string{}
+	displayed := []string{}
+	done := false
+
+	// updates aren't guaranteed to run in the order they're queued, so each one
+	// renders everything we've received so far rather than just the new line
+	renderOutput := func(g *gocui.Gui) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if done {
+			return nil
+		}
+		v, err := g.View("confirmation")
+		if err != nil {
+			return nil // the user may have closed the popup
+		}
+		content := strings.Join(displayed, "\n")
+		gui.setViewContent(v, content)
+		width, height := v.Size()
+		if lineCount := gui.getMessageHeight(true, content, width); lineCount > height {
+			_ = v.SetOrigin(0, lineCount-height)
+		}
+		return nil
+	}
+
+	go func() {
+		err := gui.withCancellableCommand(currentView.Name(), func(ctx context.Context) error {
+			return run(ctx, func(line string) {
+				mutex.Lock()
+				output = appendOutputLine(output, line)
+				if formatLine != nil {
+					line = formatLine(line)
+				}
+				if line != "" {
+					displayed = appendOutputLine(displayed, line)
+				}
+				mutex.Unlock()
+				gui.g.Update(renderOutput)
+			})
+		})
+
+		gui.g.Update(func(g *gocui.Gui) error {
+			mutex.Lock()
+			done = true
+			finalOutput := strings.Join(output, "\n")
+			mutex.Unlock()
+
+			if err == context.Canceled {
+				// the user closed the popup themselves
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+			}
+			if err := gui.closeConfirmationPrompt(g, false); err != nil {
+				return err
+			}
+			return onDone(finalOutput, err)
+		})
+	}()
+
+	return nil
+}
+
+// appendOutputLine adds a line of command output, replacing the previous line
+// if both are updates from the same git progress meter (e.g. 'Counting
+// objects: 45% (9/20)') so that the meter ticks over in place
+func appendOutputLine(output []string, line string) []string {
+	if len(output) > 0 {
+		last := output[len(output)-1]
+		if prefix := progressPrefix(line); prefix != "" && prefix == progressPrefix(last) {
+			output[len(output)-1] = line
+			return output
+		}
+	}
+	return append(output, line)
+}
+
+func progressPrefix(line string) string {
+	split := strings.SplitN(line, ": ", 2)
+	if len(split) != 2 || !strings.Contains(split[1], "%") {
+		return ""
+	}
+	return split[0]
+}
@@ -0,0 +1,46 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleEditCommitAnnotation prompts for a local annotation on the selected
+// commit, pre-filled with the existing one. Clearing the prompt removes it
+func (gui *Gui) handleEditCommitAnnotation(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit()
+	if commit == nil {
+		return nil
+	}
+
+	existing := gui.GitCommand.GetCommitAnnotation(commit.Sha)
+	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("AnnotateCommitTitle"), existing, func(g *gocui.Gui, promptView *gocui.View) error {
+		if err := gui.GitCommand.SetCommitAnnotation(commit.Sha, promptView.Buffer()); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		annotatedShas := gui.GitCommand.GetAnnotatedShas()
+		commands.SetAnnotatedStatuses(gui.State.Commits, annotatedShas)
+		commands.SetAnnotatedStatuses(gui.State.FilteredReflogCommits, annotatedShas)
+		if err := gui.renderBranchCommitsWithSelection(); err != nil {
+			return err
+		}
+		return gui.refreshCommitMessagePreview(commit)
+	})
+}
+
+// commitAnnotationDisplayString renders a commit's annotation for the bottom
+// of the commit message preview
+func (gui *Gui) commitAnnotationDisplayString(commit *commands.Commit) string {
+	if !commit.Annotated {
+		return ""
+	}
+	annotation := gui.GitCommand.GetCommitAnnotation(commit.Sha)
+	if annotation == "" {
+		return ""
+	}
+	heading := utils.ColoredString(gui.Tr.SLocalize("CommitAnnotationHeading"), color.FgMagenta)
+	return "\n\n" + heading + "\n" + annotation
+}
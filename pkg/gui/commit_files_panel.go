@@ -1,10 +1,12 @@
 package gui
 
 import (
+	"github.com/fatih/color"
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 func (gui *Gui) getSelectedCommitFile() *commands.CommitFile {
@@ -46,6 +48,13 @@ func (gui *Gui) handleCommitFileSelect(g *gocui.Gui, v *gocui.View) error {
 
 	v.FocusPoint(0, gui.State.Panels.CommitFiles.SelectedLine)
 
+	if !commitFile.IsDirectory && commitFile.IsBinary {
+		return gui.newLazyStringTask("main", func() string {
+			oldVersion, newVersion := gui.GitCommand.CommitFileAssetVersions(commitFile.Sha, commitFile.Name)
+			return commands.AssetSummary(commitFile.Name, oldVersion, newVersion)
+		})
+	}
+
 	cmd := gui.OSCommand.ExecutableFromString(
 		gui.GitCommand.ShowCommitFileCmdStr(commitFile.Sha, commitFile.Name, false),
 	)
@@ -60,14 +69,43 @@ func (gui *Gui) handleSwitchToCommitsPanel(g *gocui.Gui, v *gocui.View) error {
 	return gui.switchFocus(g, v, gui.getCommitsView())
 }
 
+// handleCheckoutCommitFile shows what checking out the file at this commit
+// would do to the working tree, and asks whether the index should be updated too
 func (gui *Gui) handleCheckoutCommitFile(g *gocui.Gui, v *gocui.View) error {
-	file := gui.State.CommitFiles[gui.State.Panels.CommitFiles.SelectedLine]
+	file := gui.getSelectedCommitFile()
+	if file == nil {
+		return nil
+	}
 
-	if err := gui.GitCommand.CheckoutFile(file.Sha, file.Name); err != nil {
-		return gui.surfaceError(err)
+	gui.getMainView().Title = gui.Tr.SLocalize("CheckoutPreviewTitle")
+	cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.CheckoutFileDiffCmdStr(file.Sha, file.Name))
+	if err := gui.newPtyTask("main", cmd); err != nil {
+		gui.Log.Error(err)
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("checkoutFileIntoWorkingTree"), utils.ColoredString("git restore --source "+file.Sha, color.FgBlue)},
+			onPress: func() error {
+				if err := gui.GitCommand.RestoreFileToWorkingTree(file.Sha, file.Name); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("checkoutFileIntoWorkingTreeAndIndex"), utils.ColoredString("git checkout "+file.Sha, color.FgBlue)},
+			onPress: func() error {
+				if err := gui.GitCommand.CheckoutFile(file.Sha, file.Name); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+			},
+		},
 	}
 
-	return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+	title := gui.Tr.TemplateLocalize("CheckoutFileAtCommit", Teml{"file": file.Name, "sha": gui.formatting().Sha(file.Sha)})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
 }
 
 func (gui *Gui) handleDiscardOldFileChange(g *gocui.Gui, v *gocui.View) error {
@@ -75,7 +113,11 @@ func (gui *Gui) handleDiscardOldFileChange(g *gocui.Gui, v *gocui.View) error {
 		return err
 	}
 
-	fileName := gui.State.CommitFiles[gui.State.Panels.CommitFiles.SelectedLine].Name
+	file := gui.getSelectedCommitFile()
+	if file.IsDirectory {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NotSupportedForDirectories"))
+	}
+	fileName := file.Name
 
 	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("DiscardFileChangesTitle"), gui.Tr.SLocalize("DiscardFileChangesPrompt"), func(g *gocui.Gui, v *gocui.View) error {
 		return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
@@ -108,12 +150,22 @@ func (gui *Gui) refreshCommitFilesView() error {
 	if err != nil {
 		return gui.surfaceError(err)
 	}
+	if gui.State.ShowCommitFileTree {
+		files = commands.BuildCommitFileTree(files)
+	}
+	commands.SetViewedStatuses(files, gui.GitCommand.GetViewedFiles(commit.Sha))
 	gui.State.CommitFiles = files
 
 	gui.refreshSelectedLine(&gui.State.Panels.CommitFiles.SelectedLine, len(gui.State.CommitFiles))
 
 	commitsFileView := gui.getCommitFilesView()
-	displayStrings := presentation.GetCommitFileListDisplayStrings(gui.State.CommitFiles, gui.State.Diff.Ref)
+	commitsFileView.Subtitle = gui.reviewProgressSubtitle()
+	formatting := gui.formatting()
+	if gui.State.ShowCommitFileTree {
+		// the tree is the repo's, from its root down
+		formatting.PathPrefix = ""
+	}
+	displayStrings := presentation.GetCommitFileListDisplayStrings(gui.State.CommitFiles, gui.State.Diff.Ref, formatting)
 	gui.renderDisplayStrings(commitsFileView, displayStrings)
 
 	return gui.handleCommitFileSelect(gui.g, commitsFileView)
@@ -142,7 +194,19 @@ func (gui *Gui) handleToggleFileForPatch(g *gocui.Gui, v *gocui.View) error {
 			}
 		}
 
-		gui.GitCommand.PatchManager.ToggleFileWhole(commitFile.Name)
+		if commitFile.IsDirectory {
+			// if the whole directory is already in the patch we take it out,
+			// otherwise we add whatever isn't in there yet
+			for _, file := range commands.FilesInDirectory(gui.State.CommitFiles, commitFile.Name) {
+				if commitFile.Status == commands.WHOLE {
+					gui.GitCommand.PatchManager.RemoveFile(file.Name)
+				} else {
+					gui.GitCommand.PatchManager.AddFile(file.Name)
+				}
+			}
+		} else {
+			gui.GitCommand.PatchManager.ToggleFileWhole(commitFile.Name)
+		}
 
 		return gui.refreshCommitFilesView()
 	}
@@ -160,6 +224,9 @@ func (gui *Gui) handleToggleFileForPatch(g *gocui.Gui, v *gocui.View) error {
 func (gui *Gui) startPatchManager() error {
 	diffMap := map[string]string{}
 	for _, commitFile := range gui.State.CommitFiles {
+		if commitFile.IsDirectory {
+			continue
+		}
 		commitText, err := gui.GitCommand.ShowCommitFile(commitFile.Sha, commitFile.Name, true)
 		if err != nil {
 			return err
@@ -190,6 +257,9 @@ func (gui *Gui) enterCommitFile(selectedLineIdx int) error {
 		gui.renderString(gui.g, "commitFiles", gui.Tr.SLocalize("NoCommiteFiles"))
 		return nil
 	}
+	if commitFile.IsDirectory {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CannotEnterDirectory"))
+	}
 
 	enterTheFile := func(selectedLineIdx int) error {
 		if !gui.GitCommand.PatchManager.CommitSelected() {
@@ -221,3 +291,8 @@ func (gui *Gui) onCommitFilesPanelSearchSelect(selectedLine int) error {
 	gui.State.Panels.CommitFiles.SelectedLine = selectedLine
 	return gui.handleCommitFileSelect(gui.g, gui.getCommitFilesView())
 }
+
+func (gui *Gui) handleToggleCommitFileTree(g *gocui.Gui, v *gocui.View) error {
+	gui.State.ShowCommitFileTree = !gui.State.ShowCommitFileTree
+	return gui.refreshCommitFilesView()
+}
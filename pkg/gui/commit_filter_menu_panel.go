@@ -0,0 +1,115 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// setCommitFilter narrows the commits panel down to the commits matching the
+// filter. An empty filter shows everything again
+func (gui *Gui) setCommitFilter(filter commands.CommitFilter) error {
+	gui.State.CommitFilter = filter
+	gui.State.Panels.Commits.SelectedLine = 0
+
+	return gui.refreshSidePanels(refreshOptions{scope: []int{COMMITS}})
+}
+
+// commitsTabTitle is the label of the commits tab, which mentions the active
+// commit filter and whether we're only showing the mainline
+func (gui *Gui) commitsTabTitle() string {
+	qualifiers := []string{}
+	if gui.State.FirstParent {
+		qualifiers = append(qualifiers, "first-parent")
+	}
+	if !gui.State.CommitFilter.IsEmpty() {
+		qualifiers = append(qualifiers, gui.State.CommitFilter.String())
+	}
+	if len(qualifiers) == 0 {
+		return "Commits"
+	}
+	return fmt.Sprintf("Commits (%s)", strings.Join(qualifiers, "; "))
+}
+
+// startOfWeek returns the date of the most recent monday
+func startOfWeek(now time.Time) string {
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	return now.AddDate(0, 0, -daysSinceMonday).Format("2006-01-02")
+}
+
+func (gui *Gui) handleCreateCommitFilterMenu(g *gocui.Gui, v *gocui.View) error {
+	current := gui.State.CommitFilter
+
+	// each prompt edits one field of the current filter so that they can be
+	// combined, e.g. a date range for a particular author
+	promptFor := func(title string, initialValue string, apply func(filter *commands.CommitFilter, value string)) func() error {
+		return func() error {
+			return gui.createPromptPanel(gui.g, v, title, initialValue, func(g *gocui.Gui, promptView *gocui.View) error {
+				filter := gui.State.CommitFilter
+				apply(&filter, strings.TrimSpace(promptView.Buffer()))
+				return gui.setCommitFilter(filter)
+			})
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("commitFilterToday"),
+			onPress: func() error {
+				return gui.setCommitFilter(commands.CommitFilter{Since: "midnight", Author: current.Author})
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("commitFilterThisWeek"),
+			onPress: func() error {
+				return gui.setCommitFilter(commands.CommitFilter{Since: startOfWeek(time.Now()), Author: current.Author})
+			},
+		},
+	}
+
+	if email := gui.GitCommand.CurrentUserEmail(); email != "" {
+		menuItems = append(menuItems, &menuItem{
+			displayString: fmt.Sprintf("%s (%s)", gui.Tr.SLocalize("commitFilterMine"), email),
+			onPress: func() error {
+				filter := current
+				filter.Author = email
+				return gui.setCommitFilter(filter)
+			},
+		})
+	}
+
+	menuItems = append(menuItems,
+		&menuItem{
+			displayString: gui.Tr.SLocalize("commitFilterSince"),
+			onPress: promptFor(gui.Tr.SLocalize("CommitFilterSinceTitle"), current.Since, func(filter *commands.CommitFilter, value string) {
+				filter.Since = value
+			}),
+		},
+		&menuItem{
+			displayString: gui.Tr.SLocalize("commitFilterUntil"),
+			onPress: promptFor(gui.Tr.SLocalize("CommitFilterUntilTitle"), current.Until, func(filter *commands.CommitFilter, value string) {
+				filter.Until = value
+			}),
+		},
+		&menuItem{
+			displayString: gui.Tr.SLocalize("commitFilterAuthor"),
+			onPress: promptFor(gui.Tr.SLocalize("CommitFilterAuthorTitle"), current.Author, func(filter *commands.CommitFilter, value string) {
+				filter.Author = value
+			}),
+		},
+	)
+
+	if !current.IsEmpty() {
+		menuItems = append(menuItems, &menuItem{
+			displayString: fmt.Sprintf("%s (%s)", gui.Tr.SLocalize("clearCommitFilter"), current.String()),
+			onPress: func() error {
+				return gui.setCommitFilter(commands.CommitFilter{})
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CommitFilterMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
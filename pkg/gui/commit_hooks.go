@@ -0,0 +1,44 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// commitWithHookOutput runs the commit in the background, streaming the output
+// of the repo's commit hooks into a popup as they run. If the commit fails the
+// output stays on screen along with the exit code, and the user can retry
+// without the hooks
+func (gui *Gui) commitWithHookOutput(message string, flags string, commitMessageView *gocui.View) error {
+	return gui.streamCommandOutput(commitMessageView, gui.Tr.SLocalize("RunningCommitHooks"), gui.GitCommand.CommitCmdStr(message, flags), func(output string, err error) error {
+		if err != nil {
+			return gui.handleCommitHookFailure(commitMessageView, message, output, err)
+		}
+		return gui.onCommitSuccess(gui.g, commitMessageView)
+	})
+}
+
+func (gui *Gui) handleCommitHookFailure(commitMessageView *gocui.View, message string, output string, err error) error {
+	if output == "" {
+		output = err.Error()
+	}
+	title := gui.Tr.TemplateLocalize("CommitHookFailedTitle", Teml{"exitCode": commands.ExitCode(err)})
+	prompt := output + "\n\n" + gui.Tr.SLocalize("CommitHookFailedPrompt")
+
+	return gui.createConfirmationPanel(gui.g, commitMessageView, false, title, prompt, func(g *gocui.Gui, v *gocui.View) error {
+		ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.Commit(message, "--no-verify"))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		return gui.onCommitSuccess(g, commitMessageView)
+	}, func(g *gocui.Gui, v *gocui.View) error {
+		// back to the message so the user can fix whatever the hook complained about
+		if _, err := g.SetViewOnTop("commitMessage"); err != nil {
+			return err
+		}
+		return gui.switchFocus(g, nil, commitMessageView)
+	})
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"fmt"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -30,11 +31,21 @@ func (gui *Gui) handleCommitConfirm(g *gocui.Gui, v *gocui.View) error {
 	if message == "" {
 		return gui.createErrorPanel(gui.Tr.SLocalize("CommitWithoutMessageErr"))
 	}
+	if trailers := gui.GitCommand.AutoTrailers(); len(trailers) > 0 {
+		var err error
+		message, err = gui.GitCommand.AddTrailers(message, trailers)
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+	}
 	flags := ""
 	skipHookPrefix := gui.Config.GetUserConfig().GetString("git.skipHookPrefix")
 	if skipHookPrefix != "" && strings.HasPrefix(message, skipHookPrefix) {
 		flags = "--no-verify"
 	}
+	if flags != "--no-verify" && gui.GitCommand.CanStreamCommitHooks() {
+		return gui.commitWithHookOutput(message, flags, v)
+	}
 	ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.Commit(message, flags))
 	if err != nil {
 		return err
@@ -43,6 +54,10 @@ func (gui *Gui) handleCommitConfirm(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
+	return gui.onCommitSuccess(g, v)
+}
+
+func (gui *Gui) onCommitSuccess(g *gocui.Gui, v *gocui.View) error {
 	v.Clear()
 	_ = v.SetCursor(0, 0)
 	_ = v.SetOrigin(0, 0)
@@ -56,6 +71,49 @@ func (gui *Gui) handleCommitClose(g *gocui.Gui, v *gocui.View) error {
 	return gui.switchFocus(g, v, gui.getFilesView())
 }
 
+// handleCreateTrailerMenu lets the user add one of the standard trailers to the
+// message they're writing. Signed-off-by is filled in with their identity, the
+// others ask for whose name should go in the trailer
+func (gui *Gui) handleCreateTrailerMenu(g *gocui.Gui, commitMessageView *gocui.View) error {
+	insertTrailer := func(trailer string) error {
+		message, err := gui.GitCommand.AddTrailers(gui.trimmedContent(commitMessageView), []string{trailer})
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+
+		commitMessageView.Clear()
+		fmt.Fprint(commitMessageView, message)
+		lines := strings.Split(message, "\n")
+		_ = commitMessageView.SetOrigin(0, 0)
+		_ = commitMessageView.SetCursor(len(lines[len(lines)-1]), len(lines)-1)
+		gui.RenderCommitLength()
+		return nil
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{"Signed-off-by", gui.GitCommand.GetUserIdentity()},
+			onPress: func() error {
+				return insertTrailer("Signed-off-by: " + gui.GitCommand.GetUserIdentity())
+			},
+		},
+	}
+
+	for _, key := range []string{"Reviewed-by", "Acked-by", "Tested-by", "Co-authored-by"} {
+		key := key
+		menuItems = append(menuItems, &menuItem{
+			displayString: key,
+			onPress: func() error {
+				return gui.createPromptPanel(gui.g, commitMessageView, key, "", func(g *gocui.Gui, promptView *gocui.View) error {
+					return insertTrailer(key + ": " + gui.trimmedContent(promptView))
+				})
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("InsertTrailer"), menuItems, createMenuOptions{showCancel: true})
+}
+
 func (gui *Gui) handleCommitFocused(g *gocui.Gui, v *gocui.View) error {
 	if _, err := g.SetViewOnTop("commitMessage"); err != nil {
 		return err
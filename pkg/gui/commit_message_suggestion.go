@@ -0,0 +1,58 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// suggestCommitMessage runs the user's commitMessageCommand over the staged
+// diff in the background and puts its suggestion in the commit message panel,
+// after any commit prefix, for them to edit or clear. When the suggestion is
+// automatic we leave the panel alone if they've started typing in the meantime
+func (gui *Gui) suggestCommitMessage(prefix string, automatic bool) error {
+	command := gui.Config.GetUserConfig().GetString("git.commitMessageCommand")
+	if command == "" {
+		if automatic {
+			return nil
+		}
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoCommitMessageCommand"))
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("GeneratingCommitMessage"), func() error {
+		suggestion, err := gui.GitCommand.GenerateCommitMessage(command)
+		gui.g.Update(func(g *gocui.Gui) error {
+			if err != nil {
+				return gui.createErrorPanel(gui.Tr.TemplateLocalize("CommitMessageCommandFailed", Teml{"error": err.Error()}))
+			}
+
+			v := gui.getCommitMessageView()
+			if g.CurrentView() != v {
+				// they've committed or given up on committing already
+				return nil
+			}
+			if automatic && gui.trimmedContent(v) != strings.TrimSpace(prefix) {
+				return nil
+			}
+
+			message := prefix + suggestion
+			v.Clear()
+			fmt.Fprint(v, message)
+			lines := strings.Split(message, "\n")
+			_ = v.SetOrigin(0, 0)
+			_ = v.SetCursor(len(lines[len(lines)-1]), len(lines)-1)
+			gui.RenderCommitLength()
+			return nil
+		})
+		return nil
+	})
+}
+
+func (gui *Gui) handleSuggestCommitMessage(g *gocui.Gui, v *gocui.View) error {
+	prefix, err := gui.commitPrefix()
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+	return gui.suggestCommitMessage(prefix, false)
+}
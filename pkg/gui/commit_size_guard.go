@@ -0,0 +1,128 @@
+package gui
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// largeStagedFiles returns the staged files bigger than the configured limit.
+// We go by the size on disk, which is what gets staged unless the file has
+// changed since
+func (gui *Gui) largeStagedFiles(staged []*commands.File) []*commands.File {
+	maxFileSizeMB := gui.Config.GetUserConfig().GetInt("git.commitSizeGuard.maxFileSizeMB")
+	if maxFileSizeMB <= 0 {
+		return nil
+	}
+
+	large := []*commands.File{}
+	for _, file := range staged {
+		split := strings.Split(file.Name, " -> ")
+		info, err := os.Stat(split[len(split)-1])
+		if err != nil || info.IsDir() {
+			continue
+		}
+		if info.Size() > int64(maxFileSizeMB)<<20 {
+			large = append(large, file)
+		}
+	}
+	return large
+}
+
+// busiestStagedDirectory returns the top level directory containing the most
+// staged files, which is usually the culprit when a commit has far too many
+// files in it (e.g. node_modules)
+func busiestStagedDirectory(staged []*commands.File) (string, int) {
+	counts := map[string]int{}
+	for _, file := range staged {
+		split := strings.SplitN(file.Name, "/", 2)
+		if len(split) == 2 {
+			counts[split[0]]++
+		}
+	}
+
+	dirs := make([]string, 0, len(counts))
+	for dir := range counts {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	busiest := ""
+	for _, dir := range dirs {
+		if counts[dir] > counts[busiest] {
+			busiest = dir
+		}
+	}
+	return busiest, counts[busiest]
+}
+
+// withCommitSizeGuard warns the user before they commit an unusually large
+// file or an unusually large number of files, giving them the chance to
+// unstage the offenders. If nothing looks off we go straight to onCommit
+func (gui *Gui) withCommitSizeGuard(onCommit func() error) error {
+	staged := gui.stagedFiles()
+	maxFiles := gui.Config.GetUserConfig().GetInt("git.commitSizeGuard.maxFiles")
+	tooManyFiles := maxFiles > 0 && len(staged) > maxFiles
+	largeFiles := gui.largeStagedFiles(staged)
+
+	if !tooManyFiles && len(largeFiles) == 0 {
+		return onCommit()
+	}
+
+	warnings := []string{}
+	if tooManyFiles {
+		warnings = append(warnings, gui.Tr.TemplateLocalize("TooManyStagedFiles", Teml{"count": len(staged), "max": maxFiles}))
+	}
+	if len(largeFiles) > 0 {
+		names := make([]string, len(largeFiles))
+		for i, file := range largeFiles {
+			names[i] = "  " + file.Name
+		}
+		warnings = append(warnings, gui.Tr.TemplateLocalize("LargeStagedFiles", Teml{
+			"max":   gui.Config.GetUserConfig().GetInt("git.commitSizeGuard.maxFileSizeMB"),
+			"files": strings.Join(names, "\n"),
+		}))
+	}
+
+	if err := gui.showBehindMenu(gui.Tr.SLocalize("CommitSizeWarningTitle"), strings.Join(warnings, "\n\n")); err != nil {
+		return err
+	}
+
+	unstage := func(files []*commands.File) func() error {
+		return func() error {
+			for _, file := range files {
+				if err := gui.GitCommand.UnStageFile(file.Name, file.Tracked); err != nil {
+					return gui.surfaceError(err)
+				}
+			}
+			return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+		}
+	}
+
+	menuItems := []*menuItem{}
+	if len(largeFiles) > 0 {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.TemplateLocalize("unstageLargeFiles", Teml{"count": len(largeFiles)}),
+			onPress:       unstage(largeFiles),
+		})
+	}
+	if dir, count := busiestStagedDirectory(staged); tooManyFiles && dir != "" {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.TemplateLocalize("unstageDirectory", Teml{"dir": dir + "/", "count": count}),
+			onPress: func() error {
+				if err := gui.GitCommand.UnStageFile(dir, true); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+			},
+		})
+	}
+	menuItems = append(menuItems, &menuItem{
+		displayString: gui.Tr.SLocalize("commitAnyway"),
+		onPress:       onCommit,
+	})
+
+	return gui.createMenu(gui.Tr.SLocalize("CommitSizeWarningTitle"), menuItems, createMenuOptions{showCancel: true})
+}
@@ -1,9 +1,12 @@
 package gui
 
 import (
+	"context"
 	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
@@ -60,10 +63,11 @@ func (gui *Gui) handleCommitSelect(g *gocui.Gui, v *gocui.View) error {
 		return gui.renderDiff()
 	}
 
-	cmd := gui.OSCommand.ExecutableFromString(
-		gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.FilterPath),
-	)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	if err := gui.refreshCommitMessagePreview(commit); err != nil {
+		return err
+	}
+
+	if err := gui.newPtyTask("main", gui.showCommitCmd(commit.Sha)); err != nil {
 		gui.Log.Error(err)
 	}
 
@@ -121,11 +125,30 @@ func (gui *Gui) refreshCommitsWithLimit() error {
 		return err
 	}
 
-	commits, err := builder.GetCommits(commands.GetCommitsOptions{Limit: gui.State.Panels.Commits.LimitCommits, FilterPath: gui.State.FilterPath})
+	limit := gui.State.Panels.Commits.LimitCommits
+	var commits []*commands.Commit
+	err = gui.withCancellableLog(!limit, func(ctx context.Context) error {
+		var err error
+		commits, err = builder.GetCommitsWithContext(ctx, commands.GetCommitsOptions{Limit: limit, FilterPath: gui.State.FilterPath, BaseBranch: gui.State.ComparisonBase, Filter: gui.State.CommitFilter, FirstParent: gui.State.FirstParent})
+		return err
+	})
+	if err == context.Canceled {
+		// either a newer load superseded this one or the user gave up on the
+		// full log, and either way we keep showing the commits we had
+		return nil
+	}
 	if err != nil {
 		return err
 	}
+	if gui.State.FirstParent {
+		commits, err = builder.ExpandMerges(commits, gui.State.ExpandedMerges)
+		if err != nil {
+			return err
+		}
+	}
+	commands.SetAnnotatedStatuses(commits, gui.GitCommand.GetAnnotatedShas())
 	gui.State.Commits = commits
+	gui.markRefreshed("commits")
 
 	if gui.getCommitsView().Context == "branch-commits" {
 		if err := gui.renderBranchCommitsWithSelection(); err != nil {
@@ -586,7 +609,7 @@ func (gui *Gui) renderBranchCommitsWithSelection() error {
 	commitsView := gui.getCommitsView()
 
 	gui.refreshSelectedLine(&gui.State.Panels.Commits.SelectedLine, len(gui.State.Commits))
-	displayStrings := presentation.GetCommitListDisplayStrings(gui.State.Commits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Diff.Ref)
+	displayStrings := presentation.GetCommitListDisplayStrings(gui.State.Commits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Diff.Ref, gui.formatting())
 	gui.renderDisplayStrings(commitsView, displayStrings)
 	if gui.g.CurrentView() == commitsView && commitsView.Context == "branch-commits" {
 		if err := gui.handleCommitSelect(gui.g, commitsView); err != nil {
@@ -618,6 +641,7 @@ func (gui *Gui) switchCommitsPanelContext(context string) error {
 	}
 
 	commitsView.TabIndex = contextTabIndexMap[context]
+	gui.announce(commitsView.Tabs[commitsView.TabIndex])
 
 	return gui.refreshCommitsViewWithSelection()
 }
@@ -712,3 +736,89 @@ func (gui *Gui) handleClipboardCopyCommit(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.OSCommand.CopyToClipboard(commit.Sha)
 }
+
+// handleCreateCommitRefsMenu lists every ref pointing at the selected commit,
+// including the ones left out of the commits panel for lack of space
+func (gui *Gui) handleCreateCommitRefsMenu(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit()
+	if commit == nil {
+		return nil
+	}
+	if len(commit.Refs) == 0 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoRefsForCommit"))
+	}
+
+	menuItems := make([]*menuItem, len(commit.Refs))
+	for i, ref := range commit.Refs {
+		ref := ref
+		menuItems[i] = &menuItem{
+			displayStrings: []string{ref.Name, utils.ColoredString(ref.Kind, color.FgBlue)},
+			onPress: func() error {
+				return gui.handleCheckoutRef(ref.Name, handleCheckoutRefOptions{})
+			},
+		}
+		if ref.Kind == "remote" {
+			// checking out a remote branch directly would leave us on a
+			// detached HEAD, which is rarely what anyone wants
+			menuItems[i].displayStrings[1] = utils.ColoredString(gui.Tr.SLocalize("checkoutAsNewLocalBranch"), color.FgBlue)
+			menuItems[i].onPress = func() error {
+				return gui.checkoutRemoteRefAsNewBranch(ref.Name)
+			}
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CommitRefsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// checkoutRemoteRefAsNewBranch prompts for the name of a local branch to make
+// from a remote branch, suggesting the remote branch's own name, and checks it out
+func (gui *Gui) checkoutRemoteRefAsNewBranch(remoteRef string) error {
+	split := strings.SplitN(remoteRef, "/", 2)
+	suggestedName := split[len(split)-1]
+	message := gui.Tr.TemplateLocalize("NewBranchNameBranchOff", Teml{"branchName": remoteRef})
+	return gui.createPromptPanel(gui.g, gui.getCommitsView(), message, suggestedName, func(g *gocui.Gui, v *gocui.View) error {
+		if err := gui.GitCommand.NewBranch(gui.trimmedContent(v), remoteRef); err != nil {
+			return gui.surfaceError(err)
+		}
+		gui.State.Panels.Branches.SelectedLine = 0
+		gui.State.Panels.Commits.SelectedLine = 0
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+	})
+}
+
+// refreshCommitMessagePreview shows the selected commit's message in the
+// secondary panel, unless that's already showing a custom patch
+func (gui *Gui) refreshCommitMessagePreview(commit *commands.Commit) error {
+	if !gui.State.ShowCommitMessagePreview || gui.GitCommand.PatchManager.CommitSelected() {
+		return nil
+	}
+
+	message, err := gui.GitCommand.GetCommitMessage(commit.Sha)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.SplitMainPanel = true
+	secondaryView := gui.getSecondaryView()
+	secondaryView.Title = gui.Tr.SLocalize("CommitMessageTitle")
+	secondaryView.Wrap = true
+	return gui.newStringTask("secondary", presentation.GetCommitMessageDisplayString(commands.ParseCommitMessage(message))+gui.commitAnnotationDisplayString(commit))
+}
+
+func (gui *Gui) handleToggleCommitMessagePreview(g *gocui.Gui, v *gocui.View) error {
+	gui.State.ShowCommitMessagePreview = !gui.State.ShowCommitMessagePreview
+	return gui.handleCommitSelect(g, v)
+}
+
+func (gui *Gui) handleOpenCommitInBrowser(g *gocui.Gui, v *gocui.View) error {
+	commit := gui.getSelectedCommit()
+	if commit == nil {
+		return nil
+	}
+
+	if err := commands.NewPullRequest(gui.GitCommand).OpenCommit(commit.Sha); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+func (gui *Gui) inComparisonBaseMode() bool {
+	return gui.State.ComparisonBase != ""
+}
+
+// setComparisonBase makes the commits panel mark commits as merged based on
+// whether they (or an equivalent patch) are in the given ref. Choosing the
+// current comparison base again goes back to the default
+func (gui *Gui) setComparisonBase(ref string) error {
+	if gui.State.ComparisonBase == ref {
+		ref = ""
+	}
+	gui.State.ComparisonBase = ref
+
+	return gui.refreshSidePanels(refreshOptions{scope: []int{COMMITS}})
+}
+
+func (gui *Gui) exitComparisonBaseMode() error {
+	return gui.setComparisonBase("")
+}
+
+func (gui *Gui) handleSetComparisonBaseToBranch(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+
+	return gui.setComparisonBase(branch.Name)
+}
+
+func (gui *Gui) handleSetComparisonBaseToRemoteBranch(g *gocui.Gui, v *gocui.View) error {
+	remoteBranch := gui.getSelectedRemoteBranch()
+	if remoteBranch == nil {
+		return nil
+	}
+
+	return gui.setComparisonBase(remoteBranch.FullName())
+}
@@ -167,6 +167,12 @@ func (gui *Gui) setKeyBindings(g *gocui.Gui, handleConfirm, handleClose func(*go
 // this function is to be used over the more generic createErrorPanel, with
 // willLog set to false
 func (gui *Gui) createSpecificErrorPanel(message string, nextView *gocui.View, willLog bool) error {
+	if gui.State.GitTraceMode {
+		if command := gui.OSCommand.LastFailedCommand(message); command != "" {
+			return gui.offerGitTrace(command, message, nextView)
+		}
+	}
+
 	if willLog {
 		go func() {
 			// when reporting is switched on this log call sometimes introduces
@@ -15,5 +15,10 @@ func (gui *Gui) changeMainViewsContext(context string) {
 		gui.getSecondaryView().Context = context
 	}
 
+	// staging, patch building and merging happen in the main view itself
+	if context != "normal" {
+		gui.unpinMainView()
+	}
+
 	gui.State.MainContext = context
 }
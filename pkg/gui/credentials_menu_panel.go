@@ -0,0 +1,65 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) handleCreateCredentialsMenu(g *gocui.Gui, v *gocui.View) error {
+	helper := gui.GitCommand.GetCredentialHelper()
+	if helper == "" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoCredentialHelper"))
+	}
+
+	// origin may be pushed to somewhere else than it's fetched from, with
+	// credentials of its own
+	urls := []string{gui.GitCommand.GetRemoteURL()}
+	if _, pushURLs, err := gui.GitCommand.GetRemoteURLs("origin"); err == nil {
+		for _, pushURL := range pushURLs {
+			if !utils.IncludesString(urls, pushURL) {
+				urls = append(urls, pushURL)
+			}
+		}
+	}
+
+	menuItems := make([]*menuItem, len(urls))
+	for i, url := range urls {
+		url := url
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				gui.Tr.SLocalize("ClearCachedCredentials"),
+				color.New(color.FgRed).Sprint(url),
+			},
+			onPress: func() error {
+				return gui.clearCachedCredentials(url)
+			},
+		}
+	}
+
+	title := gui.Tr.TemplateLocalize("CredentialHelperTitle", Teml{"helper": helper})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) clearCachedCredentials(url string) error {
+	if url == "" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoRemoteURL"))
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("ClearingCredentialsStatus"), func() error {
+		if err := gui.GitCommand.ClearCachedCredentials(url); err != nil {
+			return gui.surfaceError(err)
+		}
+		return nil
+	})
+}
+
+// offerToClearCredentials is shown when authentication fails while a
+// credential helper is configured, because the usual culprit is a stale token
+// that the helper keeps handing back to git
+func (gui *Gui) offerToClearCredentials(v *gocui.View, helper string) error {
+	prompt := gui.Tr.TemplateLocalize("ClearStaleCredentialsPrompt", Teml{"helper": helper})
+	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("PassUnameWrong"), prompt, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.clearCachedCredentials(gui.GitCommand.GetRemoteURL())
+	}, nil)
+}
@@ -13,10 +13,20 @@ func (gui *Gui) waitForPassUname(g *gocui.Gui, currentView *gocui.View, passOrUn
 	gui.credentials = make(chan string)
 	g.Update(func(g *gocui.Gui) error {
 		credentialsView, _ := g.View("credentials")
-		if passOrUname == "username" {
+		switch passOrUname {
+		case "username":
 			credentialsView.Title = gui.Tr.SLocalize("CredentialsUsername")
 			credentialsView.Mask = 0
-		} else {
+		case "otp":
+			credentialsView.Title = gui.Tr.SLocalize("CredentialsOTP")
+			credentialsView.Mask = '*'
+		case "hostKey":
+			credentialsView.Title = gui.Tr.SLocalize("CredentialsHostKey")
+			credentialsView.Mask = 0
+		case "passphrase":
+			credentialsView.Title = gui.Tr.SLocalize("CredentialsPassphrase")
+			credentialsView.Mask = '*'
+		default:
 			credentialsView.Title = gui.Tr.SLocalize("CredentialsPassword")
 			credentialsView.Mask = '*'
 		}
@@ -76,6 +86,24 @@ func (gui *Gui) handleCredentialsViewFocused(g *gocui.Gui, v *gocui.View) error
 	return nil
 }
 
+// runWithCredentials shows a loader while running a command that talks to a
+// remote, asking the user for any username, password or ssh key passphrase it
+// prompts for
+func (gui *Gui) runWithCredentials(v *gocui.View, loaderText string, run func(ask func(string) string) error) error {
+	if err := gui.createLoaderPanel(gui.g, v, loaderText); err != nil {
+		return err
+	}
+	go func() {
+		unamePassOpened := false
+		err := run(func(passOrUname string) string {
+			unamePassOpened = true
+			return gui.waitForPassUname(gui.g, v, passOrUname)
+		})
+		gui.HandleCredentialsPopup(gui.g, unamePassOpened, err)
+	}()
+	return nil
+}
+
 // HandleCredentialsPopup handles the views after executing a command that might ask for credentials
 func (gui *Gui) HandleCredentialsPopup(g *gocui.Gui, popupOpened bool, cmdErr error) {
 	if popupOpened {
@@ -83,7 +111,11 @@ func (gui *Gui) HandleCredentialsPopup(g *gocui.Gui, popupOpened bool, cmdErr er
 	}
 	if cmdErr != nil {
 		errMessage := cmdErr.Error()
-		if strings.Contains(errMessage, "Invalid username or password") {
+		if strings.Contains(errMessage, "Invalid username or password") || strings.Contains(errMessage, "Authentication failed") {
+			if helper := gui.GitCommand.GetCredentialHelper(); helper != "" {
+				_ = gui.offerToClearCredentials(gui.getFilesView(), helper)
+				return
+			}
 			errMessage = gui.Tr.SLocalize("PassUnameWrong")
 		}
 		// we are not logging this error because it may contain a password
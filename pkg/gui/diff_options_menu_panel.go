@@ -0,0 +1,80 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// diffOptionsSubtitle summarises the non-default diff flags so it's obvious
+// when the main view isn't showing every change
+func (gui *Gui) diffOptionsSubtitle() string {
+	return strings.Join(gui.GitCommand.DiffOptions.Flags(), " ")
+}
+
+// rerenderDiffs re-runs whatever is in the main view so the new diff options take effect
+func (gui *Gui) rerenderDiffs() error {
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+}
+
+func (gui *Gui) handleToggleIgnoreWhitespace(g *gocui.Gui, v *gocui.View) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	gui.GitCommand.DiffOptions.IgnoreWhitespace = !gui.GitCommand.DiffOptions.IgnoreWhitespace
+	return gui.rerenderDiffs()
+}
+
+func (gui *Gui) handleCreateDiffOptionsMenu(g *gocui.Gui, v *gocui.View) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	options := &gui.GitCommand.DiffOptions
+
+	onOff := func(on bool) string {
+		if on {
+			return gui.Tr.SLocalize("on")
+		}
+		return gui.Tr.SLocalize("off")
+	}
+
+	algorithm := options.Algorithm
+	if algorithm == "" {
+		algorithm = gui.Tr.SLocalize("defaultDiffAlgorithm")
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("ignoreWhitespace"), onOff(options.IgnoreWhitespace)},
+			onPress: func() error {
+				options.IgnoreWhitespace = !options.IgnoreWhitespace
+				return gui.rerenderDiffs()
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("ignoreBlankLines"), onOff(options.IgnoreBlankLines)},
+			onPress: func() error {
+				options.IgnoreBlankLines = !options.IgnoreBlankLines
+				return gui.rerenderDiffs()
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("ignoreLineEndings"), onOff(options.IgnoreLineEndings)},
+			onPress: func() error {
+				options.IgnoreLineEndings = !options.IgnoreLineEndings
+				return gui.rerenderDiffs()
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("diffAlgorithm"), algorithm},
+			onPress: func() error {
+				options.Algorithm = options.NextAlgorithm()
+				return gui.rerenderDiffs()
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("DiffOptionsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
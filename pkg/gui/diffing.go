@@ -26,7 +26,7 @@ func (gui *Gui) renderDiff() error {
 	}
 
 	cmd := gui.OSCommand.ExecutableFromString(
-		fmt.Sprintf("git diff --color %s %s", gui.diffStr(), filterArg),
+		fmt.Sprintf("git diff --color%s %s %s", gui.GitCommand.DiffFlags(), gui.diffStr(), filterArg),
 	)
 	if err := gui.newPtyTask("main", cmd); err != nil {
 		gui.Log.Error(err)
@@ -158,7 +158,17 @@ func (gui *Gui) handleCreateDiffingMenuPanel(g *gocui.Gui, v *gocui.View) error
 		},
 	}...)
 
+	menuItems = append(menuItems, gui.dirDiffMenuItem("HEAD", ""))
+
 	if gui.inDiffMode() {
+		left, right := gui.State.Diff.Ref, gui.currentDiffTerminal()
+		if gui.State.Diff.Reverse {
+			left, right = right, left
+		}
+		if left != "" {
+			menuItems = append(menuItems, gui.dirDiffMenuItem(left, right))
+		}
+
 		menuItems = append(menuItems, []*menuItem{
 			{
 				displayString: gui.Tr.SLocalize("swapDiff"),
@@ -179,3 +189,20 @@ func (gui *Gui) handleCreateDiffingMenuPanel(g *gocui.Gui, v *gocui.View) error
 
 	return gui.createMenu(gui.Tr.SLocalize("DiffingMenuTitle"), menuItems, createMenuOptions{showCancel: true})
 }
+
+// dirDiffMenuItem opens the differences between two refs in the user's
+// directory diff tool. An empty right ref means the working tree
+func (gui *Gui) dirDiffMenuItem(left string, right string) *menuItem {
+	rightName := right
+	if rightName == "" {
+		rightName = gui.Tr.SLocalize("workingTree")
+	}
+
+	return &menuItem{
+		displayString: gui.Tr.TemplateLocalize("openDirDiff", Teml{"left": left, "right": rightName}),
+		onPress: func() error {
+			gui.SubProcess = gui.GitCommand.PrepareDirDiffSubProcess(left, right)
+			return gui.Errors.ErrSubProcess
+		},
+	}
+}
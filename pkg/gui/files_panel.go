@@ -7,10 +7,12 @@ import (
 
 	// "strings"
 
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
 
+	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
@@ -60,13 +62,22 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 		return gui.refreshMergePanel()
 	}
 
+	if gui.inWorkingTreeRefMode() && (file.Tracked || file.HasStagedChanges) {
+		gui.State.SplitMainPanel = false
+		gui.getMainView().Title = gui.Tr.TemplateLocalize("DiffAgainstRefTitle", Teml{"ref": gui.State.WorkingTreeRef})
+		cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.DiffAgainstRefCmdStr(gui.State.WorkingTreeRef, file))
+		return gui.newPtyTask("main", cmd)
+	}
+
+	if gui.State.SplitFileDiffs && file.Tracked {
+		return gui.renderSplitFileDiffs(file)
+	}
+
 	if file.HasStagedChanges && file.HasUnstagedChanges {
 		gui.State.SplitMainPanel = true
 		gui.getMainView().Title = gui.Tr.SLocalize("UnstagedChanges")
 		gui.getSecondaryView().Title = gui.Tr.SLocalize("StagedChanges")
-		cmdStr := gui.GitCommand.DiffCmdStr(file, false, true)
-		cmd := gui.OSCommand.ExecutableFromString(cmdStr)
-		if err := gui.newPtyTask("secondary", cmd); err != nil {
+		if err := gui.newFileDiffTask("secondary", file, true); err != nil {
 			return err
 		}
 	} else {
@@ -78,15 +89,94 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 		}
 	}
 
-	cmdStr := gui.GitCommand.DiffCmdStr(file, false, !file.HasUnstagedChanges && file.HasStagedChanges)
-	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	if err := gui.newFileDiffTask("main", file, !file.HasUnstagedChanges && file.HasStagedChanges); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// newFileDiffTask shows a file's diff in the given view. Binary files get a
+// summary of what changed, files that aren't UTF-8 get transcoded up front,
+// and otherwise we stream the diff straight from git. Working out which means
+// reading the file, so we do that in the background rather than hold up
+// moving through the files, and flag diffs that only change line endings in
+// the view's title while we're at it, because otherwise every line shows up
+// as changed with no visible difference
+func (gui *Gui) newFileDiffTask(viewName string, file *commands.File, cached bool) error {
+	go func() {
+		isBinary := !file.IsSubmodule && commands.IsBinaryFile(file.NewName())
+		encoding := gui.GitCommand.FileEncoding(file)
+		onlyLineEndings := gui.GitCommand.HasOnlyLineEndingChanges(file, cached)
+
+		gui.g.Update(func(*gocui.Gui) error {
+			if selectedFile, err := gui.getSelectedFile(); err != nil || selectedFile != file {
+				// we've moved on to another file since
+				return nil
+			}
+
+			if onlyLineEndings {
+				view, err := gui.g.View(viewName)
+				if err != nil {
+					return nil
+				}
+				view.Title += " " + gui.Tr.SLocalize("LineEndingChangesOnly")
+			}
+
+			if isBinary {
+				return gui.newLazyStringTask(viewName, func() string {
+					oldVersion, newVersion := gui.GitCommand.FileAssetVersions(file, cached)
+					return commands.AssetSummary(file.Name, oldVersion, newVersion)
+				})
+			}
+			if encoding != commands.EncodingUTF8 {
+				return gui.newLazyStringTask(viewName, func() string {
+					return gui.GitCommand.TranscodedDiff(file, cached, encoding)
+				})
+			}
+			cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.DiffCmdStr(file, false, cached))
+			return gui.newPtyTask(viewName, cmd)
+		})
+	}()
+	return nil
+}
+
+// renderSplitFileDiffs shows the unstaged diff in the main view and the staged
+// diff in the secondary view, even when one of them is empty, so that both
+// stay in the same place as we move between files
+func (gui *Gui) renderSplitFileDiffs(file *commands.File) error {
+	gui.State.SplitMainPanel = true
+	gui.getMainView().Title = gui.Tr.SLocalize("UnstagedChanges")
+	gui.getSecondaryView().Title = gui.Tr.SLocalize("StagedChanges")
+
+	if file.HasStagedChanges {
+		gui.getSecondaryView().Title = gui.Tr.SLocalize("StagedChanges")
+		if err := gui.newFileDiffTask("secondary", file, true); err != nil {
+			return err
+		}
+	} else if err := gui.newStringTask("secondary", gui.Tr.SLocalize("NoStagedChanges")); err != nil {
+		return err
+	}
+
+	if !file.HasUnstagedChanges {
+		return gui.newStringTask("main", gui.Tr.SLocalize("NoUnstagedChanges"))
+	}
+	gui.getMainView().Title = gui.Tr.SLocalize("UnstagedChanges")
+	return gui.newFileDiffTask("main", file, false)
+}
+
+func (gui *Gui) handleToggleSplitFileDiffs(g *gocui.Gui, v *gocui.View) error {
+	gui.State.SplitFileDiffs = !gui.State.SplitFileDiffs
+	return gui.selectFile(false)
+}
+
+// stackSplitFileDiffs tells the layout to put the staged diff under the unstaged
+// one rather than beside it, so that both get the full width
+func (gui *Gui) stackSplitFileDiffs() bool {
+	v := gui.g.CurrentView()
+	return gui.State.SplitFileDiffs && v != nil && v.Name() == "files"
+}
+
 func (gui *Gui) refreshFiles() error {
 	gui.State.RefreshingFilesMutex.Lock()
 	gui.State.IsRefreshingFiles = true
@@ -94,6 +184,7 @@ func (gui *Gui) refreshFiles() error {
 		gui.State.IsRefreshingFiles = false
 		gui.State.RefreshingFilesMutex.Unlock()
 	}()
+	defer gui.markRefreshed("files")
 
 	selectedFile, _ := gui.getSelectedFile()
 
@@ -107,7 +198,7 @@ func (gui *Gui) refreshFiles() error {
 	}
 
 	gui.g.Update(func(g *gocui.Gui) error {
-		displayStrings := presentation.GetFileListDisplayStrings(gui.State.Files, gui.State.Diff.Ref)
+		displayStrings := presentation.GetFileListDisplayStrings(gui.State.Files, gui.State.Diff.Ref, gui.formatting())
 		gui.renderDisplayStrings(filesView, displayStrings)
 
 		if g.CurrentView() == filesView || (g.CurrentView() == gui.getMainView() && g.CurrentView().Context == "merging") {
@@ -165,6 +256,9 @@ func (gui *Gui) enterFile(forceSecondaryFocused bool, selectedLineIdx int) error
 		}
 		return nil
 	}
+	if file.IsSubmodule {
+		return gui.handleCreateSubmoduleMenu(file)
+	}
 	if file.HasInlineMergeConflicts {
 		return gui.handleSwitchToMerge(gui.g, gui.getFilesView())
 	}
@@ -191,20 +285,23 @@ func (gui *Gui) handleFilePress(g *gocui.Gui, v *gocui.View) error {
 		return gui.handleSwitchToMerge(g, v)
 	}
 
-	if file.HasUnstagedChanges {
-		err = gui.GitCommand.StageFile(file.Name)
-	} else {
-		err = gui.GitCommand.UnStageFile(file.Name, file.Tracked)
+	onToggled := func() error {
+		if err := gui.refreshSidePanels(refreshOptions{scope: []int{FILES}}); err != nil {
+			return err
+		}
+
+		return gui.selectFile(true)
 	}
-	if err != nil {
-		return gui.surfaceError(err)
+
+	if file.HasUnstagedChanges {
+		return gui.stageWithLfsPrompt(file, onToggled)
 	}
 
-	if err := gui.refreshSidePanels(refreshOptions{scope: []int{FILES}}); err != nil {
-		return err
+	if err := gui.GitCommand.UnStageFile(file.Name, file.Tracked); err != nil {
+		return gui.surfaceError(err)
 	}
 
-	return gui.selectFile(true)
+	return onToggled()
 }
 
 func (gui *Gui) allFilesStaged() bool {
@@ -287,21 +384,43 @@ func (gui *Gui) handleCommitPress(g *gocui.Gui, filesView *gocui.View) error {
 	if len(gui.stagedFiles()) == 0 && gui.GitCommand.WorkingTreeState() == "normal" {
 		return gui.createErrorPanel(gui.Tr.SLocalize("NoStagedFilesToCommit"))
 	}
-	commitMessageView := gui.getCommitMessageView()
+	return gui.withPreCommitChecks(func() error {
+		return gui.openCommitMessagePanel(g, filesView)
+	})
+}
+
+// commitPrefix is the prefix the user has configured for this repo's commit
+// messages, derived from the checked out branch's name
+func (gui *Gui) commitPrefix() (string, error) {
 	prefixPattern := gui.Config.GetUserConfig().GetString("git.commitPrefixes." + utils.GetCurrentRepoName() + ".pattern")
 	prefixReplace := gui.Config.GetUserConfig().GetString("git.commitPrefixes." + utils.GetCurrentRepoName() + ".replace")
-	if len(prefixPattern) > 0 && len(prefixReplace) > 0 {
-		rgx, err := regexp.Compile(prefixPattern)
-		if err != nil {
-			return gui.createErrorPanel(fmt.Sprintf("%s: %s", gui.Tr.SLocalize("commitPrefixPatternError"), err.Error()))
-		}
-		prefix := rgx.ReplaceAllString(gui.getCheckedOutBranch().Name, prefixReplace)
+	if len(prefixPattern) == 0 || len(prefixReplace) == 0 {
+		return "", nil
+	}
+	rgx, err := regexp.Compile(prefixPattern)
+	if err != nil {
+		return "", fmt.Errorf("%s: %s", gui.Tr.SLocalize("commitPrefixPatternError"), err.Error())
+	}
+	return rgx.ReplaceAllString(gui.getCheckedOutBranch().Name, prefixReplace), nil
+}
+
+func (gui *Gui) openCommitMessagePanel(g *gocui.Gui, filesView *gocui.View) error {
+	commitMessageView := gui.getCommitMessageView()
+	prefix, err := gui.commitPrefix()
+	if err != nil {
+		return gui.createErrorPanel(err.Error())
+	}
+	if prefix != "" {
 		gui.renderString(g, "commitMessage", prefix)
 		if err := commitMessageView.SetCursor(len(prefix), 0); err != nil {
 			return err
 		}
 	}
 
+	if err := gui.suggestCommitMessage(prefix, true); err != nil {
+		return err
+	}
+
 	g.Update(func(g *gocui.Gui) error {
 		if _, err := g.SetViewOnTop("commitMessage"); err != nil {
 			return err
@@ -318,18 +437,12 @@ func (gui *Gui) handleCommitPress(g *gocui.Gui, filesView *gocui.View) error {
 }
 
 func (gui *Gui) handleAmendCommitPress(g *gocui.Gui, filesView *gocui.View) error {
-	if len(gui.stagedFiles()) == 0 && gui.GitCommand.WorkingTreeState() == "normal" {
-		return gui.createErrorPanel(gui.Tr.SLocalize("NoStagedFilesToCommit"))
-	}
 	if len(gui.State.Commits) == 0 {
 		return gui.createErrorPanel(gui.Tr.SLocalize("NoCommitToAmend"))
 	}
 
-	title := strings.Title(gui.Tr.SLocalize("AmendLastCommit"))
-	question := gui.Tr.SLocalize("SureToAmend")
-
-	return gui.createConfirmationPanel(g, filesView, true, title, question, func(g *gocui.Gui, v *gocui.View) error {
-		ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.AmendHead())
+	amend := func(options commands.AmendOptions) error {
+		ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.AmendHeadWithOptions(options))
 		if err != nil {
 			return err
 		}
@@ -338,7 +451,48 @@ func (gui *Gui) handleAmendCommitPress(g *gocui.Gui, filesView *gocui.View) erro
 		}
 
 		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
-	}, nil)
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("AmendKeepingEverything"), utils.ColoredString("--no-edit", color.FgBlue)},
+			onPress: func() error {
+				if len(gui.stagedFiles()) == 0 && gui.GitCommand.WorkingTreeState() == "normal" {
+					return gui.createErrorPanel(gui.Tr.SLocalize("NoStagedFilesToCommit"))
+				}
+				return amend(commands.AmendOptions{})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("AmendResetAuthor"), utils.ColoredString("--reset-author", color.FgBlue)},
+			onPress: func() error {
+				return amend(commands.AmendOptions{ResetAuthor: true})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("AmendResetDate"), utils.ColoredString("--date=now", color.FgBlue)},
+			onPress: func() error {
+				return amend(commands.AmendOptions{ResetDate: true})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("AmendSetAuthor"), utils.ColoredString("--author", color.FgBlue)},
+			onPress: func() error {
+				return gui.createPromptPanel(g, filesView, gui.Tr.SLocalize("AmendSetAuthorPrompt"), gui.GitCommand.GetUserIdentity(), func(g *gocui.Gui, promptView *gocui.View) error {
+					return amend(commands.AmendOptions{Author: gui.trimmedContent(promptView)})
+				})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("AmendEditMessage"), utils.ColoredString("git commit --amend", color.FgBlue)},
+			onPress: func() error {
+				gui.SubProcess = gui.GitCommand.PrepareCommitAmendSubProcess()
+				return gui.Errors.ErrSubProcess
+			},
+		},
+	}
+
+	return gui.createMenu(strings.Title(gui.Tr.SLocalize("AmendLastCommit")), menuItems, createMenuOptions{showCancel: true})
 }
 
 // handleCommitEditorPress - handle when the user wants to commit changes via
@@ -347,8 +501,10 @@ func (gui *Gui) handleCommitEditorPress(g *gocui.Gui, filesView *gocui.View) err
 	if len(gui.stagedFiles()) == 0 && gui.GitCommand.WorkingTreeState() == "normal" {
 		return gui.createErrorPanel(gui.Tr.SLocalize("NoStagedFilesToCommit"))
 	}
-	gui.PrepareSubProcess(g, "git", "commit")
-	return nil
+	return gui.withPreCommitChecks(func() error {
+		gui.PrepareSubProcess(g, "git", "commit")
+		return nil
+	})
 }
 
 // PrepareSubProcess - prepare a subprocess for execution and tell the gui to switch to it
@@ -388,6 +544,9 @@ func (gui *Gui) handleRefreshFiles(g *gocui.Gui, v *gocui.View) error {
 func (gui *Gui) refreshStateFiles() error {
 	// get files to stage
 	files := gui.GitCommand.GetStatusFiles()
+	if gui.inWorkingTreeRefMode() {
+		files = gui.GitCommand.GetFilesChangedAgainstRef(gui.State.WorkingTreeRef, files)
+	}
 	gui.State.Files = gui.GitCommand.MergeStatusFiles(gui.State.Files, files)
 
 	if err := gui.fileWatcher.addFilesToFileWatcher(files); err != nil {
@@ -430,17 +589,44 @@ func (gui *Gui) handlePullFiles(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) pullFiles(v *gocui.View, args string) error {
-	if err := gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("PullWait")); err != nil {
+	if err := gui.createCancellableLoaderPanel(v, gui.Tr.SLocalize("PullWait")); err != nil {
 		return err
 	}
 
 	go func() {
 		unamePassOpend := false
-		err := gui.GitCommand.Pull(args, func(passOrUname string) string {
-			unamePassOpend = true
-			return gui.waitForPassUname(gui.g, v, passOrUname)
+		previousHead := gui.GitCommand.HeadSha()
+		var stats *commands.TransferStats
+		err := gui.withCancellableCommand(v.Name(), func(ctx context.Context) error {
+			var err error
+			stats, err = gui.GitCommand.Pull(ctx, args, func(passOrUname string) string {
+				unamePassOpend = true
+				return gui.waitForPassUname(gui.g, v, passOrUname)
+			})
+			return err
 		})
+		if err == context.Canceled {
+			if unamePassOpend {
+				_, _ = gui.g.SetViewOnBottom("credentials")
+			}
+			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+			gui.warnAboutIndexLock()
+			return
+		}
+		if err != nil && isDirtyWorkingTreeError(err) {
+			if unamePassOpend {
+				_, _ = gui.g.SetViewOnBottom("credentials")
+			}
+			_ = gui.offerAutoStashPull(v, args)
+			return
+		}
 		gui.HandleCredentialsPopup(gui.g, unamePassOpend, err)
+		if err == nil {
+			gui.g.Update(func(*gocui.Gui) error {
+				return gui.showTransferSummary(v, stats)
+			})
+			gui.afterCheckout(v, previousHead)
+		}
 	}()
 
 	return nil
@@ -453,11 +639,16 @@ func (gui *Gui) pushWithForceFlag(g *gocui.Gui, v *gocui.View, force bool, upstr
 	go func() {
 		unamePassOpend := false
 		branchName := gui.getCheckedOutBranch().Name
-		err := gui.GitCommand.Push(branchName, force, upstream, args, func(passOrUname string) string {
+		stats, err := gui.GitCommand.Push(branchName, force, upstream, args, func(passOrUname string) string {
 			unamePassOpend = true
 			return gui.waitForPassUname(g, v, passOrUname)
 		})
 		gui.HandleCredentialsPopup(g, unamePassOpend, err)
+		if err == nil {
+			gui.g.Update(func(*gocui.Gui) error {
+				return gui.showTransferSummary(v, stats)
+			})
+		}
 	}()
 	return nil
 }
@@ -481,18 +672,65 @@ func (gui *Gui) pushFiles(g *gocui.Gui, v *gocui.View) error {
 		if gui.GitCommand.PushToCurrent {
 			return gui.pushWithForceFlag(g, v, false, "", "--set-upstream")
 		} else {
-			return gui.createPromptPanel(g, v, gui.Tr.SLocalize("EnterUpstream"), "origin "+currentBranch.Name, func(g *gocui.Gui, v *gocui.View) error {
-				return gui.pushWithForceFlag(g, v, false, gui.trimmedContent(v), "")
-			})
+			return gui.promptForUpstream(v, currentBranch.Name)
 		}
 	} else if currentBranch.Pullables == "0" {
 		return gui.pushWithForceFlag(g, v, false, "", "")
 	}
+	filesView := v
 	return gui.createConfirmationPanel(g, v, true, gui.Tr.SLocalize("ForcePush"), gui.Tr.SLocalize("ForcePushPrompt"), func(g *gocui.Gui, v *gocui.View) error {
-		return gui.pushWithForceFlag(g, v, true, "", "")
+		return gui.withTypedConfirmationIf(gui.needsTypedConfirmation("forcePush", currentBranch.Name), filesView, currentBranch.Name, func() error {
+			return gui.pushWithForceFlag(g, filesView, true, "", "")
+		})
 	}, nil)
 }
 
+// promptForUpstream asks which remote (and remote branch name) a branch without
+// an upstream should be pushed to, so that we can set it as the upstream.
+// We skip straight to asking for the branch name if there is only one remote
+func (gui *Gui) promptForUpstream(v *gocui.View, branchName string) error {
+	remotes := gui.State.Remotes
+	if len(remotes) == 0 {
+		return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("EnterUpstream"), "origin "+branchName, func(g *gocui.Gui, promptView *gocui.View) error {
+			return gui.pushWithForceFlag(g, v, false, gui.trimmedContent(promptView), "")
+		})
+	}
+
+	if len(remotes) == 1 {
+		return gui.promptForRemoteBranchName(v, remotes[0].Name, branchName)
+	}
+
+	menuItems := make([]*menuItem, 0, len(remotes))
+	for _, remote := range remotes {
+		remote := remote
+		item := &menuItem{
+			displayStrings: []string{remote.Name, utils.ColoredString(strings.Join(remote.Urls, " "), color.FgBlue)},
+			onPress: func() error {
+				return gui.promptForRemoteBranchName(v, remote.Name, branchName)
+			},
+		}
+		// origin is where people push to most of the time so we put it at the top
+		if remote.Name == "origin" {
+			menuItems = append([]*menuItem{item}, menuItems...)
+		} else {
+			menuItems = append(menuItems, item)
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("SelectRemoteToPushTo"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) promptForRemoteBranchName(v *gocui.View, remoteName string, branchName string) error {
+	title := gui.Tr.TemplateLocalize("EnterRemoteBranchName", Teml{"remote": remoteName})
+	return gui.createPromptPanel(gui.g, v, title, branchName, func(g *gocui.Gui, promptView *gocui.View) error {
+		refspec := branchName
+		if remoteBranchName := gui.trimmedContent(promptView); remoteBranchName != "" && remoteBranchName != branchName {
+			refspec = branchName + ":" + remoteBranchName
+		}
+		return gui.pushWithForceFlag(g, v, false, remoteName+" "+refspec, "")
+	})
+}
+
 func (gui *Gui) handleSwitchToMerge(g *gocui.Gui, v *gocui.View) error {
 	file, err := gui.getSelectedFile()
 	if err != nil {
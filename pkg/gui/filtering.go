@@ -1,6 +1,9 @@
 package gui
 
-import "github.com/jesseduffield/gocui"
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
 
 func (gui *Gui) inFilterMode() bool {
 	return gui.State.FilterPath != ""
@@ -12,6 +15,18 @@ func (gui *Gui) validateNotInFilterMode() (bool, error) {
 			return gui.exitFilterMode()
 		}, nil)
 	}
+	// the rebase commands work off the position of a commit in the list, so
+	// they need every commit to be there, in the usual order
+	if !gui.State.CommitFilter.IsEmpty() {
+		return false, gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, gui.Tr.SLocalize("MustExitFilterModeTitle"), gui.Tr.SLocalize("MustClearCommitFilterPrompt"), func(*gocui.Gui, *gocui.View) error {
+			return gui.setCommitFilter(commands.CommitFilter{})
+		}, nil)
+	}
+	if gui.State.FirstParent {
+		return false, gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, gui.Tr.SLocalize("MustExitFilterModeTitle"), gui.Tr.SLocalize("MustExitFirstParentModePrompt"), func(*gocui.Gui, *gocui.View) error {
+			return gui.setFirstParent(false)
+		}, nil)
+	}
 	return true, nil
 }
 
@@ -0,0 +1,51 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// setFirstParent switches the commits panel between the full history and the
+// current branch's mainline
+func (gui *Gui) setFirstParent(firstParent bool) error {
+	gui.State.FirstParent = firstParent
+	gui.State.ExpandedMerges = map[string]bool{}
+	gui.State.Panels.Commits.SelectedLine = 0
+
+	return gui.refreshSidePanels(refreshOptions{scope: []int{COMMITS}})
+}
+
+func (gui *Gui) handleToggleFirstParent(g *gocui.Gui, v *gocui.View) error {
+	return gui.setFirstParent(!gui.State.FirstParent)
+}
+
+// handleToggleMergeExpansion lists the selected merge's merged-in commits
+// beneath it, or hides them again. From one of those commits it collapses the
+// merge they belong to
+func (gui *Gui) handleToggleMergeExpansion(g *gocui.Gui, v *gocui.View) error {
+	if !gui.State.FirstParent {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NotInFirstParentMode"))
+	}
+
+	selectedLine := gui.State.Panels.Commits.SelectedLine
+	if selectedLine == -1 {
+		return nil
+	}
+
+	// find the mainline merge this line belongs to
+	for selectedLine > 0 && gui.State.Commits[selectedLine].MergeExpansion == "merged-in" {
+		selectedLine--
+	}
+	merge := gui.State.Commits[selectedLine]
+
+	switch merge.MergeExpansion {
+	case "collapsed":
+		gui.State.ExpandedMerges[merge.Sha] = true
+	case "expanded":
+		delete(gui.State.ExpandedMerges, merge.Sha)
+	default:
+		return gui.createErrorPanel(gui.Tr.SLocalize("NotAMergeCommit"))
+	}
+	gui.State.Panels.Commits.SelectedLine = selectedLine
+
+	return gui.refreshSidePanels(refreshOptions{scope: []int{COMMITS}})
+}
@@ -0,0 +1,43 @@
+package gui
+
+import (
+	"io/ioutil"
+
+	"github.com/jesseduffield/gocui"
+)
+
+func (gui *Gui) handleToggleGitTraceMode(g *gocui.Gui, v *gocui.View) error {
+	gui.State.GitTraceMode = !gui.State.GitTraceMode
+	return gui.refreshSidePanels(refreshOptions{scope: []int{STATUS}})
+}
+
+// offerGitTrace is shown in place of an error panel while in git trace mode,
+// letting the user rerun the failed command with tracing enabled
+func (gui *Gui) offerGitTrace(command string, message string, nextView *gocui.View) error {
+	prompt := message + "\n\n" + gui.Tr.TemplateLocalize("RerunWithGitTracePrompt", Teml{"command": command})
+	return gui.createConfirmationPanel(gui.g, nextView, true, gui.Tr.SLocalize("Error"), prompt, func(g *gocui.Gui, v *gocui.View) error {
+		return gui.traceCommand(command)
+	}, nil)
+}
+
+func (gui *Gui) traceCommand(command string) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("TracingStatus"), func() error {
+		path, err := gui.GitCommand.TraceCommand(command)
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+
+		trace, err := ioutil.ReadFile(path)
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.showInMainView(func() error {
+				gui.getMainView().Title = gui.Tr.TemplateLocalize("GitTraceTitle", Teml{"path": path})
+				return gui.newStringTask("main", string(trace))
+			})
+		})
+		return nil
+	})
+}
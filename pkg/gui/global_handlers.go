@@ -1,11 +1,13 @@
 package gui
 
 import (
+	"context"
 	"math"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
@@ -38,13 +40,21 @@ func (gui *Gui) prevScreenMode(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) scrollUpView(viewName string) error {
+	return gui.scrollUpViewBy(viewName, gui.Config.GetUserConfig().GetInt("gui.scrollHeight"))
+}
+
+func (gui *Gui) scrollUpViewBy(viewName string, scrollHeight int) error {
 	mainView, _ := gui.g.View(viewName)
 	ox, oy := mainView.Origin()
-	newOy := int(math.Max(0, float64(oy-gui.Config.GetUserConfig().GetInt("gui.scrollHeight"))))
+	newOy := int(math.Max(0, float64(oy-scrollHeight)))
 	return mainView.SetOrigin(ox, newOy)
 }
 
 func (gui *Gui) scrollDownView(viewName string) error {
+	return gui.scrollDownViewBy(viewName, gui.Config.GetUserConfig().GetInt("gui.scrollHeight"))
+}
+
+func (gui *Gui) scrollDownViewBy(viewName string, scrollHeight int) error {
 	mainView, _ := gui.g.View(viewName)
 	ox, oy := mainView.Origin()
 	y := oy
@@ -52,7 +62,6 @@ func (gui *Gui) scrollDownView(viewName string) error {
 		_, sy := mainView.Size()
 		y += sy
 	}
-	scrollHeight := gui.Config.GetUserConfig().GetInt("gui.scrollHeight")
 	if y < mainView.LinesHeight() {
 		if err := mainView.SetOrigin(ox, oy+scrollHeight); err != nil {
 			return err
@@ -68,6 +77,7 @@ func (gui *Gui) scrollUpMain(g *gocui.Gui, v *gocui.View) error {
 	if gui.canScrollMergePanel() {
 		gui.State.Panels.Merging.UserScrolling = true
 	}
+	gui.State.ScrollMainToBottom = false
 
 	return gui.scrollUpView("main")
 }
@@ -80,6 +90,98 @@ func (gui *Gui) scrollDownMain(g *gocui.Gui, v *gocui.View) error {
 	return gui.scrollDownView("main")
 }
 
+// the following handlers give the main panel less-style navigation
+
+func (gui *Gui) halfPageUpMain(g *gocui.Gui, v *gocui.View) error {
+	if gui.canScrollMergePanel() {
+		gui.State.Panels.Merging.UserScrolling = true
+	}
+	gui.State.ScrollMainToBottom = false
+
+	_, height := gui.getMainView().Size()
+	return gui.scrollUpViewBy("main", height/2)
+}
+
+func (gui *Gui) halfPageDownMain(g *gocui.Gui, v *gocui.View) error {
+	if gui.canScrollMergePanel() {
+		gui.State.Panels.Merging.UserScrolling = true
+	}
+
+	_, height := gui.getMainView().Size()
+	return gui.scrollDownViewBy("main", height/2)
+}
+
+func (gui *Gui) scrollToTopMain(g *gocui.Gui, v *gocui.View) error {
+	if gui.canScrollMergePanel() {
+		gui.State.Panels.Merging.UserScrolling = true
+	}
+	gui.State.ScrollMainToBottom = false
+
+	mainView := gui.getMainView()
+	ox, _ := mainView.Origin()
+	return mainView.SetOrigin(ox, 0)
+}
+
+func (gui *Gui) scrollToBottomMain(g *gocui.Gui, v *gocui.View) error {
+	if gui.canScrollMergePanel() {
+		gui.State.Panels.Merging.UserScrolling = true
+	}
+
+	// the main view loads its content lazily, so we ask for the rest of it and
+	// keep jumping to the bottom as it comes in
+	gui.State.ScrollMainToBottom = true
+	if manager, ok := gui.viewBufferManagerMap["main"]; ok {
+		manager.ReadLines(math.MaxInt32)
+	}
+
+	return gui.scrollMainToBottom()
+}
+
+func (gui *Gui) scrollMainToBottom() error {
+	mainView := gui.getMainView()
+	_, height := mainView.Size()
+	ox, _ := mainView.Origin()
+	return mainView.SetOrigin(ox, int(math.Max(0, float64(mainView.LinesHeight()-height))))
+}
+
+// fullMainContent returns whatever is in the main panel. Content produced by a
+// command is regenerated in full, because the main panel only loads as much of
+// it as has been scrolled to
+func (gui *Gui) fullMainContent() string {
+	content := gui.getMainView().Buffer()
+	if args := gui.State.MainContentCmdArgs; len(args) > 0 {
+		cmd := gui.OSCommand.PrepareSubProcess(args[0], args[1:]...)
+		if output, err := gui.OSCommand.RunExecutableWithOutput(cmd); err == nil {
+			content = output
+		}
+	}
+	return content
+}
+
+// handleOpenMainInPager writes out whatever is in the main panel and opens it
+// in the user's pager
+func (gui *Gui) handleOpenMainInPager(g *gocui.Gui, v *gocui.View) error {
+	filename, err := gui.OSCommand.CreateTempFile("lazygit-main", gui.fullMainContent())
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.SubProcess = gui.OSCommand.PageFile(filename)
+	return gui.Errors.ErrSubProcess
+}
+
+// handleOpenMainInEditor is like handleOpenMainInPager but for the user's
+// editor, which won't understand colour codes. The .diff extension gets most
+// editors to highlight it as one
+func (gui *Gui) handleOpenMainInEditor(g *gocui.Gui, v *gocui.View) error {
+	filename, err := gui.OSCommand.CreateTempFile("lazygit-main-*.diff", utils.Decolorise(gui.fullMainContent()))
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.editFile(filename)
+}
+
 func (gui *Gui) scrollUpSecondary(g *gocui.Gui, v *gocui.View) error {
 	return gui.scrollUpView("secondary")
 }
@@ -160,15 +262,43 @@ func (gui *Gui) handleInfoClick(g *gocui.Gui, v *gocui.View) error {
 		}
 	}
 
+	if gui.inComparisonBaseMode() {
+		if width-cx <= len(gui.Tr.SLocalize("(reset)")) {
+			return gui.exitComparisonBaseMode()
+		} else {
+			return nil
+		}
+	}
+
+	if gui.inWorkingTreeRefMode() {
+		if width-cx <= len(gui.Tr.SLocalize("(reset)")) {
+			return gui.exitWorkingTreeRefMode()
+		} else {
+			return nil
+		}
+	}
+
+	// the user's status bar takes the donate button's place
+	if gui.State.StatusBar != "" {
+		return nil
+	}
+
+	if gui.State.AvailableUpdate != "" {
+		if cx <= len(gui.updateBanner()) && !gui.State.Updating {
+			return gui.showUpdatePrompt(gui.State.AvailableUpdate)
+		}
+		return nil
+	}
+
 	if cx <= len(gui.Tr.SLocalize("Donate")) {
 		return gui.OSCommand.OpenLink("https://github.com/sponsors/jesseduffield")
 	}
 	return nil
 }
 
-func (gui *Gui) fetch(g *gocui.Gui, v *gocui.View, canAskForCredentials bool) (unamePassOpend bool, err error) {
+func (gui *Gui) fetch(ctx context.Context, g *gocui.Gui, v *gocui.View, canAskForCredentials bool) (stats *commands.TransferStats, unamePassOpend bool, err error) {
 	unamePassOpend = false
-	err = gui.GitCommand.Fetch(func(passOrUname string) string {
+	stats, err = gui.GitCommand.Fetch(ctx, func(passOrUname string) string {
 		unamePassOpend = true
 		return gui.waitForPassUname(gui.g, v, passOrUname)
 	}, canAskForCredentials)
@@ -184,5 +314,5 @@ func (gui *Gui) fetch(g *gocui.Gui, v *gocui.View, canAskForCredentials bool) (u
 
 	gui.refreshSidePanels(refreshOptions{scope: []int{BRANCHES, COMMITS, REMOTES, TAGS}, mode: ASYNC})
 
-	return unamePassOpend, err
+	return stats, unamePassOpend, err
 }
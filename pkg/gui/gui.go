@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -92,6 +93,29 @@ type Gui struct {
 	fileWatcher          *fileWatcher
 	viewBufferManagerMap map[string]*tasks.ViewBufferManager
 	stopChan             chan struct{}
+	// onSubProcessExit, if set, is told how SubProcess exited
+	onSubProcessExit   func(error)
+	taskExitCodes      map[string]int // how each of the user's tasks last exited, keyed by task name
+	taskExitCodesMutex sync.Mutex
+	// tagVerifications caches how each signed tag's signature checked out, keyed
+	// by tag object sha, because running gpg on every refresh would be slow.
+	// verifyingTags are the tags being checked at the moment
+	tagVerifications      map[string]string
+	verifyingTags         map[string]bool
+	tagVerificationsMutex sync.Mutex
+	// announcement is the screen reader command currently speaking, which we
+	// stop when there's something newer to say
+	announcement      *exec.Cmd
+	announcementMutex sync.Mutex
+	// runningCommand is the fetch, pull etc. that the user can currently cancel
+	runningCommand      *runningCommand
+	runningCommandMutex sync.Mutex
+	// loadingLog is the load of the commits panel's log that's in progress, which
+	// the user can cancel if it's the full log
+	loadingLog *loadingLog
+	// savedToggles are the toggles' values as they are in the config file, so
+	// that saving them only writes the ones that have been flipped
+	savedToggles map[string]bool
 }
 
 // for now the staging panel state, unlike the other panel states, is going to be
@@ -105,6 +129,15 @@ type lineByLinePanelState struct {
 	PatchParser      *commands.PatchParser
 	SelectMode       int  // one of LINE, HUNK, or RANGE
 	SecondaryFocused bool // this is for if we show the left or right panel
+
+	// RangeStartLineIdx is where a range selection was started. The range
+	// spans from here to the selected line, in whichever direction we move
+	RangeStartLineIdx int
+	// Count is the number typed before a motion, e.g. the 5 in #5j. Counting
+	// is set from the key starting a count until the motion taking it, so
+	// that digits only make up a count while one is being typed
+	Count    int
+	Counting bool
 }
 
 type mergingPanelState struct {
@@ -230,15 +263,48 @@ type guiState struct {
 	OldInformation        string
 	StartupStage          int    // one of INITIAL and COMPLETE. Allows us to not load everything at once
 	FilterPath            string // the filename that gets passed to git log
-	Diff                  DiffState
+	ComparisonBase        string // the ref that commits are checked against to see if they've been merged
+	CommitFilter          commands.CommitFilter
+	FirstParent           bool            // whether the commits panel only shows the mainline
+	ExpandedMerges        map[string]bool // the mainline merges whose merged-in commits we're showing
+	MarkedBranches        []string        // the branches to merge together, in the order they were marked
+	WorkingTreeRef        string          // the ref the files panel shows working tree changes against, if not HEAD
+	// the list items we've visited this session, for going back and forth between them
+	Timeline          []listLocation
+	TimelineIndex     int
+	Marks             map[string]listLocation // vim style marks, keyed by their one character name
+	MainViewPinned    bool                    // when on, the main view keeps its content as we navigate the side panels
+	PinnedMainTitle   string
+	Diff              DiffState
+	SigningKeyWarning string // shown in the status panel when the commit signing key is unusable or expiring soon
+	StatusBar         string // the user's gui.statusBar.template filled in, shown in place of the version
+	AvailableUpdate   string // the newer version found by the update check, advertised in place of the donate button
+	GitTraceMode      bool   // when on, errors from git commands offer to rerun the command with GIT_TRACE enabled
+	// MainContentCmdArgs is the command whose output is in the main view, if any
+	MainContentCmdArgs       []string
+	ScrollMainToBottom       bool
+	ShowCommitMessagePreview bool
+	ShowCommitFileTree       bool
+	SplitFileDiffs           bool // when on, the files panel always shows a file's staged and unstaged diffs one above the other
+	// LastRefreshed is when each side panel's model was last loaded, keyed by view name
+	LastRefreshed      map[string]time.Time
+	LastRefreshedMutex sync.Mutex
+	// RepoLinks is where shas and issue references link to, worked out the
+	// first time they're shown as hyperlinks
+	RepoLinks      *commands.RepoLinks
+	RepoLinksMutex sync.Mutex
 }
 
 func (gui *Gui) resetState() {
-	// we carry over the filter path and diff state
+	// we carry over the filter path, commit filter, first-parent mode and diff state
 	prevFilterPath := ""
+	prevCommitFilter := commands.CommitFilter{}
+	prevFirstParent := false
 	prevDiff := DiffState{}
 	if gui.State != nil {
 		prevFilterPath = gui.State.FilterPath
+		prevCommitFilter = gui.State.CommitFilter
+		prevFirstParent = gui.State.FirstParent
 		prevDiff = gui.State.Diff
 	}
 
@@ -268,10 +334,16 @@ func (gui *Gui) resetState() {
 				EditHistory:   stack.New(),
 			},
 		},
-		SideView:   nil,
-		Ptmx:       nil,
-		FilterPath: prevFilterPath,
-		Diff:       prevDiff,
+		SideView:                 nil,
+		Ptmx:                     nil,
+		FilterPath:               prevFilterPath,
+		CommitFilter:             prevCommitFilter,
+		FirstParent:              prevFirstParent,
+		ExpandedMerges:           map[string]bool{},
+		Diff:                     prevDiff,
+		ShowCommitMessagePreview: gui.Config.GetUserConfig().GetBool("gui.commitMessagePreview"),
+		ShowCommitFileTree:       gui.Config.GetUserConfig().GetBool("gui.commitFileTree"),
+		SplitFileDiffs:           gui.Config.GetUserConfig().GetBool("gui.splitFileDiffs"),
 	}
 }
 
@@ -292,7 +364,13 @@ func NewGui(log *logrus.Entry, gitCommand *commands.GitCommand, oSCommand *comma
 	gui.resetState()
 	gui.State.FilterPath = filterPath
 
+	gui.savedToggles = map[string]bool{}
+	for _, toggle := range gui.toggles() {
+		gui.savedToggles[toggle.key] = config.GetUserConfig().GetBool(toggle.key)
+	}
+
 	gui.watchFilesForChanges()
+	oSCommand.SetOnNetworkRetry(gui.onNetworkRetry)
 
 	gui.GenerateSentinelErrors()
 
@@ -324,6 +402,13 @@ func (gui *Gui) Run() error {
 
 	g.ASCII = runtime.GOOS == "windows" && runewidth.IsEastAsian()
 
+	if gui.accessibilityMode() {
+		// box-drawing characters get read out one by one, and with only the
+		// focused panel on screen there's a single place to read from
+		g.ASCII = true
+		gui.State.ScreenMode = SCREEN_FULL
+	}
+
 	if gui.Config.GetUserConfig().GetBool("gui.mouseEvents") {
 		g.Mouse = true
 	}
@@ -350,7 +435,16 @@ func (gui *Gui) Run() error {
 		go gui.startBackgroundFetch()
 	}
 
-	gui.goEvery(time.Second*10, gui.stopChan, gui.refreshFiles)
+	if gui.Config.GetUserConfig().GetBool("git.snapshots.enabled") {
+		gui.startSnapshotting()
+	}
+
+	go gui.checkSigningKey()
+	go gui.suggestPerformanceFeatures()
+
+	if gui.Config.GetUserConfig().GetBool("git.autoRefresh") {
+		gui.goEvery(time.Second*10, gui.stopChan, gui.refreshFiles)
+	}
 
 	g.SetManager(gocui.ManagerFunc(gui.layout), gocui.ManagerFunc(gui.getFocusLayout()))
 
@@ -412,11 +506,16 @@ func (gui *Gui) runCommand() error {
 
 	fmt.Fprintf(os.Stdout, "\n%s\n\n", utils.ColoredString("+ "+strings.Join(gui.SubProcess.Args, " "), color.FgBlue))
 
-	if err := gui.SubProcess.Run(); err != nil {
+	err := gui.SubProcess.Run()
+	if err != nil {
 		// not handling the error explicitly because usually we're going to see it
 		// in the output anyway
 		gui.Log.Error(err)
 	}
+	if gui.onSubProcessExit != nil {
+		gui.onSubProcessExit(err)
+		gui.onSubProcessExit = nil
+	}
 
 	gui.SubProcess.Stdout = ioutil.Discard
 	gui.SubProcess.Stderr = ioutil.Discard
@@ -501,12 +600,12 @@ func (gui *Gui) startBackgroundFetch() {
 	if !isNew {
 		time.After(60 * time.Second)
 	}
-	_, err := gui.fetch(gui.g, gui.g.CurrentView(), false)
+	_, _, err := gui.fetch(context.Background(), gui.g, gui.g.CurrentView(), false)
 	if err != nil && strings.Contains(err.Error(), "exit status 128") && isNew {
 		_ = gui.createConfirmationPanel(gui.g, gui.g.CurrentView(), true, gui.Tr.SLocalize("NoAutomaticGitFetchTitle"), gui.Tr.SLocalize("NoAutomaticGitFetchBody"), nil, nil)
 	} else {
 		gui.goEvery(time.Second*60, gui.stopChan, func() error {
-			_, err := gui.fetch(gui.g, gui.g.CurrentView(), false)
+			_, _, err := gui.fetch(context.Background(), gui.g, gui.g.CurrentView(), false)
 			return err
 		})
 	}
@@ -0,0 +1,81 @@
+package gui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+)
+
+// hyperlinkTerminalPrograms are the TERM_PROGRAMs of terminals which support
+// OSC 8 hyperlinks
+var hyperlinkTerminalPrograms = map[string]bool{
+	"iTerm.app": true, "WezTerm": true, "vscode": true, "Hyper": true,
+	"ghostty": true, "Tabby": true, "rio": true,
+}
+
+// terminalSupportsHyperlinks guesses, from the environment, whether the
+// terminal we're in supports OSC 8 hyperlinks. Most others ignore them, but
+// some older ones print them, so we only use them where we know they work
+func terminalSupportsHyperlinks(getenv func(string) string) bool {
+	// tmux and screen only pass them on when set up to
+	if getenv("TMUX") != "" || getenv("STY") != "" {
+		return false
+	}
+	if hyperlinkTerminalPrograms[getenv("TERM_PROGRAM")] {
+		return true
+	}
+	if getenv("KITTY_WINDOW_ID") != "" || getenv("WT_SESSION") != "" || getenv("DOMTERM") != "" {
+		return true
+	}
+	// GNOME Terminal and the other VTE based terminals, since VTE 0.50
+	if version, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+	term := getenv("TERM")
+	return term == "xterm-kitty" || strings.HasPrefix(term, "foot") || term == "alacritty" || term == "xterm-ghostty"
+}
+
+// hyperlinksEnabled tells us whether to show shas, file paths and issue
+// references as hyperlinks: always, never, or by default if the terminal
+// supports them
+func (gui *Gui) hyperlinksEnabled() bool {
+	switch gui.Config.GetUserConfig().GetString("gui.hyperlinks") {
+	case "always":
+		return true
+	case "never":
+		return false
+	default:
+		return terminalSupportsHyperlinks(os.Getenv)
+	}
+}
+
+// hyperlinks returns where shas, file paths and issue references link to, or
+// nil if they aren't to be hyperlinks
+func (gui *Gui) hyperlinks() *presentation.Hyperlinks {
+	if !gui.hyperlinksEnabled() {
+		return nil
+	}
+
+	gui.State.RepoLinksMutex.Lock()
+	if gui.State.RepoLinks == nil {
+		gui.State.RepoLinks = commands.NewPullRequest(gui.GitCommand).RepoLinks()
+	}
+	repoLinks := gui.State.RepoLinks
+	gui.State.RepoLinksMutex.Unlock()
+
+	// we're always in the repo's top level directory
+	repoPath, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	hostname, _ := os.Hostname()
+	return &presentation.Hyperlinks{
+		CommitURL: repoLinks.Commit,
+		IssueURL:  repoLinks.Issue,
+		RepoPath:  repoPath,
+		Hostname:  hostname,
+	}
+}
@@ -0,0 +1,61 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleCreateIssueBranchMenu lists the issues assigned to the user so that
+// they can create a branch for one, off the selected branch
+func (gui *Gui) handleCreateIssueBranchMenu(g *gocui.Gui, v *gocui.View) error {
+	baseBranch := gui.getSelectedBranch()
+	if baseBranch == nil {
+		return nil
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("FetchingIssuesStatus"), func() error {
+		issues, err := gui.GitCommand.GetAssignedIssues()
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+		if len(issues) == 0 {
+			return gui.createErrorPanel(gui.Tr.SLocalize("NoAssignedIssues"))
+		}
+
+		menuItems := make([]*menuItem, len(issues))
+		for i, issue := range issues {
+			issue := issue
+			menuItems[i] = &menuItem{
+				displayStrings: []string{utils.ColoredString("#"+issue.ID, color.FgCyan), issue.Title},
+				onPress: func() error {
+					return gui.createIssueBranch(v, issue, baseBranch)
+				},
+			}
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.createMenu(gui.Tr.SLocalize("AssignedIssuesTitle"), menuItems, createMenuOptions{showCancel: true})
+		})
+		return nil
+	})
+}
+
+// createIssueBranch prompts for the name of a branch for the issue, suggesting
+// one from git.issues.branchNameTemplate, then creates it and links it to the
+// issue in its description
+func (gui *Gui) createIssueBranch(v *gocui.View, issue *commands.Issue, baseBranch *commands.Branch) error {
+	title := gui.Tr.TemplateLocalize("NewBranchNameBranchOff", Teml{"branchName": baseBranch.Name})
+	return gui.createPromptPanel(gui.g, v, title, gui.GitCommand.IssueBranchName(issue), func(g *gocui.Gui, promptView *gocui.View) error {
+		branchName := gui.trimmedContent(promptView)
+		if err := gui.GitCommand.NewBranch(branchName, baseBranch.Name); err != nil {
+			return gui.surfaceError(err)
+		}
+		if err := gui.GitCommand.SetBranchDescription(branchName, commands.IssueBranchDescription(issue)); err != nil {
+			return gui.surfaceError(err)
+		}
+		gui.State.Panels.Branches.SelectedLine = 0
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+	})
+}
@@ -217,7 +217,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName: "",
 			Key:      gui.getKey("universal.return"),
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleQuit,
+			Handler:  gui.handleReturn,
 		},
 		{
 			ViewName:    "",
@@ -246,16 +246,46 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:  gui.scrollDownMain,
 		},
 		{
-			ViewName: "",
-			Key:      gui.getKey("universal.scrollUpMain-alt2"),
-			Modifier: gocui.ModNone,
-			Handler:  gui.scrollUpMain,
+			ViewName:    "",
+			Key:         gui.getKey("universal.scrollUpMain-alt2"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.halfPageUpMain,
+			Description: gui.Tr.SLocalize("halfPageUpMainPanel"),
 		},
 		{
-			ViewName: "",
-			Key:      gui.getKey("universal.scrollDownMain-alt2"),
-			Modifier: gocui.ModNone,
-			Handler:  gui.scrollDownMain,
+			ViewName:    "",
+			Key:         gui.getKey("universal.scrollDownMain-alt2"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.halfPageDownMain,
+			Description: gui.Tr.SLocalize("halfPageDownMainPanel"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.scrollToTopMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.scrollToTopMain,
+			Description: gui.Tr.SLocalize("scrollToTopMainPanel"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.scrollToBottomMain"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.scrollToBottomMain,
+			Description: gui.Tr.SLocalize("scrollToBottomMainPanel"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.openMainInPager"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleOpenMainInPager,
+			Description: gui.Tr.SLocalize("openMainInPager"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.openMainInEditor"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleOpenMainInEditor,
+			Description: gui.Tr.SLocalize("openMainInEditor"),
 		},
 		{
 			ViewName:    "",
@@ -287,6 +317,18 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleRefresh,
 			Description: gui.Tr.SLocalize("refresh"),
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.tasksMenu"),
+			Handler:     gui.handleCreateTasksMenu,
+			Description: gui.Tr.SLocalize("viewTasks"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.refreshPanel"),
+			Handler:     gui.handleRefreshPanel,
+			Description: gui.Tr.SLocalize("refreshPanel"),
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey("universal.optionMenu"),
@@ -353,6 +395,36 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateRecentReposMenu,
 			Description: gui.Tr.SLocalize("SwitchRepo"),
 		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.viewCredentialOptions"),
+			Handler:     gui.handleCreateCredentialsMenu,
+			Description: gui.Tr.SLocalize("viewCredentialOptions"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.viewSnapshots"),
+			Handler:     gui.handleCreateSnapshotsMenu,
+			Description: gui.Tr.SLocalize("viewSnapshots"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.repoMaintenance"),
+			Handler:     gui.handleCreateRepoMaintenanceMenu,
+			Description: gui.Tr.SLocalize("viewRepoMaintenanceOptions"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.standupReport"),
+			Handler:     gui.handleCreateStandupMenu,
+			Description: gui.Tr.SLocalize("generateStandupReport"),
+		},
+		{
+			ViewName:    "status",
+			Key:         gui.getKey("status.settings"),
+			Handler:     gui.handleCreateSettingsMenu,
+			Description: gui.Tr.SLocalize("editSettings"),
+		},
 		{
 			ViewName:    "files",
 			Key:         gui.getKey("files.commitChanges"),
@@ -425,6 +497,36 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateStashMenu,
 			Description: gui.Tr.SLocalize("viewStashOptions"),
 		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.stageByPattern"),
+			Handler:     gui.handleCreatePatternStagingMenu,
+			Description: gui.Tr.SLocalize("stageByPatternOptions"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.diffAgainstRef"),
+			Handler:     gui.handleCreateWorkingTreeRefMenu,
+			Description: gui.Tr.SLocalize("diffWorkingTreeAgainstRef"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.toggleSplitDiffs"),
+			Handler:     gui.handleToggleSplitFileDiffs,
+			Description: gui.Tr.SLocalize("toggleSplitFileDiffs"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.scrollUpStagedDiff"),
+			Handler:     gui.scrollUpSecondary,
+			Description: gui.Tr.SLocalize("scrollUpStagedDiff"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.scrollDownStagedDiff"),
+			Handler:     gui.scrollDownSecondary,
+			Description: gui.Tr.SLocalize("scrollDownStagedDiff"),
+		},
 		{
 			ViewName:    "files",
 			Key:         gui.getKey("files.toggleStagedAll"),
@@ -443,6 +545,12 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleEnterFile,
 			Description: gui.Tr.SLocalize("StageLines"),
 		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("files.viewLineEndingOptions"),
+			Handler:     gui.handleCreateLineEndingsMenu,
+			Description: gui.Tr.SLocalize("viewLineEndingOptions"),
+		},
 		{
 			ViewName:    "files",
 			Key:         gui.getKey("files.fetch"),
@@ -475,6 +583,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreatePullRequestPress,
 			Description: gui.Tr.SLocalize("createPullRequest"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.pullRequestDescription"),
+			Handler:     gui.handleCreatePullRequestDescriptionMenu,
+			Description: gui.Tr.SLocalize("pullRequestDescription"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.viewPullRequests"),
+			Handler:     gui.handleViewPullRequests,
+			Description: gui.Tr.SLocalize("viewPullRequests"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{"local-branches"},
@@ -517,6 +639,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleMerge,
 			Description: gui.Tr.SLocalize("mergeIntoCurrentBranch"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.markForMerge"),
+			Handler:     gui.handleToggleBranchMarkedForMerge,
+			Description: gui.Tr.SLocalize("markBranchForMerge"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.branchFromIssue"),
+			Handler:     gui.handleCreateIssueBranchMenu,
+			Description: gui.Tr.SLocalize("branchFromIssue"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{"local-branches"},
@@ -538,6 +674,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateResetToBranchMenu,
 			Description: gui.Tr.SLocalize("viewResetOptions"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.compareBranches"),
+			Handler:     gui.handleCompareBranches,
+			Description: gui.Tr.SLocalize("compareBranches"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"local-branches"},
+			Key:         gui.getKey("branches.setComparisonBase"),
+			Handler:     gui.handleSetComparisonBaseToBranch,
+			Description: gui.Tr.SLocalize("setComparisonBase"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{"local-branches"},
@@ -573,6 +723,34 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handlePushTag,
 			Description: gui.Tr.SLocalize("pushTag"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"tags"},
+			Key:         gui.getKey("branches.pushAllTags"),
+			Handler:     gui.handlePushAllTags,
+			Description: gui.Tr.SLocalize("pushAllTags"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"tags"},
+			Key:         gui.getKey("branches.generateChangelog"),
+			Handler:     gui.handleCreateChangelogMenu,
+			Description: gui.Tr.SLocalize("generateChangelog"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"tags"},
+			Key:         gui.getKey("branches.createVersionTag"),
+			Handler:     gui.handleCreateVersionTagMenu,
+			Description: gui.Tr.SLocalize("createVersionTag"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"tags"},
+			Key:         gui.getKey("branches.verifyTag"),
+			Handler:     gui.handleViewTagVerification,
+			Description: gui.Tr.SLocalize("verifyTag"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{"tags"},
@@ -730,6 +908,69 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCommitRevert,
 			Description: gui.Tr.SLocalize("revertCommit"),
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.toggleMessagePreview"),
+			Handler:     gui.handleToggleCommitMessagePreview,
+			Description: gui.Tr.SLocalize("toggleCommitMessagePreview"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.viewRefs"),
+			Handler:     gui.handleCreateCommitRefsMenu,
+			Description: gui.Tr.SLocalize("viewCommitRefs"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits", "reflog-commits"},
+			Key:         gui.getKey("commits.fetchMissingObjects"),
+			Handler:     gui.handleFetchMissingObjects,
+			Description: gui.Tr.SLocalize("fetchMissingObjects"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits", "reflog-commits"},
+			Key:         gui.getKey("commits.viewMergeDiffOptions"),
+			Handler:     gui.handleCreateMergeDiffMenu,
+			Description: gui.Tr.SLocalize("viewMergeDiffOptions"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.annotateCommit"),
+			Handler:     gui.handleEditCommitAnnotation,
+			Description: gui.Tr.SLocalize("annotateCommit"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.filterCommits"),
+			Handler:     gui.handleCreateCommitFilterMenu,
+			Description: gui.Tr.SLocalize("filterCommits"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.toggleFirstParent"),
+			Handler:     gui.handleToggleFirstParent,
+			Description: gui.Tr.SLocalize("toggleFirstParent"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.toggleMergeExpansion"),
+			Handler:     gui.handleToggleMergeExpansion,
+			Description: gui.Tr.SLocalize("toggleMergeExpansion"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{"branch-commits"},
+			Key:         gui.getKey("commits.openInBrowser"),
+			Handler:     gui.handleOpenCommitInBrowser,
+			Description: gui.Tr.SLocalize("openCommitInBrowser"),
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{"branch-commits"},
@@ -818,6 +1059,18 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleStashDrop,
 			Description: gui.Tr.SLocalize("drop"),
 		},
+		{
+			ViewName:    "stash",
+			Key:         gui.getKey("stash.renameStash"),
+			Handler:     gui.handleRenameStash,
+			Description: gui.Tr.SLocalize("renameStash"),
+		},
+		{
+			ViewName:    "stash",
+			Key:         gui.getKey("universal.new"),
+			Handler:     gui.handleStashBranch,
+			Description: gui.Tr.SLocalize("stashBranch"),
+		},
 		{
 			ViewName: "commitMessage",
 			Key:      gocui.KeyEnter,
@@ -830,6 +1083,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Modifier: gocui.ModNone,
 			Handler:  gui.handleCommitClose,
 		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey("commitMessage.insertTrailer"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleCreateTrailerMenu,
+			Description: gui.Tr.SLocalize("InsertTrailer"),
+		},
+		{
+			ViewName:    "commitMessage",
+			Key:         gui.getKey("commitMessage.suggestMessage"),
+			Modifier:    gocui.ModNone,
+			Handler:     gui.handleSuggestCommitMessage,
+			Description: gui.Tr.SLocalize("suggestCommitMessage"),
+		},
 		{
 			ViewName: "credentials",
 			Key:      gocui.KeyEnter,
@@ -896,6 +1163,18 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleEnterCommitFile,
 			Description: gui.Tr.SLocalize("enterFile"),
 		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey("commitFiles.toggleTreeView"),
+			Handler:     gui.handleToggleCommitFileTree,
+			Description: gui.Tr.SLocalize("toggleTreeView"),
+		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey("commitFiles.toggleViewed"),
+			Handler:     gui.handleToggleCommitFileViewed,
+			Description: gui.Tr.SLocalize("toggleViewed"),
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey("universal.filteringMenu"),
@@ -908,6 +1187,66 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateDiffingMenuPanel,
 			Description: gui.Tr.SLocalize("openDiffingMenu"),
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.toggleWhitespaceInDiff"),
+			Handler:     gui.handleToggleIgnoreWhitespace,
+			Description: gui.Tr.SLocalize("toggleWhitespaceInDiff"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.diffOptionsMenu"),
+			Handler:     gui.handleCreateDiffOptionsMenu,
+			Description: gui.Tr.SLocalize("openDiffOptionsMenu"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.timelineBack"),
+			Handler:     gui.handleTimelineBack,
+			Description: gui.Tr.SLocalize("timelineBack"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.timelineForward"),
+			Handler:     gui.handleTimelineForward,
+			Description: gui.Tr.SLocalize("timelineForward"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.setMark"),
+			Handler:     gui.handleSetMark,
+			Description: gui.Tr.SLocalize("setMark"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.jumpToMark"),
+			Handler:     gui.handleCreateMarksMenu,
+			Description: gui.Tr.SLocalize("jumpToMark"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.togglePinMainView"),
+			Handler:     gui.handleTogglePinMainView,
+			Description: gui.Tr.SLocalize("togglePinMainView"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.togglesMenu"),
+			Handler:     gui.handleCreateTogglesMenu,
+			Description: gui.Tr.SLocalize("openTogglesMenu"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.toggleGitTrace"),
+			Handler:     gui.handleToggleGitTraceMode,
+			Description: gui.Tr.SLocalize("toggleGitTrace"),
+		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.commandHistory"),
+			Handler:     gui.handleCreateCommandHistoryMenu,
+			Description: gui.Tr.SLocalize("viewCommandHistory"),
+		},
 		{
 			ViewName: "secondary",
 			Key:      gocui.MouseWheelUp,
@@ -961,7 +1300,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "main",
 			Contexts:    []string{"staging"},
 			Key:         gui.getKey("universal.return"),
-			Handler:     gui.handleStagingEscape,
+			Handler:     gui.escapeRangeSelectOr(gui.handleStagingEscape),
 			Description: gui.Tr.SLocalize("ReturnToFilesPanel"),
 		},
 		{
@@ -978,6 +1317,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleResetSelection,
 			Description: gui.Tr.SLocalize("ResetSelection"),
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"staging"},
+			Key:         gui.getKey("main.stageSelection"),
+			Handler:     gui.handleToggleStagedSelection,
+			Description: gui.Tr.SLocalize("StageSelection"),
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{"staging"},
@@ -989,7 +1335,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "main",
 			Contexts:    []string{"patch-building"},
 			Key:         gui.getKey("universal.return"),
-			Handler:     gui.handleEscapePatchBuildingPanel,
+			Handler:     gui.escapeRangeSelectOr(gui.handleEscapePatchBuildingPanel),
 			Description: gui.Tr.SLocalize("ExitLineByLineMode"),
 		},
 		{
@@ -1006,6 +1352,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleSelectNextLine,
 			Description: gui.Tr.SLocalize("NextLine"),
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"patch-building", "staging"},
+			Key:         gui.getKey("universal.copyToClipboard"),
+			Handler:     gui.handleCopySelectedLines,
+			Description: gui.Tr.SLocalize("copySelectedLines"),
+		},
 		{
 			ViewName: "main",
 			Contexts: []string{"patch-building", "staging"},
@@ -1062,6 +1415,27 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Modifier: gocui.ModNone,
 			Handler:  gui.handleSelectNextHunk,
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"patch-building", "staging"},
+			Key:         gui.getKey("main.prevHunkBoundary"),
+			Handler:     gui.handleSelectPrevHunkBoundary,
+			Description: gui.Tr.SLocalize("PrevHunkBoundary"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"patch-building", "staging"},
+			Key:         gui.getKey("main.nextHunkBoundary"),
+			Handler:     gui.handleSelectNextHunkBoundary,
+			Description: gui.Tr.SLocalize("NextHunkBoundary"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{"patch-building", "staging"},
+			Key:         gui.getKey("main.startCount"),
+			Handler:     gui.handleStartCount,
+			Description: gui.Tr.SLocalize("StartCount"),
+		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{"staging"},
@@ -1323,6 +1697,20 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleSetBranchUpstream,
 			Description: gui.Tr.SLocalize("setUpstream"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"remote-branches"},
+			Key:         gui.getKey("branches.setComparisonBase"),
+			Handler:     gui.handleSetComparisonBaseToRemoteBranch,
+			Description: gui.Tr.SLocalize("setComparisonBase"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{"remote-branches"},
+			Key:         gui.getKey("branches.fetchRemoteBranch"),
+			Handler:     gui.handleFetchRemoteBranch,
+			Description: gui.Tr.SLocalize("fetchRemoteBranch"),
+		},
 		{
 			ViewName: "stash",
 			Key:      gocui.MouseLeft,
@@ -1390,10 +1778,22 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 	}
 
 	// Appends keybindings to jump to a particular sideView using numbers
-	for i, viewName := range []string{"status", "files", "branches", "commits", "stash"} {
+	sideViewNames := []string{"status", "files", "branches", "commits", "stash"}
+	for i, viewName := range sideViewNames {
 		bindings = append(bindings, &Binding{ViewName: "", Key: rune(i+1) + '0', Modifier: gocui.ModNone, Handler: gui.goToSideView(viewName)})
 	}
 
+	// Appends keybindings for typing a count before a motion in the staging and
+	// patch building panels, e.g. #5j. Unless a count was started the digits
+	// still jump to the side views
+	for digit := 0; digit <= 9; digit++ {
+		var otherwise func(*gocui.Gui, *gocui.View) error
+		if digit >= 1 && digit <= len(sideViewNames) {
+			otherwise = gui.goToSideView(sideViewNames[digit-1])
+		}
+		bindings = append(bindings, &Binding{ViewName: "main", Contexts: []string{"patch-building", "staging"}, Key: rune(digit) + '0', Modifier: gocui.ModNone, Handler: gui.handleCountDigit(digit, otherwise)})
+	}
+
 	for _, listView := range gui.getListViews() {
 		bindings = append(bindings, []*Binding{
 			{ViewName: listView.viewName, Contexts: []string{listView.context}, Key: gui.getKey("universal.prevItem-alt"), Modifier: gocui.ModNone, Handler: listView.handlePrevLine},
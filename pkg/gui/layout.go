@@ -2,6 +2,7 @@ package gui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
@@ -27,6 +28,7 @@ func (gui *Gui) getFocusLayout() func(g *gocui.Gui) error {
 			}
 			previousView = newView
 		}
+		gui.recordTimeline()
 		return nil
 	}
 }
@@ -141,6 +143,26 @@ func (gui *Gui) getViewHeights() map[string]int {
 }
 
 // layout is called for every screen re-render e.g. when the screen is resized
+func (gui *Gui) minSidePanelWidth() int {
+	return gui.Config.GetUserConfig().GetInt("gui.minSidePanelWidth")
+}
+
+func (gui *Gui) minMainPanelWidth() int {
+	return gui.Config.GetUserConfig().GetInt("gui.minMainPanelWidth")
+}
+
+// normalSidePanelWidth applies the configured ratio of the screen to the side
+// panels, without squeezing either them or the main panel below their minimum
+// widths. By the time we get here the layout has checked there's room for both
+func (gui *Gui) normalSidePanelWidth(width int, ratio float64) int {
+	sideWidth := int(float64(width) * ratio)
+	sideWidth = max(sideWidth, gui.minSidePanelWidth())
+	if maxSideWidth := width - gui.minMainPanelWidth() - 1; sideWidth > maxSideWidth {
+		sideWidth = maxSideWidth
+	}
+	return sideWidth
+}
+
 func (gui *Gui) layout(g *gocui.Gui) error {
 	g.Highlight = true
 	width, height := g.Size()
@@ -150,16 +172,29 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		donate := color.New(color.FgMagenta, color.Underline).Sprint(gui.Tr.SLocalize("Donate"))
 		information = donate + " " + information
 	}
+	if gui.State.AvailableUpdate != "" {
+		information = utils.ColoredString(gui.updateBanner(), color.FgYellow, color.Underline) + " " + gui.Config.GetVersion()
+	}
+	if gui.State.StatusBar != "" {
+		information = gui.State.StatusBar
+	}
 	if gui.inDiffMode() {
 		information = utils.ColoredString(fmt.Sprintf("%s %s %s", gui.Tr.SLocalize("showingGitDiff"), "git diff "+gui.diffStr(), utils.ColoredString(gui.Tr.SLocalize("(reset)"), color.Underline)), color.FgMagenta)
 	} else if gui.inFilterMode() {
 		information = utils.ColoredString(fmt.Sprintf("%s '%s' %s", gui.Tr.SLocalize("filteringBy"), gui.State.FilterPath, utils.ColoredString(gui.Tr.SLocalize("(reset)"), color.Underline)), color.FgRed, color.Bold)
+	} else if gui.inComparisonBaseMode() {
+		information = utils.ColoredString(fmt.Sprintf("%s '%s' %s", gui.Tr.SLocalize("comparingAgainst"), gui.State.ComparisonBase, utils.ColoredString(gui.Tr.SLocalize("(reset)"), color.Underline)), color.FgGreen)
+	} else if gui.inWorkingTreeRefMode() {
+		information = utils.ColoredString(fmt.Sprintf("%s '%s' %s", gui.Tr.SLocalize("diffingWorkingTreeAgainst"), gui.State.WorkingTreeRef, utils.ColoredString(gui.Tr.SLocalize("(reset)"), color.Underline)), color.FgBlue)
 	} else if len(gui.State.CherryPickedCommits) > 0 {
 		information = utils.ColoredString(fmt.Sprintf("%d commits copied", len(gui.State.CherryPickedCommits)), color.FgCyan)
 	}
 
 	minimumHeight := 9
 	minimumWidth := 10
+	if gui.State.ScreenMode == SCREEN_NORMAL {
+		minimumWidth = max(minimumWidth, gui.minSidePanelWidth()+gui.minMainPanelWidth()+1)
+	}
 	if height < minimumHeight || width < minimumWidth {
 		v, err := g.SetView("limit", 0, 0, width-1, height-1, 0)
 		if err != nil {
@@ -170,6 +205,13 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 			v.Wrap = true
 			_, _ = g.SetViewOnTop("limit")
 		}
+		// the size changes as the user resizes their terminal, so we keep this up to date
+		gui.setViewContent(v, gui.Tr.TemplateLocalize("TerminalTooSmall", Teml{
+			"width":         width,
+			"height":        height,
+			"minimumWidth":  minimumWidth,
+			"minimumHeight": minimumHeight,
+		}))
 		return nil
 	}
 
@@ -192,7 +234,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 	var leftSideWidth int
 	switch gui.State.ScreenMode {
 	case SCREEN_NORMAL:
-		leftSideWidth = int(float64(width) * sidePanelWidthRatio)
+		leftSideWidth = gui.normalSidePanelWidth(width, sidePanelWidthRatio)
 	case SCREEN_HALF:
 		leftSideWidth = width/2 - 2
 	case SCREEN_FULL:
@@ -215,7 +257,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 			panelSplitX := width/2 - 4
 			mainPanelRight = panelSplitX
 			secondaryPanelLeft = panelSplitX + 1
-		} else if width < 220 {
+		} else if width < 220 || gui.stackSplitFileDiffs() {
 			mainPanelBottom = height/2 - 1
 			secondaryPanelTop = mainPanelBottom + 1
 			secondaryPanelLeft = leftSideWidth + 1
@@ -262,6 +304,16 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		v.FgColor = textColor
 		v.IgnoreCarriageReturns = true
 	}
+	// staging, patch building and merging render their own plain diffs, which ignore these options
+	if v.Context == "" || v.Context == "normal" {
+		v.Subtitle = gui.diffOptionsSubtitle()
+	} else {
+		v.Subtitle = ""
+	}
+	if gui.State.MainViewPinned {
+		v.Title = gui.State.PinnedMainTitle
+		v.Subtitle = strings.TrimSpace(gui.Tr.SLocalize("PinnedSubtitle") + " " + v.Subtitle)
+	}
 
 	hiddenViewOffset := 9999
 
@@ -345,6 +397,12 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		stashView.ContainsList = true
 	}
 
+	commitsView.Tabs[0] = gui.commitsTabTitle()
+
+	for _, view := range []*gocui.View{gui.getStatusView(), filesView, branchesView, commitsView, stashView} {
+		view.Subtitle = gui.lastRefreshedSubtitle(view.Name())
+	}
+
 	if v, err := g.SetView("options", appStatusOptionsBoundary-1, height-2, optionsVersionBoundary-1, height, 0); err != nil {
 		if err.Error() != "unknown view" {
 			return err
@@ -0,0 +1,68 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// shouldSuggestLfs tells us whether the file is a big binary that the user
+// might not realise they're about to add to the repo's history for good
+func (gui *Gui) shouldSuggestLfs(file *commands.File) bool {
+	userConfig := gui.Config.GetUserConfig()
+	if !userConfig.GetBool("git.lfsPrompt.enabled") || !file.HasUnstagedChanges || file.Deleted || file.IsSubmodule {
+		return false
+	}
+
+	info, err := os.Stat(file.NewName())
+	if err != nil || info.IsDir() || info.Size() < int64(userConfig.GetInt("git.lfsPrompt.minFileSizeMB"))<<20 {
+		return false
+	}
+
+	return commands.IsBinaryFile(file.NewName()) && !gui.GitCommand.IsTrackedByLfs(file.NewName()) && gui.GitCommand.LfsInstalled()
+}
+
+// stageWithLfsPrompt stages the file, first offering to track it with git-lfs
+// if it's a large binary
+func (gui *Gui) stageWithLfsPrompt(file *commands.File, onStaged func() error) error {
+	stage := func() error {
+		if err := gui.GitCommand.StageFile(file.Name); err != nil {
+			return gui.surfaceError(err)
+		}
+		return onStaged()
+	}
+
+	if !gui.shouldSuggestLfs(file) {
+		return stage()
+	}
+
+	trackThenStage := func(pattern string) func() error {
+		return func() error {
+			if err := gui.GitCommand.LfsTrack(pattern); err != nil {
+				return gui.surfaceError(err)
+			}
+			return stage()
+		}
+	}
+
+	menuItems := []*menuItem{}
+	if ext := filepath.Ext(file.NewName()); ext != "" {
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{gui.Tr.SLocalize("lfsTrackExtension"), "git lfs track '*" + ext + "'"},
+			onPress:        trackThenStage("*" + ext),
+		})
+	}
+	menuItems = append(menuItems,
+		&menuItem{
+			displayStrings: []string{gui.Tr.SLocalize("lfsTrackFile"), "git lfs track '" + file.NewName() + "'"},
+			onPress:        trackThenStage(file.NewName()),
+		},
+		&menuItem{
+			displayStrings: []string{gui.Tr.SLocalize("stageWithoutLfs"), "git add"},
+			onPress:        stage,
+		},
+	)
+
+	return gui.createMenu(gui.Tr.TemplateLocalize("LfsPromptTitle", Teml{"file": file.Name}), menuItems, createMenuOptions{showCancel: true})
+}
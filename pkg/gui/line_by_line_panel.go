@@ -61,13 +61,14 @@ func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, second
 	}
 
 	gui.State.Panels.LineByLine = &lineByLinePanelState{
-		PatchParser:      patchParser,
-		SelectedLineIdx:  selectedLineIdx,
-		SelectMode:       selectMode,
-		FirstLineIdx:     firstLineIdx,
-		LastLineIdx:      lastLineIdx,
-		Diff:             diff,
-		SecondaryFocused: secondaryFocused,
+		PatchParser:       patchParser,
+		SelectedLineIdx:   selectedLineIdx,
+		SelectMode:        selectMode,
+		FirstLineIdx:      firstLineIdx,
+		LastLineIdx:       lastLineIdx,
+		Diff:              diff,
+		SecondaryFocused:  secondaryFocused,
+		RangeStartLineIdx: selectedLineIdx,
 	}
 
 	if err := gui.refreshMainView(); err != nil {
@@ -96,23 +97,23 @@ func (gui *Gui) refreshLineByLinePanel(diff string, secondaryDiff string, second
 }
 
 func (gui *Gui) handleSelectPrevLine(g *gocui.Gui, v *gocui.View) error {
-	return gui.handleCycleLine(-1)
+	return gui.handleCycleLine(-gui.takeCount())
 }
 
 func (gui *Gui) handleSelectNextLine(g *gocui.Gui, v *gocui.View) error {
-	return gui.handleCycleLine(+1)
+	return gui.handleCycleLine(gui.takeCount())
 }
 
 func (gui *Gui) handleSelectPrevHunk(g *gocui.Gui, v *gocui.View) error {
 	state := gui.State.Panels.LineByLine
-	newHunk := state.PatchParser.GetHunkContainingLine(state.SelectedLineIdx, -1)
+	newHunk := state.PatchParser.GetHunkContainingLine(state.SelectedLineIdx, -gui.takeCount())
 
 	return gui.selectNewHunk(newHunk)
 }
 
 func (gui *Gui) handleSelectNextHunk(g *gocui.Gui, v *gocui.View) error {
 	state := gui.State.Panels.LineByLine
-	newHunk := state.PatchParser.GetHunkContainingLine(state.SelectedLineIdx, 1)
+	newHunk := state.PatchParser.GetHunkContainingLine(state.SelectedLineIdx, gui.takeCount())
 
 	return gui.selectNewHunk(newHunk)
 }
@@ -120,15 +121,19 @@ func (gui *Gui) handleSelectNextHunk(g *gocui.Gui, v *gocui.View) error {
 func (gui *Gui) selectNewHunk(newHunk *commands.PatchHunk) error {
 	state := gui.State.Panels.LineByLine
 	state.SelectedLineIdx = state.PatchParser.GetNextStageableLineIndex(newHunk.FirstLineIdx)
-	if state.SelectMode == HUNK {
+	switch state.SelectMode {
+	case HUNK:
 		state.FirstLineIdx, state.LastLineIdx = newHunk.FirstLineIdx, newHunk.LastLineIdx
-	} else {
+	case RANGE:
+		gui.extendRangeToSelectedLine()
+	default:
 		state.FirstLineIdx, state.LastLineIdx = state.SelectedLineIdx, state.SelectedLineIdx
 	}
 
 	if err := gui.refreshMainView(); err != nil {
 		return err
 	}
+	gui.announce(state.PatchParser.PatchLines[state.SelectedLineIdx].Content)
 
 	return gui.focusSelection(true)
 }
@@ -156,11 +161,7 @@ func (gui *Gui) handleSelectNewLine(newSelectedLineIdx int) error {
 	state.SelectedLineIdx = newSelectedLineIdx
 
 	if state.SelectMode == RANGE {
-		if state.SelectedLineIdx < state.FirstLineIdx {
-			state.FirstLineIdx = state.SelectedLineIdx
-		} else {
-			state.LastLineIdx = state.SelectedLineIdx
-		}
+		gui.extendRangeToSelectedLine()
 	} else {
 		state.LastLineIdx = state.SelectedLineIdx
 		state.FirstLineIdx = state.SelectedLineIdx
@@ -169,10 +170,107 @@ func (gui *Gui) handleSelectNewLine(newSelectedLineIdx int) error {
 	if err := gui.refreshMainView(); err != nil {
 		return err
 	}
+	gui.announce(state.PatchParser.PatchLines[state.SelectedLineIdx].Content)
 
 	return gui.focusSelection(false)
 }
 
+// extendRangeToSelectedLine makes the range selection span from where it was
+// started to the selected line, so that like in vim's visual mode, moving back
+// past the start flips the range rather than growing it
+func (gui *Gui) extendRangeToSelectedLine() {
+	state := gui.State.Panels.LineByLine
+
+	state.FirstLineIdx, state.LastLineIdx = state.RangeStartLineIdx, state.SelectedLineIdx
+	if state.LastLineIdx < state.FirstLineIdx {
+		state.FirstLineIdx, state.LastLineIdx = state.LastLineIdx, state.FirstLineIdx
+	}
+}
+
+// handleStartCount starts a count for the next motion, so that e.g. #5j moves
+// down five lines
+func (gui *Gui) handleStartCount(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.LineByLine
+	state.Count = 0
+	state.Counting = true
+	return nil
+}
+
+// handleCountDigit adds a digit to the count being typed. The digits are also
+// the keys for jumping to a side panel, so when we're not typing a count we
+// leave it to otherwise, if there's anything to do
+func (gui *Gui) handleCountDigit(digit int, otherwise func(g *gocui.Gui, v *gocui.View) error) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		state := gui.State.Panels.LineByLine
+		if !state.Counting {
+			if otherwise == nil {
+				return nil
+			}
+			return otherwise(g, v)
+		}
+		state.Count = state.Count*10 + digit
+		return nil
+	}
+}
+
+// takeCount returns the count typed before a motion, defaulting to 1, and
+// clears it for the next one
+func (gui *Gui) takeCount() int {
+	state := gui.State.Panels.LineByLine
+
+	count := state.Count
+	state.Count = 0
+	state.Counting = false
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+// handleSelectPrevHunkBoundary moves to the first line of the hunk, or of the
+// previous hunk if we're already there, like vim's { motion
+func (gui *Gui) handleSelectPrevHunkBoundary(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.LineByLine
+	if state.SelectMode == HUNK {
+		return gui.handleSelectPrevHunk(g, v)
+	}
+
+	newSelectedLineIdx := state.SelectedLineIdx
+	for i := gui.takeCount(); i > 0; i-- {
+		hunk := state.PatchParser.GetHunkContainingLine(newSelectedLineIdx, 0)
+		start := state.PatchParser.GetNextStageableLineIndex(hunk.FirstLineIdx)
+		if newSelectedLineIdx <= start {
+			hunk = state.PatchParser.GetHunkContainingLine(newSelectedLineIdx, -1)
+			start = state.PatchParser.GetNextStageableLineIndex(hunk.FirstLineIdx)
+		}
+		newSelectedLineIdx = start
+	}
+
+	return gui.handleSelectNewLine(newSelectedLineIdx)
+}
+
+// handleSelectNextHunkBoundary moves to the last line of the hunk, or of the
+// next hunk if we're already there, like vim's } motion
+func (gui *Gui) handleSelectNextHunkBoundary(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.LineByLine
+	if state.SelectMode == HUNK {
+		return gui.handleSelectNextHunk(g, v)
+	}
+
+	newSelectedLineIdx := state.SelectedLineIdx
+	for i := gui.takeCount(); i > 0; i-- {
+		hunk := state.PatchParser.GetHunkContainingLine(newSelectedLineIdx, 0)
+		end := state.PatchParser.GetPrevStageableLineIndex(hunk.LastLineIdx)
+		if newSelectedLineIdx >= end {
+			hunk = state.PatchParser.GetHunkContainingLine(newSelectedLineIdx, 1)
+			end = state.PatchParser.GetPrevStageableLineIndex(hunk.LastLineIdx)
+		}
+		newSelectedLineIdx = end
+	}
+
+	return gui.handleSelectNewLine(newSelectedLineIdx)
+}
+
 func (gui *Gui) handleMouseDown(g *gocui.Gui, v *gocui.View) error {
 	state := gui.State.Panels.LineByLine
 
@@ -183,6 +281,7 @@ func (gui *Gui) handleMouseDown(g *gocui.Gui, v *gocui.View) error {
 	newSelectedLineIdx := v.SelectedLineIdx()
 	state.FirstLineIdx = newSelectedLineIdx
 	state.LastLineIdx = newSelectedLineIdx
+	state.RangeStartLineIdx = newSelectedLineIdx
 
 	state.SelectMode = RANGE
 
@@ -298,10 +397,34 @@ func (gui *Gui) handleToggleSelectRange(g *gocui.Gui, v *gocui.View) error {
 		state.SelectMode = RANGE
 	}
 	state.FirstLineIdx, state.LastLineIdx = state.SelectedLineIdx, state.SelectedLineIdx
+	state.RangeStartLineIdx = state.SelectedLineIdx
 
 	return gui.refreshMainView()
 }
 
+// escapeRangeSelectOr returns a handler that drops a count being typed, or
+// leaves range select, as escape leaves visual mode in vim, or when doing
+// neither, calls onEscape
+func (gui *Gui) escapeRangeSelectOr(onEscape func(g *gocui.Gui, v *gocui.View) error) func(g *gocui.Gui, v *gocui.View) error {
+	return func(g *gocui.Gui, v *gocui.View) error {
+		state := gui.State.Panels.LineByLine
+		if state != nil && state.Counting {
+			state.Count = 0
+			state.Counting = false
+			return nil
+		}
+		if state == nil || state.SelectMode != RANGE {
+			return onEscape(g, v)
+		}
+
+		state.SelectMode = LINE
+		state.Count = 0
+		state.FirstLineIdx, state.LastLineIdx = state.SelectedLineIdx, state.SelectedLineIdx
+
+		return gui.refreshMainView()
+	}
+}
+
 func (gui *Gui) handleToggleSelectHunk(g *gocui.Gui, v *gocui.View) error {
 	state := gui.State.Panels.LineByLine
 
@@ -321,6 +444,29 @@ func (gui *Gui) handleToggleSelectHunk(g *gocui.Gui, v *gocui.View) error {
 	return gui.focusSelection(state.SelectMode == HUNK)
 }
 
+func (gui *Gui) handleCopySelectedLines(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.LineByLine
+
+	copyLines := func(withPrefixes bool) func() error {
+		return func() error {
+			return gui.OSCommand.CopyToClipboard(state.PatchParser.RangeContent(state.FirstLineIdx, state.LastLineIdx, withPrefixes))
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("copyLinesWithPrefixes"),
+			onPress:       copyLines(true),
+		},
+		{
+			displayString: gui.Tr.SLocalize("copyLinesWithoutPrefixes"),
+			onPress:       copyLines(false),
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("copySelectedLines"), menuItems, createMenuOptions{showCancel: true})
+}
+
 func (gui *Gui) handleEscapeLineByLinePanel() {
 	gui.changeMainViewsContext("normal")
 	gui.State.Panels.LineByLine = nil
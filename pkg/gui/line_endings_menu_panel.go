@@ -0,0 +1,91 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// lineEndingExplanation spells out which settings could be behind a file
+// showing up as modified with no visible diff
+func (gui *Gui) lineEndingExplanation(file *commands.File) string {
+	insight := gui.GitCommand.GetLineEndingInsight(file)
+
+	reasons := []string{}
+	if insight.OnlyLineEndings {
+		reasons = append(reasons, gui.Tr.TemplateLocalize("OnlyLineEndingsChanged", Teml{"file": file.NewName()}))
+	}
+	if insight.AutoCRLF != "" && insight.AutoCRLF != "false" {
+		reasons = append(reasons, gui.Tr.TemplateLocalize("AutoCRLFExplanation", Teml{"value": insight.AutoCRLF}))
+	}
+	if insight.Text != "" {
+		reasons = append(reasons, gui.Tr.TemplateLocalize("TextAttributeExplanation", Teml{"value": insight.Text}))
+	}
+	if insight.Eol != "" {
+		reasons = append(reasons, gui.Tr.TemplateLocalize("EolAttributeExplanation", Teml{"value": insight.Eol}))
+	}
+	if insight.Filter != "" {
+		reasons = append(reasons, gui.Tr.TemplateLocalize("FilterAttributeExplanation", Teml{"value": insight.Filter}))
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, gui.Tr.SLocalize("NoLineEndingExplanation"))
+	}
+
+	return strings.Join(reasons, "\n\n")
+}
+
+func (gui *Gui) handleCreateLineEndingsMenu(g *gocui.Gui, v *gocui.View) error {
+	file, err := gui.getSelectedFile()
+	if err != nil {
+		if err != gui.Errors.ErrNoFiles {
+			return gui.surfaceError(err)
+		}
+		return nil
+	}
+
+	if err := gui.showBehindMenu(gui.Tr.SLocalize("LineEndingsTitle"), gui.lineEndingExplanation(file)); err != nil {
+		return err
+	}
+
+	// a renamed file's name is 'old -> new', and it's the new one we're after
+	fileName := file.NewName()
+
+	withRefresh := func(f func() error) func() error {
+		return func() error {
+			if err := f(); err != nil {
+				return gui.surfaceError(err)
+			}
+			return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("renormalizeFile"), "git add --renormalize -- " + fileName},
+			onPress: withRefresh(func() error {
+				return gui.GitCommand.RenormalizeFile(fileName)
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("renormalizeAllFiles"), "git add --renormalize -- ."},
+			onPress: withRefresh(func() error {
+				return gui.GitCommand.RenormalizeFile(".")
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("normalizeLineEndingsInAttributes"), "* text=auto"},
+			onPress: withRefresh(func() error {
+				return gui.GitCommand.AddGitAttribute("*", "text=auto")
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("stopConvertingFileInAttributes"), fileName + " -text"},
+			onPress: withRefresh(func() error {
+				return gui.GitCommand.AddGitAttribute(fileName, "-text")
+			}),
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("LineEndingsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
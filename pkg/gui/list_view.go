@@ -39,7 +39,11 @@ func (lv *listView) handleLineChange(change int) error {
 	if err != nil {
 		return err
 	}
-	return lv.handleItemSelect(lv.gui.g, view)
+	if err := lv.handleItemSelect(lv.gui.g, view); err != nil {
+		return err
+	}
+	lv.gui.announceSelectedLine(view, *lv.getSelectedLineIdxPtr())
+	return nil
 }
 
 func (lv *listView) handleNextPage(g *gocui.Gui, v *gocui.View) error {
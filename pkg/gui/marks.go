@@ -0,0 +1,82 @@
+package gui
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// marks work like vim's: you give the selected item a one character name and
+// can then jump back to it from anywhere. They only last for the session
+
+func (gui *Gui) handleSetMark(g *gocui.Gui, v *gocui.View) error {
+	location, ok := gui.currentListLocation()
+	if !ok {
+		return nil
+	}
+
+	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("SetMarkPrompt"), "", func(g *gocui.Gui, promptView *gocui.View) error {
+		name := gui.trimmedContent(promptView)
+		if name == "" {
+			return nil
+		}
+		if len([]rune(name)) != 1 {
+			return gui.createErrorPanel(gui.Tr.SLocalize("MarkNameMustBeOneCharacter"))
+		}
+		if gui.State.Marks == nil {
+			gui.State.Marks = map[string]listLocation{}
+		}
+		gui.State.Marks[name] = location
+		return nil
+	})
+}
+
+func (gui *Gui) markDescription(location listLocation) string {
+	section := location.viewName
+	if location.context != "" {
+		section = location.context
+	}
+
+	key := location.key
+	if location.viewName == "commits" {
+		key = gui.formatting().Sha(key)
+	}
+
+	return section + " " + key
+}
+
+func (gui *Gui) handleCreateMarksMenu(g *gocui.Gui, v *gocui.View) error {
+	if len(gui.State.Marks) == 0 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoMarks"))
+	}
+
+	names := make([]string, 0, len(gui.State.Marks))
+	for name := range gui.State.Marks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	menuItems := make([]*menuItem, len(names))
+	for i, name := range names {
+		location := gui.State.Marks[name]
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				utils.ColoredString(name, color.FgCyan),
+				gui.markDescription(location),
+			},
+			onPress: func() error {
+				if err := gui.goToListLocation(location); err != nil {
+					return err
+				}
+				// the menu hands focus back to whatever we had focused before it
+				// opened, so we make that the view we've just jumped to
+				gui.State.PreviousView = location.viewName
+				return nil
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("MarksTitle"), menuItems, createMenuOptions{showCancel: true})
+}
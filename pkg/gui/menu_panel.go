@@ -45,6 +45,17 @@ func (gui *Gui) handleMenuClose(g *gocui.Gui, v *gocui.View) error {
 	return gui.returnFocus(g, v)
 }
 
+// showBehindMenu puts content in the main view, full width, for a menu about
+// to be opened over it, so that whatever the menu's choices are about stays in
+// view while the user picks one
+func (gui *Gui) showBehindMenu(title string, content string) error {
+	return gui.showInMainView(func() error {
+		gui.State.SplitMainPanel = false
+		gui.getMainView().Title = title
+		return gui.newStringTask("main", content)
+	})
+}
+
 type createMenuOptions struct {
 	showCancel bool
 }
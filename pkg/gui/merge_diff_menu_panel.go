@@ -0,0 +1,70 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleCreateMergeDiffMenu offers the different ways of looking at a merge
+// commit's changes: combined, or against each of its parents
+func (gui *Gui) handleCreateMergeDiffMenu(g *gocui.Gui, v *gocui.View) error {
+	var commit *commands.Commit
+	switch v.Context {
+	case "reflog-commits":
+		commit = gui.getSelectedReflogCommit()
+	default:
+		commit = gui.getSelectedCommit()
+	}
+	if commit == nil {
+		return nil
+	}
+
+	parents, err := gui.GitCommand.GetCommitParents(commit.Sha)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(parents) < 2 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NotAMergeCommit"))
+	}
+
+	showDiff := func(title string, cmdStr string) func() error {
+		return func() error {
+			return gui.showInMainView(func() error {
+				gui.getMainView().Title = title
+				return gui.newPtyTask("main", gui.commitDiffCmd(cmdStr))
+			})
+		}
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("combinedDiff"), utils.ColoredString("--cc", color.FgCyan)},
+			onPress:        showDiff(gui.Tr.SLocalize("CombinedDiffTitle"), gui.GitCommand.MergeShowCmdStr(commit.Sha, "--cc", gui.State.FilterPath)),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("diffAgainstEachParent"), utils.ColoredString("-m", color.FgCyan)},
+			onPress:        showDiff(gui.Tr.SLocalize("DiffAgainstEachParentTitle"), gui.GitCommand.MergeShowCmdStr(commit.Sha, "-m", gui.State.FilterPath)),
+		},
+	}
+
+	for i, parent := range parents {
+		number := i + 1
+		subject := ""
+		if message, err := gui.GitCommand.GetCommitMessage(parent); err == nil {
+			subject = commands.ParseCommitMessage(message).Subject
+		}
+		title := gui.Tr.TemplateLocalize("DiffAgainstParentTitle", Teml{"number": number})
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				gui.Tr.TemplateLocalize("diffAgainstParent", Teml{"number": number}),
+				utils.ColoredString(parent[:8], color.FgYellow),
+				subject,
+			},
+			onPress: showDiff(title, gui.GitCommand.DiffAgainstParentCmdStr(commit.Sha, number, gui.State.FilterPath)),
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("MergeDiffMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
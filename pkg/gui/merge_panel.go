@@ -56,11 +56,11 @@ func (gui *Gui) coloredConflictFile(content string, conflicts []commands.Conflic
 		if i == conflict.Start || i == conflict.Middle || i == conflict.End {
 			colourAttr = color.FgRed
 		}
-		colour := color.New(colourAttr)
+		attributes := []color.Attribute{colourAttr}
 		if hasFocus && conflictIndex < len(conflicts) && conflicts[conflictIndex] == conflict && gui.shouldHighlightLine(i, conflict, conflictTop) {
-			colour.Add(color.Bold)
-			colour.Add(theme.SelectedRangeBgColor)
+			attributes = append(attributes, color.Bold, theme.SelectedRangeBgColor)
 		}
+		colour := theme.NewColor(attributes...)
 		if i == conflict.End && len(remainingConflicts) > 0 {
 			conflict, remainingConflicts = gui.shiftConflict(remainingConflicts)
 		}
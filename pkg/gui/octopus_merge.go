@@ -0,0 +1,80 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// handleToggleBranchMarkedForMerge adds the selected branch to the set of
+// branches to merge together in one go, or takes it back out
+func (gui *Gui) handleToggleBranchMarkedForMerge(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	if branch.Name == gui.getCheckedOutBranch().Name {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantMergeBranchIntoItself"))
+	}
+
+	for i, name := range gui.State.MarkedBranches {
+		if name == branch.Name {
+			gui.State.MarkedBranches = append(gui.State.MarkedBranches[:i], gui.State.MarkedBranches[i+1:]...)
+			return gui.renderLocalBranchesWithSelection()
+		}
+	}
+	gui.State.MarkedBranches = append(gui.State.MarkedBranches, branch.Name)
+	return gui.renderLocalBranchesWithSelection()
+}
+
+// handleOctopusMerge merges all the marked branches into the checked out
+// branch. If we expect conflicts we offer to merge them one at a time instead,
+// because an octopus merge gives up rather than stopping for them
+func (gui *Gui) handleOctopusMerge() error {
+	if gui.GitCommand.IsHeadDetached() {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantMergeInDetachedHead"))
+	}
+
+	branchNames := append([]string{}, gui.State.MarkedBranches...)
+	checkedOutBranchName := gui.getCheckedOutBranch().Name
+	templateValues := Teml{
+		"checkedOutBranch": checkedOutBranchName,
+		"branches":         strings.Join(branchNames, ", "),
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("CheckingForConflictsStatus"), func() error {
+		ours, theirs, err := gui.GitCommand.FindExpectedOctopusConflict(branchNames)
+		if err != nil {
+			// most likely a git too old to check, in which case git will tell
+			// us itself if the octopus merge can't go ahead
+			gui.Log.Error(err)
+		}
+
+		if ours == "" {
+			return gui.createConfirmationPanel(gui.g, gui.getBranchesView(), true, gui.Tr.SLocalize("MergingTitle"), gui.Tr.TemplateLocalize("ConfirmOctopusMerge", templateValues),
+				func(g *gocui.Gui, v *gocui.View) error {
+					gui.State.MarkedBranches = nil
+					return gui.handleGenericMergeCommandResult(gui.GitCommand.OctopusMerge(branchNames))
+				}, nil)
+		}
+
+		templateValues["ours"] = ours
+		templateValues["theirs"] = theirs
+		return gui.createConfirmationPanel(gui.g, gui.getBranchesView(), true, gui.Tr.SLocalize("MergingTitle"), gui.Tr.TemplateLocalize("OctopusConflictExpected", templateValues),
+			func(g *gocui.Gui, v *gocui.View) error {
+				gui.State.MarkedBranches = nil
+				return gui.mergeSequentially(branchNames)
+			}, nil)
+	})
+}
+
+// mergeSequentially merges each branch in turn, stopping at the first one
+// that needs its conflicts resolved
+func (gui *Gui) mergeSequentially(branchNames []string) error {
+	for _, branchName := range branchNames {
+		if err := gui.GitCommand.Merge(branchName); err != nil {
+			return gui.handleGenericMergeCommandResult(err)
+		}
+	}
+	return gui.handleGenericMergeCommandResult(nil)
+}
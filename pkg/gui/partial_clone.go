@@ -0,0 +1,53 @@
+package gui
+
+import (
+	"os/exec"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// showCommitCmd is the command for showing a commit in the main view. In a
+// partial clone we don't let it fetch missing objects, because a big commit
+// could trigger a huge download just by being selected. The user can fetch
+// them explicitly instead
+func (gui *Gui) showCommitCmd(sha string) *exec.Cmd {
+	return gui.commitDiffCmd(gui.GitCommand.ShowCmdStr(sha, gui.State.FilterPath))
+}
+
+// commitDiffCmd prepares any command that shows a commit's diff the same way
+func (gui *Gui) commitDiffCmd(cmdStr string) *exec.Cmd {
+	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+	cmd.Env = append(cmd.Env, gui.GitCommand.NoLazyFetchEnv()...)
+	return cmd
+}
+
+func (gui *Gui) handleFetchMissingObjects(g *gocui.Gui, v *gocui.View) error {
+	if gui.GitCommand.PartialCloneFilter == "" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NotAPartialClone"))
+	}
+
+	var commit *commands.Commit
+	var onFetched func(*gocui.Gui, *gocui.View) error
+	switch v.Context {
+	case "reflog-commits":
+		commit = gui.getSelectedReflogCommit()
+		onFetched = gui.handleReflogCommitSelect
+	default:
+		commit = gui.getSelectedCommit()
+		onFetched = gui.handleCommitSelect
+	}
+	if commit == nil {
+		return nil
+	}
+
+	return gui.runWithCredentials(v, gui.Tr.SLocalize("FetchingMissingObjects"), func(ask func(string) string) error {
+		if err := gui.GitCommand.FetchMissingObjects(commit.Sha, ask); err != nil {
+			return err
+		}
+		gui.g.Update(func(g *gocui.Gui) error {
+			return onFetched(g, v)
+		})
+		return nil
+	})
+}
@@ -21,6 +21,9 @@ func (gui *Gui) refreshPatchBuildingPanel(selectedLineIdx int) error {
 		gui.renderString(gui.g, "commitFiles", gui.Tr.SLocalize("NoCommiteFiles"))
 		return nil
 	}
+	if commitFile.IsDirectory {
+		return nil
+	}
 
 	diff, err := gui.GitCommand.ShowCommitFile(commitFile.Sha, commitFile.Name, true)
 	if err != nil {
@@ -0,0 +1,51 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+func (gui *Gui) handleCreatePatternStagingMenu(g *gocui.Gui, v *gocui.View) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("stageByPattern"),
+			onPress: func() error {
+				return gui.promptForPattern(v, gui.GitCommand.FilesToStageByPattern, gui.GitCommand.StageByPattern, "NoFilesToStageByPattern", "StageByPatternPrompt")
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("unstageByPattern"),
+			onPress: func() error {
+				return gui.promptForPattern(v, gui.GitCommand.FilesToUnstageByPattern, gui.GitCommand.UnstageByPattern, "NoFilesToUnstageByPattern", "UnstageByPatternPrompt")
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("StageByPatternTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// promptForPattern asks for a pathspec, then shows which files it matches
+// before calling apply with it
+func (gui *Gui) promptForPattern(v *gocui.View, matchingFiles func(string) ([]string, error), apply func(string) error, noFilesKey string, promptKey string) error {
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("EnterPattern"), "", func(g *gocui.Gui, promptView *gocui.View) error {
+		pattern := gui.trimmedContent(promptView)
+		if pattern == "" {
+			return nil
+		}
+
+		files, err := matchingFiles(pattern)
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+		if len(files) == 0 {
+			return gui.createErrorPanel(gui.Tr.TemplateLocalize(noFilesKey, Teml{"pattern": pattern}))
+		}
+
+		prompt := gui.Tr.TemplateLocalize(promptKey, Teml{"files": gui.fileListPreview(files)})
+		return gui.createConfirmationPanel(g, v, true, pattern, prompt, func(*gocui.Gui, *gocui.View) error {
+			if err := apply(pattern); err != nil {
+				return gui.surfaceError(err)
+			}
+			return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+		}, nil)
+	})
+}
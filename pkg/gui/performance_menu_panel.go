@@ -0,0 +1,116 @@
+package gui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// missingPerformanceFeatures returns the features this repo could benefit from
+// that aren't switched on yet
+func (gui *Gui) missingPerformanceFeatures() []commands.PerformanceFeature {
+	missing := []commands.PerformanceFeature{}
+	for _, feature := range gui.GitCommand.GetPerformanceFeatures() {
+		if feature.Supported && !feature.Enabled {
+			missing = append(missing, feature)
+		}
+	}
+	return missing
+}
+
+// suggestPerformanceFeatures offers the user git's performance features the
+// first time they open a big repo that doesn't have them switched on
+func (gui *Gui) suggestPerformanceFeatures() {
+	gui.waitForIntro.Wait()
+
+	userConfig := gui.Config.GetUserConfig()
+	if !userConfig.GetBool("git.performancePrompt.enabled") {
+		return
+	}
+	if gui.GitCommand.TrackedFileCount() < userConfig.GetInt("git.performancePrompt.minTrackedFiles") {
+		return
+	}
+	currentRepo, err := os.Getwd()
+	if err != nil || utils.IncludesString(gui.Config.GetAppState().DismissedPerformancePrompts, currentRepo) {
+		return
+	}
+	if len(gui.missingPerformanceFeatures()) == 0 {
+		return
+	}
+
+	gui.g.Update(func(g *gocui.Gui) error {
+		return gui.createPerformanceMenu(g.CurrentView())
+	})
+}
+
+func (gui *Gui) createPerformanceMenu(v *gocui.View) error {
+	missing := gui.missingPerformanceFeatures()
+
+	explanations := []string{gui.Tr.TemplateLocalize("PerformanceIntro", Teml{"count": gui.GitCommand.TrackedFileCount()})}
+	menuItems := []*menuItem{}
+	for _, feature := range missing {
+		key := feature.Key
+		explanations = append(explanations, gui.Tr.SLocalize("performance."+key+".explanation"))
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("performance." + key),
+			onPress: func() error {
+				if err := gui.GitCommand.EnablePerformanceFeature(key); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+			},
+		})
+	}
+	if len(missing) == 0 {
+		explanations = append(explanations, gui.Tr.SLocalize("AllPerformanceFeaturesEnabled"))
+	}
+
+	if gui.GitCommand.UsingBuiltinFsmonitor() && !gui.GitCommand.FsmonitorDaemonRunning() {
+		explanations = append(explanations, gui.Tr.SLocalize("FsmonitorDaemonNotRunning"))
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("startFsmonitorDaemon"),
+			onPress: func() error {
+				if err := gui.GitCommand.StartFsmonitorDaemon(); err != nil {
+					return gui.surfaceError(err)
+				}
+				return nil
+			},
+		})
+	}
+
+	if len(missing) > 1 {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("enableAllPerformanceFeatures"),
+			onPress: func() error {
+				for _, feature := range missing {
+					if err := gui.GitCommand.EnablePerformanceFeature(feature.Key); err != nil {
+						return gui.surfaceError(err)
+					}
+				}
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+			},
+		})
+	}
+
+	menuItems = append(menuItems, &menuItem{
+		displayString: gui.Tr.SLocalize("dontSuggestPerformanceFeatures"),
+		onPress: func() error {
+			currentRepo, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			appState := gui.Config.GetAppState()
+			appState.DismissedPerformancePrompts = append(appState.DismissedPerformancePrompts, currentRepo)
+			return gui.Config.SaveAppState()
+		},
+	})
+
+	if err := gui.showBehindMenu(gui.Tr.SLocalize("PerformanceTitle"), strings.Join(explanations, "\n\n")); err != nil {
+		return err
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("PerformanceTitle"), menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,54 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// while the main view is pinned, selecting things in the side panels leaves its
+// content alone, so you can navigate elsewhere and compare against it
+
+// mainViewPinned tells the task functions whether to leave the view's content
+// where it is
+func (gui *Gui) mainViewPinned(viewName string) bool {
+	return viewName == "main" && gui.State.MainViewPinned
+}
+
+// showInMainView runs f, which puts something the user asked to see in the main
+// view, e.g. an explanation behind a menu or a command's output. Pinning only
+// stops selecting things in the side panels from replacing the main view's
+// content, so this goes through regardless, and when pinned, what it shows
+// becomes the pinned content, title and all
+func (gui *Gui) showInMainView(f func() error) error {
+	if !gui.State.MainViewPinned {
+		return f()
+	}
+
+	gui.State.MainViewPinned = false
+	err := f()
+	gui.State.MainViewPinned = true
+	gui.State.PinnedMainTitle = gui.getMainView().Title
+	return err
+}
+
+func (gui *Gui) unpinMainView() {
+	gui.State.MainViewPinned = false
+	gui.State.PinnedMainTitle = ""
+}
+
+func (gui *Gui) handleTogglePinMainView(g *gocui.Gui, v *gocui.View) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	if !gui.State.MainViewPinned {
+		// panels set the main view's title along with its content, so we hold
+		// onto the title that goes with the pinned content
+		gui.State.MainViewPinned = true
+		gui.State.PinnedMainTitle = gui.getMainView().Title
+		return nil
+	}
+
+	gui.unpinMainView()
+	// bring the main view back in line with whatever is selected now
+	return gui.newLineFocused(g, g.CurrentView())
+}
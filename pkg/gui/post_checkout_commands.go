@@ -0,0 +1,62 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// runPostCheckoutCommands runs, one after the other, those of the user's
+// post-checkout commands whose paths changed between previousHead and the
+// current HEAD, streaming each one's output into a popup
+func (gui *Gui) runPostCheckoutCommands(v *gocui.View, previousHead string) error {
+	postCheckoutCommands, err := gui.GitCommand.GetPostCheckoutCommands()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(postCheckoutCommands) == 0 || previousHead == "" {
+		return nil
+	}
+
+	currentHead := gui.GitCommand.HeadSha()
+	if currentHead == previousHead {
+		return nil
+	}
+
+	changedFiles, err := gui.GitCommand.ChangedFilesBetween(previousHead, currentHead)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	cmdStrs := []string{}
+	for _, postCheckoutCommand := range postCheckoutCommands {
+		if postCheckoutCommand.ShouldRun(changedFiles) {
+			cmdStrs = append(cmdStrs, postCheckoutCommand.Command)
+		}
+	}
+
+	return gui.runPostCheckoutCommandStrs(v, cmdStrs)
+}
+
+func (gui *Gui) runPostCheckoutCommandStrs(v *gocui.View, cmdStrs []string) error {
+	if len(cmdStrs) == 0 {
+		// the commands may well have touched the working tree, e.g. regenerating a lockfile
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+	}
+
+	cmdStr := cmdStrs[0]
+	title := gui.Tr.TemplateLocalize("RunningPostCheckoutCommand", Teml{"command": cmdStr})
+	return gui.streamExecutableOutput(v, title, gui.OSCommand.ShellExecutable(cmdStr), nil, func(output string, err error) error {
+		if err != nil {
+			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+			return gui.createErrorPanel(gui.Tr.TemplateLocalize("PostCheckoutCommandFailed", Teml{"command": cmdStr, "output": output}))
+		}
+		return gui.runPostCheckoutCommandStrs(v, cmdStrs[1:])
+	})
+}
+
+// afterCheckout queues up the post-checkout commands to run once whatever
+// popup the checkout or pull was shown in has gone
+func (gui *Gui) afterCheckout(v *gocui.View, previousHead string) {
+	gui.g.Update(func(*gocui.Gui) error {
+		return gui.runPostCheckoutCommands(v, previousHead)
+	})
+}
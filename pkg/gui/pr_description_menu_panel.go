@@ -0,0 +1,63 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// pullRequestBase is the branch a pull request from the given branch will be
+// compared against: the comparison base if there is one, otherwise the branch
+// it would most likely be merged into
+func (gui *Gui) pullRequestBase(branch *commands.Branch) string {
+	if gui.State.ComparisonBase != "" {
+		return gui.State.ComparisonBase
+	}
+	return gui.GitCommand.PullRequestBase(branch.Name)
+}
+
+func (gui *Gui) handleCreatePullRequestDescriptionMenu(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+
+	base := gui.pullRequestBase(branch)
+	messages, err := gui.GitCommand.GetBranchCommitMessages(branch.Name, base)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(messages) == 0 {
+		return gui.createErrorPanel(gui.Tr.TemplateLocalize("NoCommitsForPullRequest", Teml{"branch": branch.Name, "base": base}))
+	}
+	description := commands.BuildPullRequestDescription(messages)
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("copyPullRequestDescription"),
+			onPress: func() error {
+				return gui.OSCommand.CopyToClipboard(description.String())
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("createPullRequestWithDescription"),
+			onPress: func() error {
+				return gui.WithWaitingStatus(gui.Tr.SLocalize("CreatingPullRequestStatus"), func() error {
+					prefilled, err := commands.NewPullRequest(gui.GitCommand).CreateWithDescription(branch, base, description)
+					if err != nil {
+						return err
+					}
+					if !prefilled {
+						// the service can't take the description in the link, so it'll have to be pasted in
+						return gui.OSCommand.CopyToClipboard(description.String())
+					}
+					return nil
+				})
+			},
+		},
+	}
+
+	title := utils.ColoredString(description.Title, color.FgYellow)
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
@@ -2,6 +2,7 @@ package presentation
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
@@ -10,19 +11,28 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetBranchListDisplayStrings(branches []*commands.Branch, fullDescription bool, diffName string) [][]string {
+func GetBranchListDisplayStrings(branches []*commands.Branch, fullDescription bool, diffName string, markedBranches []string, formatting Formatting) [][]string {
 	lines := make([][]string, len(branches))
 
+	markPositions := map[string]int{}
+	for i, name := range markedBranches {
+		markPositions[name] = i + 1
+	}
+
 	for i := range branches {
 		diffed := branches[i].Name == diffName
-		lines[i] = getBranchDisplayStrings(branches[i], fullDescription, diffed)
+		lines[i] = getBranchDisplayStrings(branches[i], fullDescription, diffed, formatting)
+		if position, ok := markPositions[branches[i].Name]; ok {
+			// the position tells the user the order the branches will be merged in
+			lines[i][1] = utils.ColoredString(fmt.Sprintf("+%d ", position), color.FgMagenta) + lines[i][1]
+		}
 	}
 
 	return lines
 }
 
 // getBranchDisplayStrings returns the display string of branch
-func getBranchDisplayStrings(b *commands.Branch, fullDescription bool, diffed bool) []string {
+func getBranchDisplayStrings(b *commands.Branch, fullDescription bool, diffed bool, formatting Formatting) []string {
 	displayName := b.Name
 	if b.DisplayName != "" {
 		displayName = b.DisplayName
@@ -32,16 +42,20 @@ func getBranchDisplayStrings(b *commands.Branch, fullDescription bool, diffed bo
 	if diffed {
 		nameColorAttr = theme.DiffTerminalColor
 	}
-	coloredName := utils.ColoredString(displayName, nameColorAttr)
+	coloredName := utils.ColoredString(formatting.WithIcon(formatting.icons().Branch, displayName), nameColorAttr)
 	if b.Pushables != "" && b.Pullables != "" && b.Pushables != "?" && b.Pullables != "?" {
 		trackColor := color.FgYellow
 		if b.Pushables == "0" && b.Pullables == "0" {
 			trackColor = color.FgGreen
 		}
-		track := utils.ColoredString(fmt.Sprintf("↑%s↓%s", b.Pushables, b.Pullables), trackColor)
+		track := utils.ColoredString(formatting.icons().AheadBehind(b.Pushables, b.Pullables), trackColor)
 		coloredName = fmt.Sprintf("%s %s", coloredName, track)
 	}
 
+	if b.WorktreePath != "" {
+		coloredName = fmt.Sprintf("%s %s", coloredName, utils.ColoredString("(worktree: "+filepath.Base(b.WorktreePath)+")", color.FgBlue))
+	}
+
 	recencyColor := color.FgCyan
 	if b.Recency == "  *" {
 		recencyColor = color.FgGreen
@@ -6,19 +6,19 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/theme"
 )
 
-func GetCommitFileListDisplayStrings(commitFiles []*commands.CommitFile, diffName string) [][]string {
+func GetCommitFileListDisplayStrings(commitFiles []*commands.CommitFile, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(commitFiles))
 
 	for i := range commitFiles {
 		diffed := commitFiles[i].Name == diffName
-		lines[i] = getCommitFileDisplayStrings(commitFiles[i], diffed)
+		lines[i] = getCommitFileDisplayStrings(commitFiles[i], diffed, formatting)
 	}
 
 	return lines
 }
 
 // getCommitFileDisplayStrings returns the display string of branch
-func getCommitFileDisplayStrings(f *commands.CommitFile, diffed bool) []string {
+func getCommitFileDisplayStrings(f *commands.CommitFile, diffed bool, formatting Formatting) []string {
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
 	defaultColor := color.New(theme.DefaultTextColor)
@@ -36,5 +36,10 @@ func getCommitFileDisplayStrings(f *commands.CommitFile, diffed bool) []string {
 	if diffed {
 		colour = diffTerminalColor
 	}
-	return []string{colour.Sprint(f.DisplayString)}
+	displayString := formatting.RelativePathIn(f.DisplayString, f.Name)
+	displayString = formatting.PathLink(formatting.FileIcon(displayString, f.Name, f.IsDirectory), f.Name)
+	if f.Viewed {
+		return []string{colour.Sprint(displayString) + green.Sprint(" "+formatting.icons().Good)}
+	}
+	return []string{colour.Sprint(displayString)}
 }
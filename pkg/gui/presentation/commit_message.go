@@ -0,0 +1,42 @@
+package presentation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+var bulletRegex = regexp.MustCompile(`^(\s*)[-*] `)
+
+// GetCommitMessageDisplayString renders a commit message for the message
+// preview: the subject is bold, list items get a proper bullet and trailers are
+// laid out as labelled fields
+func GetCommitMessageDisplayString(message *commands.CommitMessage) string {
+	lines := []string{utils.ColoredString(message.Subject, color.Bold)}
+
+	if message.Body != "" {
+		lines = append(lines, "")
+		for _, line := range strings.Split(message.Body, "\n") {
+			lines = append(lines, bulletRegex.ReplaceAllString(line, "$1• "))
+		}
+	}
+
+	if len(message.Trailers) > 0 {
+		lines = append(lines, "")
+		padding := 0
+		for _, trailer := range message.Trailers {
+			if len(trailer.Key) > padding {
+				padding = len(trailer.Key)
+			}
+		}
+		for _, trailer := range message.Trailers {
+			key := utils.ColoredString(utils.WithPadding(trailer.Key+":", padding+1), color.FgCyan)
+			lines = append(lines, key+" "+trailer.Value)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
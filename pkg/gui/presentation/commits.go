@@ -9,10 +9,10 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetCommitListDisplayStrings(commits []*commands.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string) [][]string {
+func GetCommitListDisplayStrings(commits []*commands.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(commits))
 
-	var displayFunc func(*commands.Commit, map[string]bool, bool) []string
+	var displayFunc func(*commands.Commit, map[string]bool, bool, Formatting) []string
 	if fullDescription {
 		displayFunc = getFullDescriptionDisplayStringsForCommit
 	} else {
@@ -21,13 +21,13 @@ func GetCommitListDisplayStrings(commits []*commands.Commit, fullDescription boo
 
 	for i := range commits {
 		diffed := commits[i].Sha == diffName
-		lines[i] = displayFunc(commits[i], cherryPickedCommitShaMap, diffed)
+		lines[i] = displayFunc(commits[i], cherryPickedCommitShaMap, diffed, formatting)
 	}
 
 	return lines
 }
 
-func getFullDescriptionDisplayStringsForCommit(c *commands.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool) []string {
+func getFullDescriptionDisplayStringsForCommit(c *commands.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool, formatting Formatting) []string {
 	red := color.New(color.FgRed)
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
@@ -49,6 +49,8 @@ func getFullDescriptionDisplayStringsForCommit(c *commands.Commit, cherryPickedC
 		shaColor = yellow
 	case "merged":
 		shaColor = green
+	case "equivalent":
+		shaColor = green
 	case "rebasing":
 		shaColor = blue
 	case "reflog":
@@ -64,20 +66,19 @@ func getFullDescriptionDisplayStringsForCommit(c *commands.Commit, cherryPickedC
 	}
 
 	tagString := ""
-	secondColumnString := blue.Sprint(utils.UnixToDate(c.UnixTimestamp))
+	secondColumnString := blue.Sprint(formatting.Date(c.UnixTimestamp))
 	if c.Action != "" {
 		secondColumnString = cyan.Sprint(c.Action)
-	} else if c.ExtraInfo != "" {
-		tagColor := color.New(color.FgMagenta, color.Bold)
-		tagString = utils.ColoredStringDirect(c.ExtraInfo, tagColor) + " "
+	} else {
+		tagString = refBadges(c.Refs, formatting)
 	}
 
 	truncatedAuthor := utils.TruncateWithEllipsis(c.Author, 17)
 
-	return []string{shaColor.Sprint(c.ShortSha()), secondColumnString, yellow.Sprint(truncatedAuthor), tagString + defaultColor.Sprint(c.Name)}
+	return []string{shaColor.Sprint(commitSha(c, formatting)), secondColumnString, yellow.Sprint(truncatedAuthor), mergeExpansionBadge(c) + equivalentBadge(c) + annotationBadge(c) + tagString + defaultColor.Sprint(formatting.IssueLinks(c.Name))}
 }
 
-func getDisplayStringsForCommit(c *commands.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool) []string {
+func getDisplayStringsForCommit(c *commands.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool, formatting Formatting) []string {
 	red := color.New(color.FgRed)
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
@@ -99,6 +100,8 @@ func getDisplayStringsForCommit(c *commands.Commit, cherryPickedCommitShaMap map
 		shaColor = yellow
 	case "merged":
 		shaColor = green
+	case "equivalent":
+		shaColor = green
 	case "rebasing":
 		shaColor = blue
 	case "reflog":
@@ -117,10 +120,125 @@ func getDisplayStringsForCommit(c *commands.Commit, cherryPickedCommitShaMap map
 	tagString := ""
 	if c.Action != "" {
 		actionString = cyan.Sprint(utils.WithPadding(c.Action, 7)) + " "
-	} else if len(c.Tags) > 0 {
-		tagColor := color.New(color.FgMagenta, color.Bold)
-		tagString = utils.ColoredStringDirect(strings.Join(c.Tags, " "), tagColor) + " "
+	} else {
+		tagString = refBadges(c.Refs, formatting)
+	}
+
+	return []string{shaColor.Sprint(commitSha(c, formatting)), actionString + mergeExpansionBadge(c) + equivalentBadge(c) + annotationBadge(c) + tagString + defaultColor.Sprint(formatting.IssueLinks(c.Name))}
+}
+
+// commitSha abbreviates the commit's sha, linking it to the commit on the
+// remote if the commit is there
+func commitSha(c *commands.Commit, formatting Formatting) string {
+	if c.Status != "pushed" && c.Status != "merged" {
+		return formatting.Sha(c.Sha)
+	}
+	return formatting.ShaLink(c.Sha)
+}
+
+// equivalentBadge marks commits whose changes are already in the base branch
+// under a different sha (e.g. after a cherry-pick), meaning they're safe to drop
+func equivalentBadge(c *commands.Commit) string {
+	if c.Status != "equivalent" {
+		return ""
+	}
+	return color.New(color.FgGreen).Sprint("= ")
+}
+
+// annotationBadge marks commits that have a local annotation attached
+func annotationBadge(c *commands.Commit) string {
+	if !c.Annotated {
+		return ""
+	}
+	return color.New(color.FgMagenta).Sprint("✎ ")
+}
+
+// mergeExpansionBadge shows, in first-parent mode, whether a merge commit's
+// merged-in commits are listed beneath it, and indents those commits
+func mergeExpansionBadge(c *commands.Commit) string {
+	switch c.MergeExpansion {
+	case "collapsed":
+		return color.New(color.FgCyan).Sprint("▸ ")
+	case "expanded":
+		return color.New(color.FgCyan).Sprint("▾ ")
+	case "merged-in":
+		return color.New(color.FgCyan).Sprint("│ ")
+	default:
+		return ""
+	}
+}
+
+var refKindOrder = []string{"head", "branch", "tag", "remote", "other"}
+
+// visibleRefs orders a commit's refs with the checked out branch first. A remote
+// branch pointing at the same commit as its local branch tells us nothing new,
+// so we leave those out
+func visibleRefs(refs []*commands.CommitRef) []*commands.CommitRef {
+	localNames := map[string]bool{}
+	for _, ref := range refs {
+		if ref.Kind == "head" || ref.Kind == "branch" {
+			localNames[ref.Name] = true
+		}
+	}
+
+	visible := []*commands.CommitRef{}
+	for _, kind := range refKindOrder {
+		for _, ref := range refs {
+			if ref.Kind != kind {
+				continue
+			}
+			if kind == "remote" {
+				split := strings.SplitN(ref.Name, "/", 2)
+				if len(split) == 2 && (split[1] == "HEAD" || localNames[split[1]]) {
+					continue
+				}
+			}
+			visible = append(visible, ref)
+		}
+	}
+	return visible
+}
+
+func refColor(kind string) *color.Color {
+	switch kind {
+	case "head":
+		return color.New(color.FgCyan, color.Bold)
+	case "branch":
+		return color.New(color.FgGreen)
+	case "tag":
+		return color.New(color.FgMagenta, color.Bold)
+	case "remote":
+		return color.New(color.FgRed)
+	default:
+		return color.New(color.FgBlue)
+	}
+}
+
+// refBadges renders a commit's refs as coloured names. When a commit has lots
+// of refs (e.g. release tags) we show the first few and a count of the rest
+func refBadges(refs []*commands.CommitRef, formatting Formatting) string {
+	visible := visibleRefs(refs)
+	if len(visible) == 0 {
+		return ""
+	}
+
+	hiddenCount := 0
+	if formatting.MaxRefBadges > 0 && len(visible) > formatting.MaxRefBadges {
+		hiddenCount = len(visible) - formatting.MaxRefBadges
+		visible = visible[:formatting.MaxRefBadges]
+	}
+
+	badges := make([]string, 0, len(visible)+1)
+	for _, ref := range visible {
+		name := ref.Name
+		if formatting.MaxRefLength > 0 {
+			name = utils.TruncateWithEllipsis(name, formatting.MaxRefLength)
+		}
+		badges = append(badges, refColor(ref.Kind).Sprint(name))
+	}
+	if hiddenCount > 0 {
+		badges = append(badges, color.New(color.FgMagenta).Sprintf("+%d", hiddenCount))
 	}
 
-	return []string{shaColor.Sprint(c.ShortSha()), actionString + tagString + defaultColor.Sprint(c.Name)}
+	return strings.Join(badges, " ") + " "
 }
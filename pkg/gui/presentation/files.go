@@ -1,31 +1,39 @@
 package presentation
 
 import (
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 )
 
-func GetFileListDisplayStrings(files []*commands.File, diffName string) [][]string {
+func GetFileListDisplayStrings(files []*commands.File, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(files))
 
 	for i := range files {
 		diffed := files[i].Name == diffName
-		lines[i] = getFileDisplayStrings(files[i], diffed)
+		lines[i] = getFileDisplayStrings(files[i], diffed, formatting)
 	}
 
 	return lines
 }
 
 // getFileDisplayStrings returns the display string of branch
-func getFileDisplayStrings(f *commands.File, diffed bool) []string {
+func getFileDisplayStrings(f *commands.File, diffed bool, formatting Formatting) []string {
 	// potentially inefficient to be instantiating these color
 	// objects with each render
 	red := color.New(color.FgRed)
 	green := color.New(color.FgGreen)
 	diffColor := color.New(theme.DiffTerminalColor)
+	// a renamed file is named 'old -> new', and only the new one is there
+	path := f.Name
+	if i := strings.LastIndex(path, " -> "); i != -1 {
+		path = path[i+len(" -> "):]
+	}
 	if !f.Tracked && !f.HasStagedChanges {
-		return []string{red.Sprint(f.DisplayString)}
+		displayString := formatting.FileIcon(formatting.RelativePathIn(f.DisplayString, f.Name), path, f.Type == "directory")
+		return []string{red.Sprint(formatting.PathLink(displayString, path))}
 	}
 
 	var restColor *color.Color
@@ -52,6 +60,32 @@ func getFileDisplayStrings(f *commands.File, diffed bool) []string {
 
 	output := firstCharCl.Sprint(firstChar)
 	output += secondCharCl.Sprint(secondChar)
-	output += restColor.Sprintf(" %s", f.Name)
+	name := formatting.FileIcon(formatting.RelativePath(f.Name), path, f.Type == "directory")
+	output += restColor.Sprintf(" %s", formatting.PathLink(name, path))
+	if f.IsSubmodule {
+		output += color.New(color.FgCyan).Sprint(submoduleDescription(f))
+	}
+	if summary := f.HunkSummary(); summary != "" {
+		output += color.New(color.FgYellow).Sprintf(" (%s)", summary)
+	}
 	return []string{output}
 }
+
+// submoduleDescription spells out what has changed in a submodule, because
+// git status just calls it 'modified' either way
+func submoduleDescription(f *commands.File) string {
+	changes := []string{}
+	if f.SubmoduleHasNewCommits {
+		changes = append(changes, "new commits")
+	}
+	if f.SubmoduleHasModifiedContent {
+		changes = append(changes, "modified content")
+	}
+	if f.SubmoduleHasUntrackedContent {
+		changes = append(changes, "untracked content")
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(changes, ", ") + ")"
+}
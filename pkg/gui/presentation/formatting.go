@@ -0,0 +1,82 @@
+package presentation
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Formatting holds the user's preferences for how shas, dates, refs and icons
+// are displayed, so that the commits, reflog and stash views all render them the same way
+type Formatting struct {
+	ShaLength     int
+	RelativeDates bool
+	DateFormat    string // a go time layout e.g. '02 Jan 06 15:04 MST'
+	MaxRefBadges  int    // how many refs to show against a commit before summarising the rest
+	MaxRefLength  int    // longer ref names are truncated
+	// Hyperlinks, if set, is where shas, file paths and issue references link
+	// to in terminals that support hyperlinks
+	Hyperlinks *Hyperlinks
+	// Icons are the icons and status symbols to show, by gui.icons
+	Icons *Icons
+	// PathPrefix, if set, is the directory file paths are shown relative to,
+	// itself relative to the repo's root, e.g. 'pkg/gui'
+	PathPrefix string
+	// StaleFetchAge, if set, is how many seconds after a remote branch was
+	// last fetched we start showing that as stale
+	StaleFetchAge int64
+}
+
+// Sha abbreviates a sha to the configured length
+func (f Formatting) Sha(sha string) string {
+	if f.ShaLength <= 0 || len(sha) <= f.ShaLength {
+		return sha
+	}
+	return sha[:f.ShaLength]
+}
+
+// Date renders a unix timestamp either relative to now or with the configured layout
+func (f Formatting) Date(timestamp int64) string {
+	if f.RelativeDates {
+		return utils.UnixToTimeAgo(timestamp)
+	}
+	if f.DateFormat == "" {
+		return utils.UnixToDate(timestamp)
+	}
+	return time.Unix(timestamp, 0).Format(f.DateFormat)
+}
+
+// RelativePath shows a path, which git gives relative to the repo's root,
+// relative to PathPrefix instead, e.g. 'pkg/app/app.go' as '../app/app.go'
+// from 'pkg/gui'. A renamed file's 'old -> new' has both paths shown that way
+func (f Formatting) RelativePath(path string) string {
+	if f.PathPrefix == "" {
+		return path
+	}
+	if i := strings.Index(path, " -> "); i != -1 {
+		return f.RelativePath(path[:i]) + " -> " + f.RelativePath(path[i+len(" -> "):])
+	}
+
+	relativePath, err := filepath.Rel(filepath.FromSlash(f.PathPrefix), filepath.FromSlash(path))
+	if err != nil {
+		return path
+	}
+	relativePath = filepath.ToSlash(relativePath)
+	// untracked directories end in a slash, which Rel drops
+	if strings.HasSuffix(path, "/") && !strings.HasSuffix(relativePath, "/") {
+		relativePath += "/"
+	}
+	return relativePath
+}
+
+// RelativePathIn shows the path at the end of text, as in 'M  dir/file.go',
+// relative to PathPrefix. Text which doesn't end in the whole path, like a
+// file tree's 'file.go', is left alone
+func (f Formatting) RelativePathIn(text string, path string) string {
+	if f.PathPrefix == "" || !strings.HasSuffix(text, path) {
+		return text
+	}
+	return strings.TrimSuffix(text, path) + f.RelativePath(path)
+}
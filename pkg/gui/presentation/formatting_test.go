@@ -0,0 +1,131 @@
+package presentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFormattingRelativePath is a function.
+func TestFormattingRelativePath(t *testing.T) {
+	type scenario struct {
+		testName   string
+		pathPrefix string
+		path       string
+		expected   string
+	}
+
+	scenarios := []scenario{
+		{
+			"no prefix",
+			"",
+			"pkg/app/app.go",
+			"pkg/app/app.go",
+		},
+		{
+			"file below the prefix",
+			"pkg/gui",
+			"pkg/gui/gui.go",
+			"gui.go",
+		},
+		{
+			"file beside the prefix",
+			"pkg/gui",
+			"pkg/app/app.go",
+			"../app/app.go",
+		},
+		{
+			"file at the repo's root",
+			"pkg/gui",
+			"README.md",
+			"../../README.md",
+		},
+		{
+			"untracked directory keeps its trailing slash",
+			"pkg/gui",
+			"pkg/app/",
+			"../app/",
+		},
+		{
+			"the prefix itself",
+			"pkg/gui",
+			"pkg/gui/",
+			"./",
+		},
+		{
+			"rename",
+			"pkg/gui",
+			"pkg/gui/old.go -> pkg/app/new.go",
+			"old.go -> ../app/new.go",
+		},
+		{
+			"rename without a prefix",
+			"",
+			"old.go -> new.go",
+			"old.go -> new.go",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			formatting := Formatting{PathPrefix: s.pathPrefix}
+			assert.EqualValues(t, s.expected, formatting.RelativePath(s.path))
+		})
+	}
+}
+
+// TestFormattingRelativePathIn is a function.
+func TestFormattingRelativePathIn(t *testing.T) {
+	type scenario struct {
+		testName   string
+		pathPrefix string
+		text       string
+		path       string
+		expected   string
+	}
+
+	scenarios := []scenario{
+		{
+			"no prefix",
+			"",
+			"M  pkg/app/app.go",
+			"pkg/app/app.go",
+			"M  pkg/app/app.go",
+		},
+		{
+			"status line",
+			"pkg/gui",
+			"M  pkg/app/app.go",
+			"pkg/app/app.go",
+			"M  ../app/app.go",
+		},
+		{
+			"untracked directory",
+			"pkg/gui",
+			"?? pkg/gui/presentation/",
+			"pkg/gui/presentation/",
+			"?? presentation/",
+		},
+		{
+			"rename",
+			"pkg/gui",
+			"R  pkg/gui/old.go -> pkg/app/new.go",
+			"pkg/gui/old.go -> pkg/app/new.go",
+			"R  old.go -> ../app/new.go",
+		},
+		{
+			"text which doesn't end in the whole path is left alone",
+			"pkg/gui",
+			"app.go",
+			"pkg/app/app.go",
+			"app.go",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			formatting := Formatting{PathPrefix: s.pathPrefix}
+			assert.EqualValues(t, s.expected, formatting.RelativePathIn(s.text, s.path))
+		})
+	}
+}
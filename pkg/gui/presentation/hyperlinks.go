@@ -0,0 +1,64 @@
+package presentation
+
+import (
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Hyperlinks knows where to link shas, file paths and issue references to
+type Hyperlinks struct {
+	// CommitURL and IssueURL return the link to a commit or an issue, or
+	// nothing if there isn't one
+	CommitURL func(sha string) string
+	IssueURL  func(id string) string
+	// RepoPath is the absolute path of the repo, which file paths are relative
+	// to, and Hostname the machine it's on
+	RepoPath string
+	Hostname string
+}
+
+// issueReferenceRegexp matches issue references like '#123' in commit messages
+var issueReferenceRegexp = regexp.MustCompile(`(^|[\s(\[])(#(\d+))\b`)
+
+// ShaLink abbreviates a sha like Sha does, linking it to the commit
+func (f Formatting) ShaLink(sha string) string {
+	text := f.Sha(sha)
+	if f.Hyperlinks == nil {
+		return text
+	}
+	return utils.Hyperlink(text, f.Hyperlinks.CommitURL(sha))
+}
+
+// PathLink links the part of text which names the file at path, relative to
+// the repo, to the file. That's the whole path if it's there, as in
+// 'M  dir/file.go' or '../dir/file.go', or else just the file's name, as in a
+// file tree
+func (f Formatting) PathLink(text string, path string) string {
+	i := f.nameIndex(text, path)
+	if f.Hyperlinks == nil || i == -1 {
+		return text
+	}
+
+	fullPath := filepath.ToSlash(filepath.Join(f.Hyperlinks.RepoPath, path))
+	if !strings.HasPrefix(fullPath, "/") {
+		// a Windows path like C:/repo
+		fullPath = "/" + fullPath
+	}
+	fileURL := (&url.URL{Scheme: "file", Host: f.Hyperlinks.Hostname, Path: fullPath}).String()
+	return text[:i] + utils.Hyperlink(text[i:], fileURL)
+}
+
+// IssueLinks links the issue references in text, like '#123', to their issues
+func (f Formatting) IssueLinks(text string) string {
+	if f.Hyperlinks == nil || !strings.Contains(text, "#") {
+		return text
+	}
+	return issueReferenceRegexp.ReplaceAllStringFunc(text, func(match string) string {
+		groups := issueReferenceRegexp.FindStringSubmatch(match)
+		return groups[1] + utils.Hyperlink(groups[2], f.Hyperlinks.IssueURL(groups[3]))
+	})
+}
@@ -0,0 +1,204 @@
+package presentation
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Icons are the icons shown against files, branches, remotes, tags and stash
+// entries, and the symbols shown for statuses. An empty icon isn't shown
+type Icons struct {
+	Directory    string
+	File         string
+	Branch       string
+	RemoteBranch string
+	Remote       string
+	Tag          string
+	Stash        string
+	// FileNames and FileExtensions are the icons of particular files, by name
+	// and by lowercase extension, in place of File
+	FileNames      map[string]string
+	FileExtensions map[string]string
+	// RemoteHosts are the icons of remotes on particular hosts, in place of
+	// Remote, by a part of the host, e.g. 'github'
+	RemoteHosts map[string]string
+
+	// the symbols for commits ahead and behind the upstream, and for things
+	// which check out, don't, or are still being checked
+	Ahead   string
+	Behind  string
+	Good    string
+	Bad     string
+	Pending string
+}
+
+// DefaultIcons has no icons, just the symbols lazygit has always shown
+var DefaultIcons = &Icons{
+	Ahead:   "↑",
+	Behind:  "↓",
+	Good:    "✓",
+	Bad:     "✗",
+	Pending: "…",
+}
+
+// ASCIIIcons is for fonts and terminals which can't show those symbols
+var ASCIIIcons = &Icons{
+	Ahead:   "^",
+	Behind:  "v",
+	Good:    "ok",
+	Bad:     "x",
+	Pending: "...",
+}
+
+// NerdFontIcons needs a patched Nerd Font, see https://www.nerdfonts.com
+var NerdFontIcons = &Icons{
+	Directory:    "\uf07b",
+	File:         "\uf15b",
+	Branch:       "\ue725",
+	RemoteBranch: "\ue725",
+	Remote:       "\uf0c2",
+	Tag:          "\uf02b",
+	Stash:        "\uf01c",
+	FileNames: map[string]string{
+		".gitignore":     "\uf1d3",
+		".gitattributes": "\uf1d3",
+		".gitmodules":    "\uf1d3",
+		"Dockerfile":     "\uf308",
+		"Makefile":       "\ue615",
+		"LICENSE":        "\uf02d",
+		"go.mod":         "\ue627",
+		"go.sum":         "\ue627",
+	},
+	FileExtensions: map[string]string{
+		"go":   "\ue627",
+		"js":   "\ue74e",
+		"jsx":  "\ue7ba",
+		"ts":   "\ue628",
+		"tsx":  "\ue7ba",
+		"py":   "\ue606",
+		"rb":   "\ue739",
+		"rs":   "\ue7a8",
+		"java": "\ue738",
+		"c":    "\ue61e",
+		"h":    "\ue61e",
+		"cpp":  "\ue61d",
+		"php":  "\ue73d",
+		"lua":  "\ue620",
+		"vim":  "\ue62b",
+		"html": "\ue736",
+		"css":  "\ue749",
+		"md":   "\ue609",
+		"json": "\ue60b",
+		"yml":  "\ue615",
+		"yaml": "\ue615",
+		"toml": "\ue615",
+		"sh":   "\uf489",
+		"bash": "\uf489",
+		"zsh":  "\uf489",
+		"txt":  "\uf15c",
+		"lock": "\uf023",
+		"png":  "\uf1c5",
+		"jpg":  "\uf1c5",
+		"jpeg": "\uf1c5",
+		"gif":  "\uf1c5",
+		"svg":  "\uf1c5",
+		"pdf":  "\uf1c1",
+		"zip":  "\uf410",
+		"gz":   "\uf410",
+		"tar":  "\uf410",
+	},
+	RemoteHosts: map[string]string{
+		"github":    "\uf09b",
+		"gitlab":    "\uf296",
+		"bitbucket": "\uf171",
+		"azure":     "\uebd8",
+	},
+	Ahead:   "\uf062",
+	Behind:  "\uf063",
+	Good:    "\uf00c",
+	Bad:     "\uf00d",
+	Pending: "\uf017",
+}
+
+// IconsNamed returns the icons named by gui.icons: 'nerdfonts', 'ascii', or
+// anything else for the default
+func IconsNamed(name string) *Icons {
+	switch name {
+	case "nerdfonts":
+		return NerdFontIcons
+	case "ascii":
+		return ASCIIIcons
+	default:
+		return DefaultIcons
+	}
+}
+
+// AheadBehind is e.g. '↑2↓0', for a branch two commits ahead of its upstream
+func (icons *Icons) AheadBehind(pushables string, pullables string) string {
+	return icons.Ahead + pushables + icons.Behind + pullables
+}
+
+// icons returns the icons to show, which are the default ones if none were set
+func (f Formatting) icons() *Icons {
+	if f.Icons == nil {
+		return DefaultIcons
+	}
+	return f.Icons
+}
+
+// WithIcon puts the icon in front of the text, unless there's no icon
+func (f Formatting) WithIcon(icon string, text string) string {
+	if icon == "" {
+		return text
+	}
+	return icon + " " + text
+}
+
+// FileIcon puts the icon of the file at path in front of the part of text
+// which names it, as in 'M  dir/file.go' or a file tree's 'file.go'
+func (f Formatting) FileIcon(text string, path string, isDirectory bool) string {
+	icons := f.icons()
+	icon := icons.File
+	if isDirectory {
+		icon = icons.Directory
+	} else if byName, ok := icons.FileNames[filepath.Base(path)]; ok {
+		icon = byName
+	} else if byExtension, ok := icons.FileExtensions[strings.ToLower(strings.TrimPrefix(filepath.Ext(path), "."))]; ok {
+		icon = byExtension
+	}
+
+	i := f.nameIndex(text, path)
+	if icon == "" || i == -1 {
+		return text
+	}
+	return text[:i] + f.WithIcon(icon, text[i:])
+}
+
+// RemoteIcon returns the icon of a remote with the given urls
+func (f Formatting) RemoteIcon(urls []string) string {
+	icons := f.icons()
+	for _, url := range urls {
+		for host, icon := range icons.RemoteHosts {
+			if strings.Contains(url, host) {
+				return icon
+			}
+		}
+	}
+	return icons.Remote
+}
+
+// nameIndex returns where in text the file at path is named: where the whole
+// path is, as it is or relative to PathPrefix, or failing that its last part,
+// or -1 if none of those are there
+func (f Formatting) nameIndex(text string, path string) int {
+	if path == "" {
+		return -1
+	}
+	if i := strings.LastIndex(text, path); i != -1 {
+		return i
+	}
+	if i := strings.LastIndex(text, f.RelativePath(path)); f.PathPrefix != "" && i != -1 {
+		return i
+	}
+	return strings.LastIndex(text, filepath.Base(path))
+}
@@ -7,10 +7,10 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetReflogCommitListDisplayStrings(commits []*commands.Commit, fullDescription bool, diffName string) [][]string {
+func GetReflogCommitListDisplayStrings(commits []*commands.Commit, fullDescription bool, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(commits))
 
-	var displayFunc func(*commands.Commit, bool) []string
+	var displayFunc func(*commands.Commit, bool, Formatting) []string
 	if fullDescription {
 		displayFunc = getFullDescriptionDisplayStringsForReflogCommit
 	} else {
@@ -19,27 +19,27 @@ func GetReflogCommitListDisplayStrings(commits []*commands.Commit, fullDescripti
 
 	for i := range commits {
 		diffed := commits[i].Sha == diffName
-		lines[i] = displayFunc(commits[i], diffed)
+		lines[i] = displayFunc(commits[i], diffed, formatting)
 	}
 
 	return lines
 }
 
-func getFullDescriptionDisplayStringsForReflogCommit(c *commands.Commit, diffed bool) []string {
+func getFullDescriptionDisplayStringsForReflogCommit(c *commands.Commit, diffed bool, formatting Formatting) []string {
 	colorAttr := theme.DefaultTextColor
 	if diffed {
 		colorAttr = theme.DiffTerminalColor
 	}
 
 	return []string{
-		utils.ColoredString(c.ShortSha(), color.FgBlue),
-		utils.ColoredString(utils.UnixToDate(c.UnixTimestamp), color.FgMagenta),
+		utils.ColoredString(formatting.Sha(c.Sha), color.FgBlue),
+		utils.ColoredString(formatting.Date(c.UnixTimestamp), color.FgMagenta),
 		utils.ColoredString(c.Name, colorAttr),
 	}
 }
 
-func getDisplayStringsForReflogCommit(c *commands.Commit, diffed bool) []string {
+func getDisplayStringsForReflogCommit(c *commands.Commit, diffed bool, formatting Formatting) []string {
 	defaultColor := color.New(theme.DefaultTextColor)
 
-	return []string{utils.ColoredString(c.ShortSha(), color.FgBlue), defaultColor.Sprint(c.Name)}
+	return []string{utils.ColoredString(formatting.Sha(c.Sha), color.FgBlue), defaultColor.Sprint(c.Name)}
 }
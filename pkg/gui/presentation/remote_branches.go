@@ -1,30 +1,46 @@
 package presentation
 
 import (
+	"time"
+
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetRemoteBranchListDisplayStrings(branches []*commands.RemoteBranch, diffName string) [][]string {
+func GetRemoteBranchListDisplayStrings(branches []*commands.RemoteBranch, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(branches))
 
 	for i := range branches {
 		diffed := branches[i].FullName() == diffName
-		lines[i] = getRemoteBranchDisplayStrings(branches[i], diffed)
+		lines[i] = getRemoteBranchDisplayStrings(branches[i], diffed, formatting)
 	}
 
 	return lines
 }
 
 // getRemoteBranchDisplayStrings returns the display string of branch
-func getRemoteBranchDisplayStrings(b *commands.RemoteBranch, diffed bool) []string {
+func getRemoteBranchDisplayStrings(b *commands.RemoteBranch, diffed bool, formatting Formatting) []string {
 	nameColorAttr := GetBranchColor(b.Name)
 	if diffed {
 		nameColorAttr = theme.DiffTerminalColor
 	}
 
-	displayName := utils.ColoredString(b.Name, nameColorAttr)
+	displayName := utils.ColoredString(formatting.WithIcon(formatting.icons().RemoteBranch, b.Name), nameColorAttr)
+
+	return []string{formatting.FetchAge(b.FetchedAt), displayName}
+}
 
-	return []string{displayName}
+// FetchAge is how long ago something was fetched, e.g. '3h', in yellow once
+// that's more than StaleFetchAge seconds ago
+func (f Formatting) FetchAge(fetchedAt int64) string {
+	if fetchedAt == 0 {
+		return ""
+	}
+	ageColor := color.FgCyan
+	if f.StaleFetchAge > 0 && time.Now().Unix()-fetchedAt > f.StaleFetchAge {
+		ageColor = color.FgYellow
+	}
+	return utils.ColoredString(utils.UnixToTimeAgo(fetchedAt), ageColor)
 }
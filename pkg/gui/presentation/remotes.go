@@ -9,19 +9,19 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetRemoteListDisplayStrings(remotes []*commands.Remote, diffName string) [][]string {
+func GetRemoteListDisplayStrings(remotes []*commands.Remote, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(remotes))
 
 	for i := range remotes {
 		diffed := remotes[i].Name == diffName
-		lines[i] = getRemoteDisplayStrings(remotes[i], diffed)
+		lines[i] = getRemoteDisplayStrings(remotes[i], diffed, formatting)
 	}
 
 	return lines
 }
 
 // getRemoteDisplayStrings returns the display string of branch
-func getRemoteDisplayStrings(r *commands.Remote, diffed bool) []string {
+func getRemoteDisplayStrings(r *commands.Remote, diffed bool, formatting Formatting) []string {
 	branchCount := len(r.Branches)
 
 	nameColorAttr := theme.DefaultTextColor
@@ -29,5 +29,5 @@ func getRemoteDisplayStrings(r *commands.Remote, diffed bool) []string {
 		nameColorAttr = theme.DiffTerminalColor
 	}
 
-	return []string{utils.ColoredString(r.Name, nameColorAttr), utils.ColoredString(fmt.Sprintf("%d branches", branchCount), color.FgBlue)}
+	return []string{utils.ColoredString(formatting.WithIcon(formatting.RemoteIcon(r.Urls), r.Name), nameColorAttr), utils.ColoredString(fmt.Sprintf("%d branches", branchCount), color.FgBlue)}
 }
@@ -1,27 +1,36 @@
 package presentation
 
 import (
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetStashEntryListDisplayStrings(stashEntries []*commands.StashEntry, diffName string) [][]string {
+func GetStashEntryListDisplayStrings(stashEntries []*commands.StashEntry, fullDescription bool, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(stashEntries))
 
 	for i := range stashEntries {
 		diffed := stashEntries[i].RefName() == diffName
-		lines[i] = getStashEntryDisplayStrings(stashEntries[i], diffed)
+		lines[i] = getStashEntryDisplayStrings(stashEntries[i], diffed, formatting)
+		if fullDescription && stashEntries[i].UnixTimestamp != 0 {
+			date := utils.ColoredString(formatting.Date(stashEntries[i].UnixTimestamp), color.FgMagenta)
+			lines[i] = append([]string{date}, lines[i]...)
+		}
 	}
 
 	return lines
 }
 
 // getStashEntryDisplayStrings returns the display string of branch
-func getStashEntryDisplayStrings(s *commands.StashEntry, diffed bool) []string {
+func getStashEntryDisplayStrings(s *commands.StashEntry, diffed bool, formatting Formatting) []string {
 	attr := theme.DefaultTextColor
 	if diffed {
 		attr = theme.DiffTerminalColor
 	}
-	return []string{utils.ColoredString(s.Name, attr)}
+	displayName := utils.ColoredString(formatting.WithIcon(formatting.icons().Stash, s.Message()), attr)
+	if branch := s.Branch(); branch != "" {
+		displayName += utils.ColoredString(" on "+branch, color.FgBlue)
+	}
+	return []string{displayName}
 }
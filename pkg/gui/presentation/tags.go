@@ -1,27 +1,46 @@
 package presentation
 
 import (
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetTagListDisplayStrings(tags []*commands.Tag, diffName string) [][]string {
+func GetTagListDisplayStrings(tags []*commands.Tag, diffName string, formatting Formatting) [][]string {
 	lines := make([][]string, len(tags))
 
 	for i := range tags {
 		diffed := tags[i].Name == diffName
-		lines[i] = getTagDisplayStrings(tags[i], diffed)
+		lines[i] = getTagDisplayStrings(tags[i], diffed, formatting)
 	}
 
 	return lines
 }
 
 // getTagDisplayStrings returns the display string of branch
-func getTagDisplayStrings(t *commands.Tag, diffed bool) []string {
+func getTagDisplayStrings(t *commands.Tag, diffed bool, formatting Formatting) []string {
 	attr := theme.DefaultTextColor
 	if diffed {
 		attr = theme.DiffTerminalColor
 	}
-	return []string{utils.ColoredString(t.Name, attr)}
+	return []string{utils.ColoredString(formatting.WithIcon(formatting.icons().Tag, t.Name), attr) + tagVerificationBadge(t, formatting.icons())}
+}
+
+// tagVerificationBadge shows whether a signed tag's signature checks out, or
+// that we're still checking it
+func tagVerificationBadge(t *commands.Tag, icons *Icons) string {
+	if !t.Signed {
+		return ""
+	}
+	switch t.Verification {
+	case "good":
+		return utils.ColoredString(" "+icons.Good+" signed", color.FgGreen)
+	case "bad":
+		return utils.ColoredString(" "+icons.Bad+" bad signature", color.FgRed)
+	case "unknownKey":
+		return utils.ColoredString(" signed by unknown key", color.FgYellow)
+	default:
+		return utils.ColoredString(" "+icons.Pending+" signed", color.FgYellow)
+	}
 }
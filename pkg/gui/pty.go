@@ -31,6 +31,10 @@ func (gui *Gui) onResize() error {
 // pseudo-terminal meaning we'll get the behaviour we want from the underlying
 // command.
 func (gui *Gui) newPtyTask(viewName string, cmd *exec.Cmd) error {
+	if gui.mainViewPinned(viewName) {
+		return nil
+	}
+
 	width, _ := gui.getMainView().Size()
 	pager := gui.GitCommand.GetPager(width)
 
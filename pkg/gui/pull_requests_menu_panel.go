@@ -0,0 +1,59 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleViewPullRequests lists the repo's open pull requests with the status
+// of their CI checks. Picking one opens it in the browser
+func (gui *Gui) handleViewPullRequests(g *gocui.Gui, v *gocui.View) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("FetchingPullRequestsStatus"), func() error {
+		pullRequests, err := commands.NewPullRequest(gui.GitCommand).ListOpen()
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+		if len(pullRequests) == 0 {
+			return gui.createErrorPanel(gui.Tr.SLocalize("NoOpenPullRequests"))
+		}
+
+		menuItems := make([]*menuItem, len(pullRequests))
+		for i, pullRequest := range pullRequests {
+			pullRequest := pullRequest
+			menuItems[i] = &menuItem{
+				displayStrings: []string{
+					utils.ColoredString(fmt.Sprintf("#%d", pullRequest.Number), color.FgCyan),
+					ciStatusDisplay(pullRequest.CIStatus, gui.icons()),
+					pullRequest.Title,
+					utils.ColoredString(pullRequest.Head.Ref+" → "+pullRequest.Base.Ref, color.FgMagenta),
+				},
+				onPress: func() error {
+					return gui.OSCommand.OpenLink(pullRequest.URL)
+				},
+			}
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.createMenu(gui.Tr.SLocalize("PullRequestsTitle"), menuItems, createMenuOptions{showCancel: true})
+		})
+		return nil
+	})
+}
+
+func ciStatusDisplay(status string, icons *presentation.Icons) string {
+	switch status {
+	case "success":
+		return utils.ColoredString(icons.Good, color.FgGreen)
+	case "failure", "error":
+		return utils.ColoredString(icons.Bad, color.FgRed)
+	case "pending", "warning":
+		return utils.ColoredString(icons.Pending, color.FgYellow)
+	default:
+		return " "
+	}
+}
@@ -0,0 +1,106 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func pushRefResultDisplayString(result *commands.PushRefResult) string {
+	line := fmt.Sprintf("%s %s", result.Name(), result.Summary)
+	switch {
+	case result.Rejected():
+		return utils.ColoredString(line, color.FgRed)
+	case result.UpToDate():
+		return utils.ColoredString(line, color.FgBlue)
+	default:
+		return utils.ColoredString(line, color.FgGreen)
+	}
+}
+
+// formatPushProgressLine shows git's verdict on each ref as it comes in, and
+// drops the bits of porcelain output that only make sense to a script
+func formatPushProgressLine(line string) string {
+	if result := commands.ParsePushRefResult(line); result != nil {
+		return pushRefResultDisplayString(result)
+	}
+	if line == "Done" || strings.HasPrefix(line, "To ") {
+		return ""
+	}
+	return line
+}
+
+// showPushResults lists what happened to each ref in a push, with a tally of
+// pushed/rejected/up to date refs in the title, followed by what the push
+// transferred unless gui.transferSummary is off
+func (gui *Gui) showPushResults(v *gocui.View, output string, stats *commands.TransferStats, err error) error {
+	pushed, rejected, upToDate := 0, 0, 0
+	lines := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		result := commands.ParsePushRefResult(line)
+		if result == nil {
+			continue
+		}
+		switch {
+		case result.Rejected():
+			rejected++
+		case result.UpToDate():
+			upToDate++
+		default:
+			pushed++
+		}
+		lines = append(lines, pushRefResultDisplayString(result))
+	}
+
+	if len(lines) == 0 {
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+		lines = append(lines, gui.Tr.SLocalize("NothingPushed"))
+	}
+	if stats != nil && gui.Config.GetUserConfig().GetBool("gui.transferSummary") {
+		lines = append(lines, "", gui.transferSummary(stats))
+	}
+
+	title := gui.Tr.TemplateLocalize("PushResultsTitle", Teml{"pushed": pushed, "rejected": rejected, "upToDate": upToDate})
+	return gui.createConfirmationPanel(gui.g, v, true, title, strings.Join(lines, "\n"), nil, nil)
+}
+
+// pushAllTagsWithProgress pushes every tag, showing each tag's result live in
+// a popup rather than behind a single spinner, and asking for credentials if
+// the remote wants them
+func (gui *Gui) pushAllTagsWithProgress(v *gocui.View, remoteName string) error {
+	var stats *commands.TransferStats
+	return gui.streamOutput(v, gui.Tr.SLocalize("PushWait"), formatPushProgressLine, func(ctx context.Context, onLine func(string)) error {
+		var err error
+		stats, err = gui.GitCommand.PushAllTags(ctx, remoteName, onLine, func(passOrUname string) string {
+			return gui.waitForPassUname(gui.g, v, passOrUname)
+		})
+		return err
+	}, func(output string, err error) error {
+		if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{BRANCHES, TAGS, REMOTES}}); err != nil {
+			return err
+		}
+		return gui.showPushResults(v, output, stats, err)
+	})
+}
+
+func (gui *Gui) handlePushAllTags(g *gocui.Gui, v *gocui.View) error {
+	defaultRemote := "origin"
+	if branch := gui.getCheckedOutBranch(); branch != nil {
+		defaultRemote = gui.GitCommand.GetUpstreamRemote(branch.Name)
+	}
+	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("PushAllTagsTitle"), defaultRemote, func(g *gocui.Gui, promptView *gocui.View) error {
+		remoteName := gui.trimmedContent(promptView)
+		// the prompt closes once we return, so we open our popup after that
+		g.Update(func(*gocui.Gui) error {
+			return gui.pushAllTagsWithProgress(v, remoteName)
+		})
+		return nil
+	})
+}
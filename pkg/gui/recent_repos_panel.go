@@ -24,16 +24,7 @@ func (gui *Gui) handleCreateRecentReposMenu(g *gocui.Gui, v *gocui.View) error {
 				yellow.Sprint(innerPath),
 			},
 			onPress: func() error {
-				if err := os.Chdir(innerPath); err != nil {
-					return err
-				}
-				newGitCommand, err := commands.NewGitCommand(gui.Log, gui.OSCommand, gui.Tr, gui.Config)
-				if err != nil {
-					return err
-				}
-				gui.GitCommand = newGitCommand
-				gui.State.FilterPath = ""
-				return gui.Errors.ErrSwitchRepo
+				return gui.switchToRepo(innerPath)
 			},
 		}
 	}
@@ -41,6 +32,20 @@ func (gui *Gui) handleCreateRecentReposMenu(g *gocui.Gui, v *gocui.View) error {
 	return gui.createMenu(gui.Tr.SLocalize("RecentRepos"), menuItems, createMenuOptions{showCancel: true})
 }
 
+// switchToRepo restarts the gui in the repo at the given path
+func (gui *Gui) switchToRepo(path string) error {
+	if err := os.Chdir(path); err != nil {
+		return err
+	}
+	newGitCommand, err := commands.NewGitCommand(gui.Log, gui.OSCommand, gui.Tr, gui.Config)
+	if err != nil {
+		return err
+	}
+	gui.GitCommand = newGitCommand
+	gui.State.FilterPath = ""
+	return gui.Errors.ErrSwitchRepo
+}
+
 // updateRecentRepoList registers the fact that we opened lazygit in this repo,
 // so that we can open the same repo via the 'recent repos' menu
 func (gui *Gui) updateRecentRepoList() error {
@@ -41,10 +41,7 @@ func (gui *Gui) handleReflogCommitSelect(g *gocui.Gui, v *gocui.View) error {
 		return gui.renderDiff()
 	}
 
-	cmd := gui.OSCommand.ExecutableFromString(
-		gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.FilterPath),
-	)
-	if err := gui.newPtyTask("main", cmd); err != nil {
+	if err := gui.newPtyTask("main", gui.showCommitCmd(commit.Sha)); err != nil {
 		gui.Log.Error(err)
 	}
 
@@ -92,6 +89,8 @@ func (gui *Gui) refreshReflogCommits() error {
 	} else {
 		state.FilteredReflogCommits = state.ReflogCommits
 	}
+	commands.SetAnnotatedStatuses(state.FilteredReflogCommits, gui.GitCommand.GetAnnotatedShas())
+	gui.markRefreshed("commits")
 
 	if gui.getCommitsView().Context == "reflog-commits" {
 		return gui.renderReflogCommitsWithSelection()
@@ -104,7 +103,7 @@ func (gui *Gui) renderReflogCommitsWithSelection() error {
 	commitsView := gui.getCommitsView()
 
 	gui.refreshSelectedLine(&gui.State.Panels.ReflogCommits.SelectedLine, len(gui.State.FilteredReflogCommits))
-	displayStrings := presentation.GetReflogCommitListDisplayStrings(gui.State.FilteredReflogCommits, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Diff.Ref)
+	displayStrings := presentation.GetReflogCommitListDisplayStrings(gui.State.FilteredReflogCommits, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Diff.Ref, gui.formatting())
 	gui.renderDisplayStrings(commitsView, displayStrings)
 	if gui.g.CurrentView() == commitsView && commitsView.Context == "reflog-commits" {
 		if err := gui.handleReflogCommitSelect(gui.g, commitsView); err != nil {
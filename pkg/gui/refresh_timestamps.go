@@ -0,0 +1,69 @@
+package gui
+
+import (
+	"time"
+
+	"github.com/jesseduffield/gocui"
+)
+
+// markRefreshed records that a side panel's model was just loaded, so we can
+// show how stale it is
+func (gui *Gui) markRefreshed(viewName string) {
+	gui.State.LastRefreshedMutex.Lock()
+	defer gui.State.LastRefreshedMutex.Unlock()
+
+	if gui.State.LastRefreshed == nil {
+		gui.State.LastRefreshed = map[string]time.Time{}
+	}
+	gui.State.LastRefreshed[viewName] = time.Now()
+}
+
+// lastRefreshedSubtitle is e.g. 'loaded 14:02:11', or blank if the view
+// hasn't been loaded yet or the user has turned these off
+func (gui *Gui) lastRefreshedSubtitle(viewName string) string {
+	if !gui.Config.GetUserConfig().GetBool("gui.showLastRefreshed") {
+		return ""
+	}
+
+	gui.State.LastRefreshedMutex.Lock()
+	defer gui.State.LastRefreshedMutex.Unlock()
+
+	refreshedAt, ok := gui.State.LastRefreshed[viewName]
+	if !ok {
+		return ""
+	}
+	return gui.Tr.TemplateLocalize("LastRefreshedSubtitle", Teml{"time": refreshedAt.Format("15:04:05")})
+}
+
+// refreshScopeForView is what needs reloading to bring the given view's
+// current tab up to date. An empty scope refreshes everything
+func (gui *Gui) refreshScopeForView(v *gocui.View) []int {
+	switch v.Name() {
+	case "files":
+		return []int{FILES}
+	case "status", "commitFiles":
+		// commits are refreshed along with branches, which the status is derived from
+		return []int{COMMITS}
+	case "branches":
+		switch v.Context {
+		case "remotes", "remote-branches":
+			return []int{REMOTES}
+		case "tags":
+			return []int{TAGS}
+		default:
+			return []int{BRANCHES}
+		}
+	case "commits":
+		if v.Context == "reflog-commits" {
+			return []int{REFLOG}
+		}
+		return []int{COMMITS}
+	case "stash":
+		return []int{STASH}
+	}
+	return nil
+}
+
+func (gui *Gui) handleRefreshPanel(g *gocui.Gui, v *gocui.View) error {
+	return gui.refreshSidePanels(refreshOptions{scope: gui.refreshScopeForView(v), mode: ASYNC})
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/jesseduffield/gocui"
@@ -61,7 +62,7 @@ func (gui *Gui) renderRemoteBranchesWithSelection() error {
 	branchesView := gui.getBranchesView()
 
 	gui.refreshSelectedLine(&gui.State.Panels.RemoteBranches.SelectedLine, len(gui.State.RemoteBranches))
-	displayStrings := presentation.GetRemoteBranchListDisplayStrings(gui.State.RemoteBranches, gui.State.Diff.Ref)
+	displayStrings := presentation.GetRemoteBranchListDisplayStrings(gui.State.RemoteBranches, gui.State.Diff.Ref, gui.formatting())
 	gui.renderDisplayStrings(branchesView, displayStrings)
 	if gui.g.CurrentView() == branchesView && branchesView.Context == "remote-branches" {
 		if err := gui.handleRemoteBranchSelect(gui.g, branchesView); err != nil {
@@ -95,12 +96,8 @@ func (gui *Gui) handleDeleteRemoteBranch(g *gocui.Gui, v *gocui.View) error {
 	}
 	message := fmt.Sprintf("%s '%s/%s'?", gui.Tr.SLocalize("DeleteRemoteBranchMessage"), remoteBranch.RemoteName, remoteBranch.Name)
 	return gui.createConfirmationPanel(g, v, true, gui.Tr.SLocalize("DeleteRemoteBranch"), message, func(*gocui.Gui, *gocui.View) error {
-		return gui.WithWaitingStatus(gui.Tr.SLocalize("DeletingStatus"), func() error {
-			if err := gui.GitCommand.DeleteRemoteBranch(remoteBranch.RemoteName, remoteBranch.Name); err != nil {
-				return err
-			}
-
-			return gui.refreshSidePanels(refreshOptions{scope: []int{BRANCHES, REMOTES}})
+		return gui.runWithCredentials(v, gui.Tr.SLocalize("DeletingStatus"), func(ask func(string) string) error {
+			return gui.GitCommand.DeleteRemoteBranch(remoteBranch.RemoteName, remoteBranch.Name, ask)
 		})
 	}, nil)
 }
@@ -131,6 +128,31 @@ func (gui *Gui) handleSetBranchUpstream(g *gocui.Gui, v *gocui.View) error {
 	}, nil)
 }
 
+// handleFetchRemoteBranch fetches just the selected branch from its remote,
+// for when fetching the whole remote would take too long
+func (gui *Gui) handleFetchRemoteBranch(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedRemoteBranch()
+	if branch == nil {
+		return nil
+	}
+
+	title := fmt.Sprintf("%s %s", gui.Tr.SLocalize("FetchingRemoteStatus"), branch.FullName())
+	var stats *commands.TransferStats
+	return gui.streamOutput(v, title, nil, func(ctx context.Context, onLine func(string)) error {
+		var err error
+		stats, err = gui.GitCommand.FetchRemoteBranch(ctx, branch.RemoteName, branch.Name, onLine)
+		return err
+	}, func(output string, err error) error {
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+		if err := gui.refreshSidePanels(refreshOptions{scope: []int{BRANCHES, REMOTES}}); err != nil {
+			return err
+		}
+		return gui.showTransferSummary(v, stats)
+	})
+}
+
 func (gui *Gui) handleCreateResetToRemoteBranchMenu(g *gocui.Gui, v *gocui.View) error {
 	selectedBranch := gui.getSelectedRemoteBranch()
 	if selectedBranch == nil {
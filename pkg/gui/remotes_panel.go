@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -45,7 +46,15 @@ func (gui *Gui) handleRemoteSelect(g *gocui.Gui, v *gocui.View) error {
 		return gui.renderDiff()
 	}
 
-	return gui.newStringTask("main", fmt.Sprintf("%s\nUrls:\n%s", utils.ColoredString(remote.Name, color.FgGreen), strings.Join(remote.Urls, "\n")))
+	content := fmt.Sprintf("%s\nUrls:\n%s", utils.ColoredString(remote.Name, color.FgGreen), strings.Join(remote.Urls, "\n"))
+	if transfers := gui.GitCommand.TransferLog(remote.Name); len(transfers) > 0 {
+		// newest first
+		for i, j := 0, len(transfers)-1; i < j; i, j = i+1, j-1 {
+			transfers[i], transfers[j] = transfers[j], transfers[i]
+		}
+		content += fmt.Sprintf("\n\n%s:\n%s", gui.Tr.SLocalize("RemoteTransfers"), strings.Join(transfers, "\n"))
+	}
+	return gui.newStringTask("main", content)
 }
 
 func (gui *Gui) refreshRemotes() error {
@@ -57,6 +66,7 @@ func (gui *Gui) refreshRemotes() error {
 	}
 
 	gui.State.Remotes = remotes
+	gui.markRefreshed("branches")
 
 	// we need to ensure our selected remote branches aren't now outdated
 	if prevSelectedRemote != nil && gui.State.RemoteBranches != nil {
@@ -84,7 +94,7 @@ func (gui *Gui) renderRemotesWithSelection() error {
 
 	gui.refreshSelectedLine(&gui.State.Panels.Remotes.SelectedLine, len(gui.State.Remotes))
 
-	displayStrings := presentation.GetRemoteListDisplayStrings(gui.State.Remotes, gui.State.Diff.Ref)
+	displayStrings := presentation.GetRemoteListDisplayStrings(gui.State.Remotes, gui.State.Diff.Ref, gui.formatting())
 	gui.renderDisplayStrings(branchesView, displayStrings)
 
 	if gui.g.CurrentView() == branchesView && branchesView.Context == "remotes" {
@@ -189,11 +199,19 @@ func (gui *Gui) handleFetchRemote(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	return gui.WithWaitingStatus(gui.Tr.SLocalize("FetchingRemoteStatus"), func() error {
-		if err := gui.GitCommand.FetchRemote(remote.Name); err != nil {
+	title := fmt.Sprintf("%s %s", gui.Tr.SLocalize("FetchingRemoteStatus"), remote.Name)
+	var stats *commands.TransferStats
+	return gui.streamOutput(v, title, nil, func(ctx context.Context, onLine func(string)) error {
+		var err error
+		stats, err = gui.GitCommand.FetchRemote(ctx, remote.Name, onLine)
+		return err
+	}, func(output string, err error) error {
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+		if err := gui.refreshSidePanels(refreshOptions{scope: []int{BRANCHES, REMOTES}}); err != nil {
 			return err
 		}
-
-		return gui.refreshSidePanels(refreshOptions{scope: []int{BRANCHES, REMOTES}})
+		return gui.showTransferSummary(v, stats)
 	})
 }
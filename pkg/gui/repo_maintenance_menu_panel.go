@@ -0,0 +1,84 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// runMaintenanceTasks runs each command in turn, streaming its output into a
+// popup, and shows everything that was printed once they've all finished.
+// We stop at the first failure because e.g. there's no point repacking a
+// repo that fsck has found to be corrupt
+func (gui *Gui) runMaintenanceTasks(v *gocui.View, cmdStrs []string, previousOutput []string) error {
+	if len(cmdStrs) == 0 {
+		return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("MaintenanceFinished"), strings.Join(previousOutput, "\n\n"), nil, nil)
+	}
+
+	cmdStr := cmdStrs[0]
+	return gui.streamCommandOutput(v, cmdStr, cmdStr, func(output string, err error) error {
+		output = "$ " + cmdStr + "\n" + output
+		if err != nil {
+			return gui.createErrorPanel(strings.Join(append(previousOutput, output), "\n\n"))
+		}
+		return gui.runMaintenanceTasks(v, cmdStrs[1:], append(previousOutput, output))
+	})
+}
+
+func (gui *Gui) handleCreateRepoMaintenanceMenu(g *gocui.Gui, v *gocui.View) error {
+	blue := color.FgBlue
+
+	menuItems := []*menuItem{}
+	allCmdStrs := []string{}
+	for _, task := range commands.MaintenanceTasks {
+		cmdStr := task.Command
+		allCmdStrs = append(allCmdStrs, cmdStr)
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{gui.Tr.SLocalize("maintenance." + task.Key), utils.ColoredString(cmdStr, blue)},
+			onPress: func() error {
+				return gui.runMaintenanceTasks(v, []string{cmdStr}, nil)
+			},
+		})
+	}
+
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{gui.Tr.SLocalize("runAllMaintenance"), ""},
+		onPress: func() error {
+			return gui.runMaintenanceTasks(v, allCmdStrs, nil)
+		},
+	})
+
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{gui.Tr.SLocalize("viewPerformanceFeatures"), ""},
+		onPress: func() error {
+			return gui.createPerformanceMenu(v)
+		},
+	})
+
+	if gui.GitCommand.SupportsBackgroundMaintenance() {
+		if gui.GitCommand.BackgroundMaintenanceRegistered() {
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{gui.Tr.SLocalize("stopBackgroundMaintenance"), utils.ColoredString("git maintenance unregister", blue)},
+				onPress: func() error {
+					if err := gui.GitCommand.StopBackgroundMaintenance(); err != nil {
+						return gui.surfaceError(err)
+					}
+					return nil
+				},
+			})
+		} else {
+			cmdStr := gui.GitCommand.StartBackgroundMaintenanceCmdStr()
+			menuItems = append(menuItems, &menuItem{
+				displayStrings: []string{gui.Tr.SLocalize("startBackgroundMaintenance"), utils.ColoredString(cmdStr, blue)},
+				onPress: func() error {
+					return gui.runMaintenanceTasks(v, []string{cmdStr}, nil)
+				},
+			})
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("RepoMaintenanceTitle"), menuItems, createMenuOptions{showCancel: true})
+}
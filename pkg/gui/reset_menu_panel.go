@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 )
 
@@ -32,18 +33,29 @@ func (gui *Gui) resetToRef(ref string, strength string, options commands.RunComm
 }
 
 func (gui *Gui) createResetMenu(ref string) error {
-	strengths := []string{"soft", "mixed", "hard"}
+	currentView := gui.g.CurrentView()
+	strengths := []string{"soft", "mixed", "hard", "keep"}
+	descriptions := map[string]string{
+		"soft":  gui.Tr.SLocalize("SoftResetDescription"),
+		"mixed": gui.Tr.SLocalize("MixedResetDescription"),
+		"hard":  gui.Tr.SLocalize("HardResetDescription"),
+		"keep":  gui.Tr.SLocalize("KeepResetDescription"),
+	}
 	menuItems := make([]*menuItem, len(strengths))
 	for i, strength := range strengths {
 		strength := strength
 		menuItems[i] = &menuItem{
 			displayStrings: []string{
 				fmt.Sprintf("%s reset", strength),
+				descriptions[strength],
 				color.New(color.FgRed).Sprint(
 					fmt.Sprintf("reset --%s %s", strength, ref),
 				),
 			},
 			onPress: func() error {
+				if strength == "hard" {
+					return gui.confirmHardReset(currentView, ref)
+				}
 				return gui.resetToRef(ref, strength, commands.RunCommandOptions{})
 			},
 		}
@@ -51,3 +63,33 @@ func (gui *Gui) createResetMenu(ref string) error {
 
 	return gui.createMenu(fmt.Sprintf("%s %s", gui.Tr.SLocalize("resetTo"), ref), menuItems, createMenuOptions{showCancel: true})
 }
+
+// confirmHardReset lists the files a hard reset would change before doing it,
+// because any uncommitted changes to them can't be brought back with undo
+func (gui *Gui) confirmHardReset(currentView *gocui.View, ref string) error {
+	files, err := gui.GitCommand.GetFilesChangedSince(ref)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	branchName := gui.checkedOutBranchName()
+	reset := func() error {
+		return gui.withTypedConfirmationIf(gui.needsTypedConfirmation("hardReset", branchName), currentView, branchName, func() error {
+			return gui.resetToRef(ref, "hard", commands.RunCommandOptions{})
+		})
+	}
+	if len(files) == 0 {
+		return reset()
+	}
+
+	prompt := gui.Tr.TemplateLocalize(
+		"HardResetPrompt",
+		Teml{
+			"files":       gui.fileListPreview(files),
+			"keyBindUndo": gui.getKeyDisplay("universal.undo"),
+		},
+	)
+	return gui.createConfirmationPanel(gui.g, currentView, true, gui.Tr.SLocalize("HardReset"), prompt, func(*gocui.Gui, *gocui.View) error {
+		return reset()
+	}, nil)
+}
@@ -0,0 +1,48 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// reviewProgressSubtitle is e.g. '3/12 viewed', or blank if no files in the
+// commit have been marked as viewed
+func (gui *Gui) reviewProgressSubtitle() string {
+	viewed, total := 0, 0
+	for _, file := range gui.State.CommitFiles {
+		if file.IsDirectory {
+			continue
+		}
+		total++
+		if file.Viewed {
+			viewed++
+		}
+	}
+	if viewed == 0 {
+		return ""
+	}
+	return gui.Tr.TemplateLocalize("ViewedFilesSubtitle", Teml{"viewed": viewed, "total": total})
+}
+
+// handleToggleCommitFileViewed marks the selected file as viewed, or unmarks
+// it if it already is, so you can keep track of where you're up to in a big
+// commit. Doing so for a directory does the same for everything in it
+func (gui *Gui) handleToggleCommitFileViewed(g *gocui.Gui, v *gocui.View) error {
+	commitFile := gui.getSelectedCommitFile()
+	if commitFile == nil {
+		return nil
+	}
+
+	names := []string{commitFile.Name}
+	if commitFile.IsDirectory {
+		names = []string{}
+		for _, file := range commands.FilesInDirectory(gui.State.CommitFiles, commitFile.Name) {
+			names = append(names, file.Name)
+		}
+	}
+
+	if err := gui.GitCommand.SetFilesViewed(commitFile.Sha, names, !commitFile.Viewed); err != nil {
+		return gui.surfaceError(err)
+	}
+	return gui.refreshCommitFilesView()
+}
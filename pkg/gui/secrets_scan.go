@@ -0,0 +1,49 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// withPreCommitChecks runs each of the checks we do on the staged changes
+// before letting the user write their commit message
+func (gui *Gui) withPreCommitChecks(onCommit func() error) error {
+	return gui.withSecretsScan(func() error {
+		return gui.withCommitSizeGuard(onCommit)
+	})
+}
+
+// withSecretsScan blocks the commit if the staged changes add anything that
+// looks like a credential, unless the user chooses to commit anyway. It's
+// opt-in because the entropy check has false positives
+func (gui *Gui) withSecretsScan(onCommit func() error) error {
+	if !gui.Config.GetUserConfig().GetBool("git.secretsScan") {
+		return onCommit()
+	}
+
+	findings, err := gui.GitCommand.ScanStagedChangesForSecrets()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(findings) == 0 {
+		return onCommit()
+	}
+
+	lines := []string{gui.Tr.SLocalize("PossibleSecretsFound"), ""}
+	for _, finding := range findings {
+		lines = append(lines, fmt.Sprintf("%s:%d (%s)", finding.FileName, finding.Line, finding.Kind), "  "+finding.Content)
+	}
+
+	if err := gui.showBehindMenu(gui.Tr.SLocalize("PossibleSecretsTitle"), strings.Join(lines, "\n")); err != nil {
+		return err
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("commitAnyway"),
+			onPress:       onCommit,
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("PossibleSecretsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
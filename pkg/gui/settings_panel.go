@@ -0,0 +1,120 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// setting is a config option that can be changed from the settings menu
+type setting struct {
+	key string
+	// the i18n ID of what the option does
+	description string
+	// the values to pick from. Options without choices are typed in
+	choices []interface{}
+	// some options are only read when lazygit starts
+	needsRestart bool
+}
+
+var onOff = []interface{}{true, false}
+
+// settings are the options we think people most often want to change. The
+// rest are a matter of editing the config file
+var settings = []setting{
+	{key: "gui.theme.mode", description: "settingThemeMode", choices: []interface{}{"default", "high-contrast", "no-color"}},
+	{key: "gui.theme.lightTheme", description: "settingLightTheme", choices: onOff},
+	{key: "gui.keymap", description: "settingKeymap", choices: []interface{}{"default", "vim", "emacs", "arrows"}, needsRestart: true},
+	{key: "git.pull.mode", description: "settingPullMode", choices: []interface{}{"", "merge", "rebase", "ff-only"}},
+	{key: "git.autoFetch", description: "settingAutoFetch", choices: onOff, needsRestart: true},
+	{key: "git.autoRefresh", description: "settingAutoRefresh", choices: onOff, needsRestart: true},
+	{key: "gui.relativeDates", description: "settingRelativeDates", choices: onOff},
+	{key: "gui.dateFormat", description: "settingDateFormat"},
+	{key: "gui.sidePanelWidth", description: "settingSidePanelWidth"},
+	{key: "gui.mouseEvents", description: "settingMouseEvents", choices: onOff, needsRestart: true},
+	{key: "gui.accessibility.enabled", description: "settingAccessibility", choices: onOff, needsRestart: true},
+	{key: "update.method", description: "settingUpdateMethod", choices: []interface{}{"prompt", "background", "checkOnly", "never"}},
+	{key: "confirmOnQuit", description: "settingConfirmOnQuit", choices: onOff},
+}
+
+// handleCreateSettingsMenu lists the settings along with their current values
+func (gui *Gui) handleCreateSettingsMenu(g *gocui.Gui, v *gocui.View) error {
+	menuItems := make([]*menuItem, len(settings))
+	for i := range settings {
+		setting := settings[i]
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				setting.key,
+				color.New(color.FgCyan).Sprint(formatSettingValue(gui.Config.GetUserConfig().Get(setting.key))),
+				gui.Tr.SLocalize(setting.description),
+			},
+			onPress: func() error {
+				return gui.editSetting(v, setting)
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("SettingsMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) editSetting(v *gocui.View, setting setting) error {
+	if setting.choices == nil {
+		current := formatSettingValue(gui.Config.GetUserConfig().Get(setting.key))
+		return gui.createPromptPanel(gui.g, v, setting.key, current, func(g *gocui.Gui, promptView *gocui.View) error {
+			// typed values are read as YAML, so that numbers stay numbers
+			var value interface{}
+			if err := yaml.Unmarshal([]byte(gui.trimmedContent(promptView)), &value); err != nil {
+				return gui.surfaceError(err)
+			}
+			if value == nil {
+				value = ""
+			}
+			return gui.saveSetting(v, setting, value)
+		})
+	}
+
+	menuItems := make([]*menuItem, len(setting.choices))
+	for i := range setting.choices {
+		choice := setting.choices[i]
+		menuItems[i] = &menuItem{
+			displayString: formatSettingValue(choice),
+			onPress: func() error {
+				return gui.saveSetting(v, setting, choice)
+			},
+		}
+	}
+	return gui.createMenu(setting.key, menuItems, createMenuOptions{showCancel: true})
+}
+
+// saveSetting writes the new value to the config file, keeping the user's
+// comments, and applies it straight away where it can be
+func (gui *Gui) saveSetting(v *gocui.View, setting setting, value interface{}) error {
+	if err := gui.Config.WriteToUserConfig(setting.key, value); err != nil {
+		return gui.surfaceError(err)
+	}
+	gui.Config.GetUserConfig().Set(setting.key, value)
+
+	if strings.HasPrefix(setting.key, "gui.theme.") {
+		if err := gui.setColorScheme(); err != nil {
+			return err
+		}
+	}
+	if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC}); err != nil {
+		return err
+	}
+
+	if setting.needsRestart {
+		return gui.createConfirmationPanel(gui.g, v, true, setting.key, gui.Tr.SLocalize("SettingNeedsRestart"), nil, nil)
+	}
+	return gui.handleCreateSettingsMenu(gui.g, v)
+}
+
+func formatSettingValue(value interface{}) string {
+	if value == "" {
+		return "''"
+	}
+	return fmt.Sprint(value)
+}
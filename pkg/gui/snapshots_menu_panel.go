@@ -0,0 +1,94 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) startSnapshotting() {
+	interval := gui.Config.GetUserConfig().GetInt("git.snapshots.interval")
+	if interval <= 0 {
+		interval = 300
+	}
+
+	gui.goEvery(time.Second*time.Duration(interval), gui.stopChan, func() error {
+		_, err := gui.createSnapshot()
+		if err != nil {
+			gui.Log.Error(err)
+		}
+		return err
+	})
+}
+
+// createSnapshot records the working tree against the snapshot ref. We don't
+// snapshot mid-rebase or mid-merge because the working tree is in flux and
+// the user has their own way back via abort
+func (gui *Gui) createSnapshot() (bool, error) {
+	if gui.GitCommand.WorkingTreeState() != "normal" {
+		return false, nil
+	}
+
+	branchName, _, err := gui.GitCommand.CurrentBranchName()
+	if err != nil {
+		branchName = "HEAD"
+	}
+
+	return gui.GitCommand.CreateSnapshot(fmt.Sprintf("snapshot on %s", branchName))
+}
+
+func (gui *Gui) handleCreateSnapshotsMenu(g *gocui.Gui, v *gocui.View) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("TakeSnapshotNow"),
+			onPress: func() error {
+				created, err := gui.createSnapshot()
+				if err != nil {
+					return gui.surfaceError(err)
+				}
+				if !created {
+					return gui.createErrorPanel(gui.Tr.SLocalize("NothingToSnapshot"))
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, snapshot := range gui.GitCommand.GetSnapshots() {
+		snapshot := snapshot
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{
+				utils.ColoredString(gui.formatting().Date(snapshot.UnixTimestamp), color.FgMagenta),
+				utils.ColoredString(gui.formatting().Sha(snapshot.Sha), color.FgYellow),
+				snapshot.Message,
+			},
+			onPress: func() error {
+				return gui.confirmRestoreSnapshot(v, snapshot)
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("SnapshotsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) confirmRestoreSnapshot(v *gocui.View, snapshot *commands.Snapshot) error {
+	_ = gui.showInMainView(func() error {
+		gui.getMainView().Title = gui.Tr.SLocalize("SnapshotPreviewTitle")
+		cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.ShowCmdStr(snapshot.Sha, ""))
+		if err := gui.newPtyTask("main", cmd); err != nil {
+			gui.Log.Error(err)
+		}
+		return nil
+	})
+
+	return gui.createConfirmationPanel(gui.g, v, true, gui.Tr.SLocalize("RestoreSnapshot"), gui.Tr.SLocalize("RestoreSnapshotPrompt"), func(g *gocui.Gui, _ *gocui.View) error {
+		if err := gui.GitCommand.RestoreSnapshot(snapshot.Sha); err != nil {
+			return gui.surfaceError(err)
+		}
+		return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+	}, nil)
+}
@@ -0,0 +1,85 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// handleCreateStandupMenu asks how far back the standup report should go
+func (gui *Gui) handleCreateStandupMenu(g *gocui.Gui, v *gocui.View) error {
+	author := gui.GitCommand.CurrentUserEmail()
+	if author == "" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoUserEmailForStandup"))
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("standupYesterday"),
+			onPress: func() error {
+				return gui.showStandupReport("yesterday.midnight", gui.Tr.SLocalize("standupYesterday"), author)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("standupLastFriday"),
+			onPress: func() error {
+				return gui.showStandupReport("last.friday.midnight", gui.Tr.SLocalize("standupLastFriday"), author)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("standupCustom"),
+			onPress: func() error {
+				return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("StandupSincePrompt"), "", func(g *gocui.Gui, promptView *gocui.View) error {
+					since := strings.TrimSpace(promptView.Buffer())
+					return gui.showStandupReport(since, since, author)
+				})
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("StandupMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// showStandupReport gathers the author's commits and branch switches since the
+// given date across the current repo and those in standup.repos, then shows the
+// report in the main view with a menu for copying it
+func (gui *Gui) showStandupReport(since string, sinceLabel string, author string) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("GeneratingStandupStatus"), func() error {
+		timestamp, err := gui.GitCommand.StandupSince(since)
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+
+		repos := append([]string{"."}, gui.Config.GetUserConfig().GetStringSlice("standup.repos")...)
+		activities := make([]*commands.StandupActivity, 0, len(repos))
+		for _, repo := range repos {
+			activity, err := gui.GitCommand.GetStandupActivity(repo, timestamp, author)
+			if err != nil {
+				return gui.surfaceError(err)
+			}
+			activities = append(activities, activity)
+		}
+		report := commands.BuildStandupReport(gui.Tr.TemplateLocalize("StandupReportHeading", Teml{"since": sinceLabel}), activities)
+
+		menuItems := []*menuItem{
+			{
+				displayString: gui.Tr.SLocalize("copyStandupReport"),
+				onPress: func() error {
+					return gui.OSCommand.CopyToClipboard(report)
+				},
+			},
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			if err := gui.showInMainView(func() error {
+				gui.getMainView().Title = gui.Tr.SLocalize("StandupReportTitle")
+				return gui.newStringTask("main", report)
+			}); err != nil {
+				return err
+			}
+			return gui.createMenu(gui.Tr.SLocalize("StandupReportTitle"), menuItems, createMenuOptions{showCancel: true})
+		})
+		return nil
+	})
+}
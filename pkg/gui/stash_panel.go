@@ -52,12 +52,13 @@ func (gui *Gui) handleStashEntrySelect(g *gocui.Gui, v *gocui.View) error {
 
 func (gui *Gui) refreshStashEntries(g *gocui.Gui) error {
 	gui.State.StashEntries = gui.GitCommand.GetStashEntries(gui.State.FilterPath)
+	gui.markRefreshed("stash")
 
 	gui.refreshSelectedLine(&gui.State.Panels.Stash.SelectedLine, len(gui.State.StashEntries))
 
 	stashView := gui.getStashView()
 
-	displayStrings := presentation.GetStashEntryListDisplayStrings(gui.State.StashEntries, gui.State.Diff.Ref)
+	displayStrings := presentation.GetStashEntryListDisplayStrings(gui.State.StashEntries, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Diff.Ref, gui.formatting())
 	gui.renderDisplayStrings(stashView, displayStrings)
 
 	return gui.resetOrigin(stashView)
@@ -109,6 +110,40 @@ func (gui *Gui) handleStashDrop(g *gocui.Gui, v *gocui.View) error {
 	}, nil)
 }
 
+func (gui *Gui) handleRenameStash(g *gocui.Gui, v *gocui.View) error {
+	stashEntry := gui.getSelectedStashEntry()
+	if stashEntry == nil {
+		return nil
+	}
+
+	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("RenameStashPrompt"), stashEntry.Message(), func(g *gocui.Gui, promptView *gocui.View) error {
+		message := gui.trimmedContent(promptView)
+		if message == "" || message == stashEntry.Message() {
+			return nil
+		}
+		if err := gui.GitCommand.RenameStash(stashEntry.Index, message); err != nil {
+			return gui.surfaceError(err)
+		}
+		gui.State.Panels.Stash.SelectedLine = 0
+		return gui.refreshSidePanels(refreshOptions{scope: []int{STASH}})
+	})
+}
+
+func (gui *Gui) handleStashBranch(g *gocui.Gui, v *gocui.View) error {
+	stashEntry := gui.getSelectedStashEntry()
+	if stashEntry == nil {
+		return nil
+	}
+
+	return gui.createPromptPanel(g, v, gui.Tr.SLocalize("NewBranchFromStashPrompt"), "", func(g *gocui.Gui, promptView *gocui.View) error {
+		if err := gui.GitCommand.StashBranch(stashEntry.Index, gui.trimmedContent(promptView)); err != nil {
+			return gui.surfaceError(err)
+		}
+		gui.State.Panels.Branches.SelectedLine = 0
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+	})
+}
+
 func (gui *Gui) stashDo(g *gocui.Gui, v *gocui.View, method string) error {
 	stashEntry := gui.getSelectedStashEntry()
 	if stashEntry == nil {
@@ -0,0 +1,91 @@
+package gui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// statusBarSegment is a segment of the status bar filled in with the output
+// of one of the user's commands
+type statusBarSegment struct {
+	Name    string `mapstructure:"name"`
+	Command string `mapstructure:"command"`
+}
+
+// refreshStatusBar renders the user's gui.statusBar.template, if they have
+// one, to show in place of the version in the bottom right
+func (gui *Gui) refreshStatusBar(currentBranch *commands.Branch) {
+	template := gui.Config.GetUserConfig().GetString("gui.statusBar.template")
+	if template == "" {
+		gui.setStatusBar("")
+		return
+	}
+
+	segments := map[string]string{
+		"repo":        utils.GetCurrentRepoName(),
+		"branch":      currentBranch.Name,
+		"aheadBehind": "",
+		"state":       "",
+		"lastFetch":   gui.Tr.SLocalize("NeverFetched"),
+	}
+	if currentBranch.Pushables != "" && currentBranch.Pullables != "" {
+		segments["aheadBehind"] = gui.icons().AheadBehind(currentBranch.Pushables, currentBranch.Pullables)
+	}
+	if state := gui.GitCommand.WorkingTreeState(); state != "normal" {
+		segments["state"] = state
+	}
+	if lastFetch := gui.GitCommand.LastFetchTime(); !lastFetch.IsZero() {
+		segments["lastFetch"] = utils.UnixToTimeAgo(lastFetch.Unix())
+	}
+
+	customSegments := []statusBarSegment{}
+	if err := gui.Config.GetUserConfig().UnmarshalKey("gui.statusBar.segments", &customSegments); err != nil {
+		gui.Log.Error(err)
+	}
+	if len(customSegments) == 0 {
+		gui.setStatusBar(commands.RenderStatusBar(template, segments))
+		return
+	}
+
+	// the user's commands can take as long as they like, so they mustn't hold
+	// up the rest of the status panel's refresh
+	go func() {
+		timeout := time.Duration(gui.Config.GetUserConfig().GetInt64("gui.statusBar.timeout")) * time.Second
+		for _, segment := range customSegments {
+			segments[segment.Name] = gui.statusBarSegmentOutput(segment, timeout)
+		}
+		gui.setStatusBar(commands.RenderStatusBar(template, segments))
+	}()
+}
+
+// statusBarSegmentOutput returns the first line the segment's command writes
+// to stdout, or nothing if it fails or takes longer than timeout
+func (gui *Gui) statusBarSegmentOutput(segment statusBarSegment, timeout time.Duration) string {
+	var stdout strings.Builder
+	_, err := gui.OSCommand.RunShellCommandWithOutputWithOptions(segment.Command, commands.RunCommandOptions{
+		Timeout: timeout,
+		OnOutput: func(chunk commands.OutputChunk) {
+			if chunk.Stream == commands.Stdout {
+				stdout.WriteString(chunk.Text)
+			}
+		},
+	})
+	if err != nil {
+		gui.Log.Errorf("status bar segment %s failed: %v", segment.Name, err)
+		return ""
+	}
+	return strings.TrimSpace(strings.SplitN(stdout.String(), "\n", 2)[0])
+}
+
+// setStatusBar sets what's shown in place of the version, on the UI goroutine
+// which reads it when laying out the views
+func (gui *Gui) setStatusBar(statusBar string) {
+	gui.g.Update(func(*gocui.Gui) error {
+		gui.State.StatusBar = statusBar
+		return nil
+	})
+}
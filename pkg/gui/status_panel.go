@@ -3,6 +3,7 @@ package gui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
@@ -20,6 +21,8 @@ func (gui *Gui) refreshStatus() {
 		// need to wait for branches to refresh
 		return
 	}
+	gui.markRefreshed("status")
+
 	status := ""
 
 	if currentBranch.Pushables != "" && currentBranch.Pullables != "" {
@@ -30,23 +33,76 @@ func (gui *Gui) refreshStatus() {
 			trackColor = color.FgRed
 		}
 
-		status = utils.ColoredString(fmt.Sprintf("↑%s↓%s ", currentBranch.Pushables, currentBranch.Pullables), trackColor)
+		status = utils.ColoredString(gui.icons().AheadBehind(currentBranch.Pushables, currentBranch.Pullables)+" ", trackColor)
 	}
 
 	if gui.GitCommand.WorkingTreeState() != "normal" {
 		status += utils.ColoredString(fmt.Sprintf("(%s) ", gui.GitCommand.WorkingTreeState()), color.FgYellow)
 	}
 
-	name := utils.ColoredString(currentBranch.Name, presentation.GetBranchColor(currentBranch.Name))
+	name := utils.ColoredString(gui.formatting().WithIcon(gui.icons().Branch, currentBranch.Name), presentation.GetBranchColor(currentBranch.Name))
 	repoName := utils.GetCurrentRepoName()
 	status += fmt.Sprintf("%s → %s ", repoName, name)
 
+	if filter := gui.GitCommand.PartialCloneFilter; filter != "" {
+		status += utils.ColoredString(fmt.Sprintf("[partial clone: %s] ", filter), color.FgCyan)
+	}
+
+	if taskResults := gui.taskResultsStatus(); taskResults != "" {
+		status += taskResults + " "
+	}
+
+	if gui.State.GitTraceMode {
+		status += utils.ColoredString("[GIT_TRACE] ", color.FgMagenta)
+	}
+
+	if gui.State.SigningKeyWarning != "" {
+		status += utils.ColoredString(gui.State.SigningKeyWarning, color.FgRed)
+	}
+
+	gui.refreshStatusBar(currentBranch)
+
 	gui.g.Update(func(*gocui.Gui) error {
 		gui.setViewContent(gui.getStatusView(), status)
 		return nil
 	})
 }
 
+// signingKeyExpiryWarningPeriod is how far ahead of a signing key's expiry we
+// start warning about it
+const signingKeyExpiryWarningPeriod = time.Hour * 24 * 14
+
+// checkSigningKey warns in the status panel about a signing key that will
+// make commits fail, so the user isn't left deciphering gpg's error output
+func (gui *Gui) checkSigningKey() {
+	status := gui.GitCommand.GetSigningKeyStatus()
+	if status == nil {
+		return
+	}
+
+	warning := ""
+	switch {
+	case status.Missing:
+		warning = gui.Tr.SLocalize("SigningKeyMissing")
+	case status.Revoked:
+		warning = gui.Tr.SLocalize("SigningKeyRevoked")
+	case status.Expired || (!status.ExpiresAt.IsZero() && time.Now().After(status.ExpiresAt)):
+		warning = gui.Tr.SLocalize("SigningKeyExpired")
+	case !status.ExpiresAt.IsZero() && time.Until(status.ExpiresAt) < signingKeyExpiryWarningPeriod:
+		warning = gui.Tr.TemplateLocalize(
+			"SigningKeyExpiresSoon",
+			Teml{"date": status.ExpiresAt.Format("2006-01-02")},
+		)
+	}
+
+	if warning == "" {
+		return
+	}
+
+	gui.State.SigningKeyWarning = warning
+	_ = gui.refreshSidePanels(refreshOptions{scope: []int{STATUS}, mode: ASYNC})
+}
+
 func runeCount(str string) int {
 	return len([]rune(str))
 }
@@ -64,7 +120,7 @@ func (gui *Gui) handleStatusClick(g *gocui.Gui, v *gocui.View) error {
 	currentBranch := gui.currentBranch()
 
 	cx, _ := v.Cursor()
-	upstreamStatus := fmt.Sprintf("↑%s↓%s", currentBranch.Pushables, currentBranch.Pullables)
+	upstreamStatus := gui.icons().AheadBehind(currentBranch.Pushables, currentBranch.Pullables)
 	repoName := utils.GetCurrentRepoName()
 	switch gui.GitCommand.WorkingTreeState() {
 	case "rebasing", "merging":
@@ -0,0 +1,44 @@
+package gui
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+func (gui *Gui) handleCreateSubmoduleMenu(file *commands.File) error {
+	path := file.Name
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("updateSubmodule"),
+			onPress: func() error {
+				return gui.WithWaitingStatus(gui.Tr.SLocalize("UpdatingSubmoduleStatus"), func() error {
+					if err := gui.GitCommand.UpdateSubmodule(path); err != nil {
+						return gui.surfaceError(err)
+					}
+					return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+				})
+			},
+		},
+	}
+
+	if file.SubmoduleHasModifiedContent || file.SubmoduleHasUntrackedContent {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("stashSubmoduleChanges"),
+			onPress: func() error {
+				if err := gui.GitCommand.StashSubmoduleChanges(path); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+			},
+		})
+	}
+
+	menuItems = append(menuItems, &menuItem{
+		displayString: gui.Tr.SLocalize("openSubmodule"),
+		onPress: func() error {
+			return gui.switchToRepo(path)
+		},
+	})
+
+	return gui.createMenu(gui.Tr.TemplateLocalize("SubmoduleMenuTitle", Teml{"path": path}), menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,98 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+)
+
+// setTagVerifications fills in what we already know about the given tags'
+// signatures. Tags are only touched on the gui goroutine, so this has to be
+// called from there
+func (gui *Gui) setTagVerifications(tags []*commands.Tag) {
+	gui.tagVerificationsMutex.Lock()
+	defer gui.tagVerificationsMutex.Unlock()
+
+	for _, tag := range tags {
+		tag.Verification = ""
+		if tag.Signed {
+			tag.Verification = gui.tagVerifications[tag.ObjectSha]
+		}
+	}
+}
+
+// verifySelectedTag checks the signature of the selected tag if it's signed
+// and we haven't checked it yet, then shows the result in the tags panel.
+// Checking every tag would mean running gpg once per tag on each refresh, so
+// we only check the ones the user looks at, and each of them just the once
+func (gui *Gui) verifySelectedTag() {
+	tag := gui.getSelectedTag()
+	if tag == nil || !tag.Signed || tag.Verification != "" {
+		return
+	}
+	name, objectSha := tag.Name, tag.ObjectSha
+
+	gui.tagVerificationsMutex.Lock()
+	if gui.verifyingTags == nil {
+		gui.verifyingTags = map[string]bool{}
+	}
+	verifying := gui.verifyingTags[objectSha]
+	gui.verifyingTags[objectSha] = true
+	gui.tagVerificationsMutex.Unlock()
+	if verifying {
+		return
+	}
+
+	go func() {
+		verification := gui.GitCommand.VerifyTag(name)
+		gui.storeTagVerification(objectSha, verification)
+
+		gui.g.Update(func(*gocui.Gui) error {
+			gui.setTagVerifications(gui.State.Tags)
+			// we leave the main view alone, as it doesn't show the signature
+			if gui.getBranchesView().Context == "tags" {
+				gui.renderDisplayStrings(gui.getBranchesView(), presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.Diff.Ref, gui.formatting()))
+			}
+			return nil
+		})
+	}()
+}
+
+// storeTagVerification remembers how the signature of the tag object with
+// the given sha checked out
+func (gui *Gui) storeTagVerification(objectSha string, verification string) {
+	gui.tagVerificationsMutex.Lock()
+	defer gui.tagVerificationsMutex.Unlock()
+
+	if gui.tagVerifications == nil {
+		gui.tagVerifications = map[string]string{}
+	}
+	gui.tagVerifications[objectSha] = verification
+	delete(gui.verifyingTags, objectSha)
+}
+
+// handleViewTagVerification shows what git verify-tag has to say about the
+// selected tag
+func (gui *Gui) handleViewTagVerification(g *gocui.Gui, v *gocui.View) error {
+	tag := gui.getSelectedTag()
+	if tag == nil {
+		return nil
+	}
+	if !tag.Signed {
+		return gui.createErrorPanel(gui.Tr.TemplateLocalize("TagNotSigned", Teml{"tagName": tag.Name}))
+	}
+
+	verification := gui.GitCommand.VerifyTag(tag.Name)
+	gui.storeTagVerification(tag.ObjectSha, verification)
+	gui.setTagVerifications(gui.State.Tags)
+	if err := gui.renderTagsWithSelection(); err != nil {
+		return err
+	}
+
+	title := map[string]string{
+		"good":       gui.Tr.SLocalize("GoodTagSignatureTitle"),
+		"bad":        gui.Tr.SLocalize("BadTagSignatureTitle"),
+		"unknownKey": gui.Tr.SLocalize("UnknownKeyTagSignatureTitle"),
+	}[verification]
+	return gui.createConfirmationPanel(gui.g, v, true, title, gui.GitCommand.TagVerificationOutput(tag.Name), nil, nil)
+}
@@ -35,6 +35,7 @@ func (gui *Gui) handleTagSelect(g *gocui.Gui, v *gocui.View) error {
 		return gui.newStringTask("main", "No tags")
 	}
 	v.FocusPoint(0, gui.State.Panels.Tags.SelectedLine)
+	gui.verifySelectedTag()
 
 	if gui.inDiffMode() {
 		return gui.renderDiff()
@@ -56,7 +57,10 @@ func (gui *Gui) refreshTags() error {
 		return gui.surfaceError(err)
 	}
 
+	commands.SetTagSignatures(tags, gui.GitCommand.GetTagSignatures())
+	gui.setTagVerifications(tags)
 	gui.State.Tags = tags
+	gui.markRefreshed("branches")
 
 	if gui.getBranchesView().Context == "tags" {
 		return gui.renderTagsWithSelection()
@@ -69,7 +73,7 @@ func (gui *Gui) renderTagsWithSelection() error {
 	branchesView := gui.getBranchesView()
 
 	gui.refreshSelectedLine(&gui.State.Panels.Tags.SelectedLine, len(gui.State.Tags))
-	displayStrings := presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.Diff.Ref)
+	displayStrings := presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.Diff.Ref, gui.formatting())
 	gui.renderDisplayStrings(branchesView, displayStrings)
 	if gui.g.CurrentView() == branchesView && branchesView.Context == "tags" {
 		if err := gui.handleTagSelect(gui.g, branchesView); err != nil {
@@ -125,14 +129,33 @@ func (gui *Gui) handlePushTag(g *gocui.Gui, v *gocui.View) error {
 		},
 	)
 
-	return gui.createPromptPanel(gui.g, v, title, "origin", func(g *gocui.Gui, v *gocui.View) error {
-		if err := gui.GitCommand.PushTag(v.Buffer(), tag.Name); err != nil {
-			return gui.surfaceError(err)
-		}
-		return nil
+	return gui.createPromptPanel(gui.g, v, title, "origin", func(g *gocui.Gui, promptView *gocui.View) error {
+		return gui.pushTag(v, gui.trimmedContent(promptView), tag.Name)
 	})
 }
 
+// pushTag pushes the tag, asking for credentials if the remote wants them,
+// and then shows what was transferred
+func (gui *Gui) pushTag(v *gocui.View, remoteName string, tagName string) error {
+	if err := gui.createLoaderPanel(gui.g, v, gui.Tr.SLocalize("PushingTagStatus")); err != nil {
+		return err
+	}
+	go func() {
+		unamePassOpened := false
+		stats, err := gui.GitCommand.PushTag(remoteName, tagName, func(passOrUname string) string {
+			unamePassOpened = true
+			return gui.waitForPassUname(gui.g, v, passOrUname)
+		})
+		gui.HandleCredentialsPopup(gui.g, unamePassOpened, err)
+		if err == nil {
+			gui.g.Update(func(*gocui.Gui) error {
+				return gui.showTransferSummary(v, stats)
+			})
+		}
+	}()
+	return nil
+}
+
 func (gui *Gui) handleCreateTag(g *gocui.Gui, v *gocui.View) error {
 	return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("CreateTagTitle"), "", func(g *gocui.Gui, v *gocui.View) error {
 		// leaving commit SHA blank so that we're just creating the tag for the current commit
@@ -4,10 +4,15 @@ import (
 	"os/exec"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/tasks"
 )
 
 func (gui *Gui) newCmdTask(viewName string, cmd *exec.Cmd) error {
+	if gui.mainViewPinned(viewName) {
+		return nil
+	}
+
 	view, err := gui.g.View(viewName)
 	if err != nil {
 		return nil // swallowing for now
@@ -16,6 +21,11 @@ func (gui *Gui) newCmdTask(viewName string, cmd *exec.Cmd) error {
 	_, height := view.Size()
 	_, oy := view.Origin()
 
+	if viewName == "main" {
+		gui.State.MainContentCmdArgs = cmd.Args
+		gui.State.ScrollMainToBottom = false
+	}
+
 	manager := gui.getManager(view)
 
 	r, err := cmd.StdoutPipe()
@@ -24,7 +34,9 @@ func (gui *Gui) newCmdTask(viewName string, cmd *exec.Cmd) error {
 	}
 	cmd.Stderr = cmd.Stdout
 
-	if err := cmd.Start(); err != nil {
+	// so that switching to something else kills the pager the command pipes
+	// its output through as well
+	if err := commands.StartInProcessGroup(cmd); err != nil {
 		return err
 	}
 
@@ -36,6 +48,10 @@ func (gui *Gui) newCmdTask(viewName string, cmd *exec.Cmd) error {
 }
 
 func (gui *Gui) newTask(viewName string, f func(chan struct{}) error) error {
+	if gui.mainViewPinned(viewName) {
+		return nil
+	}
+
 	view, err := gui.g.View(viewName)
 	if err != nil {
 		return nil // swallowing for now
@@ -51,15 +67,30 @@ func (gui *Gui) newTask(viewName string, f func(chan struct{}) error) error {
 }
 
 func (gui *Gui) newStringTask(viewName string, str string) error {
+	return gui.newLazyStringTask(viewName, func() string { return str })
+}
+
+// newLazyStringTask is newStringTask for a string that takes a while to work
+// out, which it does in the task rather than holding up the UI
+func (gui *Gui) newLazyStringTask(viewName string, getString func() string) error {
+	if gui.mainViewPinned(viewName) {
+		return nil
+	}
+
 	view, err := gui.g.View(viewName)
 	if err != nil {
 		return nil // swallowing for now
 	}
 
+	if viewName == "main" {
+		gui.State.MainContentCmdArgs = nil
+		gui.State.ScrollMainToBottom = false
+	}
+
 	manager := gui.getManager(view)
 
 	f := func(stop chan struct{}) error {
-		gui.renderString(gui.g, viewName, str)
+		gui.renderString(gui.g, viewName, getString())
 		return nil
 	}
 
@@ -71,6 +102,10 @@ func (gui *Gui) newStringTask(viewName string, str string) error {
 }
 
 func (gui *Gui) newStringTaskWithoutScroll(viewName string, str string) error {
+	if gui.mainViewPinned(viewName) {
+		return nil
+	}
+
 	view, err := gui.g.View(viewName)
 	if err != nil {
 		return nil // swallowing for now
@@ -101,6 +136,9 @@ func (gui *Gui) getManager(view *gocui.View) *tasks.ViewBufferManager {
 			},
 			func() {
 				gui.g.Update(func(*gocui.Gui) error {
+					if view.Name() == "main" && gui.State.ScrollMainToBottom {
+						return gui.scrollMainToBottom()
+					}
 					return nil
 				})
 			})
@@ -0,0 +1,138 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// workspaceTask is one of the user's configured build/test/lint commands
+type workspaceTask struct {
+	Name    string `mapstructure:"name"`
+	Command string `mapstructure:"command"`
+	// Subprocess runs the task in the terminal rather than in a popup, for
+	// tasks that are interactive or whose output is best read in full
+	Subprocess bool `mapstructure:"subprocess"`
+	// Env is extra environment variables for the task, like 'NAME=value'
+	Env []string `mapstructure:"env"`
+}
+
+func (gui *Gui) getWorkspaceTasks() ([]workspaceTask, error) {
+	workspaceTasks := []workspaceTask{}
+	err := gui.Config.GetUserConfig().UnmarshalKey("tasks", &workspaceTasks)
+	return workspaceTasks, err
+}
+
+// recordTaskResult remembers how a task last exited. These live on the gui
+// rather than its state so that they survive running a task as a subprocess
+func (gui *Gui) recordTaskResult(name string, err error) {
+	exitCode := 0
+	if err != nil {
+		exitCode = commands.ExitCode(err)
+	}
+
+	gui.taskExitCodesMutex.Lock()
+	defer gui.taskExitCodesMutex.Unlock()
+	if gui.taskExitCodes == nil {
+		gui.taskExitCodes = map[string]int{}
+	}
+	gui.taskExitCodes[name] = exitCode
+}
+
+// taskResultDisplayString is e.g. a green 'build ✓' or a red 'test ✗1', or
+// blank if the task hasn't been run this session
+func (gui *Gui) taskResultDisplayString(name string) string {
+	gui.taskExitCodesMutex.Lock()
+	defer gui.taskExitCodesMutex.Unlock()
+
+	icons := gui.icons()
+	exitCode, ok := gui.taskExitCodes[name]
+	switch {
+	case !ok:
+		return ""
+	case exitCode == 0:
+		return utils.ColoredString(name+" "+icons.Good, color.FgGreen)
+	case exitCode == -1:
+		// we couldn't even start it
+		return utils.ColoredString(name+" "+icons.Bad, color.FgRed)
+	default:
+		return utils.ColoredString(fmt.Sprintf("%s %s%d", name, icons.Bad, exitCode), color.FgRed)
+	}
+}
+
+// taskResultsStatus summarises how each task last exited, for the status panel
+func (gui *Gui) taskResultsStatus() string {
+	workspaceTasks, err := gui.getWorkspaceTasks()
+	if err != nil {
+		return ""
+	}
+
+	results := []string{}
+	for _, task := range workspaceTasks {
+		if result := gui.taskResultDisplayString(task.Name); result != "" {
+			results = append(results, result)
+		}
+	}
+	return strings.Join(results, " ")
+}
+
+func (gui *Gui) runWorkspaceTask(v *gocui.View, task workspaceTask) error {
+	if task.Subprocess {
+		subProcess := gui.OSCommand.RunCustomCommand(task.Command)
+		gui.OSCommand.AddEnv(subProcess, task.Env)
+		if err := gui.OSCommand.WithSecrets(subProcess, task.Command); err != nil {
+			return gui.surfaceError(err)
+		}
+		gui.SubProcess = subProcess
+		gui.onSubProcessExit = func(err error) {
+			gui.recordTaskResult(task.Name, err)
+		}
+		return gui.Errors.ErrSubProcess
+	}
+
+	cmd := gui.OSCommand.ShellExecutable(task.Command)
+	gui.OSCommand.AddEnv(cmd, task.Env)
+	if err := gui.OSCommand.WithSecrets(cmd, task.Command); err != nil {
+		return gui.surfaceError(err)
+	}
+	return gui.streamExecutableOutput(v, task.Command, cmd, nil, func(output string, err error) error {
+		gui.recordTaskResult(task.Name, err)
+		// tasks like formatters may well have changed files
+		if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}}); err != nil {
+			return err
+		}
+
+		title := gui.Tr.TemplateLocalize("TaskSucceeded", Teml{"name": task.Name})
+		if err != nil {
+			title = gui.Tr.TemplateLocalize("TaskFailed", Teml{"name": task.Name, "exitCode": commands.ExitCode(err)})
+		}
+		return gui.createConfirmationPanel(gui.g, v, true, title, output, nil, nil)
+	})
+}
+
+func (gui *Gui) handleCreateTasksMenu(g *gocui.Gui, v *gocui.View) error {
+	workspaceTasks, err := gui.getWorkspaceTasks()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(workspaceTasks) == 0 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoTasksConfigured"))
+	}
+
+	menuItems := make([]*menuItem, 0, len(workspaceTasks))
+	for _, task := range workspaceTasks {
+		task := task
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{task.Name, utils.ColoredString(task.Command, color.FgBlue), gui.taskResultDisplayString(task.Name)},
+			onPress: func() error {
+				return gui.runWorkspaceTask(v, task)
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("TasksTitle"), menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,200 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// a listLocation identifies an item in one of the side panels' lists. We go by
+// the item's key (e.g. a commit sha or filename) so that we still find it when
+// the list has changed underneath us, falling back on its old position
+type listLocation struct {
+	viewName string
+	context  string
+	key      string
+	lineIdx  int
+}
+
+const maxTimelineLength = 100
+
+func (gui *Gui) getListViewFor(viewName string, context string) *listView {
+	for _, listView := range gui.getListViews() {
+		if listView.viewName == viewName && (listView.context == "" || listView.context == context) {
+			return listView
+		}
+	}
+	return nil
+}
+
+// listItemKey returns something that identifies the item at the given index of
+// a list, independent of its position
+func (gui *Gui) listItemKey(viewName string, context string, idx int) string {
+	switch viewName {
+	case "files":
+		if idx < len(gui.State.Files) {
+			return gui.State.Files[idx].Name
+		}
+	case "branches":
+		switch context {
+		case "local-branches":
+			if idx < len(gui.State.Branches) {
+				return gui.State.Branches[idx].Name
+			}
+		case "remotes":
+			if idx < len(gui.State.Remotes) {
+				return gui.State.Remotes[idx].Name
+			}
+		case "remote-branches":
+			if idx < len(gui.State.RemoteBranches) {
+				return gui.State.RemoteBranches[idx].FullName()
+			}
+		case "tags":
+			if idx < len(gui.State.Tags) {
+				return gui.State.Tags[idx].Name
+			}
+		}
+	case "commits":
+		switch context {
+		case "branch-commits":
+			if idx < len(gui.State.Commits) {
+				return gui.State.Commits[idx].Sha
+			}
+		case "reflog-commits":
+			if idx < len(gui.State.FilteredReflogCommits) {
+				return gui.State.FilteredReflogCommits[idx].Sha
+			}
+		}
+	case "stash":
+		if idx < len(gui.State.StashEntries) {
+			return gui.State.StashEntries[idx].Name
+		}
+	case "commitFiles":
+		if idx < len(gui.State.CommitFiles) {
+			file := gui.State.CommitFiles[idx]
+			return file.Sha + ":" + file.Name
+		}
+	}
+	return ""
+}
+
+// currentListLocation returns where we are if the focused view is one of the
+// side panels' lists
+func (gui *Gui) currentListLocation() (listLocation, bool) {
+	v := gui.g.CurrentView()
+	if v == nil || gui.isPopupPanel(v.Name()) {
+		return listLocation{}, false
+	}
+	listView := gui.getListViewFor(v.Name(), v.Context)
+	if listView == nil {
+		return listLocation{}, false
+	}
+	lineIdx := *listView.getSelectedLineIdxPtr()
+	if lineIdx < 0 {
+		return listLocation{}, false
+	}
+
+	return listLocation{
+		viewName: v.Name(),
+		context:  v.Context,
+		key:      gui.listItemKey(v.Name(), v.Context, lineIdx),
+		lineIdx:  lineIdx,
+	}, true
+}
+
+// goToListLocation focuses the list and selects the item at the given location
+func (gui *Gui) goToListLocation(location listLocation) error {
+	view, err := gui.g.View(location.viewName)
+	if err != nil {
+		return nil
+	}
+
+	if location.context != "" && view.Context != location.context {
+		switch location.viewName {
+		case "branches":
+			if err := gui.switchBranchesPanelContext(location.context); err != nil {
+				return err
+			}
+		case "commits":
+			if err := gui.switchCommitsPanelContext(location.context); err != nil {
+				return err
+			}
+		}
+	}
+
+	listView := gui.getListViewFor(location.viewName, location.context)
+	if listView == nil {
+		return nil
+	}
+
+	count := listView.getItemsLength()
+	if count == 0 {
+		return nil
+	}
+	lineIdx := location.lineIdx
+	for i := 0; i < count; i++ {
+		if location.key != "" && gui.listItemKey(location.viewName, location.context, i) == location.key {
+			lineIdx = i
+			break
+		}
+	}
+	if lineIdx >= count {
+		lineIdx = count - 1
+	}
+	*listView.getSelectedLineIdxPtr() = lineIdx
+
+	if err := gui.switchFocus(gui.g, gui.g.CurrentView(), view); err != nil {
+		return err
+	}
+	return listView.handleItemSelect(gui.g, view)
+}
+
+// recordTimeline is called on every render. Moving around within a list just
+// updates where we are in that list, whereas moving to a different list (or tab)
+// adds a new step to the timeline, much like a browser's history
+func (gui *Gui) recordTimeline() {
+	location, ok := gui.currentListLocation()
+	if !ok {
+		return
+	}
+
+	timeline := gui.State.Timeline
+	if len(timeline) > 0 {
+		current := timeline[gui.State.TimelineIndex]
+		if current.viewName == location.viewName && current.context == location.context {
+			timeline[gui.State.TimelineIndex] = location
+			return
+		}
+	}
+
+	// like in a browser, going somewhere new drops anything we'd gone back from
+	if len(timeline) > 0 {
+		timeline = timeline[:gui.State.TimelineIndex+1]
+	}
+	timeline = append(timeline, location)
+	if len(timeline) > maxTimelineLength {
+		timeline = timeline[len(timeline)-maxTimelineLength:]
+	}
+	gui.State.Timeline = timeline
+	gui.State.TimelineIndex = len(timeline) - 1
+}
+
+func (gui *Gui) moveInTimeline(change int) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	newIndex := gui.State.TimelineIndex + change
+	if newIndex < 0 || newIndex >= len(gui.State.Timeline) {
+		return nil
+	}
+	gui.State.TimelineIndex = newIndex
+
+	return gui.goToListLocation(gui.State.Timeline[newIndex])
+}
+
+func (gui *Gui) handleTimelineBack(g *gocui.Gui, v *gocui.View) error {
+	return gui.moveInTimeline(-1)
+}
+
+func (gui *Gui) handleTimelineForward(g *gocui.Gui, v *gocui.View) error {
+	return gui.moveInTimeline(1)
+}
@@ -0,0 +1,113 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// toggle is an on/off option that can be flipped from the toggles menu for the
+// rest of the session, and saved to the config file if the user likes it
+type toggle struct {
+	// the i18n ID of what the toggle does
+	description string
+	// the config key the toggle starts out from and is saved to
+	key string
+	get func() bool
+	set func(bool)
+}
+
+func (gui *Gui) toggles() []toggle {
+	userConfig := gui.Config.GetUserConfig()
+	diffOptions := &gui.GitCommand.DiffOptions
+
+	return []toggle{
+		{
+			description: "toggleIgnoreWhitespace",
+			key:         "git.diff.ignoreWhitespace",
+			get:         func() bool { return diffOptions.IgnoreWhitespace },
+			set:         func(on bool) { diffOptions.IgnoreWhitespace = on },
+		},
+		{
+			description: "toggleSplitFileDiffs",
+			key:         "gui.splitFileDiffs",
+			get:         func() bool { return gui.State.SplitFileDiffs },
+			set:         func(on bool) { gui.State.SplitFileDiffs = on },
+		},
+		{
+			description: "toggleCommitMessagePreviewSetting",
+			key:         "gui.commitMessagePreview",
+			get:         func() bool { return gui.State.ShowCommitMessagePreview },
+			set:         func(on bool) { gui.State.ShowCommitMessagePreview = on },
+		},
+		{
+			description: "toggleCommitFileTreeSetting",
+			key:         "gui.commitFileTree",
+			get:         func() bool { return gui.State.ShowCommitFileTree },
+			set:         func(on bool) { gui.State.ShowCommitFileTree = on },
+		},
+		{
+			description: "toggleRelativeDates",
+			key:         "gui.relativeDates",
+			get:         func() bool { return userConfig.GetBool("gui.relativeDates") },
+			set:         func(on bool) { userConfig.Set("gui.relativeDates", on) },
+		},
+		{
+			description: "toggleRelativePaths",
+			key:         "gui.relativePaths",
+			get:         func() bool { return userConfig.GetBool("gui.relativePaths") },
+			set:         func(on bool) { userConfig.Set("gui.relativePaths", on) },
+		},
+		{
+			description: "toggleMouse",
+			key:         "gui.mouseEvents",
+			get:         func() bool { return gui.g.Mouse },
+			set:         func(on bool) { gui.g.SetMouse(on) },
+		},
+	}
+}
+
+// handleCreateTogglesMenu lists the toggles with whether each is on. Flipping
+// one brings the menu back, so that several can be flipped in a row
+func (gui *Gui) handleCreateTogglesMenu(g *gocui.Gui, v *gocui.View) error {
+	if gui.popupPanelFocused() {
+		return nil
+	}
+
+	toggles := gui.toggles()
+	menuItems := make([]*menuItem, 0, len(toggles)+1)
+	for i := range toggles {
+		toggle := toggles[i]
+		onOff := gui.Tr.SLocalize("off")
+		if toggle.get() {
+			onOff = gui.Tr.SLocalize("on")
+		}
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{onOff, gui.Tr.SLocalize(toggle.description)},
+			onPress: func() error {
+				toggle.set(!toggle.get())
+				if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC}); err != nil {
+					return err
+				}
+				return gui.handleCreateTogglesMenu(g, v)
+			},
+		})
+	}
+
+	menuItems = append(menuItems, &menuItem{
+		displayStrings: []string{"", gui.Tr.SLocalize("saveToggles")},
+		onPress: func() error {
+			for _, toggle := range toggles {
+				on := toggle.get()
+				if on == gui.savedToggles[toggle.key] {
+					continue
+				}
+				if err := gui.Config.WriteToUserConfig(toggle.key, on); err != nil {
+					return gui.surfaceError(err)
+				}
+				gui.savedToggles[toggle.key] = on
+			}
+			return nil
+		},
+	})
+
+	return gui.createMenu(gui.Tr.SLocalize("TogglesMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
@@ -0,0 +1,31 @@
+package gui
+
+import (
+	"time"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// showTransferSummary pops up what a fetch, pull or push transferred, unless
+// nothing was or gui.transferSummary is off
+func (gui *Gui) showTransferSummary(v *gocui.View, stats *commands.TransferStats) error {
+	if stats == nil || !gui.Config.GetUserConfig().GetBool("gui.transferSummary") {
+		return nil
+	}
+
+	title := gui.Tr.TemplateLocalize("TransferSummaryTitle", Teml{"operation": stats.Operation, "remote": stats.Remote})
+	return gui.createConfirmationPanel(gui.g, v, true, title, gui.transferSummary(stats), nil, nil)
+}
+
+// transferSummary is the body of the transfer summary popup
+func (gui *Gui) transferSummary(stats *commands.TransferStats) string {
+	return gui.Tr.TemplateLocalize("TransferSummary", Teml{
+		"objects":    stats.Objects,
+		"compressed": stats.CompressedObjects,
+		"deltas":     stats.Deltas,
+		"reused":     stats.Reused,
+		"size":       stats.Size(),
+		"duration":   stats.Duration.Round(time.Millisecond).String(),
+	})
+}
@@ -0,0 +1,58 @@
+package gui
+
+import (
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) checkedOutBranchName() string {
+	branch := gui.getCheckedOutBranch()
+	if branch == nil {
+		return ""
+	}
+	return branch.Name
+}
+
+func (gui *Gui) isProtectedBranch(branchName string) bool {
+	return utils.IncludesString(gui.Config.GetUserConfig().GetStringSlice("gui.typedConfirmation.protectedBranches"), branchName)
+}
+
+// needsTypedConfirmation tells us whether an operation of the given class
+// (e.g. 'hardReset', 'forcePush', 'deleteBranch') on the given branch is
+// dangerous enough that a single keypress shouldn't be enough to confirm it
+func (gui *Gui) needsTypedConfirmation(class string, branchName string) bool {
+	return gui.Config.GetUserConfig().GetBool("gui.typedConfirmation."+class) && gui.isProtectedBranch(branchName)
+}
+
+// discardNeedsTypedConfirmation tells us whether discarding changes to this
+// many files at once is dangerous enough to need typed confirmation
+func (gui *Gui) discardNeedsTypedConfirmation(fileCount int) bool {
+	threshold := gui.Config.GetUserConfig().GetInt("gui.typedConfirmation.discardFileThreshold")
+	return threshold > 0 && fileCount >= threshold
+}
+
+// withTypedConfirmation only calls onConfirm once the user has typed out the
+// expected text (typically the branch name), GitHub-style. With nothing to
+// type, e.g. on a detached head, an empty prompt would confirm it, so we
+// refuse instead
+func (gui *Gui) withTypedConfirmation(v *gocui.View, expected string, onConfirm func() error) error {
+	if expected == "" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NothingToTypeToConfirm"))
+	}
+	title := gui.Tr.TemplateLocalize("TypeToConfirm", Teml{"expected": expected})
+	return gui.createPromptPanel(gui.g, v, title, "", func(g *gocui.Gui, promptView *gocui.View) error {
+		if gui.trimmedContent(promptView) != expected {
+			return gui.createErrorPanel(gui.Tr.SLocalize("TypedConfirmationMismatch"))
+		}
+		return onConfirm()
+	})
+}
+
+// withTypedConfirmationIf is withTypedConfirmation for when the operation is only
+// sometimes dangerous enough to need it
+func (gui *Gui) withTypedConfirmationIf(required bool, v *gocui.View, expected string, onConfirm func() error) error {
+	if !required {
+		return onConfirm()
+	}
+	return gui.withTypedConfirmation(v, expected, onConfirm)
+}
@@ -2,14 +2,44 @@ package gui
 
 import "github.com/jesseduffield/gocui"
 
+// showUpdatePrompt shows what's changed since the current version and, unless
+// the user only wants to be told about updates, offers to download the new one
 func (gui *Gui) showUpdatePrompt(newVersion string) error {
-	title := "New version available!"
-	message := "Download latest version? (enter/esc)"
+	title := gui.Tr.TemplateLocalize("NewVersionAvailable", Teml{"version": newVersion})
+	message := gui.Tr.SLocalize("DownloadLatestVersion")
+	var onConfirm func(*gocui.Gui, *gocui.View) error
+	packageManager := gui.Updater.PackageManager()
+	if gui.Updater.CheckOnly() {
+		message = gui.Tr.SLocalize("UpdateCheckOnly")
+	} else if packageManager != nil && packageManager.RunManually {
+		message = gui.Tr.TemplateLocalize("UpdateWithPackageManagerInstructions", Teml{"packageManager": packageManager.Name, "command": packageManager.UpdateCommand})
+	} else if packageManager != nil {
+		message = gui.Tr.TemplateLocalize("UpdateWithPackageManager", Teml{"packageManager": packageManager.Name, "command": packageManager.UpdateCommand})
+		onConfirm = func(g *gocui.Gui, v *gocui.View) error {
+			if err := gui.closeConfirmationPrompt(g, true); err != nil {
+				return err
+			}
+			gui.State.AvailableUpdate = ""
+			gui.SubProcess = gui.OSCommand.ShellExecutable(packageManager.UpdateCommand)
+			return gui.Errors.ErrSubProcess
+		}
+	} else {
+		onConfirm = func(g *gocui.Gui, v *gocui.View) error {
+			gui.startUpdating(newVersion)
+			return nil
+		}
+	}
+	if changelog := gui.Updater.Changelog(newVersion); changelog != "" {
+		message += "\n\n" + changelog
+	}
 	currentView := gui.g.CurrentView()
-	return gui.createConfirmationPanel(gui.g, currentView, true, title, message, func(g *gocui.Gui, v *gocui.View) error {
-		gui.startUpdating(newVersion)
-		return nil
-	}, nil)
+	return gui.createConfirmationPanel(gui.g, currentView, true, title, message, onConfirm, nil)
+}
+
+// updateBanner is what we show in the information bar while there's a new
+// version waiting
+func (gui *Gui) updateBanner() string {
+	return gui.Tr.TemplateLocalize("UpdateAvailableBanner", Teml{"version": gui.State.AvailableUpdate})
 }
 
 func (gui *Gui) onUserUpdateCheckFinish(newVersion string, err error) error {
@@ -19,6 +49,7 @@ func (gui *Gui) onUserUpdateCheckFinish(newVersion string, err error) error {
 	if newVersion == "" {
 		return gui.createErrorPanel("New version not found")
 	}
+	gui.State.AvailableUpdate = newVersion
 	return gui.showUpdatePrompt(newVersion)
 }
 
@@ -31,7 +62,10 @@ func (gui *Gui) onBackgroundUpdateCheckFinish(newVersion string, err error) erro
 	if newVersion == "" {
 		return nil
 	}
-	if gui.Config.GetUserConfig().Get("update.method") == "background" {
+	gui.State.AvailableUpdate = newVersion
+	// a package manager update can't run unattended, because it takes over
+	// the terminal and may want the user's password
+	if gui.Config.GetUserConfig().Get("update.method") == "background" && gui.Updater.PackageManager() == nil {
 		gui.startUpdating(newVersion)
 		return nil
 	}
@@ -51,6 +85,7 @@ func (gui *Gui) onUpdateFinish(err error) error {
 	if err != nil {
 		return gui.createErrorPanel("Update failed: " + err.Error())
 	}
+	gui.State.AvailableUpdate = ""
 	return nil
 }
 
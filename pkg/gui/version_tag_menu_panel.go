@@ -0,0 +1,60 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// handleCreateVersionTagMenu offers the next patch, minor and major versions
+// after the latest release tag, to be tagged on HEAD
+func (gui *Gui) handleCreateVersionTagMenu(g *gocui.Gui, v *gocui.View) error {
+	latest, found := commands.LatestSemVer(gui.State.Tags)
+	previous := ""
+	if found {
+		previous = latest.String()
+	}
+
+	menuItems := []*menuItem{}
+	for _, part := range []string{"patch", "minor", "major"} {
+		next := latest.Bump(part)
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{part, utils.ColoredString(next.String(), color.FgGreen)},
+			onPress: func() error {
+				return gui.createVersionTag(next, previous)
+			},
+		})
+	}
+
+	title := gui.Tr.SLocalize("NoVersionTagsYet")
+	if found {
+		title = gui.Tr.TemplateLocalize("BumpVersionTagTitle", Teml{"version": previous})
+	}
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+// createVersionTag tags HEAD with an annotated release tag and offers to push it
+func (gui *Gui) createVersionTag(version commands.SemVer, previous string) error {
+	tagName := version.String()
+	if err := gui.GitCommand.CreateAnnotatedTag(tagName, gui.GitCommand.VersionTagMessage(version, previous)); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if err := gui.refreshSidePanels(refreshOptions{scope: []int{COMMITS, TAGS}, then: func() {
+		for i, tag := range gui.State.Tags {
+			if tag.Name == tagName {
+				gui.State.Panels.Tags.SelectedLine = i
+				gui.renderTagsWithSelection()
+				return
+			}
+		}
+	}}); err != nil {
+		return err
+	}
+
+	return gui.createConfirmationPanel(gui.g, gui.getBranchesView(), true, gui.Tr.SLocalize("PushTagTitleShort"), gui.Tr.TemplateLocalize("PushNewVersionTagPrompt", Teml{"tagName": tagName}),
+		func(g *gocui.Gui, v *gocui.View) error {
+			return gui.pushTag(gui.getBranchesView(), "origin", tagName)
+		}, nil)
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-errors/errors"
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/spkg/bom"
 )
@@ -306,6 +307,10 @@ func (gui *Gui) switchFocus(g *gocui.Gui, oldView, newView *gocui.View) error {
 		gui.State.PreviousView = oldView.Name()
 	}
 
+	if !gui.isPopupPanel(newView.Name()) {
+		gui.cancelCommandOnPanelSwitch(newView.Name())
+	}
+
 	gui.Log.Info("setting highlight to true for view" + newView.Name())
 	message := gui.Tr.TemplateLocalize(
 		"newFocusedViewIs",
@@ -327,6 +332,8 @@ func (gui *Gui) switchFocus(g *gocui.Gui, oldView, newView *gocui.View) error {
 		return err
 	}
 
+	gui.announceFocus(newView)
+
 	return gui.newLineFocused(g, newView)
 }
 
@@ -559,3 +566,43 @@ func (gui *Gui) wrappedHandler(f func() error) func(g *gocui.Gui, v *gocui.View)
 		return f()
 	}
 }
+
+// formatting returns the user's sha, date, ref and hyperlink display preferences
+func (gui *Gui) formatting() presentation.Formatting {
+	userConfig := gui.Config.GetUserConfig()
+	return presentation.Formatting{
+		ShaLength:     userConfig.GetInt("gui.shaLength"),
+		RelativeDates: userConfig.GetBool("gui.relativeDates"),
+		DateFormat:    userConfig.GetString("gui.dateFormat"),
+		MaxRefBadges:  userConfig.GetInt("gui.commitRefs.maxBadges"),
+		MaxRefLength:  userConfig.GetInt("gui.commitRefs.maxLength"),
+		Hyperlinks:    gui.hyperlinks(),
+		Icons:         gui.icons(),
+		PathPrefix:    gui.pathPrefix(),
+		StaleFetchAge: userConfig.GetInt64("gui.staleFetchAge"),
+	}
+}
+
+// pathPrefix is the directory file paths are shown relative to: where lazygit
+// was started, if gui.relativePaths is on, or else the repo's root
+func (gui *Gui) pathPrefix() string {
+	if !gui.Config.GetUserConfig().GetBool("gui.relativePaths") {
+		return ""
+	}
+	return gui.GitCommand.Prefix
+}
+
+// icons returns the icons and status symbols picked with gui.icons
+func (gui *Gui) icons() *presentation.Icons {
+	return presentation.IconsNamed(gui.Config.GetUserConfig().GetString("gui.icons"))
+}
+
+// fileListPreview lists the given files one per line for showing in a
+// confirmation panel, cutting the list short if there are too many to fit
+func (gui *Gui) fileListPreview(files []string) string {
+	maxFilesShown := 15
+	if len(files) <= maxFilesShown {
+		return strings.Join(files, "\n")
+	}
+	return strings.Join(files[:maxFilesShown], "\n") + "\n" + gui.Tr.TemplateLocalize("AndNMore", Teml{"count": len(files) - maxFilesShown})
+}
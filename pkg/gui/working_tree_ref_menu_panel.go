@@ -0,0 +1,66 @@
+package gui
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func (gui *Gui) inWorkingTreeRefMode() bool {
+	return gui.State.WorkingTreeRef != ""
+}
+
+// setWorkingTreeRef makes the files panel and main view show how the working
+// tree differs from the given ref rather than from HEAD
+func (gui *Gui) setWorkingTreeRef(ref string) error {
+	gui.State.WorkingTreeRef = ref
+	gui.State.Panels.Files.SelectedLine = 0
+
+	return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+}
+
+func (gui *Gui) exitWorkingTreeRefMode() error {
+	return gui.setWorkingTreeRef("")
+}
+
+func (gui *Gui) handleCreateWorkingTreeRefMenu(g *gocui.Gui, v *gocui.View) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("enterRefToDiff"),
+			onPress: func() error {
+				return gui.createPromptPanel(gui.g, v, gui.Tr.SLocalize("enteRefName"), "", func(g *gocui.Gui, promptView *gocui.View) error {
+					return gui.setWorkingTreeRef(gui.trimmedContent(promptView))
+				})
+			},
+		},
+	}
+
+	if gui.inWorkingTreeRefMode() {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("diffAgainstHead"),
+			onPress:       gui.exitWorkingTreeRefMode,
+		})
+	}
+
+	for _, branch := range gui.State.Branches {
+		name := branch.Name
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{utils.ColoredString(name, color.FgGreen), gui.Tr.SLocalize("RefKindBranch")},
+			onPress: func() error {
+				return gui.setWorkingTreeRef(name)
+			},
+		})
+	}
+
+	for _, tag := range gui.State.Tags {
+		name := tag.Name
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{utils.ColoredString(name, color.FgYellow), gui.Tr.SLocalize("RefKindTag")},
+			onPress: func() error {
+				return gui.setWorkingTreeRef(name)
+			},
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("DiffWorkingTreeAgainst"), menuItems, createMenuOptions{showCancel: true})
+}
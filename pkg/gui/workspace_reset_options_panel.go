@@ -8,6 +8,14 @@ import (
 func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
 	red := color.New(color.FgRed)
 
+	// discarding changes to lots of files at once is hard to come back from.
+	// This has nothing to do with the branch, which we might not even be on,
+	// so a fixed word is typed to confirm it
+	confirmWord := gui.Tr.SLocalize("DiscardConfirmationWord")
+	confirmDiscard := func(discard func() error) error {
+		return gui.withTypedConfirmationIf(gui.discardNeedsTypedConfirmation(len(gui.State.Files)), v, confirmWord, discard)
+	}
+
 	menuItems := []*menuItem{
 		{
 			displayStrings: []string{
@@ -15,11 +23,13 @@ func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
 				red.Sprint("reset --hard HEAD && git clean -fd"),
 			},
 			onPress: func() error {
-				if err := gui.GitCommand.ResetAndClean(); err != nil {
-					return gui.surfaceError(err)
-				}
+				return confirmDiscard(func() error {
+					if err := gui.GitCommand.ResetAndClean(); err != nil {
+						return gui.surfaceError(err)
+					}
 
-				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+				})
 			},
 		},
 		{
@@ -28,11 +38,13 @@ func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
 				red.Sprint("git checkout -- ."),
 			},
 			onPress: func() error {
-				if err := gui.GitCommand.DiscardAnyUnstagedFileChanges(); err != nil {
-					return gui.surfaceError(err)
-				}
+				return confirmDiscard(func() error {
+					if err := gui.GitCommand.DiscardAnyUnstagedFileChanges(); err != nil {
+						return gui.surfaceError(err)
+					}
 
-				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+				})
 			},
 		},
 		{
@@ -41,11 +53,13 @@ func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
 				red.Sprint("git clean -fd"),
 			},
 			onPress: func() error {
-				if err := gui.GitCommand.RemoveUntrackedFiles(); err != nil {
-					return gui.surfaceError(err)
-				}
+				return confirmDiscard(func() error {
+					if err := gui.GitCommand.RemoveUntrackedFiles(); err != nil {
+						return gui.surfaceError(err)
+					}
 
-				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+				})
 			},
 		},
 		{
@@ -80,11 +94,13 @@ func (gui *Gui) handleCreateResetMenu(g *gocui.Gui, v *gocui.View) error {
 				red.Sprint("git reset --hard HEAD"),
 			},
 			onPress: func() error {
-				if err := gui.GitCommand.ResetHard("HEAD"); err != nil {
-					return gui.surfaceError(err)
-				}
+				return confirmDiscard(func() error {
+					if err := gui.GitCommand.ResetHard("HEAD"); err != nil {
+						return gui.surfaceError(err)
+					}
 
-				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+				})
 			},
 		},
 	}
@@ -72,6 +72,15 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "CredentialsPassword",
 			Other: "Password",
+		}, &i18n.Message{
+			ID:    "CredentialsPassphrase",
+			Other: "Passphrase",
+		}, &i18n.Message{
+			ID:    "CredentialsOTP",
+			Other: "One-time code",
+		}, &i18n.Message{
+			ID:    "CredentialsHostKey",
+			Other: "Unknown host key, continue connecting? (yes/no/fingerprint)",
 		}, &i18n.Message{
 			ID:    "PassUnameWrong",
 			Other: "Password and/or username wrong",
@@ -490,6 +499,21 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "createPullRequest",
 			Other: `create pull request`,
+		}, &i18n.Message{
+			ID:    "pullRequestDescription",
+			Other: "pull request description from commits",
+		}, &i18n.Message{
+			ID:    "copyPullRequestDescription",
+			Other: "copy description to clipboard",
+		}, &i18n.Message{
+			ID:    "createPullRequestWithDescription",
+			Other: "create pull request with this description",
+		}, &i18n.Message{
+			ID:    "CreatingPullRequestStatus",
+			Other: "creating pull request",
+		}, &i18n.Message{
+			ID:    "NoCommitsForPullRequest",
+			Other: "'{{.branch}}' has no commits that aren't already on '{{.base}}'. Set a different comparison base with 'B' if it branched off something else",
 		}, &i18n.Message{
 			ID:    "NoBranchOnRemote",
 			Other: `This branch doesn't exist on remote. You need to push it to remote first.`,
@@ -519,7 +543,7 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 			Other: `delete change (git reset)`,
 		}, &i18n.Message{
 			ID:    "ToggleDragSelect",
-			Other: `toggle drag select`,
+			Other: `toggle drag select (visual mode)`,
 		}, &i18n.Message{
 			ID:    "ToggleSelectHunk",
 			Other: `toggle select hunk`,
@@ -987,6 +1011,60 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "pushTag",
 			Other: "push tag",
+		}, &i18n.Message{
+			ID:    "suggestCommitMessage",
+			Other: "suggest a commit message",
+		}, &i18n.Message{
+			ID:    "GeneratingCommitMessage",
+			Other: "generating commit message",
+		}, &i18n.Message{
+			ID:    "NoCommitMessageCommand",
+			Other: "Set git.commitMessageCommand in your config to a command that reads a diff on stdin and prints a commit message",
+		}, &i18n.Message{
+			ID:    "CommitMessageCommandFailed",
+			Other: "Your commitMessageCommand failed:\n\n{{.error}}",
+		}, &i18n.Message{
+			ID:    "viewTasks",
+			Other: "run a task",
+		}, &i18n.Message{
+			ID:    "TasksTitle",
+			Other: "Tasks",
+		}, &i18n.Message{
+			ID:    "NoTasksConfigured",
+			Other: "You haven't configured any tasks. Add them under 'tasks' in your config, e.g.\n\ntasks:\n  - name: test\n    command: 'go test ./...'",
+		}, &i18n.Message{
+			ID:    "TaskSucceeded",
+			Other: "{{.name}} succeeded",
+		}, &i18n.Message{
+			ID:    "TaskFailed",
+			Other: "{{.name}} failed (exit code {{.exitCode}})",
+		}, &i18n.Message{
+			ID:    "RunningPostCheckoutCommand",
+			Other: "Running post-checkout command '{{.command}}'",
+		}, &i18n.Message{
+			ID:    "PostCheckoutCommandFailed",
+			Other: "Post-checkout command '{{.command}}' failed:\n\n{{.output}}",
+		}, &i18n.Message{
+			ID:    "pushAllTags",
+			Other: "push all tags",
+		}, &i18n.Message{
+			ID:    "PushAllTagsTitle",
+			Other: "remote to push all tags to:",
+		}, &i18n.Message{
+			ID:    "TransferSummaryTitle",
+			Other: "{{.operation}} {{.remote}}",
+		}, &i18n.Message{
+			ID:    "RemoteTransfers",
+			Other: "Transfers",
+		}, &i18n.Message{
+			ID:    "TransferSummary",
+			Other: "Objects:     {{.objects}} ({{.compressed}} compressed, {{.deltas}} as deltas, {{.reused}} reused)\nTransferred: {{.size}}\nTook:        {{.duration}}",
+		}, &i18n.Message{
+			ID:    "PushResultsTitle",
+			Other: "Pushed {{.pushed}}, rejected {{.rejected}}, up to date {{.upToDate}}",
+		}, &i18n.Message{
+			ID:    "NothingPushed",
+			Other: "There was nothing to push",
 		}, &i18n.Message{
 			ID:    "createTag",
 			Other: "create tag",
@@ -996,6 +1074,9 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "fetchRemote",
 			Other: "fetch remote",
+		}, &i18n.Message{
+			ID:    "fetchRemoteBranch",
+			Other: "fetch just this branch",
 		}, &i18n.Message{
 			ID:    "FetchingRemoteStatus",
 			Other: "fetching remote",
@@ -1119,6 +1200,12 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "MustExitFilterModePrompt",
 			Other: "Command not available in filtered mode. Exit filtered mode?",
+		}, &i18n.Message{
+			ID:    "MustExitFirstParentModePrompt",
+			Other: "Command not available while only showing first parents. Show the full history?",
+		}, &i18n.Message{
+			ID:    "MustClearCommitFilterPrompt",
+			Other: "Command not available while commits are filtered by date or author. Clear the filter?",
 		}, &i18n.Message{
 			ID:    "diff",
 			Other: "diff",
@@ -1152,6 +1239,951 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "commitPrefixPatternError",
 			Other: "Error in commitPrefix pattern",
+		}, &i18n.Message{
+			ID:    "viewCredentialOptions",
+			Other: "view credential helper options",
+		}, &i18n.Message{
+			ID:    "CredentialHelperTitle",
+			Other: "Credential helper: {{.helper}}",
+		}, &i18n.Message{
+			ID:    "NoCredentialHelper",
+			Other: "No credential helper is configured (see `git config credential.helper`)",
+		}, &i18n.Message{
+			ID:    "NoRemoteURL",
+			Other: "Could not determine the url of remote 'origin'",
+		}, &i18n.Message{
+			ID:    "ClearCachedCredentials",
+			Other: "clear cached credentials",
+		}, &i18n.Message{
+			ID:    "ClearingCredentialsStatus",
+			Other: "clearing credentials",
+		}, &i18n.Message{
+			ID:    "ClearStaleCredentialsPrompt",
+			Other: "Your credential helper ({{.helper}}) may be handing git a stale token. Clear its cached credentials for this remote?",
+		}, &i18n.Message{
+			ID:    "SigningKeyMissing",
+			Other: "signing key not found",
+		}, &i18n.Message{
+			ID:    "SigningKeyRevoked",
+			Other: "signing key revoked",
+		}, &i18n.Message{
+			ID:    "SigningKeyExpired",
+			Other: "signing key expired",
+		}, &i18n.Message{
+			ID:    "SigningKeyExpiresSoon",
+			Other: "signing key expires {{.date}}",
+		}, &i18n.Message{
+			ID:    "toggleGitTrace",
+			Other: "toggle git trace mode (offer to rerun failed git commands with GIT_TRACE)",
+		}, &i18n.Message{
+			ID:    "RerunWithGitTracePrompt",
+			Other: "Rerun `{{.command}}` with GIT_TRACE and GIT_CURL_VERBOSE enabled?",
+		}, &i18n.Message{
+			ID:    "TracingStatus",
+			Other: "tracing",
+		}, &i18n.Message{
+			ID:    "GitTraceTitle",
+			Other: "Trace (saved to {{.path}})",
+		}, &i18n.Message{
+			ID:    "halfPageUpMainPanel",
+			Other: "scroll main panel up half a page",
+		}, &i18n.Message{
+			ID:    "halfPageDownMainPanel",
+			Other: "scroll main panel down half a page",
+		}, &i18n.Message{
+			ID:    "scrollToTopMainPanel",
+			Other: "scroll main panel to top",
+		}, &i18n.Message{
+			ID:    "scrollToBottomMainPanel",
+			Other: "scroll main panel to bottom",
+		}, &i18n.Message{
+			ID:    "openMainInPager",
+			Other: "open main panel content in pager",
+		}, &i18n.Message{
+			ID:    "copySelectedLines",
+			Other: "copy selected lines to clipboard",
+		}, &i18n.Message{
+			ID:    "copyLinesWithPrefixes",
+			Other: "copy with +/- prefixes",
+		}, &i18n.Message{
+			ID:    "copyLinesWithoutPrefixes",
+			Other: "copy without +/- prefixes",
+		}, &i18n.Message{
+			ID:    "toggleCommitMessagePreview",
+			Other: "show/hide commit message preview",
+		}, &i18n.Message{
+			ID:    "CommitMessageTitle",
+			Other: "Message",
+		}, &i18n.Message{
+			ID:    "InsertTrailer",
+			Other: "insert trailer",
+		}, &i18n.Message{
+			ID:    "setComparisonBase",
+			Other: "compare commits against this branch (toggle)",
+		}, &i18n.Message{
+			ID:    "comparingAgainst",
+			Other: "comparing commits against",
+		}, &i18n.Message{
+			ID:    "compareBranches",
+			Other: "compare with checked out branch",
+		}, &i18n.Message{
+			ID:    "CompareBranchesTitle",
+			Other: "{{.checkedOutBranch}} (<) vs {{.selectedBranch}} (>)",
+		}, &i18n.Message{
+			ID:    "CompareTitle",
+			Other: "Compare",
+		}, &i18n.Message{
+			ID:    "CantCompareBranchWithItself",
+			Other: "You cannot compare a branch with itself",
+		}, &i18n.Message{
+			ID:    "NoCommitsToCompare",
+			Other: "Both branches contain the same commits",
+		}, &i18n.Message{
+			ID:    "SureCherryPickCommit",
+			Other: "Are you sure you want to cherry-pick this commit onto the checked out branch?",
+		}, &i18n.Message{
+			ID:    "SelectRemoteToPushTo",
+			Other: "Push to remote",
+		}, &i18n.Message{
+			ID:    "EnterRemoteBranchName",
+			Other: "Push to branch on {{.remote}} (will be set as upstream)",
+		}, &i18n.Message{
+			ID:    "AmendKeepingEverything",
+			Other: "amend with staged changes",
+		}, &i18n.Message{
+			ID:    "AmendResetAuthor",
+			Other: "amend and make me the author",
+		}, &i18n.Message{
+			ID:    "AmendResetDate",
+			Other: "amend and set the date to now",
+		}, &i18n.Message{
+			ID:    "AmendSetAuthor",
+			Other: "amend and change the author",
+		}, &i18n.Message{
+			ID:    "AmendSetAuthorPrompt",
+			Other: "Author (Name <email>):",
+		}, &i18n.Message{
+			ID:    "AmendEditMessage",
+			Other: "amend and edit the message in your editor",
+		}, &i18n.Message{
+			ID:    "SoftResetDescription",
+			Other: "keep all changes, staged",
+		}, &i18n.Message{
+			ID:    "MixedResetDescription",
+			Other: "keep all changes, unstaged",
+		}, &i18n.Message{
+			ID:    "HardResetDescription",
+			Other: "discard all changes, including uncommitted ones",
+		}, &i18n.Message{
+			ID:    "KeepResetDescription",
+			Other: "discard committed changes, keep uncommitted ones (aborts if they conflict)",
+		}, &i18n.Message{
+			ID:    "HardReset",
+			Other: "Hard reset",
+		}, &i18n.Message{
+			ID:    "HardResetPrompt",
+			Other: "This will overwrite these files:\n\n{{.files}}\n\nYou can undo the reset with '{{.keyBindUndo}}', but uncommitted changes to these files will be lost. Continue?",
+		}, &i18n.Message{
+			ID:    "AndNMore",
+			Other: "...and {{.count}} more",
+		}, &i18n.Message{
+			ID:    "CheckoutPreviewTitle",
+			Other: "Checkout preview",
+		}, &i18n.Message{
+			ID:    "CheckoutFileAtCommit",
+			Other: "Checkout {{.file}} at {{.sha}}",
+		}, &i18n.Message{
+			ID:    "checkoutFileIntoWorkingTree",
+			Other: "into the working tree only",
+		}, &i18n.Message{
+			ID:    "checkoutFileIntoWorkingTreeAndIndex",
+			Other: "into the working tree and index",
+		}, &i18n.Message{
+			ID:    "toggleViewed",
+			Other: "mark as viewed",
+		}, &i18n.Message{
+			ID:    "ViewedFilesSubtitle",
+			Other: "{{.viewed}}/{{.total}} viewed",
+		}, &i18n.Message{
+			ID:    "toggleTreeView",
+			Other: "toggle file tree view",
+		}, &i18n.Message{
+			ID:    "NotSupportedForDirectories",
+			Other: "This can only be done on a file, not a directory",
+		}, &i18n.Message{
+			ID:    "CannotEnterDirectory",
+			Other: "Select a file to add individual lines to the patch",
+		}, &i18n.Message{
+			ID:    "stageByPatternOptions",
+			Other: "stage/unstage files matching a pattern",
+		}, &i18n.Message{
+			ID:    "StageByPatternTitle",
+			Other: "Files matching a pattern",
+		}, &i18n.Message{
+			ID:    "stageByPattern",
+			Other: "stage matching files",
+		}, &i18n.Message{
+			ID:    "unstageByPattern",
+			Other: "unstage matching files",
+		}, &i18n.Message{
+			ID:    "EnterPattern",
+			Other: "Pattern (e.g. *.go or src/**/*.test.ts):",
+		}, &i18n.Message{
+			ID:    "NoFilesToStageByPattern",
+			Other: "No unstaged changes match '{{.pattern}}'",
+		}, &i18n.Message{
+			ID:    "NoFilesToUnstageByPattern",
+			Other: "No staged changes match '{{.pattern}}'",
+		}, &i18n.Message{
+			ID:    "StageByPatternPrompt",
+			Other: "Stage these files?\n\n{{.files}}",
+		}, &i18n.Message{
+			ID:    "UnstageByPatternPrompt",
+			Other: "Unstage these files?\n\n{{.files}}",
+		}, &i18n.Message{
+			ID:    "AutoStashPullPrompt",
+			Other: "Your local changes would be overwritten by the pull. Stash them, pull, and then reapply them? (enter/esc)",
+		}, &i18n.Message{
+			ID:    "StashPrefixPull",
+			Other: "Auto-stashing changes for pull",
+		}, &i18n.Message{
+			ID:    "AutoStashPullFailed",
+			Other: "The pull failed, so your changes have been left in the stash (stash@{0}) for you to reapply once you've sorted it out:\n\n{{.error}}",
+		}, &i18n.Message{
+			ID:    "AutoStashPopConflict",
+			Other: "Your stashed changes conflicted with the new state of the branch. Resolve the conflicts in the files panel; the stash has been kept in case you need it again.",
+		}, &i18n.Message{
+			ID:    "renameStash",
+			Other: "rename stash",
+		}, &i18n.Message{
+			ID:    "RenameStashPrompt",
+			Other: "Rename stash:",
+		}, &i18n.Message{
+			ID:    "stashBranch",
+			Other: "new branch from stash",
+		}, &i18n.Message{
+			ID:    "NewBranchFromStashPrompt",
+			Other: "New branch name (branch is created from the commit the stash was based on):",
+		}, &i18n.Message{
+			ID:    "viewSnapshots",
+			Other: "view working tree snapshots",
+		}, &i18n.Message{
+			ID:    "SnapshotsTitle",
+			Other: "Working tree snapshots",
+		}, &i18n.Message{
+			ID:    "TakeSnapshotNow",
+			Other: "take a snapshot now",
+		}, &i18n.Message{
+			ID:    "NothingToSnapshot",
+			Other: "Nothing has changed since the last snapshot",
+		}, &i18n.Message{
+			ID:    "SnapshotPreviewTitle",
+			Other: "Snapshot",
+		}, &i18n.Message{
+			ID:    "RestoreSnapshot",
+			Other: "Restore snapshot",
+		}, &i18n.Message{
+			ID:    "RestoreSnapshotPrompt",
+			Other: "This will overwrite files in your working tree with their contents in the snapshot (shown in the main panel). Files created since the snapshot was taken are left alone. Continue?",
+		}, &i18n.Message{
+			ID:    "diffWorkingTreeAgainstRef",
+			Other: "diff working tree against branch/tag/commit",
+		}, &i18n.Message{
+			ID:    "DiffWorkingTreeAgainst",
+			Other: "Diff working tree against",
+		}, &i18n.Message{
+			ID:    "diffAgainstHead",
+			Other: "diff against HEAD (default)",
+		}, &i18n.Message{
+			ID:    "RefKindBranch",
+			Other: "branch",
+		}, &i18n.Message{
+			ID:    "RefKindTag",
+			Other: "tag",
+		}, &i18n.Message{
+			ID:    "DiffAgainstRefTitle",
+			Other: "Changes since {{.ref}}",
+		}, &i18n.Message{
+			ID:    "diffingWorkingTreeAgainst",
+			Other: "Diffing working tree against",
+		}, &i18n.Message{
+			ID:    "toggleWhitespaceInDiff",
+			Other: "toggle ignoring whitespace in diffs",
+		}, &i18n.Message{
+			ID:    "openDiffOptionsMenu",
+			Other: "view diff options (whitespace, blank lines, algorithm)",
+		}, &i18n.Message{
+			ID:    "DiffOptionsTitle",
+			Other: "Diff options",
+		}, &i18n.Message{
+			ID:    "ignoreWhitespace",
+			Other: "ignore whitespace",
+		}, &i18n.Message{
+			ID:    "ignoreBlankLines",
+			Other: "ignore blank lines",
+		}, &i18n.Message{
+			ID:    "ignoreLineEndings",
+			Other: "hide line-ending changes",
+		}, &i18n.Message{
+			ID:    "LineEndingChangesOnly",
+			Other: "(line endings only)",
+		}, &i18n.Message{
+			ID:    "CommitSizeWarningTitle",
+			Other: "This commit looks unusually large",
+		}, &i18n.Message{
+			ID:    "TooManyStagedFiles",
+			Other: "{{.count}} files are staged, which is more than the limit of {{.max}} (git.commitSizeGuard.maxFiles).",
+		}, &i18n.Message{
+			ID:    "LargeStagedFiles",
+			Other: "These staged files are bigger than {{.max}}MB (git.commitSizeGuard.maxFileSizeMB):\n{{.files}}",
+		}, &i18n.Message{
+			ID:    "unstageLargeFiles",
+			Other: "unstage the {{.count}} large file(s)",
+		}, &i18n.Message{
+			ID:    "unstageDirectory",
+			Other: "unstage {{.dir}} ({{.count}} files)",
+		}, &i18n.Message{
+			ID:    "PossibleSecretsTitle",
+			Other: "Possible secrets in staged changes",
+		}, &i18n.Message{
+			ID:    "PossibleSecretsFound",
+			Other: "These added lines look like they contain credentials. Unstage them, or commit anyway if they're safe (e.g. test fixtures).",
+		}, &i18n.Message{
+			ID:    "LfsPromptTitle",
+			Other: "{{.file}} is a large binary. Store it with git-lfs?",
+		}, &i18n.Message{
+			ID:    "lfsTrackExtension",
+			Other: "track all files with this extension",
+		}, &i18n.Message{
+			ID:    "lfsTrackFile",
+			Other: "track just this file",
+		}, &i18n.Message{
+			ID:    "stageWithoutLfs",
+			Other: "stage it without git-lfs",
+		}, &i18n.Message{
+			ID:    "openDirDiff",
+			Other: "open directory diff of {{.left}} vs {{.right}} in external tool",
+		}, &i18n.Message{
+			ID:    "workingTree",
+			Other: "working tree",
+		}, &i18n.Message{
+			ID:    "SwitchToWorktree",
+			Other: "Switch to worktree",
+		}, &i18n.Message{
+			ID:    "BranchCheckedOutInWorktree",
+			Other: "{{.branch}} is already checked out in the worktree at {{.path}}, and git won't check a branch out in two places at once. Switch to that worktree?",
+		}, &i18n.Message{
+			ID:    "viewRepoMaintenanceOptions",
+			Other: "view repo maintenance options",
+		}, &i18n.Message{
+			ID:    "RepoMaintenanceTitle",
+			Other: "Repo maintenance",
+		}, &i18n.Message{
+			ID:    "MaintenanceFinished",
+			Other: "Maintenance finished",
+		}, &i18n.Message{
+			ID:    "maintenance.fsck",
+			Other: "check the repo for corruption",
+		}, &i18n.Message{
+			ID:    "maintenance.gc",
+			Other: "garbage collect, repacking objects and pruning old unreachable ones",
+		}, &i18n.Message{
+			ID:    "maintenance.commitGraph",
+			Other: "write the commit-graph (speeds up log)",
+		}, &i18n.Message{
+			ID:    "runAllMaintenance",
+			Other: "run all of the above",
+		}, &i18n.Message{
+			ID:    "startBackgroundMaintenance",
+			Other: "register repo for git's background maintenance",
+		}, &i18n.Message{
+			ID:    "stopBackgroundMaintenance",
+			Other: "unregister repo from git's background maintenance",
+		}, &i18n.Message{
+			ID:    "PerformanceTitle",
+			Other: "Speed up git in this repo",
+		}, &i18n.Message{
+			ID:    "PerformanceIntro",
+			Other: "This repo tracks {{.count}} files. These git features can make status and log noticeably faster:",
+		}, &i18n.Message{
+			ID:    "performance.untrackedCache",
+			Other: "enable the untracked cache",
+		}, &i18n.Message{
+			ID:    "performance.untrackedCache.explanation",
+			Other: "untracked cache (core.untrackedCache): git remembers which directories have no new files in them, so git status doesn't have to list every directory each time.",
+		}, &i18n.Message{
+			ID:    "performance.fsmonitor",
+			Other: "enable the builtin filesystem monitor",
+		}, &i18n.Message{
+			ID:    "performance.fsmonitor.explanation",
+			Other: "filesystem monitor (core.fsmonitor): a background daemon tells git which files have changed, so git status doesn't have to check every file in the working tree.",
+		}, &i18n.Message{
+			ID:    "performance.commitGraph",
+			Other: "write and maintain the commit-graph",
+		}, &i18n.Message{
+			ID:    "performance.commitGraph.explanation",
+			Other: "commit-graph (fetch.writeCommitGraph): a precomputed index of the commit history that makes git log, especially with --graph, much faster. It's kept up to date on each fetch.",
+		}, &i18n.Message{
+			ID:    "AllPerformanceFeaturesEnabled",
+			Other: "Every feature supported by your git version and platform is already enabled.",
+		}, &i18n.Message{
+			ID:    "FsmonitorDaemonNotRunning",
+			Other: "The builtin filesystem monitor is enabled but its daemon isn't running. Git starts it on the next status, or you can start it now.",
+		}, &i18n.Message{
+			ID:    "startFsmonitorDaemon",
+			Other: "start the filesystem monitor daemon",
+		}, &i18n.Message{
+			ID:    "enableAllPerformanceFeatures",
+			Other: "enable all of the above",
+		}, &i18n.Message{
+			ID:    "dontSuggestPerformanceFeatures",
+			Other: "don't suggest these again for this repo",
+		}, &i18n.Message{
+			ID:    "viewPerformanceFeatures",
+			Other: "view performance settings",
+		}, &i18n.Message{
+			ID:    "refreshPanel",
+			Other: "refresh the focused panel",
+		}, &i18n.Message{
+			ID:    "LastRefreshedSubtitle",
+			Other: "loaded {{.time}}",
+		}, &i18n.Message{
+			ID:    "fetchMissingObjects",
+			Other: "fetch missing objects for this diff (partial clones)",
+		}, &i18n.Message{
+			ID:    "FetchingMissingObjects",
+			Other: "Fetching missing objects",
+		}, &i18n.Message{
+			ID:    "NotAPartialClone",
+			Other: "This repo is a full clone, so it has every object already",
+		}, &i18n.Message{
+			ID:    "annotateCommit",
+			Other: "annotate commit (local note, never pushed)",
+		}, &i18n.Message{
+			ID:    "AnnotateCommitTitle",
+			Other: "Annotation (leave empty to remove):",
+		}, &i18n.Message{
+			ID:    "CommitAnnotationHeading",
+			Other: "Local annotation:",
+		}, &i18n.Message{
+			ID:    "filterCommits",
+			Other: "filter commits by date or author",
+		}, &i18n.Message{
+			ID:    "CommitFilterMenuTitle",
+			Other: "Filter commits",
+		}, &i18n.Message{
+			ID:    "commitFilterToday",
+			Other: "today",
+		}, &i18n.Message{
+			ID:    "commitFilterThisWeek",
+			Other: "this week",
+		}, &i18n.Message{
+			ID:    "commitFilterMine",
+			Other: "my commits",
+		}, &i18n.Message{
+			ID:    "commitFilterSince",
+			Other: "since...",
+		}, &i18n.Message{
+			ID:    "commitFilterUntil",
+			Other: "until...",
+		}, &i18n.Message{
+			ID:    "commitFilterAuthor",
+			Other: "author...",
+		}, &i18n.Message{
+			ID:    "clearCommitFilter",
+			Other: "clear filter",
+		}, &i18n.Message{
+			ID:    "CommitFilterSinceTitle",
+			Other: "Since (e.g. 2020-06-01 or '2 weeks ago'):",
+		}, &i18n.Message{
+			ID:    "CommitFilterUntilTitle",
+			Other: "Until (e.g. 2020-06-30 or yesterday):",
+		}, &i18n.Message{
+			ID:    "CommitFilterAuthorTitle",
+			Other: "Author name or email:",
+		}, &i18n.Message{
+			ID:    "toggleFirstParent",
+			Other: "show only the mainline (--first-parent) / full history",
+		}, &i18n.Message{
+			ID:    "toggleMergeExpansion",
+			Other: "expand/collapse merge commit (first-parent mode)",
+		}, &i18n.Message{
+			ID:    "NotInFirstParentMode",
+			Other: "Merge commits can only be expanded when showing first parents only",
+		}, &i18n.Message{
+			ID:    "NotAMergeCommit",
+			Other: "This is not a merge commit",
+		}, &i18n.Message{
+			ID:    "viewMergeDiffOptions",
+			Other: "view merge commit diff options",
+		}, &i18n.Message{
+			ID:    "MergeDiffMenuTitle",
+			Other: "Merge commit diff",
+		}, &i18n.Message{
+			ID:    "combinedDiff",
+			Other: "combined diff",
+		}, &i18n.Message{
+			ID:    "diffAgainstEachParent",
+			Other: "diff against each parent in turn",
+		}, &i18n.Message{
+			ID:    "diffAgainstParent",
+			Other: "diff against parent {{.number}}",
+		}, &i18n.Message{
+			ID:    "CombinedDiffTitle",
+			Other: "Patch (combined)",
+		}, &i18n.Message{
+			ID:    "DiffAgainstEachParentTitle",
+			Other: "Patch (against each parent)",
+		}, &i18n.Message{
+			ID:    "DiffAgainstParentTitle",
+			Other: "Patch (against parent {{.number}})",
+		}, &i18n.Message{
+			ID:    "markBranchForMerge",
+			Other: "mark/unmark branch for merging several at once (octopus merge)",
+		}, &i18n.Message{
+			ID:    "CantMergeInDetachedHead",
+			Other: "Cannot merge branch in detached head state. You might have checked out a commit directly or a remote branch, in which case you should checkout the local branch you want to be on",
+		}, &i18n.Message{
+			ID:    "CheckingForConflictsStatus",
+			Other: "checking for conflicts",
+		}, &i18n.Message{
+			ID:    "ConfirmOctopusMerge",
+			Other: "Are you sure you want to merge {{.branches}} into {{.checkedOutBranch}} with a single merge commit?",
+		}, &i18n.Message{
+			ID:    "OctopusConflictExpected",
+			Other: "{{.ours}} and {{.theirs}} are expected to conflict, and an octopus merge can't stop for conflicts to be resolved. Merge {{.branches}} into {{.checkedOutBranch}} one at a time instead?",
+		}, &i18n.Message{
+			ID:    "generateChangelog",
+			Other: "generate changelog",
+		}, &i18n.Message{
+			ID:    "ChangelogMenuTitle",
+			Other: "Changelog",
+		}, &i18n.Message{
+			ID:    "changelogUnreleased",
+			Other: "unreleased changes since this tag",
+		}, &i18n.Message{
+			ID:    "changelogForTag",
+			Other: "changes in {{.tag}}",
+		}, &i18n.Message{
+			ID:    "changelogCustomRange",
+			Other: "custom range...",
+		}, &i18n.Message{
+			ID:    "ChangelogRangePrompt",
+			Other: "Range (from..to):",
+		}, &i18n.Message{
+			ID:    "UnreleasedChangelogTitle",
+			Other: "Unreleased",
+		}, &i18n.Message{
+			ID:    "NoCommitsForChangelog",
+			Other: "There are no commits in {{.range}}",
+		}, &i18n.Message{
+			ID:    "ChangelogPreviewTitle",
+			Other: "Changelog",
+		}, &i18n.Message{
+			ID:    "copyChangelog",
+			Other: "copy to clipboard",
+		}, &i18n.Message{
+			ID:    "writeChangelog",
+			Other: "add to {{.file}} and stage it",
+		}, &i18n.Message{
+			ID:    "createVersionTag",
+			Other: "create next version tag",
+		}, &i18n.Message{
+			ID:    "BumpVersionTagTitle",
+			Other: "Next version after {{.version}}",
+		}, &i18n.Message{
+			ID:    "NoVersionTagsYet",
+			Other: "No version tags yet. First version",
+		}, &i18n.Message{
+			ID:    "PushTagTitleShort",
+			Other: "Push tag",
+		}, &i18n.Message{
+			ID:    "PushNewVersionTagPrompt",
+			Other: "Created {{.tagName}}. Push it to origin?",
+		}, &i18n.Message{
+			ID:    "PushingTagStatus",
+			Other: "pushing tag",
+		}, &i18n.Message{
+			ID:    "verifyTag",
+			Other: "verify tag signature",
+		}, &i18n.Message{
+			ID:    "TagNotSigned",
+			Other: "{{.tagName}} isn't signed",
+		}, &i18n.Message{
+			ID:    "GoodTagSignatureTitle",
+			Other: "Good signature",
+		}, &i18n.Message{
+			ID:    "BadTagSignatureTitle",
+			Other: "Signature could not be verified",
+		}, &i18n.Message{
+			ID:    "UnknownKeyTagSignatureTitle",
+			Other: "Signed with a key you don't have",
+		}, &i18n.Message{
+			ID:    "branchFromIssue",
+			Other: "new branch from one of my issues",
+		}, &i18n.Message{
+			ID:    "FetchingIssuesStatus",
+			Other: "fetching issues",
+		}, &i18n.Message{
+			ID:    "NoAssignedIssues",
+			Other: "You have no open issues assigned to you",
+		}, &i18n.Message{
+			ID:    "AssignedIssuesTitle",
+			Other: "My issues",
+		}, &i18n.Message{
+			ID:    "generateStandupReport",
+			Other: "generate standup report",
+		}, &i18n.Message{
+			ID:    "StandupMenuTitle",
+			Other: "Report my work since",
+		}, &i18n.Message{
+			ID:    "standupYesterday",
+			Other: "yesterday",
+		}, &i18n.Message{
+			ID:    "standupLastFriday",
+			Other: "last friday",
+		}, &i18n.Message{
+			ID:    "standupCustom",
+			Other: "custom date",
+		}, &i18n.Message{
+			ID:    "StandupSincePrompt",
+			Other: "Since (e.g. '2 days ago' or '2020-06-01'):",
+		}, &i18n.Message{
+			ID:    "InvalidStandupDate",
+			Other: "Git doesn't understand the date '{{.date}}'",
+		}, &i18n.Message{
+			ID:    "NoUserEmailForStandup",
+			Other: "Set user.email in your git config so your commits can be found",
+		}, &i18n.Message{
+			ID:    "GeneratingStandupStatus",
+			Other: "generating report",
+		}, &i18n.Message{
+			ID:    "StandupReportHeading",
+			Other: "Since {{.since}}:",
+		}, &i18n.Message{
+			ID:    "StandupReportTitle",
+			Other: "Standup report",
+		}, &i18n.Message{
+			ID:    "copyStandupReport",
+			Other: "copy to clipboard",
+		}, &i18n.Message{
+			ID:    "PrevHunkBoundary",
+			Other: "move to start of hunk",
+		}, &i18n.Message{
+			ID:    "NextHunkBoundary",
+			Other: "move to end of hunk",
+		}, &i18n.Message{
+			ID:    "StartCount",
+			Other: "start a count for the next move, e.g. #5j",
+		}, &i18n.Message{
+			ID:    "AnnounceEmptyList",
+			Other: "empty",
+		}, &i18n.Message{
+			ID:    "NeverFetched",
+			Other: "never",
+		}, &i18n.Message{
+			ID:    "NewVersionAvailable",
+			Other: "New version available: {{.version}}",
+		}, &i18n.Message{
+			ID:    "DownloadLatestVersion",
+			Other: "Download latest version? (enter/esc)",
+		}, &i18n.Message{
+			ID:    "UpdateCheckOnly",
+			Other: "Updating from within lazygit is turned off (update.method: checkOnly), so install this version the way you installed lazygit.",
+		}, &i18n.Message{
+			ID:    "UpdateAvailableBanner",
+			Other: "{{.version}} available",
+		}, &i18n.Message{
+			ID:    "NoReleasesFoundErr",
+			Other: "No releases found on the update channel",
+		}, &i18n.Message{
+			ID:    "NoChecksumForUpdateErr",
+			Other: "The release has no checksum for {{.file}}, so the download can't be verified",
+		}, &i18n.Message{
+			ID:    "UpdateChecksumMismatchErr",
+			Other: "The checksum of the downloaded {{.file}} doesn't match the release's, so it won't be installed",
+		}, &i18n.Message{
+			ID:    "BadUpdateSignatureErr",
+			Other: "Could not verify the signature of the release's checksums: {{.error}}",
+		}, &i18n.Message{
+			ID:    "CommandCancelled",
+			Other: "cancelled",
+		}, &i18n.Message{
+			ID:    "IndexLockLeftBehind",
+			Other: "The index is still locked by {{.path}}. If no other git process is running, remove that file before carrying on",
+		}, &i18n.Message{
+			ID:    "UpdateWithPackageManager",
+			Other: "lazygit was installed with {{.packageManager}}, so it should be updated with it too. Run '{{.command}}'? (enter/esc)",
+		}, &i18n.Message{
+			ID:    "UpdateWithPackageManagerInstructions",
+			Other: "lazygit was installed with {{.packageManager}}, so it should be updated with it too. Run this in a terminal:\n\n  {{.command}}",
+		}, &i18n.Message{
+			ID:    "ManagedInstallErr",
+			Other: "lazygit was installed with {{.packageManager}}, so update it with {{.packageManager}} rather than overwriting its files",
+		}, &i18n.Message{
+			ID:    "editSettings",
+			Other: "edit settings",
+		}, &i18n.Message{
+			ID:    "SettingsMenuTitle",
+			Other: "Settings",
+		}, &i18n.Message{
+			ID:    "SettingNeedsRestart",
+			Other: "Saved to your config file. This takes effect the next time you start lazygit",
+		}, &i18n.Message{
+			ID:    "settingThemeMode",
+			Other: "colors: 'high-contrast' and 'no-color' rely on bold and reverse video instead",
+		}, &i18n.Message{
+			ID:    "settingLightTheme",
+			Other: "use dark text, for terminals with a light background",
+		}, &i18n.Message{
+			ID:    "settingKeymap",
+			Other: "keybindings to navigate with",
+		}, &i18n.Message{
+			ID:    "settingPullMode",
+			Other: "how pulling reconciles diverged branches. Empty follows git's pull.rebase",
+		}, &i18n.Message{
+			ID:    "settingAutoFetch",
+			Other: "fetch from remotes every minute",
+		}, &i18n.Message{
+			ID:    "settingAutoRefresh",
+			Other: "reload the files panel every 10 seconds",
+		}, &i18n.Message{
+			ID:    "settingRelativeDates",
+			Other: "show commit dates as e.g. '2 days ago'",
+		}, &i18n.Message{
+			ID:    "settingDateFormat",
+			Other: "format of commit dates, in Go's time layout",
+		}, &i18n.Message{
+			ID:    "settingSidePanelWidth",
+			Other: "share of the screen the side panels take up",
+		}, &i18n.Message{
+			ID:    "settingMouseEvents",
+			Other: "allow clicking and scrolling with the mouse",
+		}, &i18n.Message{
+			ID:    "settingAccessibility",
+			Other: "screen reader support",
+		}, &i18n.Message{
+			ID:    "settingUpdateMethod",
+			Other: "what to do when there's a new version of lazygit",
+		}, &i18n.Message{
+			ID:    "settingConfirmOnQuit",
+			Other: "ask before quitting",
+		}, &i18n.Message{
+			ID:    "openTogglesMenu",
+			Other: "quick toggles (whitespace in diffs, split diffs, mouse, ...)",
+		}, &i18n.Message{
+			ID:    "TogglesMenuTitle",
+			Other: "Toggles",
+		}, &i18n.Message{
+			ID:    "toggleIgnoreWhitespace",
+			Other: "ignore whitespace in diffs",
+		}, &i18n.Message{
+			ID:    "toggleSplitFileDiffs",
+			Other: "show staged and unstaged diffs of a file together",
+		}, &i18n.Message{
+			ID:    "toggleCommitMessagePreviewSetting",
+			Other: "show the commit message next to the commit's diff",
+		}, &i18n.Message{
+			ID:    "toggleCommitFileTreeSetting",
+			Other: "show a commit's files as a tree",
+		}, &i18n.Message{
+			ID:    "toggleRelativeDates",
+			Other: "relative commit dates",
+		}, &i18n.Message{
+			ID:    "toggleRelativePaths",
+			Other: "file paths relative to where lazygit was started",
+		}, &i18n.Message{
+			ID:    "toggleMouse",
+			Other: "mouse support",
+		}, &i18n.Message{
+			ID:    "saveToggles",
+			Other: "save these to the config file",
+		}, &i18n.Message{
+			ID:    "openCommitInBrowser",
+			Other: "open commit in browser",
+		}, &i18n.Message{
+			ID:    "viewPullRequests",
+			Other: "view open pull requests",
+		}, &i18n.Message{
+			ID:    "PullRequestsTitle",
+			Other: "Open pull requests",
+		}, &i18n.Message{
+			ID:    "NoOpenPullRequests",
+			Other: "There are no open pull requests",
+		}, &i18n.Message{
+			ID:    "NoPullRequestAPI",
+			Other: "Listing pull requests is only supported for repos on Gitea and Forgejo",
+		}, &i18n.Message{
+			ID:    "FetchingPullRequestsStatus",
+			Other: "fetching pull requests",
+		}, &i18n.Message{
+			ID:    "RetryingNetworkCommandStatus",
+			Other: "{{.command}} failed on the network, retrying in {{.delay}} ({{.attempt}}/{{.attempts}})",
+		}, &i18n.Message{
+			ID:    "viewCommandHistory",
+			Other: "view the commands lazygit has run",
+		}, &i18n.Message{
+			ID:    "CommandHistoryTitle",
+			Other: "Command history",
+		}, &i18n.Message{
+			ID:    "exportCommandHistory",
+			Other: "export to a file",
+		}, &i18n.Message{
+			ID:    "ExportCommandHistoryPrompt",
+			Other: "Export the command history to:",
+		}, &i18n.Message{
+			ID:    "CommandHistoryExported",
+			Other: "The command history was written to {{.path}}",
+		}, &i18n.Message{
+			ID:    "commitAnyway",
+			Other: "commit anyway",
+		}, &i18n.Message{
+			ID:    "viewLineEndingOptions",
+			Other: "explain line ending changes",
+		}, &i18n.Message{
+			ID:    "LineEndingsTitle",
+			Other: "Line endings",
+		}, &i18n.Message{
+			ID:    "OnlyLineEndingsChanged",
+			Other: "The only changes to {{.file}} are line endings (CRLF vs LF), which is why its diff looks empty.",
+		}, &i18n.Message{
+			ID:    "AutoCRLFExplanation",
+			Other: "core.autocrlf is set to '{{.value}}', so git converts line endings as files move between the working tree and the index. Files that were committed with CRLF will show as modified until they are renormalized.",
+		}, &i18n.Message{
+			ID:    "TextAttributeExplanation",
+			Other: "The file's text attribute is '{{.value}}' in .gitattributes, so git normalizes its line endings to LF when it's staged.",
+		}, &i18n.Message{
+			ID:    "EolAttributeExplanation",
+			Other: "The file's eol attribute is '{{.value}}' in .gitattributes, so git converts its line endings when checking it out.",
+		}, &i18n.Message{
+			ID:    "FilterAttributeExplanation",
+			Other: "The file goes through the '{{.value}}' filter, so the content git stores can differ from what's on disk. If the filter isn't installed the file may always show as modified.",
+		}, &i18n.Message{
+			ID:    "NoLineEndingExplanation",
+			Other: "No line ending settings or attributes apply to this file.",
+		}, &i18n.Message{
+			ID:    "renormalizeFile",
+			Other: "renormalize this file",
+		}, &i18n.Message{
+			ID:    "renormalizeAllFiles",
+			Other: "renormalize all files",
+		}, &i18n.Message{
+			ID:    "normalizeLineEndingsInAttributes",
+			Other: "normalize line endings for the whole repo in .gitattributes",
+		}, &i18n.Message{
+			ID:    "stopConvertingFileInAttributes",
+			Other: "stop converting this file's line endings in .gitattributes",
+		}, &i18n.Message{
+			ID:    "diffAlgorithm",
+			Other: "diff algorithm",
+		}, &i18n.Message{
+			ID:    "defaultDiffAlgorithm",
+			Other: "default",
+		}, &i18n.Message{
+			ID:    "on",
+			Other: "on",
+		}, &i18n.Message{
+			ID:    "off",
+			Other: "off",
+		}, &i18n.Message{
+			ID:    "updateSubmodule",
+			Other: "update submodule (check out the recorded commit)",
+		}, &i18n.Message{
+			ID:    "UpdatingSubmoduleStatus",
+			Other: "updating submodule",
+		}, &i18n.Message{
+			ID:    "stashSubmoduleChanges",
+			Other: "stash changes inside the submodule",
+		}, &i18n.Message{
+			ID:    "openSubmodule",
+			Other: "open submodule in lazygit",
+		}, &i18n.Message{
+			ID:    "SubmoduleMenuTitle",
+			Other: "Submodule {{.path}}",
+		}, &i18n.Message{
+			ID:    "RunningCommitHooks",
+			Other: "Running commit hooks",
+		}, &i18n.Message{
+			ID:    "CommitHookFailedTitle",
+			Other: "Commit failed (exit code {{.exitCode}})",
+		}, &i18n.Message{
+			ID:    "CommitHookFailedPrompt",
+			Other: "Press enter to retry the commit with --no-verify, skipping the hooks, or esc to go back to your commit message.",
+		}, &i18n.Message{
+			ID:    "TypeToConfirm",
+			Other: "Type {{.expected}} to confirm:",
+		}, &i18n.Message{
+			ID:    "TypedConfirmationMismatch",
+			Other: "That didn't match, so nothing was done",
+		}, &i18n.Message{
+			ID:    "NothingToTypeToConfirm",
+			Other: "There's no branch name to type to confirm this, so nothing was done",
+		}, &i18n.Message{
+			ID:    "DiscardConfirmationWord",
+			Other: "discard",
+		}, &i18n.Message{
+			ID:    "timelineBack",
+			Other: "go back to the previously visited panel item",
+		}, &i18n.Message{
+			ID:    "timelineForward",
+			Other: "go forward to the next visited panel item",
+		}, &i18n.Message{
+			ID:    "setMark",
+			Other: "mark the selected item",
+		}, &i18n.Message{
+			ID:    "jumpToMark",
+			Other: "jump to a marked item",
+		}, &i18n.Message{
+			ID:    "SetMarkPrompt",
+			Other: "Mark name (one character):",
+		}, &i18n.Message{
+			ID:    "MarkNameMustBeOneCharacter",
+			Other: "Mark names must be a single character",
+		}, &i18n.Message{
+			ID:    "NoMarks",
+			Other: "No marks set. Mark an item first",
+		}, &i18n.Message{
+			ID:    "MarksTitle",
+			Other: "Marks",
+		}, &i18n.Message{
+			ID:    "viewCommitRefs",
+			Other: "view branches and tags pointing at this commit",
+		}, &i18n.Message{
+			ID:    "NoRefsForCommit",
+			Other: "No branches or tags point at this commit",
+		}, &i18n.Message{
+			ID:    "CommitRefsTitle",
+			Other: "Refs (press enter to checkout)",
+		}, &i18n.Message{
+			ID:    "checkoutAsNewLocalBranch",
+			Other: "remote: checkout as new local branch",
+		}, &i18n.Message{
+			ID:    "togglePinMainView",
+			Other: "pin/unpin the main view so it keeps its content while navigating",
+		}, &i18n.Message{
+			ID:    "PinnedSubtitle",
+			Other: "pinned",
+		}, &i18n.Message{
+			ID:    "toggleSplitFileDiffs",
+			Other: "always show unstaged and staged diffs together",
+		}, &i18n.Message{
+			ID:    "scrollUpStagedDiff",
+			Other: "scroll up the staged diff",
+		}, &i18n.Message{
+			ID:    "scrollDownStagedDiff",
+			Other: "scroll down the staged diff",
+		}, &i18n.Message{
+			ID:    "NoStagedChanges",
+			Other: "No staged changes",
+		}, &i18n.Message{
+			ID:    "NoUnstagedChanges",
+			Other: "No unstaged changes",
+		}, &i18n.Message{
+			ID:    "openMainInEditor",
+			Other: "open main panel content in editor",
+		}, &i18n.Message{
+			ID:    "TerminalTooSmall",
+			Other: "Your terminal is {{.width}}x{{.height}}, but lazygit needs at least {{.minimumWidth}}x{{.minimumHeight}}. Make the terminal bigger, or lower gui.minSidePanelWidth and gui.minMainPanelWidth in your config",
 		},
 	)
 }
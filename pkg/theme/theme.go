@@ -1,6 +1,8 @@
 package theme
 
 import (
+	"os"
+
 	"github.com/fatih/color"
 	"github.com/jesseduffield/gocui"
 	"github.com/spf13/viper"
@@ -35,6 +37,24 @@ var (
 	OptionsColor gocui.Attribute
 
 	DiffTerminalColor = color.FgMagenta
+
+	// DiffAddedAttributes and DiffDeletedAttributes are what lines added and
+	// deleted by a diff are drawn with in the staging and patch building panels
+	DiffAddedAttributes   = []color.Attribute{color.FgGreen}
+	DiffDeletedAttributes = []color.Attribute{color.FgRed}
+
+	// IncludedLineAttributes mark the lines that have been added to a custom patch
+	IncludedLineAttributes = []color.Attribute{color.BgGreen}
+
+	// NoColor is set when the user wants no colors at all, either through
+	// gui.theme.mode or the NO_COLOR environment variable (see no-color.org).
+	// We then tell things apart with attributes like bold and reverse alone
+	NoColor bool
+
+	// terminalNoColor is whether the color package decided against colors by
+	// itself, e.g. because we're not writing to a terminal, which is what it
+	// goes back to when the user switches away from no-color
+	terminalNoColor = color.NoColor
 )
 
 // UpdateTheme updates all theme variables
@@ -57,6 +77,60 @@ func UpdateTheme(userConfig *viper.Viper) {
 		DefaultHiTextColor = color.FgHiWhite
 		GocuiDefaultTextColor = gocui.ColorWhite
 	}
+
+	DiffAddedAttributes = []color.Attribute{color.FgGreen}
+	DiffDeletedAttributes = []color.Attribute{color.FgRed}
+	IncludedLineAttributes = []color.Attribute{color.BgGreen}
+
+	mode := userConfig.GetString("gui.theme.mode")
+	if os.Getenv("NO_COLOR") != "" {
+		mode = "no-color"
+	}
+	NoColor = mode == "no-color"
+	color.NoColor = NoColor || terminalNoColor
+
+	switch mode {
+	case "high-contrast", "no-color":
+		// these only rely on attributes to tell selections and diff lines
+		// apart, so that they work without colors
+		ActiveBorderColor = gocui.ColorYellow | gocui.AttrBold
+		InactiveBorderColor = GocuiDefaultTextColor
+		SelectedLineBgColor = color.ReverseVideo
+		SelectedRangeBgColor = color.ReverseVideo
+		GocuiSelectedLineBgColor = gocui.AttrReverse
+		DiffAddedAttributes = []color.Attribute{color.FgHiGreen, color.Bold}
+		DiffDeletedAttributes = []color.Attribute{color.FgHiRed, color.Underline}
+		IncludedLineAttributes = []color.Attribute{color.BgGreen, color.Bold, color.Underline}
+	}
+
+	if NoColor {
+		ActiveBorderColor = gocui.AttrBold
+		InactiveBorderColor = gocui.ColorDefault
+		OptionsColor = gocui.ColorDefault
+		GocuiDefaultTextColor = gocui.ColorDefault
+	}
+}
+
+// NewColor is like color.New, except that when NoColor is set it drops the
+// colors but keeps attributes like bold and reverse, so that e.g. the selected
+// lines of a diff remain visible
+func NewColor(attributes ...color.Attribute) *color.Color {
+	if !NoColor {
+		return color.New(attributes...)
+	}
+
+	kept := []color.Attribute{}
+	for _, attribute := range attributes {
+		// everything from 30 onwards is a foreground or background color
+		if attribute < color.FgBlack {
+			kept = append(kept, attribute)
+		}
+	}
+	c := color.New(kept...)
+	if len(kept) > 0 {
+		c.EnableColor()
+	}
+	return c
 }
 
 // GetAttribute gets the gocui color attribute from the string
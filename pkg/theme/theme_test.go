@@ -0,0 +1,74 @@
+package theme
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/gocui"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// unsetNoColorEnv unsets NO_COLOR, which would otherwise override the mode we
+// set, returning the function that puts it back
+func unsetNoColorEnv(t *testing.T) func() {
+	value, ok := os.LookupEnv("NO_COLOR")
+	assert.NoError(t, os.Unsetenv("NO_COLOR"))
+	return func() {
+		if ok {
+			_ = os.Setenv("NO_COLOR", value)
+		}
+	}
+}
+
+// TestUpdateThemeSwitchingModes is a function.
+func TestUpdateThemeSwitchingModes(t *testing.T) {
+	defer unsetNoColorEnv(t)()
+	colorNoColor := color.NoColor
+	defer func() { color.NoColor = colorNoColor }()
+
+	userConfig := viper.New()
+	userConfig.Set("gui.theme.activeBorderColor", []string{"green", "bold"})
+
+	userConfig.Set("gui.theme.mode", "no-color")
+	UpdateTheme(userConfig)
+	assert.True(t, NoColor)
+	assert.True(t, color.NoColor)
+	assert.EqualValues(t, gocui.AttrBold, ActiveBorderColor)
+
+	userConfig.Set("gui.theme.mode", "default")
+	UpdateTheme(userConfig)
+	assert.False(t, NoColor)
+	assert.EqualValues(t, terminalNoColor, color.NoColor)
+	assert.EqualValues(t, gocui.ColorGreen|gocui.AttrBold, ActiveBorderColor)
+
+	userConfig.Set("gui.theme.mode", "high-contrast")
+	UpdateTheme(userConfig)
+	assert.False(t, NoColor)
+	assert.EqualValues(t, terminalNoColor, color.NoColor)
+	assert.EqualValues(t, gocui.ColorYellow|gocui.AttrBold, ActiveBorderColor)
+
+	userConfig.Set("gui.theme.mode", "no-color")
+	UpdateTheme(userConfig)
+	assert.True(t, NoColor)
+	assert.True(t, color.NoColor)
+}
+
+// TestUpdateThemeWithColorsAllowed is a function.
+func TestUpdateThemeWithColorsAllowed(t *testing.T) {
+	defer unsetNoColorEnv(t)()
+	noColor, colorNoColor := terminalNoColor, color.NoColor
+	defer func() { terminalNoColor, color.NoColor = noColor, colorNoColor }()
+	// as though we were writing to a terminal
+	terminalNoColor = false
+
+	userConfig := viper.New()
+	userConfig.Set("gui.theme.mode", "no-color")
+	UpdateTheme(userConfig)
+	assert.True(t, color.NoColor)
+
+	userConfig.Set("gui.theme.mode", "default")
+	UpdateTheme(userConfig)
+	assert.False(t, color.NoColor)
+}
@@ -0,0 +1,61 @@
+package updates
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/kardianos/osext"
+)
+
+// PackageManager is a package manager that lazygit was installed with. It owns
+// the binary, so updating is its job: if we overwrote the binary ourselves the
+// package manager would no longer know which version is installed
+type PackageManager struct {
+	Name          string
+	UpdateCommand string
+	// RunManually is true when we can't run the update command for the user,
+	// e.g. because it needs sudo, so we tell them what to run instead
+	RunManually bool
+}
+
+var (
+	homebrew = &PackageManager{Name: "Homebrew", UpdateCommand: "brew upgrade lazygit"}
+	// windows won't let scoop replace the binary while we're running it
+	scoop = &PackageManager{Name: "Scoop", UpdateCommand: "scoop update lazygit", RunManually: true}
+	apt   = &PackageManager{Name: "apt", UpdateCommand: "sudo apt-get install --only-upgrade lazygit", RunManually: true}
+)
+
+// PackageManager returns the package manager that installed the running
+// binary, or nil if it was installed some other way, e.g. by downloading a
+// release or with go get
+func (u *Updater) PackageManager() *PackageManager {
+	u.packageManagerOnce.Do(func() {
+		binaryPath, err := osext.Executable()
+		if err != nil {
+			u.Log.Error(err)
+			return
+		}
+		// e.g. /usr/local/bin/lazygit is a symlink into brew's Cellar
+		if resolvedPath, err := filepath.EvalSymlinks(binaryPath); err == nil {
+			binaryPath = resolvedPath
+		}
+		u.packageManager = u.detectPackageManager(binaryPath)
+		if u.packageManager != nil {
+			u.Log.Info("Binary was installed with " + u.packageManager.Name)
+		}
+	})
+	return u.packageManager
+}
+
+func (u *Updater) detectPackageManager(binaryPath string) *PackageManager {
+	slashPath := strings.ToLower(filepath.ToSlash(binaryPath))
+	switch {
+	case strings.Contains(slashPath, "/cellar/"):
+		return homebrew
+	case strings.Contains(slashPath, "/scoop/apps/"):
+		return scoop
+	case strings.HasPrefix(slashPath, "/usr/") && u.OSCommand.RunCommandArgs("dpkg", "-S", binaryPath) == nil:
+		return apt
+	}
+	return nil
+}
@@ -1,14 +1,19 @@
 package updates
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-errors/errors"
@@ -27,6 +32,20 @@ type Updater struct {
 	Config    config.AppConfigurer
 	OSCommand *commands.OSCommand
 	Tr        *i18n.Localizer
+
+	// releases are those found by the last check, newest first
+	releases []release
+
+	packageManager     *PackageManager
+	packageManagerOnce sync.Once
+}
+
+// release is a lazygit release as listed by GitHub's releases API
+type release struct {
+	TagName    string `json:"tag_name"`
+	Body       string `json:"body"`
+	Prerelease bool   `json:"prerelease"`
+	Draft      bool   `json:"draft"`
 }
 
 // Updaterer implements the check and update methods
@@ -36,7 +55,9 @@ type Updaterer interface {
 }
 
 const (
-	PROJECT_URL = "https://github.com/jesseduffield/lazygit"
+	PROJECT_URL      = "https://github.com/jesseduffield/lazygit"
+	RELEASES_API_URL = "https://api.github.com/repos/jesseduffield/lazygit/releases"
+	CHECKSUMS_FILE   = "checksums.txt"
 )
 
 // NewUpdater creates a new updater
@@ -51,28 +72,83 @@ func NewUpdater(log *logrus.Entry, config config.AppConfigurer, osCommand *comma
 	}, nil
 }
 
-func (u *Updater) getLatestVersionNumber() (string, error) {
-	req, err := http.NewRequest("GET", PROJECT_URL+"/releases/latest", nil)
-	if err != nil {
-		return "", err
+// getReleases lists the releases on the user's update.channel, newest first.
+// They come from GitHub unless update.releasesSource points somewhere else,
+// which can be a URL or a file, e.g. a mirror for machines without internet
+func (u *Updater) getReleases() ([]release, error) {
+	userConfig := u.Config.GetUserConfig()
+	source := userConfig.GetString("update.releasesSource")
+	if source == "" {
+		source = RELEASES_API_URL
 	}
-	req.Header.Set("Accept", "application/json")
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", err
+	var body io.ReadCloser
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		req, err := http.NewRequest("GET", source, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("error while listing lazygit releases: %s", resp.Status)
+		}
+		body = resp.Body
+	} else {
+		file, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		body = file
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	dec := json.NewDecoder(resp.Body)
-	data := struct {
-		TagName string `json:"tag_name"`
-	}{}
-	if err := dec.Decode(&data); err != nil {
-		return "", err
+	releases := []release{}
+	if err := json.NewDecoder(body).Decode(&releases); err != nil {
+		return nil, err
+	}
+
+	// nightly builds are published as prereleases
+	includePrereleases := userConfig.GetString("update.channel") == "nightly"
+	onChannel := []release{}
+	for _, release := range releases {
+		if !release.Draft && (includePrereleases || !release.Prerelease) {
+			onChannel = append(onChannel, release)
+		}
+	}
+	return onChannel, nil
+}
+
+// Changelog joins the release notes of every release from the current version
+// up to and including newVersion, newest first, so that the user can see what
+// they'd be getting before updating
+func (u *Updater) Changelog(newVersion string) string {
+	currentVersion := u.Config.GetVersion()
+	sections := []string{}
+	collecting := false
+	for _, release := range u.releases {
+		if release.TagName == currentVersion {
+			break
+		}
+		if release.TagName == newVersion {
+			collecting = true
+		}
+		if collecting {
+			sections = append(sections, fmt.Sprintf("## %s\n\n%s", release.TagName, strings.TrimSpace(release.Body)))
+		}
 	}
+	return strings.Join(sections, "\n\n")
+}
 
-	return data.TagName, nil
+// CheckOnly is true when the user only wants to be told about new versions,
+// never to download them, e.g. because they install lazygit some other way
+func (u *Updater) CheckOnly() bool {
+	return u.Config.GetUserConfig().GetString("update.method") == "checkOnly"
 }
 
 // RecordLastUpdateCheck records last time an update check was performed
@@ -98,10 +174,15 @@ func (u *Updater) checkForNewUpdate() (string, error) {
 		return "", err
 	}
 
-	newVersion, err := u.getLatestVersionNumber()
+	releases, err := u.getReleases()
 	if err != nil {
 		return "", err
 	}
+	if len(releases) == 0 {
+		return "", errors.New(u.Tr.SLocalize("NoReleasesFoundErr"))
+	}
+	u.releases = releases
+	newVersion := releases[0].TagName
 	u.Log.Info("Current version is " + currentVersion)
 	u.Log.Info("New version is " + newVersion)
 
@@ -120,6 +201,12 @@ func (u *Updater) checkForNewUpdate() (string, error) {
 		return "", errors.New(errMessage)
 	}
 
+	if u.CheckOnly() || u.PackageManager() != nil {
+		// we won't be downloading the release ourselves, and there may be no
+		// way to reach the download from here anyway
+		return newVersion, nil
+	}
+
 	rawUrl, err := u.getBinaryUrl(newVersion)
 	if err != nil {
 		return "", err
@@ -156,7 +243,7 @@ func (u *Updater) CheckForNewUpdate(onFinish func(string, error) error, userRequ
 func (u *Updater) skipUpdateCheck() bool {
 	// will remove the check for windows after adding a manifest file asking for
 	// the required permissions
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" && !u.CheckOnly() && u.PackageManager() == nil {
 		u.Log.Info("Updating is currently not supported for windows until we can fix permission issues")
 		return true
 	}
@@ -214,21 +301,24 @@ func (u *Updater) mappedArch(arch string) string {
 	return arch
 }
 
+// getAssetUrl is where a file attached to a release can be downloaded from
+func (u *Updater) getAssetUrl(version string, fileName string) string {
+	return fmt.Sprintf("%s/releases/download/%s/%s", PROJECT_URL, version, fileName)
+}
+
 // example: https://github.com/jesseduffield/lazygit/releases/download/v0.1.73/lazygit_0.1.73_Darwin_x86_64.tar.gz
 func (u *Updater) getBinaryUrl(newVersion string) (string, error) {
 	extension := "tar.gz"
 	if runtime.GOOS == "windows" {
 		extension = "zip"
 	}
-	url := fmt.Sprintf(
-		"%s/releases/download/%s/lazygit_%s_%s_%s.%s",
-		PROJECT_URL,
-		newVersion,
+	url := u.getAssetUrl(newVersion, fmt.Sprintf(
+		"lazygit_%s_%s_%s.%s",
 		newVersion[1:],
 		u.mappedOs(runtime.GOOS),
 		u.mappedArch(runtime.GOARCH),
 		extension,
-	)
+	))
 	u.Log.Info("Url for latest release is " + url)
 	return url, nil
 }
@@ -244,65 +334,129 @@ func (u *Updater) Update(newVersion string, onFinish func(error) error) {
 }
 
 func (u *Updater) update(newVersion string) error {
+	if packageManager := u.PackageManager(); packageManager != nil {
+		return errors.New(u.Tr.TemplateLocalize("ManagedInstallErr", i18n.Teml{"packageManager": packageManager.Name}))
+	}
 	rawUrl, err := u.getBinaryUrl(newVersion)
 	if err != nil {
 		return err
 	}
 	u.Log.Info("Updating with url " + rawUrl)
-	return u.downloadAndInstall(rawUrl)
+	return u.downloadAndInstall(newVersion, rawUrl)
 }
 
-func (u *Updater) downloadAndInstall(rawUrl string) error {
-	configDir := u.Config.GetUserConfigDir()
-	u.Log.Info("Download directory is " + configDir)
+func (u *Updater) downloadAndInstall(newVersion string, rawUrl string) error {
+	downloadDir := u.Config.GetCacheDir()
+	u.Log.Info("Download directory is " + downloadDir)
+	if err := os.MkdirAll(downloadDir, 0755); err != nil {
+		return err
+	}
 
-	tempPath := filepath.Join(configDir, "temp_lazygit")
+	tempPath := filepath.Join(downloadDir, "temp_lazygit")
 	u.Log.Info("Temp path to binary is " + tempPath)
 
-	// Create the file
-	out, err := os.Create(tempPath)
+	if err := u.downloadFile(rawUrl, tempPath); err != nil {
+		return err
+	}
+
+	if err := u.verifyDownload(newVersion, path.Base(rawUrl), tempPath); err != nil {
+		_ = os.Remove(tempPath)
+		return err
+	}
+
+	// get the path of the current binary
+	binaryPath, err := osext.Executable()
+	if err != nil {
+		return err
+	}
+	u.Log.Info("Binary path is " + binaryPath)
+
+	// Verify the main file exists
+	if _, err := os.Stat(tempPath); err != nil {
+		return err
+	}
+
+	// swap out the old binary for the new one
+	err = os.Rename(tempPath, binaryPath)
+	if err != nil {
+		return err
+	}
+	u.Log.Info("Update complete!")
+
+	return nil
+}
+
+func (u *Updater) downloadFile(rawUrl string, destination string) error {
+	out, err := os.Create(destination)
 	if err != nil {
 		return err
 	}
 	defer out.Close()
 
-	// Get the data
 	resp, err := http.Get(rawUrl)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
-	// Check server response
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("error while trying to download latest lazygit: %s", resp.Status)
+		return fmt.Errorf("error while trying to download %s: %s", rawUrl, resp.Status)
 	}
 
-	// Write the body to file
 	_, err = io.Copy(out, resp.Body)
-	if err != nil {
+	return err
+}
+
+// verifyDownload checks the downloaded file against the checksums published
+// with the release and, if update.verifySignature is on, checks the checksums
+// themselves against their gpg signature
+func (u *Updater) verifyDownload(version string, fileName string, downloadPath string) error {
+	checksumsPath := filepath.Join(u.Config.GetCacheDir(), "temp_lazygit_"+CHECKSUMS_FILE)
+	defer os.Remove(checksumsPath)
+	if err := u.downloadFile(u.getAssetUrl(version, CHECKSUMS_FILE), checksumsPath); err != nil {
 		return err
 	}
 
-	// get the path of the current binary
-	binaryPath, err := osext.Executable()
-	if err != nil {
-		return err
+	if u.Config.GetUserConfig().GetBool("update.verifySignature") {
+		signaturePath := checksumsPath + ".sig"
+		defer os.Remove(signaturePath)
+		if err := u.downloadFile(u.getAssetUrl(version, CHECKSUMS_FILE+".sig"), signaturePath); err != nil {
+			return err
+		}
+		if err := u.OSCommand.RunCommandArgs("gpg", "--verify", signaturePath, checksumsPath); err != nil {
+			return errors.New(u.Tr.TemplateLocalize("BadUpdateSignatureErr", i18n.Teml{"error": err.Error()}))
+		}
 	}
-	u.Log.Info("Binary path is " + binaryPath)
 
-	// Verify the main file exists
-	if _, err := os.Stat(tempPath); err != nil {
+	checksums, err := ioutil.ReadFile(checksumsPath)
+	if err != nil {
 		return err
 	}
+	expected := ""
+	// each line is '<sha256>  <file name>'
+	for _, line := range strings.Split(string(checksums), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == fileName {
+			expected = fields[0]
+		}
+	}
+	if expected == "" {
+		return errors.New(u.Tr.TemplateLocalize("NoChecksumForUpdateErr", i18n.Teml{"file": fileName}))
+	}
 
-	// swap out the old binary for the new one
-	err = os.Rename(tempPath, binaryPath)
+	file, err := os.Open(downloadPath)
 	if err != nil {
 		return err
 	}
-	u.Log.Info("Update complete!")
-
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return errors.New(u.Tr.TemplateLocalize("UpdateChecksumMismatchErr", i18n.Teml{"file": fileName}))
+	}
+	u.Log.Info("Verified checksum of " + fileName)
 	return nil
 }
 
@@ -118,10 +118,24 @@ func RenderDisplayStrings(displayStringsArr [][]string) string {
 	return strings.Join(paddedDisplayStrings, "\n")
 }
 
-// Decolorise strips a string of color
+// Decolorise strips a string of color, and of any hyperlinks
 func Decolorise(str string) string {
 	re := regexp.MustCompile(`\x1B\[([0-9]{1,2}(;[0-9]{1,2})?)?[m|K]`)
-	return re.ReplaceAllString(str, "")
+	return hyperlinkRegexp.ReplaceAllString(re.ReplaceAllString(str, ""), "")
+}
+
+// hyperlinkRegexp matches the escape sequences which start and end an OSC 8
+// hyperlink, leaving the text between them
+var hyperlinkRegexp = regexp.MustCompile("\x1b\\]8;[^;\x07\x1b]*;[^\x07\x1b]*(?:\x07|\x1b\\\\)")
+
+// Hyperlink makes the text a link to the url, which terminals that support
+// OSC 8 hyperlinks let you open with a ctrl/cmd-click, and the rest ignore.
+// With no url the text is left as it is
+func Hyperlink(text string, url string) string {
+	if url == "" {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
 }
 
 func getPadWidths(stringArrays [][]string) []int {
@@ -55,6 +55,11 @@ func TestWithPadding(t *testing.T) {
 			14,
 			"hello world ! ",
 		},
+		{
+			Hyperlink("abc1234", "https://github.com/a/b/commit/abc1234"),
+			9,
+			Hyperlink("abc1234", "https://github.com/a/b/commit/abc1234") + "  ",
+		},
 	}
 
 	for _, s := range scenarios {
@@ -27,7 +27,7 @@ type bindingSection struct {
 
 func main() {
 	langs := []string{"pl", "nl", "en"}
-	mConfig, _ := config.NewAppConfig("", "", "", "", "", true)
+	mConfig, _ := config.NewAppConfig("", "", "", "", "", true, "", "")
 
 	for _, lang := range langs {
 		os.Setenv("LC_ALL", lang)
@@ -0,0 +1,9 @@
+module github.com/jesseduffield/gocui
+
+go 1.14
+
+require (
+	github.com/go-errors/errors v1.0.2
+	github.com/jesseduffield/termbox-go v0.0.0-20200405031649-4dc645f7e8ba
+	github.com/mattn/go-runewidth v0.0.9
+)
@@ -0,0 +1,5 @@
+module github.com/jesseduffield/termbox-go
+
+go 1.14
+
+require github.com/mattn/go-runewidth v0.0.9